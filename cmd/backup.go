@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/adapters/storage"
+)
+
+var restoreForce bool
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [path]",
+	Short: "Write a consistent snapshot of the database",
+	Long: `Writes a consistent snapshot of the Flow database, safe to take while a
+session is running. Without a path, the snapshot goes to the backups
+directory next to the database (default: ~/.flow/backups).
+
+Flow also takes one snapshot automatically on the first launch of each day
+and keeps the newest [storage] backup_keep of them (0 disables this).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dest := filepath.Join(backupDir(), "flow-"+time.Now().Format("20060102-150405")+".db")
+		if len(args) == 1 {
+			dest = args[0]
+			if info, err := os.Stat(dest); err == nil && info.IsDir() {
+				dest = filepath.Join(dest, "flow-"+time.Now().Format("20060102-150405")+".db")
+			}
+		}
+
+		if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+			return fmt.Errorf("failed to create backup directory: %w", err)
+		}
+
+		if err := app.storage.Backup(context.Background(), dest); err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			data, err := json.MarshalIndent(map[string]interface{}{
+				"path":           dest,
+				"schema_version": storage.LatestSchemaVersion(),
+			}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("Backed up database to %s\n", dest)
+		return nil
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Replace the database with a snapshot",
+	Long: `Replaces the Flow database with a snapshot taken by "flow backup".
+
+The snapshot is checked before anything is touched: it must be an intact
+Flow database whose schema this version of flow understands. The current
+database is saved to the backups directory first, and the restored snapshot
+is migrated to the latest schema. Use --force to skip the confirmation prompt.`,
+	Args: cobra.ExactArgs(1),
+	// Overrides the root pre-run: the current database may be the corrupted
+	// file we are restoring over, so it must not be opened up front.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		loadConfig()
+		return resolveDBPath()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshot := args[0]
+
+		version, err := storage.ValidateSnapshot(snapshot)
+		if err != nil {
+			return fmt.Errorf("refusing to restore %s: %w", snapshot, err)
+		}
+
+		if !restoreForce {
+			fmt.Printf("This will replace %s with %s (schema version %d).\n", dbPath, snapshot, version)
+			fmt.Print("Are you sure? Type 'yes' to confirm: ")
+			reader := bufio.NewReader(os.Stdin)
+			input, _ := reader.ReadString('\n')
+			input = strings.TrimSpace(strings.ToLower(input))
+			if input != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		if _, err := os.Stat(dbPath); err == nil {
+			saved, err := backupCurrentDatabase()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not back up the current database: %v\n", err)
+			} else {
+				fmt.Printf("Saved current database to %s\n", saved)
+			}
+		}
+
+		if err := storage.RestoreSnapshot(snapshot, dbPath); err != nil {
+			return err
+		}
+
+		// Reopen through New so the restored snapshot is migrated to the latest schema.
+		app.storage, err = storage.New(dbPath)
+		if err != nil {
+			return fmt.Errorf("restored snapshot could not be opened: %w", err)
+		}
+
+		fmt.Printf("Restored %s (schema version %d → %d).\n", snapshot, version, storage.LatestSchemaVersion())
+		return nil
+	},
+}
+
+// backupCurrentDatabase snapshots the database at dbPath before it is replaced.
+func backupCurrentDatabase() (string, error) {
+	current, err := storage.Open(dbPath)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = current.Close() }()
+
+	if err := os.MkdirAll(backupDir(), 0750); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	dest := filepath.Join(backupDir(), "flow-pre-restore-"+time.Now().Format("20060102-150405")+".db")
+	if err := current.Backup(context.Background(), dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+func init() {
+	restoreCmd.Flags().BoolVarP(&restoreForce, "force", "f", false, "Skip confirmation prompt")
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+}
@@ -4,37 +4,34 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/xvierd/flow-cli/internal/config"
 	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/methodology"
 )
 
 var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "View and edit session presets and break durations",
-	Long:  `Interactively configure the three session presets, short break, long break, and sessions before long break.`,
+	Long: `Interactively configure the session presets, short break, long break, and sessions before long break.
+
+Use "flow config presets" to add, remove and reorder presets.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		reader := bufio.NewReader(os.Stdin)
 
-		methodology := app.config.Methodology
-		if methodology == "" {
-			methodology = "pomodoro"
+		current := app.config.Methodology
+		if current == "" {
+			current = "pomodoro"
 		}
-		meth := domain.Methodology(methodology)
+		meth := domain.Methodology(current)
 
 		// Show presets for the active methodology
-		var presets []config.SessionPreset
-		switch meth {
-		case domain.MethodologyDeepWork:
-			presets = app.config.DeepWork.GetPresets()
-		case domain.MethodologyMakeTime:
-			presets = app.config.MakeTime.GetPresets()
-		default:
-			presets = app.config.Pomodoro.GetPresets()
-		}
+		presets := methodology.ForMethodology(meth, app.config).Presets()
 
 		fmt.Println()
 		fmt.Println("  Current configuration:")
@@ -43,7 +40,7 @@ var configCmd = &cobra.Command{
 		fmt.Println()
 		fmt.Println("  Session presets:")
 		for i, p := range presets {
-			fmt.Printf("    [%d] %-8s  %s\n", i+1, p.Name, formatMinutes(p.Duration))
+			fmt.Printf("    [%d] %s\n", i+1, presetLine(p))
 		}
 		fmt.Println()
 		switch meth {
@@ -56,6 +53,29 @@ var configCmd = &cobra.Command{
 			fmt.Printf("    Break duration:        %s\n", formatMinutes(time.Duration(app.config.DeepWork.BreakDuration)))
 		case domain.MethodologyMakeTime:
 			fmt.Printf("    Break duration:        %s\n", formatMinutes(time.Duration(app.config.MakeTime.BreakDuration)))
+		case domain.MethodologyFlowtime:
+			policy, err := app.config.Flowtime.BreakPolicy()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("    Earned break:          %s\n", policy)
+		case domain.MethodologyMob:
+			roster := strings.Join(app.config.Mob.Roster, ", ")
+			if roster == "" {
+				roster = "(none yet)"
+			}
+			fmt.Printf("    Mob:                   %s\n", roster)
+			fmt.Printf("    Break:                 %s\n", formatMinutes(time.Duration(app.config.Mob.BreakDuration)))
+			fmt.Printf("    Rotations before break: %d\n", app.config.GetSessionsBeforeLong(meth))
+		default:
+			if mc := app.config.CustomMethodology(meth); mc != nil {
+				short, long := app.config.GetBreakDurations(meth)
+				fmt.Printf("    Short break:          %s\n", formatMinutes(short))
+				if mc.SessionsBeforeLong > 0 {
+					fmt.Printf("    Long break:           %s\n", formatMinutes(long))
+					fmt.Printf("    Sessions before long:  %d\n", mc.SessionsBeforeLong)
+				}
+			}
 		}
 		notifStatus := "off"
 		if app.config.Notifications.Enabled {
@@ -67,26 +87,34 @@ var configCmd = &cobra.Command{
 		fmt.Printf("    Notifications:         %s\n", notifStatus)
 		fmt.Println()
 		fmt.Println("  What would you like to change?")
-		fmt.Println("    [1] Edit preset 1")
-		fmt.Println("    [2] Edit preset 2")
-		fmt.Println("    [3] Edit preset 3")
+		if meth == domain.MethodologyMob {
+			fmt.Println("    [r] Edit the mob and its rotation")
+		} else if len(presets) > 1 {
+			fmt.Printf("    [1-%d] Edit a preset\n", len(presets))
+		} else {
+			fmt.Println("    [1] Edit the preset")
+		}
 		fmt.Println("    [b] Edit break durations")
 		fmt.Println("    [m] Change methodology")
 		fmt.Println("    [p] Change Deep Work philosophy")
 		fmt.Println("    [n] Toggle notifications")
 		fmt.Println("    [q] Quit without saving")
+		fmt.Println()
+		fmt.Println("  Add, remove or reorder presets with \"flow config presets\".")
 		fmt.Print("  Choose: ")
 
 		choice, _ := reader.ReadString('\n')
 		choice = strings.TrimSpace(strings.ToLower(choice))
 
+		if n, err := strconv.Atoi(choice); err == nil {
+			if n < 1 || n > len(presets) {
+				return fmt.Errorf("invalid choice %q", choice)
+			}
+			return editPreset(reader, app.config, n)
+		}
 		switch choice {
-		case "1":
-			return editPreset(reader, app.config, 1)
-		case "2":
-			return editPreset(reader, app.config, 2)
-		case "3":
-			return editPreset(reader, app.config, 3)
+		case "r":
+			return editMob(reader, app.config)
 		case "b":
 			return editBreaks(reader, app.config)
 		case "m":
@@ -104,7 +132,151 @@ var configCmd = &cobra.Command{
 	},
 }
 
+var (
+	presetTags    []string
+	presetOutcome string
+	presetBreak   time.Duration
+	presetAt      int
+)
+
+var configPresetsCmd = &cobra.Command{
+	Use:   "presets",
+	Short: "List, add, remove and reorder session presets",
+	Long: `Lists the session presets of the active methodology, or of the one given
+with --mode. Presets are listed in the order the duration picker shows them;
+the first is the default.
+
+A preset can carry tags added to every session started from it, an intended
+outcome that pre-fills the outcome prompt, and a break that replaces the
+methodology's break after the session.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, err := presetList(app.config, app.methodology)
+		if err != nil {
+			return err
+		}
+		printPresets(app.methodology, *list)
+		return nil
+	},
+}
+
+var configPresetsAddCmd = &cobra.Command{
+	Use:   "add <name> <duration>",
+	Short: "Add a session preset",
+	Example: `  flow config presets add Review 40m
+  flow config presets add Writing 90m --tags writing --break 20m --at 1
+  flow --mode deepwork config presets add Spec 2h --outcome "First draft of the spec"`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, err := presetList(app.config, app.methodology)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSpace(args[0])
+		if name == "" {
+			return fmt.Errorf("preset name cannot be empty")
+		}
+		if slices.ContainsFunc(*list, func(p config.PresetConfig) bool { return strings.EqualFold(p.Name, name) }) {
+			return fmt.Errorf("%s already has a preset named %q", app.methodology.Label(), name)
+		}
+		dur, err := parsePresetDuration(args[1])
+		if err != nil {
+			return err
+		}
+		if cmd.Flags().Changed("break") && presetBreak <= 0 {
+			return fmt.Errorf("invalid break %s: must be positive", presetBreak)
+		}
+
+		preset := config.PresetConfig{
+			Name:            name,
+			Duration:        config.Duration(dur),
+			Tags:            splitTags(strings.Join(presetTags, ",")),
+			IntendedOutcome: presetOutcome,
+			Break:           config.Duration(presetBreak),
+		}
+		at := len(*list)
+		if cmd.Flags().Changed("at") {
+			if presetAt < 1 || presetAt > len(*list)+1 {
+				return fmt.Errorf("--at must be between 1 and %d", len(*list)+1)
+			}
+			at = presetAt - 1
+		}
+		*list = slices.Insert(*list, at, preset)
+
+		if err := savePresets(app.config, app.methodology); err != nil {
+			return err
+		}
+		fmt.Printf("Added preset [%d] %s\n", at+1, presetLine(sessionPreset(preset)))
+		return nil
+	},
+}
+
+var configPresetsRmCmd = &cobra.Command{
+	Use:     "rm <name|number>",
+	Aliases: []string{"remove"},
+	Short:   "Remove a session preset",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, err := presetList(app.config, app.methodology)
+		if err != nil {
+			return err
+		}
+		i, err := config.FindPreset(*list, args[0])
+		if err != nil {
+			return err
+		}
+		if len(*list) == 1 {
+			return fmt.Errorf("%s needs at least one preset", app.methodology.Label())
+		}
+		name := (*list)[i].Name
+		*list = slices.Delete(*list, i, i+1)
+
+		if err := savePresets(app.config, app.methodology); err != nil {
+			return err
+		}
+		fmt.Printf("Removed preset %s\n", name)
+		return nil
+	},
+}
+
+var configPresetsReorderCmd = &cobra.Command{
+	Use:   "reorder <name|number> <position>",
+	Short: "Move a session preset to another position",
+	Long: `Moves a preset to the given 1-based position in the duration picker. The
+first preset is the default.`,
+	Example: `  flow config presets reorder Deep 1`,
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, err := presetList(app.config, app.methodology)
+		if err != nil {
+			return err
+		}
+		i, err := config.FindPreset(*list, args[0])
+		if err != nil {
+			return err
+		}
+		to, err := strconv.Atoi(args[1])
+		if err != nil || to < 1 || to > len(*list) {
+			return fmt.Errorf("position must be between 1 and %d", len(*list))
+		}
+		preset := (*list)[i]
+		*list = slices.Insert(slices.Delete(*list, i, i+1), to-1, preset)
+
+		if err := savePresets(app.config, app.methodology); err != nil {
+			return err
+		}
+		printPresets(app.methodology, *list)
+		return nil
+	},
+}
+
 func init() {
+	configPresetsAddCmd.Flags().StringSliceVar(&presetTags, "tags", nil, "Tags added to sessions started from the preset")
+	configPresetsAddCmd.Flags().StringVar(&presetOutcome, "outcome", "", "Intended outcome pre-filled for sessions started from the preset")
+	configPresetsAddCmd.Flags().DurationVar(&presetBreak, "break", 0, "Break after the session, in place of the methodology's")
+	configPresetsAddCmd.Flags().IntVar(&presetAt, "at", 0, "Position to insert the preset at (default: last)")
+	configPresetsCmd.AddCommand(configPresetsAddCmd, configPresetsRmCmd, configPresetsReorderCmd)
+	configCmd.AddCommand(configPresetsCmd)
 	rootCmd.AddCommand(configCmd)
 }
 
@@ -115,88 +287,220 @@ func editPreset(reader *bufio.Reader, cfg *config.Config, num int) error {
 	}
 	meth := domain.Methodology(methodology)
 
-	var presets []config.SessionPreset
-	switch meth {
-	case domain.MethodologyDeepWork:
-		presets = cfg.DeepWork.GetPresets()
-	case domain.MethodologyMakeTime:
-		presets = cfg.MakeTime.GetPresets()
-	default:
-		presets = cfg.Pomodoro.GetPresets()
+	list, err := presetList(cfg, meth)
+	if err != nil {
+		return err
 	}
+	if num < 1 || num > len(*list) {
+		return fmt.Errorf("no preset %d", num)
+	}
+	p := &(*list)[num-1]
 
-	p := presets[num-1]
-
-	fmt.Printf("\n  Editing preset %d (currently: %s — %s)\n", num, p.Name, formatMinutes(p.Duration))
+	fmt.Printf("\n  Editing preset %d (currently: %s)\n", num, presetLine(sessionPreset(*p)))
+	fmt.Println("  Enter keeps the current value; - clears it.")
 
 	fmt.Printf("  Name [%s]: ", p.Name)
-	name, _ := reader.ReadString('\n')
-	name = strings.TrimSpace(name)
-	if name == "" {
-		name = p.Name
+	if input := readLine(reader); input != "" && input != "-" {
+		p.Name = input
 	}
 
-	fmt.Printf("  Duration [%s]: ", formatMinutes(p.Duration))
-	durInput, _ := reader.ReadString('\n')
-	durInput = strings.TrimSpace(durInput)
+	fmt.Printf("  Duration [%s]: ", formatMinutes(time.Duration(p.Duration)))
+	if input := readLine(reader); input != "" {
+		dur, err := parsePresetDuration(input)
+		if err != nil {
+			return err
+		}
+		p.Duration = config.Duration(dur)
+	}
+
+	fmt.Printf("  Tags [%s]: ", strings.Join(p.Tags, ", "))
+	switch input := readLine(reader); input {
+	case "":
+	case "-":
+		p.Tags = nil
+	default:
+		p.Tags = splitTags(input)
+	}
 
-	dur := p.Duration
-	if durInput != "" {
-		parsed, err := time.ParseDuration(durInput)
+	fmt.Printf("  Intended outcome [%s]: ", p.IntendedOutcome)
+	switch input := readLine(reader); input {
+	case "":
+	case "-":
+		p.IntendedOutcome = ""
+	default:
+		p.IntendedOutcome = input
+	}
+
+	currentBreak := "methodology's"
+	if p.Break > 0 {
+		currentBreak = formatMinutes(time.Duration(p.Break))
+	}
+	fmt.Printf("  Break after [%s]: ", currentBreak)
+	switch input := readLine(reader); input {
+	case "":
+	case "-":
+		p.Break = 0
+	default:
+		dur, err := parsePresetDuration(input)
 		if err != nil {
-			return fmt.Errorf("invalid duration %q: %w", durInput, err)
+			return err
 		}
-		dur = parsed
+		p.Break = config.Duration(dur)
 	}
 
-	switch meth {
-	case domain.MethodologyDeepWork:
-		switch num {
-		case 1:
-			cfg.DeepWork.Preset1Name = name
-			cfg.DeepWork.Preset1Duration = config.Duration(dur)
-		case 2:
-			cfg.DeepWork.Preset2Name = name
-			cfg.DeepWork.Preset2Duration = config.Duration(dur)
-		case 3:
-			cfg.DeepWork.Preset3Name = name
-			cfg.DeepWork.Preset3Duration = config.Duration(dur)
+	if err := savePresets(cfg, meth); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n  Saved: [%d] %s\n", num, presetLine(sessionPreset(*p)))
+	return nil
+}
+
+// presetList returns the presets list of methodology meth for editing.
+func presetList(cfg *config.Config, meth domain.Methodology) (*[]config.PresetConfig, error) {
+	if meth.CountsUp() {
+		return nil, fmt.Errorf("%s sessions count up and have no presets to edit", meth.Label())
+	}
+	if meth == domain.MethodologyMob {
+		return nil, fmt.Errorf("every Mob rotation lasts mob.rotation; change it with [r] in \"flow config\"")
+	}
+	list := cfg.PresetList(meth)
+	if list == nil {
+		return nil, fmt.Errorf("%s has no presets in config", meth.Label())
+	}
+	return list, nil
+}
+
+// savePresets saves the config after methodology meth's presets changed.
+// A Pomodoro session started without a preset lasts as long as the first.
+func savePresets(cfg *config.Config, meth domain.Methodology) error {
+	if list := cfg.PresetList(meth); meth == domain.MethodologyPomodoro && len(*list) > 0 {
+		cfg.Pomodoro.WorkDuration = (*list)[0].Duration
+	}
+	if err := cfg.ValidateMethodologies(); err != nil {
+		return err
+	}
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
+// printPresets lists methodology meth's presets in picker order.
+func printPresets(meth domain.Methodology, list []config.PresetConfig) {
+	fmt.Printf("\n  %s presets:\n", meth.Label())
+	for i, p := range list {
+		fmt.Printf("    [%d] %s\n", i+1, presetLine(sessionPreset(p)))
+	}
+	fmt.Println()
+}
+
+// sessionPreset converts a config preset for display.
+func sessionPreset(p config.PresetConfig) config.SessionPreset {
+	return config.SessionPreset{
+		Name:            p.Name,
+		Duration:        time.Duration(p.Duration),
+		Tags:            p.Tags,
+		IntendedOutcome: p.IntendedOutcome,
+		Break:           time.Duration(p.Break),
+	}
+}
+
+// presetLine describes a preset on one line, e.g.
+// "Deep      1h30m  #writing · break 20m".
+func presetLine(p config.SessionPreset) string {
+	length := formatMinutes(p.Duration)
+	if p.Duration == 0 {
+		length = "counts up"
+	}
+	line := fmt.Sprintf("%-8s  %s", p.Name, length)
+	var extras []string
+	if len(p.Tags) > 0 {
+		extras = append(extras, "#"+strings.Join(p.Tags, " #"))
+	}
+	if p.Break > 0 {
+		extras = append(extras, "break "+formatMinutes(p.Break))
+	}
+	if p.IntendedOutcome != "" {
+		extras = append(extras, fmt.Sprintf("%q", p.IntendedOutcome))
+	}
+	if len(extras) > 0 {
+		line += "  " + strings.Join(extras, " · ")
+	}
+	return line
+}
+
+// parsePresetDuration parses a preset or break length, which must be
+// positive.
+func parsePresetDuration(input string) (time.Duration, error) {
+	dur, err := time.ParseDuration(input)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", input, err)
+	}
+	if dur <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: must be positive", input)
+	}
+	return dur, nil
+}
+
+// splitTags splits a comma- or space-separated tag list.
+func splitTags(input string) []string {
+	return domain.NormalizeTags(strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }))
+}
+
+// readLine reads one trimmed line of input.
+func readLine(reader *bufio.Reader) string {
+	line, _ := reader.ReadString('\n')
+	return strings.TrimSpace(line)
+}
+
+// editMob edits who is in the mob, in rotation order, and how long and how
+// many rotations run before the break.
+func editMob(reader *bufio.Reader, cfg *config.Config) error {
+	fmt.Println("\n  Editing the mob")
+	fmt.Println("  Enter keeps the current value.")
+
+	fmt.Printf("  Names in rotation order, comma-separated [%s]: ", strings.Join(cfg.Mob.Roster, ", "))
+	if input := readLine(reader); input != "" {
+		cfg.Mob.Roster = splitNames(input)
+	}
+
+	fmt.Printf("  Rotation [%s]: ", formatMinutes(cfg.Mob.RotationLength()))
+	if input := readLine(reader); input != "" {
+		dur, err := time.ParseDuration(input)
+		if err != nil || dur <= 0 {
+			return fmt.Errorf("invalid rotation %q: use a duration like 10m", input)
 		}
-	case domain.MethodologyMakeTime:
-		switch num {
-		case 1:
-			cfg.MakeTime.Preset1Name = name
-			cfg.MakeTime.Preset1Duration = config.Duration(dur)
-		case 2:
-			cfg.MakeTime.Preset2Name = name
-			cfg.MakeTime.Preset2Duration = config.Duration(dur)
-		case 3:
-			cfg.MakeTime.Preset3Name = name
-			cfg.MakeTime.Preset3Duration = config.Duration(dur)
-		}
-	default: // Pomodoro
-		switch num {
-		case 1:
-			cfg.Pomodoro.Preset1Name = name
-			cfg.Pomodoro.Preset1Duration = config.Duration(dur)
-			cfg.Pomodoro.WorkDuration = config.Duration(dur)
-		case 2:
-			cfg.Pomodoro.Preset2Name = name
-			cfg.Pomodoro.Preset2Duration = config.Duration(dur)
-		case 3:
-			cfg.Pomodoro.Preset3Name = name
-			cfg.Pomodoro.Preset3Duration = config.Duration(dur)
+		cfg.Mob.Rotation = config.Duration(dur)
+	}
+
+	fmt.Printf("  Rotations before break [%d]: ", cfg.GetSessionsBeforeLong(domain.MethodologyMob))
+	if input := readLine(reader); input != "" {
+		n, err := strconv.Atoi(input)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid number of rotations %q", input)
 		}
+		cfg.Mob.RotationsBeforeBreak = n
 	}
 
 	if err := config.Save(cfg); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
-
-	fmt.Printf("\n  Saved: [%d] %s — %s\n", num, name, formatMinutes(dur))
+	fmt.Printf("\n  Saved: %s, %s rotations\n", strings.Join(cfg.Mob.Roster, ", "), formatMinutes(cfg.Mob.RotationLength()))
 	return nil
 }
 
+// splitNames splits a comma-separated list of names, keeping their case.
+func splitNames(input string) []string {
+	var names []string
+	for _, name := range strings.Split(input, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func editBreaks(reader *bufio.Reader, cfg *config.Config) error {
 	methodology := cfg.Methodology
 	if methodology == "" {
@@ -204,12 +508,46 @@ func editBreaks(reader *bufio.Reader, cfg *config.Config) error {
 	}
 	meth := domain.Methodology(methodology)
 
-	if meth == domain.MethodologyPomodoro {
+	if cfg.CustomMethodology(meth) != nil {
+		return fmt.Errorf("%s is declared under [[methodologies]]; edit its breaks in the config file", meth.Label())
+	}
+	switch meth {
+	case domain.MethodologyPomodoro:
 		return editPomodoroBreaks(reader, cfg)
+	case domain.MethodologyFlowtime:
+		return editFlowtimeBreaks(reader, cfg)
 	}
 	return editMethodologyBreak(reader, cfg, meth)
 }
 
+func editFlowtimeBreaks(reader *bufio.Reader, cfg *config.Config) error {
+	if len(cfg.Flowtime.BreakRules) > 0 {
+		fmt.Println("\n  Flowtime breaks follow flowtime.break_rules; edit them in the config file.")
+		return nil
+	}
+
+	fmt.Println("\n  Editing earned break")
+	fmt.Printf("  Break per time worked [%s]: ", cfg.Flowtime.BreakRatio)
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		fmt.Println("  No changes made.")
+		return nil
+	}
+	if _, err := domain.ParseBreakRatio(input); err != nil {
+		return err
+	}
+
+	cfg.Flowtime.BreakRatio = input
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	policy, _ := cfg.Flowtime.BreakPolicy()
+	fmt.Printf("\n  Saved: earned break %s\n", policy)
+	return nil
+}
+
 func editPomodoroBreaks(reader *bufio.Reader, cfg *config.Config) error {
 	shortBreak := time.Duration(cfg.Pomodoro.ShortBreak)
 	longBreak := time.Duration(cfg.Pomodoro.LongBreak)
@@ -274,6 +612,8 @@ func editMethodologyBreak(reader *bufio.Reader, cfg *config.Config, meth domain.
 		current = cfg.DeepWork.BreakDuration
 	case domain.MethodologyMakeTime:
 		current = cfg.MakeTime.BreakDuration
+	case domain.MethodologyMob:
+		current = cfg.Mob.BreakDuration
 	}
 
 	fmt.Println("\n  Editing break duration")
@@ -295,6 +635,8 @@ func editMethodologyBreak(reader *bufio.Reader, cfg *config.Config, meth domain.
 		cfg.DeepWork.BreakDuration = config.Duration(dur)
 	case domain.MethodologyMakeTime:
 		cfg.MakeTime.BreakDuration = config.Duration(dur)
+	case domain.MethodologyMob:
+		cfg.Mob.BreakDuration = config.Duration(dur)
 	}
 
 	if err := config.Save(cfg); err != nil {
@@ -315,6 +657,11 @@ func editMethodology(reader *bufio.Reader, cfg *config.Config) error {
 	fmt.Println("    [1] Simple Pomodoro — classic 25/5 timer, quick and frictionless")
 	fmt.Println("    [2] Deep Work       — longer sessions, distraction tracking, shutdown ritual")
 	fmt.Println("    [3] Make Time       — daily Highlight, focus scoring, energize reminders")
+	fmt.Println("    [4] Flowtime        — count up until focus fades, break earned from time worked")
+	fmt.Println("    [5] Mob             — driver/navigator rotation for pairs and mobs, breaks together")
+	for i, mc := range cfg.Methodologies {
+		fmt.Printf("    [%d] %-15s — %s\n", i+6, domain.Methodology(mc.Name).Label(), mc.Description)
+	}
 	fmt.Print("  Choose: ")
 
 	choice, _ := reader.ReadString('\n')
@@ -328,9 +675,17 @@ func editMethodology(reader *bufio.Reader, cfg *config.Config) error {
 		m = "deepwork"
 	case "3":
 		m = "maketime"
+	case "4":
+		m = "flowtime"
+	case "5":
+		m = "mob"
 	default:
-		fmt.Println("  No changes made.")
-		return nil
+		n, err := strconv.Atoi(choice)
+		if err != nil || n < 6 || n-6 >= len(cfg.Methodologies) {
+			fmt.Println("  No changes made.")
+			return nil
+		}
+		m = cfg.Methodologies[n-6].Name
 	}
 
 	cfg.Methodology = m
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/adapters/daemon"
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/services"
+)
+
+// daemonTick is how often the daemon checks the active session.
+const daemonTick = time.Second
+
+// webhookRetryInterval is how often the daemon retries queued webhook deliveries.
+const webhookRetryInterval = 30 * time.Second
+
+// webhookFlushTimeout is how long a command waits on exit for webhook
+// deliveries still being sent; the rest stay queued for the daemon.
+const webhookFlushTimeout = 2 * time.Second
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Watch the active session in the background",
+	Long: `Runs in the foreground, watching the active session. When a session ends
+the daemon records its completion time, sends the desktop notification and,
+with [pomodoro] auto_break, starts the break, even if no timer is open.
+That covers sessions started by scripts or over MCP.
+
+While a timer is open it handles notifications and breaks itself, and the
+daemon only records completions. Set [daemon] auto_start = true to start
+the daemon automatically whenever a session starts.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithCancel(setupSignalHandler())
+		defer cancel()
+
+		logf := func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, "%s "+format+"\n", append([]interface{}{time.Now().Format("2006-01-02T15:04:05")}, args...)...)
+		}
+		svc := services.NewDaemonService(app.storage, app.pomodoro, app.notifier)
+		svc.SetAutoBreak(app.config.Pomodoro.AutoBreak)
+		svc.SetLogger(logf)
+
+		started := time.Now()
+		srv, err := daemon.Listen(socketPath(), func() daemon.Status {
+			status := daemon.Status{PID: os.Getpid(), StartedAt: started}
+			if session := svc.Watching(); session != nil {
+				status.SessionID = session.ID
+				if remaining := session.RemainingTime(); remaining > 0 && session.Status == domain.SessionStatusRunning {
+					endsAt := time.Now().Add(remaining)
+					status.EndsAt = &endsAt
+				}
+			}
+			return status
+		}, cancel)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = srv.Close() }()
+		svc.SetAttached(func() bool { return srv.Attached() > 0 })
+		go func() { _ = srv.Serve() }()
+		go app.webhooks.RunRetries(ctx, webhookRetryInterval, logf)
+
+		fmt.Printf("Flow daemon watching sessions (pid %d, socket %s)\n", os.Getpid(), socketPath())
+		if err := svc.Run(ctx, daemonTick); err != nil && !errors.Is(err, context.Canceled) {
+			return err
+		}
+		return nil
+	},
+}
+
+var daemonStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the daemon in the background",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if status, err := daemon.Query(socketPath()); err == nil {
+			fmt.Printf("Daemon already running (pid %d).\n", status.PID)
+			return nil
+		}
+		if err := spawnDaemon(); err != nil {
+			return err
+		}
+		fmt.Printf("Daemon started. Logs: %s\n", daemonLogPath())
+		return nil
+	},
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the background daemon",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := daemon.Stop(socketPath()); err != nil {
+			return err
+		}
+		fmt.Println("Daemon stopped.")
+		return nil
+	},
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the daemon is running",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, err := daemon.Query(socketPath())
+		if err != nil && !errors.Is(err, daemon.ErrNotRunning) {
+			return err
+		}
+
+		if jsonOutput {
+			data, err := json.MarshalIndent(map[string]interface{}{
+				"running": status != nil,
+				"daemon":  daemonStatusJSON(status),
+			}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if status == nil {
+			fmt.Println("Daemon is not running. Start it with \"flow daemon start\".")
+			return nil
+		}
+		fmt.Printf("Daemon running (pid %d) since %s\n", status.PID, status.StartedAt.Format("2006-01-02 15:04"))
+		if status.SessionID != "" {
+			fmt.Printf("  Watching session %s", status.SessionID[:8])
+			if status.EndsAt != nil {
+				fmt.Printf(", ends at %s", status.EndsAt.Format("15:04:05"))
+			}
+			fmt.Println()
+		}
+		if status.Attached > 0 {
+			fmt.Printf("  %d timer(s) attached\n", status.Attached)
+		}
+		return nil
+	},
+}
+
+// daemonStatusJSON converts a daemon status for --json output, or nil when
+// no daemon is running.
+func daemonStatusJSON(status *daemon.Status) interface{} {
+	if status == nil {
+		return nil
+	}
+	result := map[string]interface{}{
+		"pid":        status.PID,
+		"started_at": status.StartedAt.Format("2006-01-02T15:04:05"),
+		"session_id": nil,
+		"ends_at":    nil,
+		"attached":   status.Attached,
+	}
+	if status.SessionID != "" {
+		result["session_id"] = status.SessionID
+	}
+	if status.EndsAt != nil {
+		result["ends_at"] = status.EndsAt.Format("2006-01-02T15:04:05")
+	}
+	return result
+}
+
+// socketPath returns the daemon's control socket, next to the database.
+func socketPath() string {
+	return filepath.Join(getDir(dbPath), daemon.SocketName)
+}
+
+// daemonLogPath returns where a background daemon writes its log.
+func daemonLogPath() string {
+	return filepath.Join(getDir(dbPath), "daemon.log")
+}
+
+// ensureDaemon starts the background daemon when [daemon] auto_start is set
+// and none is running yet. Failing to start it never blocks a session.
+func ensureDaemon() {
+	if !app.config.Daemon.AutoStart {
+		return
+	}
+	if _, err := daemon.Query(socketPath()); err == nil {
+		return
+	}
+	if err := spawnDaemon(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to start daemon: %v\n", err)
+	}
+}
+
+// spawnDaemon runs "flow daemon" detached from the terminal and waits for
+// its socket, so callers can attach to it right away.
+func spawnDaemon() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the flow executable: %w", err)
+	}
+	logFile, err := os.OpenFile(daemonLogPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open daemon log: %w", err)
+	}
+	defer func() { _ = logFile.Close() }()
+
+	proc := exec.Command(exe, "daemon", "--db", dbPath) //nolint:gosec // re-runs this same binary
+	proc.Stdout = logFile
+	proc.Stderr = logFile
+	proc.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := proc.Start(); err != nil {
+		return fmt.Errorf("failed to start daemon: %w", err)
+	}
+	_ = proc.Process.Release()
+
+	for i := 0; i < 40; i++ {
+		if _, err := daemon.Query(socketPath()); err == nil {
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return fmt.Errorf("daemon did not start listening; see %s", daemonLogPath())
+}
+
+func init() {
+	daemonCmd.AddCommand(daemonStartCmd, daemonStopCmd, daemonStatusCmd)
+	rootCmd.AddCommand(daemonCmd)
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/adapters/storage"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Inspect and migrate the database schema",
+	Long: `Inspect and migrate the Flow database schema.
+
+Flow applies pending migrations automatically on startup. These commands
+open the database without migrating so you can see what is pending first.`,
+	// Overrides the root pre-run, which would migrate the database on open.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return openDatabase()
+	},
+}
+
+var dbStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the schema version and pending migrations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		statuses, err := app.storage.MigrationStatus(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to read migration status: %w", err)
+		}
+
+		current := currentSchemaVersion(statuses)
+
+		if jsonOutput {
+			list := make([]map[string]interface{}, 0, len(statuses))
+			for _, s := range statuses {
+				entry := map[string]interface{}{
+					"version": s.Version,
+					"name":    s.Name,
+					"applied": s.AppliedAt != nil,
+				}
+				if s.AppliedAt != nil {
+					entry["applied_at"] = s.AppliedAt.Format("2006-01-02T15:04:05")
+				}
+				list = append(list, entry)
+			}
+			data, err := json.MarshalIndent(map[string]interface{}{
+				"database":       dbPath,
+				"version":        current,
+				"latest_version": storage.LatestSchemaVersion(),
+				"migrations":     list,
+			}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("Database: %s\n", dbPath)
+		fmt.Printf("Schema version: %d (latest %d)\n\n", current, storage.LatestSchemaVersion())
+
+		pending := 0
+		for _, s := range statuses {
+			if s.AppliedAt != nil {
+				fmt.Printf("  ✓ %03d %-30s applied %s\n", s.Version, s.Name, s.AppliedAt.Local().Format("2006-01-02 15:04"))
+				continue
+			}
+			pending++
+			fmt.Printf("  · %03d %-30s pending\n", s.Version, s.Name)
+		}
+
+		if pending > 0 {
+			fmt.Printf("\n%d pending migration(s). Run 'flow db migrate' to apply.\n", pending)
+		}
+		return nil
+	},
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply pending schema migrations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		before, err := app.storage.MigrationStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read migration status: %w", err)
+		}
+
+		if err := app.storage.Migrate(); err != nil {
+			return fmt.Errorf("failed to migrate database: %w", err)
+		}
+
+		after, err := app.storage.MigrationStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read migration status: %w", err)
+		}
+
+		from, to := currentSchemaVersion(before), currentSchemaVersion(after)
+
+		if jsonOutput {
+			data, err := json.MarshalIndent(map[string]interface{}{
+				"from_version": from,
+				"to_version":   to,
+			}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if from == to {
+			fmt.Printf("Database is up to date (schema version %d).\n", to)
+			return nil
+		}
+		fmt.Printf("Migrated database from schema version %d to %d.\n", from, to)
+		return nil
+	},
+}
+
+var dbRebuildRollupsCmd = &cobra.Command{
+	Use:   "rebuild-rollups",
+	Short: "Recompute the daily stats rollups from every session",
+	Long: `Recompute the daily stats rollups from every session.
+
+Stats, reflect and the heatmap read per-day totals that are kept up to date
+as sessions are saved. Days are bucketed in the local time zone, so run this
+after moving to another time zone, or if the totals ever look wrong.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		statuses, err := app.storage.MigrationStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read migration status: %w", err)
+		}
+		if currentSchemaVersion(statuses) < storage.LatestSchemaVersion() {
+			return fmt.Errorf("database has pending migrations; run 'flow db migrate' first")
+		}
+
+		days, err := app.storage.RebuildRollups(ctx)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			data, err := json.MarshalIndent(map[string]interface{}{"days": days}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("Rebuilt daily rollups for %s.\n", pluralDays(days))
+		return nil
+	},
+}
+
+// openDatabase loads the config and opens the database without migrating it.
+func openDatabase() error {
+	loadConfig()
+
+	if err := resolveDBPath(); err != nil {
+		return err
+	}
+
+	var err error
+	app.storage, err = storage.Open(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	return nil
+}
+
+// currentSchemaVersion returns the highest applied migration version, or 0.
+func currentSchemaVersion(statuses []ports.MigrationStatus) int {
+	current := 0
+	for _, s := range statuses {
+		if s.AppliedAt != nil && s.Version > current {
+			current = s.Version
+		}
+	}
+	return current
+}
+
+func init() {
+	dbCmd.AddCommand(dbStatusCmd)
+	dbCmd.AddCommand(dbMigrateCmd)
+	dbCmd.AddCommand(dbRebuildRollupsCmd)
+	rootCmd.AddCommand(dbCmd)
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+var (
+	distractCategory string
+	distractInternal bool
+	distractExternal bool
+)
+
+var distractCmd = &cobra.Command{
+	Use:   `distract "what distracted you"`,
+	Short: "Log a distraction on the active session",
+	Long: `Log a distraction on the active work session without switching to the
+timer, e.g. from another terminal or a keybinding. The distraction records
+how far into the session it happened.
+
+--category files it under one of the categories in [distractions]
+categories; --internal and --external are shorthands for the default ones.
+
+  flow distract "slack ping" --external
+  flow distract "checked the news" -c internal`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		text := strings.TrimSpace(strings.Join(args, " "))
+		if text == "" {
+			return fmt.Errorf("describe the distraction, e.g. flow distract \"slack ping\"")
+		}
+		category, err := distractionCategory()
+		if err != nil {
+			return err
+		}
+
+		session, err := app.pomodoro.LogActiveDistraction(ctx, text, category)
+		if errors.Is(err, domain.ErrNoActiveSession) {
+			return fmt.Errorf("no active session to log a distraction on")
+		}
+		if errors.Is(err, domain.ErrNotWorkSession) {
+			return fmt.Errorf("distractions are logged during work sessions, not breaks")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to log distraction: %w", err)
+		}
+		logged := session.Distractions[len(session.Distractions)-1]
+
+		if jsonOutput {
+			return printReportJSON(map[string]interface{}{
+				"session_id":     session.ID,
+				"text":           logged.Text,
+				"category":       logged.Category,
+				"at":             logged.At.Format("2006-01-02T15:04:05"),
+				"offset_seconds": int(logged.Offset / time.Second),
+				"distractions":   len(session.Distractions),
+			})
+		}
+
+		label := logged.Text
+		if logged.Category != "" {
+			label += " (" + logged.Category + ")"
+		}
+		fmt.Printf("📝 Distraction logged %s into the session: %s\n", formatMinutes(logged.Offset), label)
+		return nil
+	},
+}
+
+func init() {
+	distractCmd.Flags().StringVarP(&distractCategory, "category", "c", "", "Category from [distractions] categories")
+	distractCmd.Flags().BoolVar(&distractInternal, "internal", false, `Same as --category internal`)
+	distractCmd.Flags().BoolVar(&distractExternal, "external", false, `Same as --category external`)
+	distractCmd.MarkFlagsMutuallyExclusive("category", "internal", "external")
+	rootCmd.AddCommand(distractCmd)
+}
+
+// distractionCategory resolves the category flags against the configured
+// categories; empty means uncategorized.
+func distractionCategory() (string, error) {
+	category := strings.ToLower(strings.TrimSpace(distractCategory))
+	switch {
+	case distractInternal:
+		category = "internal"
+	case distractExternal:
+		category = "external"
+	}
+	if category == "" {
+		return "", nil
+	}
+	categories := app.config.Distractions.CategoryList()
+	if !slices.Contains(categories, category) {
+		return "", fmt.Errorf("unknown category %q: use one of %s, or add it to [distractions] categories", category, strings.Join(categories, ", "))
+	}
+	return category, nil
+}
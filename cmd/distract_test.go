@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/xvierd/flow-cli/internal/config"
+)
+
+func TestDistractionCategory(t *testing.T) {
+	saved := app
+	defer func() { app = saved }()
+	app.config = config.DefaultConfig()
+	defer func() { distractCategory, distractInternal, distractExternal = "", false, false }()
+
+	tests := []struct {
+		category           string
+		internal, external bool
+		configured         []string
+		want               string
+		wantErr            bool
+	}{
+		{want: ""},
+		{external: true, want: "external"},
+		{internal: true, want: "internal"},
+		{category: " Slack", configured: []string{"slack", "meeting"}, want: "slack"},
+		{category: "hunger", wantErr: true},
+		{external: true, configured: []string{"slack"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		distractCategory, distractInternal, distractExternal = tt.category, tt.internal, tt.external
+		app.config.Distractions.Categories = tt.configured
+		got, err := distractionCategory()
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("distractionCategory(%+v) = %q, %v; want %q, error %v", tt, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+var distractionsTop int
+
+var distractionsCmd = &cobra.Command{
+	Use:   "distractions",
+	Short: "Show what distracts you, and when",
+	Long: `Break down the distractions logged during completed work sessions: how many
+fall in each category and methodology, the most frequent ones, how far into
+a session they happen and at what time of day.
+
+Similar wordings are grouped, so "Slack ping", "slack pings" and "ping on
+slack" count as one. --top sets how many of these groups to show.
+
+Categories come from [distractions] categories in the config file
+(internal and external by default). Times are only known for distractions
+logged since this version, so the time into a session only counts those.
+
+The range flags work as in "flow stats": the current week by default,
+--period and --offset for other periods, or --from/--to and --last for any
+range of days. --json prints the report in the layout "flow schema
+distractions" describes.
+
+  flow distractions --period month
+  flow distractions --last 30d --top 20`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		current, _, err := statsRange(time.Now(), cmd.Flags().Changed("period"))
+		if err != nil {
+			return err
+		}
+		if distractionsTop < 0 {
+			return fmt.Errorf("invalid --top %d: use 0 for all or a positive number", distractionsTop)
+		}
+
+		report, err := app.reports.Distractions(ctx, ports.DistractionQuery{
+			Start:      current.Start,
+			End:        current.End,
+			Label:      current.Label,
+			Categories: app.config.Distractions.CategoryList(),
+			Top:        distractionsTop,
+		})
+		if err != nil {
+			return err
+		}
+
+		if formatTemplate != "" {
+			return printTemplate(formatTemplate, report)
+		}
+		if jsonOutput {
+			return printReportJSON(report)
+		}
+
+		fmt.Println()
+		renderDistractions(report)
+		return nil
+	},
+}
+
+func init() {
+	// The range flags share their variables with "flow stats", so
+	// statsRange resolves them for either command.
+	distractionsCmd.Flags().StringVarP(&statsPeriod, "period", "p", "week", "Time period: week, month, quarter or year")
+	distractionsCmd.Flags().IntVar(&statsOffset, "offset", 0, "Periods to step back from the current one (e.g. -1 for the previous)")
+	distractionsCmd.Flags().StringVar(&statsFrom, "from", "", "First day of a custom range (YYYY-MM-DD)")
+	distractionsCmd.Flags().StringVar(&statsTo, "to", "", "Last day of a custom range (YYYY-MM-DD, default today)")
+	distractionsCmd.Flags().StringVar(&statsLast, "last", "", "Custom range ending today (e.g. 30d, 6w, 3m, 1y)")
+	distractionsCmd.Flags().IntVar(&distractionsTop, "top", 10, "How many of the most frequent distractions to show (0 for all)")
+	distractionsCmd.Flags().StringVar(&formatTemplate, "format", "", formatFlagUsage)
+	rootCmd.AddCommand(distractionsCmd)
+}
+
+func renderDistractions(report *ports.DistractionReport) {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C6FE0"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	valueStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
+	barColor := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C6FE0"))
+
+	fmt.Printf("  %s\n", titleStyle.Render(report.Label+" · distractions"))
+	fmt.Printf("  %s\n\n", dimStyle.Render(strings.Repeat("─", 40)))
+
+	if report.Total == 0 {
+		fmt.Printf("  %s\n\n", dimStyle.Render(fmt.Sprintf("No distractions logged in %d completed sessions.", report.Sessions)))
+		return
+	}
+	fmt.Printf("  Total: %s in %s of %s sessions (%s per session)\n\n",
+		valueStyle.Render(fmt.Sprintf("%d", report.Total)),
+		valueStyle.Render(fmt.Sprintf("%d", report.DistractedSessions)),
+		valueStyle.Render(fmt.Sprintf("%d", report.Sessions)),
+		valueStyle.Render(fmt.Sprintf("%.1f", float64(report.Total)/float64(report.Sessions))),
+	)
+
+	fmt.Printf("  %s\n", dimStyle.Render("By category"))
+	categories := make([]barEntry, 0, len(report.ByCategory))
+	for _, c := range report.ByCategory {
+		categories = append(categories, barEntry{label: c.Category, count: c.Count})
+	}
+	renderCountBars(categories, dimStyle, barColor)
+
+	fmt.Printf("  %s\n", dimStyle.Render("By methodology"))
+	for _, m := range report.ByMethodology {
+		fmt.Printf("  %s %s %s\n",
+			dimStyle.Render(fmt.Sprintf("%-14s", m.Methodology)),
+			valueStyle.Render(fmt.Sprintf("%.1f", m.PerSession)),
+			dimStyle.Render(fmt.Sprintf("per session (%d in %d)", m.Distractions, m.Sessions)),
+		)
+	}
+	fmt.Println()
+
+	fmt.Printf("  %s\n", dimStyle.Render("Most frequent"))
+	for i, t := range report.Themes {
+		line := fmt.Sprintf("  %2d. %s %s", i+1, t.Phrase, valueStyle.Render(fmt.Sprintf("×%d", t.Count)))
+		if len(t.Variants) > 0 {
+			line += dimStyle.Render("  also " + strings.Join(t.Variants, ", "))
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+
+	if report.Timed > 0 {
+		fmt.Printf("  %s\n", dimStyle.Render(fmt.Sprintf("Time into session (%d of %d logged with a time)", report.Timed, report.Total)))
+		offsets := make([]barEntry, 0, len(report.ByOffset))
+		for _, o := range report.ByOffset {
+			var label string
+			if o.ToMinutes != nil {
+				label = fmt.Sprintf("%d-%dm", o.FromMinutes, *o.ToMinutes)
+			} else {
+				label = fmt.Sprintf("%dm+", o.FromMinutes)
+			}
+			offsets = append(offsets, barEntry{label: label, count: o.Count})
+		}
+		renderCountBars(offsets, dimStyle, barColor)
+	}
+
+	fmt.Printf("  %s\n", dimStyle.Render("By hour of day"))
+	hours := make([]barEntry, 0, len(report.ByHour))
+	for _, h := range report.ByHour {
+		hours = append(hours, barEntry{label: fmt.Sprintf("%02d:00", h.Hour), count: h.Count})
+	}
+	renderCountBars(hours, dimStyle, barColor)
+}
+
+// barEntry is one labelled row of a count bar chart.
+type barEntry struct {
+	label string
+	count int
+}
+
+// renderCountBars prints counts as horizontal bars scaled to the largest.
+func renderCountBars(entries []barEntry, dimStyle, barColor lipgloss.Style) {
+	maxCount := 0
+	for _, e := range entries {
+		maxCount = max(maxCount, e.count)
+	}
+	maxBarWidth := 20
+	for _, e := range entries {
+		barWidth := 0
+		if maxCount > 0 {
+			barWidth = int(math.Round(float64(e.count) / float64(maxCount) * float64(maxBarWidth)))
+		}
+		if barWidth < 1 && e.count > 0 {
+			barWidth = 1
+		}
+		fmt.Printf("  %s %s %d\n",
+			dimStyle.Render(fmt.Sprintf("%-14s", e.label)),
+			barColor.Render(buildBar(barWidth)),
+			e.count,
+		)
+	}
+	fmt.Println()
+}
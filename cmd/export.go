@@ -3,8 +3,10 @@ package cmd
 import (
 	"context"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,14 +15,37 @@ import (
 )
 
 var (
-	exportFormat string
-	exportPeriod string
+	exportFormat  string
+	exportPeriod  string
+	exportTag     string
+	exportGroupBy string
 )
 
+// untaggedGroup heads the group of sessions without tags in grouped exports.
+const untaggedGroup = "untagged"
+
 var exportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export session history",
-	Long:  "Export your session history in markdown or CSV format.",
+	Long: `Export your session history in markdown or CSV format.
+
+--format json writes a lossless dump of every task, session and plan block
+(including breaks, pause data, git context and highlight dates) that
+"flow import" can merge back in. It always covers the whole database and
+ignores --period, --tag and --group-by.
+
+--tag keeps only sessions tagged with that tag or a tag nested beneath it,
+on the session itself or on its task. --group-by tag groups sessions under
+each of their tags; a session with several tags appears in each group.
+
+Any other --format is a Go template, inline or the name of a file in
+~/.flow/templates/ (e.g. --format weekly for weekly.tmpl). It sees the
+filtered work sessions as .sessions, and in .groups when --group-by is set:
+
+  flow export --format '{{range .sessions}}{{.started_at}} {{.task}}{{"\n"}}{{end}}'
+
+--json prints that same filtered model as JSON, in the layout "flow schema
+export" describes.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runExport(cmd.Context())
 	},
@@ -28,11 +53,20 @@ var exportCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(exportCmd)
-	exportCmd.Flags().StringVar(&exportFormat, "format", "md", "Output format: md or csv")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "md", "Output format: md, csv, json, or a Go template")
 	exportCmd.Flags().StringVar(&exportPeriod, "period", "week", "Time period: week, month, or all")
+	exportCmd.Flags().StringVar(&exportTag, "tag", "", "Only export sessions with this tag or a tag nested beneath it")
+	exportCmd.Flags().StringVar(&exportGroupBy, "group-by", "", "Group sessions: tag")
 }
 
 func runExport(ctx context.Context) error {
+	if exportFormat == "json" {
+		return exportJSON(ctx)
+	}
+	if exportGroupBy != "" && exportGroupBy != "tag" {
+		return fmt.Errorf("invalid --group-by %q: only \"tag\" is supported", exportGroupBy)
+	}
+
 	var since time.Time
 	switch exportPeriod {
 	case "week":
@@ -48,109 +82,367 @@ func runExport(ctx context.Context) error {
 		return fmt.Errorf("failed to fetch sessions: %w", err)
 	}
 
+	var work []*domain.PomodoroSession
+	for _, s := range sessions {
+		if s.IsWorkSession() {
+			work = append(work, s)
+		}
+	}
+
+	tagsOf := func(s *domain.PomodoroSession) []string { return s.Tags }
+	if exportTag != "" || exportGroupBy == "tag" {
+		tagsOf, err = effectiveTags(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	if exportTag != "" {
+		kept := work[:0]
+		for _, s := range work {
+			if domain.HasTag(tagsOf(s), exportTag) {
+				kept = append(kept, s)
+			}
+		}
+		work = kept
+	}
+
+	var groups []sessionGroup
+	if exportGroupBy == "tag" {
+		groups = groupSessionsByTag(work, tagsOf)
+	} else {
+		groups = []sessionGroup{{Sessions: work}}
+	}
+
+	if jsonOutput {
+		data, err := exportData(ctx, since, groups)
+		if err != nil {
+			return err
+		}
+		return printReportJSON(data)
+	}
+
 	switch exportFormat {
 	case "csv":
-		return exportCSV(sessions)
+		return exportCSV(groups)
+	case "md", "":
+		return exportMarkdown(groups)
 	default:
-		return exportMarkdown(sessions)
+		data, err := exportData(ctx, since, groups)
+		if err != nil {
+			return err
+		}
+		return printTemplate(exportFormat, data)
 	}
 }
 
-func exportMarkdown(sessions []*domain.PomodoroSession) error {
-	fmt.Printf("# Flow Session Export\n\n")
-	fmt.Printf("Generated: %s\n\n", time.Now().Format("2006-01-02 15:04"))
+// exportData builds the model a template export sees: the filtered work
+// sessions, both flat and in their groups.
+func exportData(ctx context.Context, since time.Time, groups []sessionGroup) (map[string]interface{}, error) {
+	tasks, err := app.storage.Tasks().FindAll(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	titles := make(map[string]string, len(tasks))
+	for _, t := range tasks {
+		titles[t.ID] = t.Title
+	}
 
-	for _, s := range sessions {
-		if !s.IsWorkSession() {
-			continue
+	groupList := make([]map[string]interface{}, 0, len(groups))
+	all := []map[string]interface{}{}
+	seen := map[string]bool{}
+	for _, g := range groups {
+		sessions := make([]map[string]interface{}, 0, len(g.Sessions))
+		for _, s := range g.Sessions {
+			entry := exportSessionData(s, titles)
+			sessions = append(sessions, entry)
+			if !seen[s.ID] {
+				seen[s.ID] = true
+				all = append(all, entry)
+			}
 		}
-		fmt.Printf("## %s — %s\n", s.StartedAt.Format("2006-01-02"), s.Methodology)
-		fmt.Printf("- Duration: %s\n", s.Duration.String())
-		if s.IntendedOutcome != "" {
-			fmt.Printf("- Goal: %s\n", s.IntendedOutcome)
+		groupList = append(groupList, map[string]interface{}{"name": g.Name, "sessions": sessions})
+	}
+
+	result := map[string]interface{}{
+		"generated_at": time.Now().Format("2006-01-02T15:04:05"),
+		"period":       exportPeriod,
+		"since":        nil,
+		"tag":          nil,
+		"group_by":     nil,
+		"groups":       groupList,
+		"sessions":     all,
+		"count":        len(all),
+	}
+	if !since.IsZero() {
+		result["since"] = since.Format("2006-01-02T15:04:05")
+	}
+	if exportTag != "" {
+		result["tag"] = domain.NormalizeTag(exportTag)
+	}
+	if exportGroupBy != "" {
+		result["group_by"] = exportGroupBy
+	}
+	return result, nil
+}
+
+// exportSessionData describes one exported session. titles maps task IDs
+// to titles.
+func exportSessionData(s *domain.PomodoroSession, titles map[string]string) map[string]interface{} {
+	end := s.WallClockEnd(time.Now())
+	pauses, paused := s.Pauses(end)
+
+	distractions := make([]map[string]interface{}, 0, len(s.Distractions))
+	for _, d := range s.Distractions {
+		entry := map[string]interface{}{
+			"text":     d.Text,
+			"category": d.Category,
 		}
-		if s.Accomplishment != "" {
-			fmt.Printf("- Accomplished: %s\n", s.Accomplishment)
+		if offset, ok := s.DistractionOffset(d); ok {
+			entry["at"] = d.At.Format("2006-01-02T15:04:05")
+			entry["offset_seconds"] = int(offset / time.Second)
 		}
-		if s.FocusScore != nil {
-			fmt.Printf("- Focus: %d/5\n", *s.FocusScore)
+		distractions = append(distractions, entry)
+	}
+
+	entry := map[string]interface{}{
+		"id":                s.ID,
+		"task_id":           s.TaskID,
+		"task":              "",
+		"methodology":       string(s.Methodology),
+		"status":            string(s.Status),
+		"started_at":        s.StartedAt.Format("2006-01-02T15:04:05"),
+		"ended_at":          end.Format("2006-01-02T15:04:05"),
+		"duration_seconds":  int(s.Duration.Seconds()),
+		"worked_seconds":    int(s.ElapsedTime().Seconds()),
+		"pauses":            pauses,
+		"paused_seconds":    int(paused.Seconds()),
+		"tags":              s.Tags,
+		"intended_outcome":  s.IntendedOutcome,
+		"accomplishment":    s.Accomplishment,
+		"notes":             s.Notes,
+		"focus_score":       s.FocusScore,
+		"energize_activity": s.EnergizeActivity,
+		"distractions":      distractions,
+		"git_branch":        s.GitBranch,
+		"git_commit":        s.GitCommit,
+	}
+	if s.TaskID != nil {
+		entry["task"] = titles[*s.TaskID]
+	}
+	return entry
+}
+
+// sessionGroup is a run of exported sessions under one heading. Name is
+// empty when the export is not grouped.
+type sessionGroup struct {
+	Name     string
+	Sessions []*domain.PomodoroSession
+}
+
+// effectiveTags returns a function giving each session's own tags followed
+// by those of its task.
+func effectiveTags(ctx context.Context) (func(*domain.PomodoroSession) []string, error) {
+	tasks, err := app.storage.Tasks().FindAll(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	taskTags := make(map[string][]string, len(tasks))
+	for _, t := range tasks {
+		taskTags[t.ID] = t.Tags
+	}
+	return func(s *domain.PomodoroSession) []string {
+		tags := append([]string{}, s.Tags...)
+		if s.TaskID != nil {
+			tags = append(tags, taskTags[*s.TaskID]...)
 		}
-		if s.EnergizeActivity != "" {
-			fmt.Printf("- Energize: %s\n", s.EnergizeActivity)
+		return domain.NormalizeTags(tags)
+	}, nil
+}
+
+// groupSessionsByTag groups sessions under each of their tags, sorted by
+// tag name, with untagged sessions last.
+func groupSessionsByTag(sessions []*domain.PomodoroSession, tagsOf func(*domain.PomodoroSession) []string) []sessionGroup {
+	byTag := map[string]*sessionGroup{}
+	var untagged []*domain.PomodoroSession
+	for _, s := range sessions {
+		tags := tagsOf(s)
+		if len(tags) == 0 {
+			untagged = append(untagged, s)
+			continue
 		}
-		if len(s.Distractions) > 0 {
-			fmt.Printf("- Distractions (%d):\n", len(s.Distractions))
-			for _, d := range s.Distractions {
-				if d.Category != "" {
-					fmt.Printf("  - [%s] %s\n", d.Category, d.Text)
-				} else {
-					fmt.Printf("  - %s\n", d.Text)
-				}
+		for _, tag := range tags {
+			key := strings.ToLower(tag)
+			g, ok := byTag[key]
+			if !ok {
+				g = &sessionGroup{Name: tag}
+				byTag[key] = g
 			}
+			g.Sessions = append(g.Sessions, s)
 		}
-		if s.ShutdownRitual != nil {
-			if s.ShutdownRitual.PendingTasksReview != "" {
-				fmt.Printf("- Pending review: %s\n", s.ShutdownRitual.PendingTasksReview)
-			}
-			if s.ShutdownRitual.CalendarReview != "" {
-				fmt.Printf("- Calendar review: %s\n", s.ShutdownRitual.CalendarReview)
-			}
-			if s.ShutdownRitual.TomorrowPlan != "" {
-				fmt.Printf("- Tomorrow: %s\n", s.ShutdownRitual.TomorrowPlan)
-			}
-			if s.ShutdownRitual.ClosingPhrase != "" {
-				fmt.Printf("- Closing: %s\n", s.ShutdownRitual.ClosingPhrase)
+	}
+
+	keys := make([]string, 0, len(byTag))
+	for k := range byTag {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	groups := make([]sessionGroup, 0, len(keys)+1)
+	for _, k := range keys {
+		groups = append(groups, *byTag[k])
+	}
+	if len(untagged) > 0 {
+		groups = append(groups, sessionGroup{Name: untaggedGroup, Sessions: untagged})
+	}
+	return groups
+}
+
+func exportJSON(ctx context.Context) error {
+	archive, err := app.archive.Export(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func exportMarkdown(groups []sessionGroup) error {
+	fmt.Printf("# Flow Session Export\n\n")
+	fmt.Printf("Generated: %s\n\n", time.Now().Format("2006-01-02 15:04"))
+
+	for _, g := range groups {
+		heading := "##"
+		if g.Name != "" {
+			heading = "###"
+			if g.Name == untaggedGroup {
+				fmt.Printf("## Untagged\n\n")
+			} else {
+				fmt.Printf("## #%s\n\n", g.Name)
 			}
 		}
-		fmt.Println()
+		for _, s := range g.Sessions {
+			writeMarkdownSession(s, heading)
+		}
 	}
 	return nil
 }
 
-func exportCSV(sessions []*domain.PomodoroSession) error {
+// writeMarkdownSession writes one session as a markdown section.
+func writeMarkdownSession(s *domain.PomodoroSession, heading string) {
+	fmt.Printf("%s %s — %s\n", heading, s.StartedAt.Format("2006-01-02"), s.Methodology)
+	fmt.Printf("- Duration: %s\n", s.Duration.String())
+	end := s.WallClockEnd(time.Now())
+	span := fmt.Sprintf("%s–%s", s.StartedAt.Format("15:04"), end.Format("15:04"))
+	if pauses, paused := s.Pauses(end); pauses > 0 {
+		span += fmt.Sprintf(" (%d pauses, %s paused)", pauses, formatHours(paused.Hours()))
+	}
+	fmt.Printf("- Span: %s\n", span)
+	if s.IntendedOutcome != "" {
+		fmt.Printf("- Goal: %s\n", s.IntendedOutcome)
+	}
+	if s.Accomplishment != "" {
+		fmt.Printf("- Accomplished: %s\n", s.Accomplishment)
+	}
+	if s.FocusScore != nil {
+		fmt.Printf("- Focus: %d/5\n", *s.FocusScore)
+	}
+	if s.EnergizeActivity != "" {
+		fmt.Printf("- Energize: %s\n", s.EnergizeActivity)
+	}
+	if len(s.Distractions) > 0 {
+		fmt.Printf("- Distractions (%d):\n", len(s.Distractions))
+		for _, d := range s.Distractions {
+			if d.Category != "" {
+				fmt.Printf("  - [%s] %s\n", d.Category, d.Text)
+			} else {
+				fmt.Printf("  - %s\n", d.Text)
+			}
+		}
+	}
+	if s.ShutdownRitual != nil {
+		if s.ShutdownRitual.PendingTasksReview != "" {
+			fmt.Printf("- Pending review: %s\n", s.ShutdownRitual.PendingTasksReview)
+		}
+		if s.ShutdownRitual.CalendarReview != "" {
+			fmt.Printf("- Calendar review: %s\n", s.ShutdownRitual.CalendarReview)
+		}
+		if s.ShutdownRitual.TomorrowPlan != "" {
+			fmt.Printf("- Tomorrow: %s\n", s.ShutdownRitual.TomorrowPlan)
+		}
+		if s.ShutdownRitual.ClosingPhrase != "" {
+			fmt.Printf("- Closing: %s\n", s.ShutdownRitual.ClosingPhrase)
+		}
+	}
+	fmt.Println()
+}
+
+func exportCSV(groups []sessionGroup) error {
 	w := csv.NewWriter(os.Stdout)
 	defer w.Flush()
 
-	_ = w.Write([]string{
+	header := []string{
 		"date", "methodology", "duration_min", "goal", "accomplished",
 		"focus_score", "tags", "energize_activity", "distraction_count",
 		"distractions", "pending_tasks_review", "calendar_review", "tomorrow_plan",
-	})
+		"started_at", "ended_at", "pause_count", "paused_min",
+	}
+	grouped := len(groups) > 0 && groups[0].Name != ""
+	if grouped {
+		header = append([]string{"group"}, header...)
+	}
+	_ = w.Write(header)
 
-	for _, s := range sessions {
-		if !s.IsWorkSession() {
-			continue
-		}
-		focusScore := ""
-		if s.FocusScore != nil {
-			focusScore = fmt.Sprintf("%d", *s.FocusScore)
+	for _, g := range groups {
+		for _, s := range g.Sessions {
+			record := csvRecord(s)
+			if grouped {
+				record = append([]string{g.Name}, record...)
+			}
+			_ = w.Write(record)
 		}
-		distractionCount := fmt.Sprintf("%d", len(s.Distractions))
-		var distractionTexts []string
-		for _, d := range s.Distractions {
-			distractionTexts = append(distractionTexts, d.Text)
-		}
-		pendingTasksReview, calendarReview, tomorrowPlan := "", "", ""
-		if s.ShutdownRitual != nil {
-			pendingTasksReview = s.ShutdownRitual.PendingTasksReview
-			calendarReview = s.ShutdownRitual.CalendarReview
-			tomorrowPlan = s.ShutdownRitual.TomorrowPlan
-		}
-		_ = w.Write([]string{
-			s.StartedAt.Format("2006-01-02"),
-			string(s.Methodology),
-			fmt.Sprintf("%.0f", s.Duration.Minutes()),
-			s.IntendedOutcome,
-			s.Accomplishment,
-			focusScore,
-			strings.Join(s.Tags, ";"),
-			s.EnergizeActivity,
-			distractionCount,
-			strings.Join(distractionTexts, "; "),
-			pendingTasksReview,
-			calendarReview,
-			tomorrowPlan,
-		})
 	}
 	return nil
 }
+
+// csvRecord returns the CSV columns for one session.
+func csvRecord(s *domain.PomodoroSession) []string {
+	focusScore := ""
+	if s.FocusScore != nil {
+		focusScore = fmt.Sprintf("%d", *s.FocusScore)
+	}
+	distractionCount := fmt.Sprintf("%d", len(s.Distractions))
+	var distractionTexts []string
+	for _, d := range s.Distractions {
+		distractionTexts = append(distractionTexts, d.Text)
+	}
+	pendingTasksReview, calendarReview, tomorrowPlan := "", "", ""
+	if s.ShutdownRitual != nil {
+		pendingTasksReview = s.ShutdownRitual.PendingTasksReview
+		calendarReview = s.ShutdownRitual.CalendarReview
+		tomorrowPlan = s.ShutdownRitual.TomorrowPlan
+	}
+	end := s.WallClockEnd(time.Now())
+	pauses, paused := s.Pauses(end)
+	return []string{
+		s.StartedAt.Format("2006-01-02"),
+		string(s.Methodology),
+		fmt.Sprintf("%.0f", s.Duration.Minutes()),
+		s.IntendedOutcome,
+		s.Accomplishment,
+		focusScore,
+		strings.Join(s.Tags, ";"),
+		s.EnergizeActivity,
+		distractionCount,
+		strings.Join(distractionTexts, "; "),
+		pendingTasksReview,
+		calendarReview,
+		tomorrowPlan,
+		s.StartedAt.Format("2006-01-02T15:04:05"),
+		end.Format("2006-01-02T15:04:05"),
+		fmt.Sprintf("%d", pauses),
+		fmt.Sprintf("%.0f", paused.Minutes()),
+	}
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/xvierd/flow-cli/internal/config"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// heatmapCell is the glyph of one day in the heatmap grid.
+const heatmapCell = "■"
+
+// renderHeatmap prints a year of daily focus time as a GitHub-style grid,
+// a week per column, shaded with the theme's work gradient.
+func renderHeatmap(h *ports.Heatmap, theme config.ThemeConfig) {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C6FE0"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	valueStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
+	levels := heatmapLevelStyles(theme)
+
+	title := "Focus time, last 12 months"
+	if h.Metric == ports.HeatmapMetricDeepWork {
+		title = "Deep work, last 12 months"
+	}
+	fmt.Printf("  %s\n", titleStyle.Render(title))
+	fmt.Printf("  %s\n\n", dimStyle.Render(strings.Repeat("─", 40)))
+
+	// Weeks are two columns wide, or one on terminals too narrow for that
+	weeks := (len(h.Days) + 6) / 7
+	cellWidth, gap := 2, " "
+	if width, _, err := term.GetSize(os.Stdout.Fd()); err == nil && width < 7+weeks*2 {
+		cellWidth, gap = 1, ""
+	}
+
+	// Month labels above the first week that holds the 1st of the month
+	labels := make([]byte, weeks*cellWidth)
+	for i := range labels {
+		labels[i] = ' '
+	}
+	lastLabel := -4
+	for w := 0; w < weeks; w++ {
+		for d := 0; d < 7 && w*7+d < len(h.Days); d++ {
+			date, _ := time.Parse("2006-01-02", h.Days[w*7+d].Date)
+			at := w * cellWidth
+			if date.Day() == 1 && at >= lastLabel+4 && at+3 <= len(labels) {
+				copy(labels[at:], date.Format("Jan"))
+				lastLabel = at
+			}
+		}
+	}
+	fmt.Printf("       %s\n", dimStyle.Render(strings.TrimRight(string(labels), " ")))
+
+	// One row per weekday, Monday first
+	for d := 0; d < 7; d++ {
+		name := ""
+		if d%2 == 0 && d < 6 {
+			name = time.Weekday((d + 1) % 7).String()[:3]
+		}
+		var row strings.Builder
+		for w := 0; w < weeks; w++ {
+			i := w*7 + d
+			if i >= len(h.Days) {
+				break
+			}
+			if w > 0 {
+				row.WriteString(gap)
+			}
+			row.WriteString(levels[h.Days[i].Level].Render(heatmapCell))
+		}
+		fmt.Printf("  %s %s\n", dimStyle.Render(fmt.Sprintf("%-4s", name)), row.String())
+	}
+	fmt.Println()
+
+	// Legend: the minutes each shade stands for
+	var legend strings.Builder
+	legend.WriteString(dimStyle.Render("Less "))
+	for _, style := range levels {
+		legend.WriteString(style.Render(heatmapCell))
+		legend.WriteString(" ")
+	}
+	legend.WriteString(dimStyle.Render("More"))
+	if len(h.Thresholds) == 3 {
+		t := h.Thresholds
+		legend.WriteString(dimStyle.Render(fmt.Sprintf("   ≤%s  ≤%s  ≤%s  >%s",
+			formatHours(secondsToHours(t[0])), formatHours(secondsToHours(t[1])),
+			formatHours(secondsToHours(t[2])), formatHours(secondsToHours(t[2])))))
+	}
+	fmt.Printf("       %s\n\n", legend.String())
+
+	// Totals and streaks
+	fmt.Printf("  %s  %s  %s  %s\n",
+		dimStyle.Render("Total:"),
+		valueStyle.Render(formatHours(secondsToHours(h.TotalSeconds))),
+		dimStyle.Render("Active days:"),
+		valueStyle.Render(fmt.Sprintf("%d", h.ActiveDays)),
+	)
+	fmt.Printf("  %s  %s  %s  %s\n\n",
+		dimStyle.Render("Current streak:"),
+		valueStyle.Render(pluralDays(h.CurrentStreak)),
+		dimStyle.Render("Longest streak:"),
+		valueStyle.Render(pluralDays(h.LongestStreak)),
+	)
+
+	// Per-month totals, four to a line
+	fmt.Printf("  %s\n", dimStyle.Render("By month"))
+	for i, m := range h.Months {
+		month, _ := time.Parse("2006-01", m.Month)
+		fmt.Printf("  %s %s",
+			dimStyle.Render(month.Format("Jan 06")),
+			valueStyle.Render(fmt.Sprintf("%-9s", formatHours(secondsToHours(m.Seconds)))),
+		)
+		if i%4 == 3 || i == len(h.Months)-1 {
+			fmt.Println()
+		}
+	}
+	fmt.Println()
+}
+
+// heatmapLevelStyles returns the style of each heatmap level: a muted
+// shade of the paused color for days without work, then four steps along
+// the work gradient.
+func heatmapLevelStyles(theme config.ThemeConfig) []lipgloss.Style {
+	styles := []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(lipgloss.Color(blendHex(theme.ColorPaused, "#000000", 0.5))),
+	}
+	for i := 0; i < 4; i++ {
+		color := blendHex(theme.WorkGradientStart, theme.WorkGradientEnd, float64(i)/3)
+		styles = append(styles, lipgloss.NewStyle().Foreground(lipgloss.Color(color)))
+	}
+	return styles
+}
+
+// blendHex mixes two #rrggbb colors, t of the way from a to b. A color that
+// doesn't parse is returned as is.
+func blendHex(a, b string, t float64) string {
+	ra, ga, ba, okA := parseHex(a)
+	rb, gb, bb, okB := parseHex(b)
+	if !okA || !okB {
+		return a
+	}
+	mix := func(x, y int) int { return x + int(float64(y-x)*t+0.5) }
+	return fmt.Sprintf("#%02X%02X%02X", mix(ra, rb), mix(ga, gb), mix(ba, bb))
+}
+
+func parseHex(color string) (r, g, b int, ok bool) {
+	if len(color) != 7 || color[0] != '#' {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(color[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return int(v >> 16 & 0xFF), int(v >> 8 & 0xFF), int(v & 0xFF), true
+}
+
+func pluralDays(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
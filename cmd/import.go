@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/services"
+)
+
+var importDryRun bool
+
+var importCmd = &cobra.Command{
+	Use:   "import <file.json>",
+	Short: "Merge a JSON export into the database",
+	Long: `Merges a dump written by "flow export --format json" into this database.
+
+Tasks, sessions and plan blocks are matched by ID. New records are added,
+identical ones are skipped, and records that differ from the local copy are
+left untouched and reported as conflicts, as are plan blocks overlapping the
+local plan. Use "-" to read from stdin.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(args[0]) //nolint:gosec // path is chosen by the user on the command line
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[0], err)
+		}
+
+		var archive services.Archive
+		if err := json.Unmarshal(data, &archive); err != nil {
+			return fmt.Errorf("invalid export file: %w", err)
+		}
+
+		result, err := app.archive.Import(cmd.Context(), &archive, importDryRun)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			conflicts := make([]map[string]interface{}, 0, len(result.Conflicts))
+			for _, c := range result.Conflicts {
+				conflicts = append(conflicts, map[string]interface{}{
+					"kind":   c.Kind,
+					"id":     c.ID,
+					"reason": c.Reason,
+				})
+			}
+			out, err := json.MarshalIndent(map[string]interface{}{
+				"dry_run":            importDryRun,
+				"tasks_added":        result.TasksAdded,
+				"tasks_unchanged":    result.TasksUnchanged,
+				"sessions_added":     result.SessionsAdded,
+				"sessions_unchanged": result.SessionsUnchanged,
+				"blocks_added":       result.BlocksAdded,
+				"blocks_unchanged":   result.BlocksUnchanged,
+				"conflicts":          conflicts,
+			}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+
+		verb := "Imported"
+		if importDryRun {
+			verb = "Would import"
+		}
+		fmt.Printf("%s %d task(s), %d session(s) and %d plan block(s).\n", verb, result.TasksAdded, result.SessionsAdded, result.BlocksAdded)
+		if result.TasksUnchanged > 0 || result.SessionsUnchanged > 0 || result.BlocksUnchanged > 0 {
+			fmt.Printf("Already present: %d task(s), %d session(s), %d plan block(s).\n", result.TasksUnchanged, result.SessionsUnchanged, result.BlocksUnchanged)
+		}
+		if len(result.Conflicts) > 0 {
+			fmt.Printf("\n%d conflict(s), not imported:\n", len(result.Conflicts))
+			for _, c := range result.Conflicts {
+				fmt.Printf("  %-7s %s  %s\n", c.Kind, c.ID, c.Reason)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Report what would be imported without writing anything")
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// insightsDefaultLast is the range insights covers without range flags:
+// a week rarely holds enough scored sessions to compare conditions.
+const insightsDefaultLast = "90d"
+
+var (
+	insightsMinSessions int
+	insightsTop         int
+)
+
+var insightsCmd = &cobra.Command{
+	Use:   "insights",
+	Short: "Find the conditions behind your best focus scores",
+	Long: `Relate the focus scores of completed work sessions to the conditions they
+ran under: hour of day, session length, day of week, tags, git branch,
+distractions logged and methodology. The report names the conditions, alone
+or in pairs, under which your sessions score best, e.g. "your 50m sessions
+before 11:00 average 4.4".
+
+Make Time always asks for a focus score when a session ends; set
+focus_score = true under [pomodoro] or [deepwork] to be asked there too.
+
+--min-sessions sets how many scored sessions a condition, tag or branch
+needs before it is listed, and --top how many conditions to show.
+
+The last 90 days are covered by default. The range flags work as in "flow
+stats": --period and --offset, or --from/--to and --last for any range of
+days. --json prints the report in the layout "flow schema insights"
+describes.
+
+  flow insights
+  flow insights --last 1y --min-sessions 10`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		// The range variables are shared with other commands, so the
+		// default range is applied here rather than as a flag default.
+		flags := cmd.Flags()
+		if !flags.Changed("period") && !flags.Changed("offset") && !flags.Changed("from") && !flags.Changed("to") && !flags.Changed("last") {
+			statsLast = insightsDefaultLast
+		}
+		current, _, err := statsRange(time.Now(), flags.Changed("period"))
+		if err != nil {
+			return err
+		}
+		if insightsMinSessions < 1 {
+			return fmt.Errorf("invalid --min-sessions %d: use a positive number", insightsMinSessions)
+		}
+		if insightsTop < 0 {
+			return fmt.Errorf("invalid --top %d: use 0 for all or a positive number", insightsTop)
+		}
+
+		report, err := app.reports.Insights(ctx, ports.InsightsQuery{
+			Start:       current.Start,
+			End:         current.End,
+			Label:       current.Label,
+			MinSessions: insightsMinSessions,
+			Top:         insightsTop,
+		})
+		if err != nil {
+			return err
+		}
+
+		if formatTemplate != "" {
+			return printTemplate(formatTemplate, report)
+		}
+		if jsonOutput {
+			return printReportJSON(report)
+		}
+
+		fmt.Println()
+		renderInsights(report)
+		return nil
+	},
+}
+
+func init() {
+	// The range flags share their variables with "flow stats", so
+	// statsRange resolves them for either command.
+	insightsCmd.Flags().StringVarP(&statsPeriod, "period", "p", "week", "Time period: week, month, quarter or year")
+	insightsCmd.Flags().IntVar(&statsOffset, "offset", 0, "Periods to step back from the current one (e.g. -1 for the previous)")
+	insightsCmd.Flags().StringVar(&statsFrom, "from", "", "First day of a custom range (YYYY-MM-DD)")
+	insightsCmd.Flags().StringVar(&statsTo, "to", "", "Last day of a custom range (YYYY-MM-DD, default today)")
+	insightsCmd.Flags().StringVar(&statsLast, "last", "", "Custom range ending today (e.g. 30d, 6w, 3m, 1y; 90d without other range flags)")
+	insightsCmd.Flags().IntVar(&insightsMinSessions, "min-sessions", 5, "Scored sessions a condition needs before it is listed")
+	insightsCmd.Flags().IntVar(&insightsTop, "top", 3, "How many of the best conditions to show (0 for all)")
+	insightsCmd.Flags().StringVar(&formatTemplate, "format", "", formatFlagUsage)
+	rootCmd.AddCommand(insightsCmd)
+}
+
+func renderInsights(report *ports.InsightsReport) {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C6FE0"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	valueStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
+	barColor := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C6FE0"))
+
+	fmt.Printf("  %s\n", titleStyle.Render(report.Label+" · insights"))
+	fmt.Printf("  %s\n\n", dimStyle.Render(strings.Repeat("─", 40)))
+
+	if report.AvgFocusScore == nil {
+		fmt.Printf("  %s\n", dimStyle.Render(fmt.Sprintf("No focus scores in %d completed sessions.", report.Sessions)))
+		fmt.Printf("  %s\n\n", dimStyle.Render("Set focus_score = true under [pomodoro] or [deepwork] to rate your sessions."))
+		return
+	}
+	fmt.Printf("  Focus: %s average over %s of %s sessions\n\n",
+		valueStyle.Render(fmt.Sprintf("%.1f", *report.AvgFocusScore)),
+		valueStyle.Render(fmt.Sprintf("%d", report.ScoredSessions)),
+		valueStyle.Render(fmt.Sprintf("%d", report.Sessions)),
+	)
+
+	fmt.Printf("  %s\n", dimStyle.Render("Your best sessions"))
+	if len(report.BestConditions) == 0 {
+		fmt.Printf("  %s\n", dimStyle.Render(fmt.Sprintf("Nothing stands out yet: conditions need %d scored sessions to count.", report.MinSessions)))
+	}
+	for _, c := range report.BestConditions {
+		summary := c.Summary
+		if summary != "" {
+			summary = strings.ToUpper(summary[:1]) + summary[1:]
+		}
+		fmt.Printf("  • %s %s\n", summary,
+			dimStyle.Render(fmt.Sprintf("(+%.1f, %d sessions)", c.Lift, c.Sessions)))
+	}
+	fmt.Println()
+
+	fmt.Printf("  %s\n", dimStyle.Render("Focus score moves with"))
+	for _, c := range report.Correlations {
+		label := strings.ReplaceAll(c.Factor, "_", " ")
+		if c.Coefficient == nil {
+			fmt.Printf("  %s %s\n", dimStyle.Render(fmt.Sprintf("%-18s", label)), dimStyle.Render("not enough variation"))
+			continue
+		}
+		fmt.Printf("  %s %s %s\n",
+			dimStyle.Render(fmt.Sprintf("%-18s", label)),
+			valueStyle.Render(fmt.Sprintf("%+.2f", *c.Coefficient)),
+			dimStyle.Render(correlationStrength(*c.Coefficient)),
+		)
+	}
+	fmt.Println()
+
+	sections := []struct {
+		title   string
+		buckets []ports.ScoreBucket
+	}{
+		{"By hour of day", report.ByHour},
+		{"By session length", report.ByDuration},
+		{"By day of week", report.ByWeekday},
+		{"By tag", report.ByTag},
+		{"By branch", report.ByBranch},
+		{"By distractions", report.ByDistractions},
+		{"By methodology", report.ByMethodology},
+	}
+	for _, s := range sections {
+		if len(s.buckets) == 0 {
+			continue
+		}
+		fmt.Printf("  %s\n", dimStyle.Render(s.title))
+		renderScoreBars(s.buckets, dimStyle, barColor)
+	}
+}
+
+// renderScoreBars prints average focus scores as bars on the 1-5 scale.
+func renderScoreBars(buckets []ports.ScoreBucket, dimStyle, barColor lipgloss.Style) {
+	maxBarWidth := 20
+	for _, b := range buckets {
+		barWidth := int(math.Round(b.AvgFocusScore / 5 * float64(maxBarWidth)))
+		fmt.Printf("  %s %s %.1f %s\n",
+			dimStyle.Render(fmt.Sprintf("%-14s", b.Key)),
+			barColor.Render(buildBar(barWidth)),
+			b.AvgFocusScore,
+			dimStyle.Render(fmt.Sprintf("(%d)", b.Sessions)),
+		)
+	}
+	fmt.Println()
+}
+
+// correlationStrength describes a correlation coefficient in words.
+func correlationStrength(r float64) string {
+	direction := "higher"
+	if r < 0 {
+		direction = "lower"
+	}
+	switch a := math.Abs(r); {
+	case a < 0.1:
+		return "no relation"
+	case a < 0.3:
+		return "weak, " + direction + " scores as it grows"
+	case a < 0.5:
+		return "moderate, " + direction + " scores as it grows"
+	default:
+		return "strong, " + direction + " scores as it grows"
+	}
+}
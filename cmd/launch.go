@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"time"
 
+	"github.com/xvierd/flow-cli/internal/adapters/daemon"
 	"github.com/xvierd/flow-cli/internal/adapters/tui"
 	"github.com/xvierd/flow-cli/internal/config"
 	"github.com/xvierd/flow-cli/internal/domain"
@@ -18,6 +20,13 @@ import (
 func launchTUI(_ context.Context, state *domain.CurrentState, workingDir string) error {
 	ctx := setupSignalHandler()
 
+	// With a daemon running, tell it this timer is watching the session so
+	// it leaves notifications and auto-breaks to the TUI until it exits.
+	ensureDaemon()
+	if attachment, err := daemon.Attach(socketPath()); err == nil {
+		defer func() { _ = attachment.Close() }()
+	}
+
 	var timer *tui.Timer
 	if inlineMode {
 		timer = tui.NewInlineTimer(&app.config.Theme)
@@ -30,20 +39,14 @@ func launchTUI(_ context.Context, state *domain.CurrentState, workingDir string)
 	// Presets and break info (used by inline setup phase; harmless for fullscreen).
 	presets := app.mode.Presets()
 	shortBreakDur, longBreakDur := app.config.GetBreakDurations(app.methodology)
-	var breakInfo string
-	if app.methodology == domain.MethodologyPomodoro {
-		breakInfo = fmt.Sprintf("Breaks: %s short / %s long (every %d) · \"flow config\" to customize",
-			formatMinutes(shortBreakDur), formatMinutes(longBreakDur), app.config.Pomodoro.SessionsBeforeLong)
-	} else {
-		breakInfo = fmt.Sprintf("Break: %s · \"flow config\" to customize", formatMinutes(shortBreakDur))
+	breakInfo := breakSummary(app.methodology)
+	earnedBreaks, err := app.config.Flowtime.BreakPolicy()
+	if err != nil {
+		return err
 	}
 
 	// Completion info: next break type and duration.
-	_, _, _, sessionsBeforeLong := app.config.ToPomodoroDomainConfig()
-	// Pomodoro Technique rule: long break always after every 4 work sessions.
-	if app.methodology == domain.MethodologyPomodoro {
-		sessionsBeforeLong = 4
-	}
+	sessionsBeforeLong := app.config.GetSessionsBeforeLong(app.methodology)
 	workSessions := state.TodayStats.WorkSessions + 1
 	sessionsUntilLong := sessionsBeforeLong - (workSessions % sessionsBeforeLong)
 	if sessionsUntilLong == sessionsBeforeLong {
@@ -61,6 +64,20 @@ func launchTUI(_ context.Context, state *domain.CurrentState, workingDir string)
 		deepWorkStreak, _ = app.pomodoro.GetDeepWorkStreak(ctx, time.Duration(app.config.DeepWork.DeepWorkGoalHours*float64(time.Hour)))
 	}
 
+	completion := &domain.CompletionInfo{
+		NextBreakType:      nextBreakType,
+		NextBreakDuration:  nextBreakDuration,
+		SessionsUntilLong:  sessionsUntilLong,
+		SessionsBeforeLong: sessionsBeforeLong,
+		DeepWorkStreak:     deepWorkStreak,
+		EarnedBreaks:       earnedBreaks,
+	}
+	// A session started from a preset with its own break is followed by that one
+	if state.ActiveSession != nil && state.ActiveSession.BreakAfter > 0 {
+		completion.NextBreakDuration = state.ActiveSession.BreakAfter
+	}
+	setNextRotation(ctx, completion)
+
 	firstRun := app.config.FirstRun
 	if firstRun {
 		app.config.FirstRun = false
@@ -69,11 +86,12 @@ func launchTUI(_ context.Context, state *domain.CurrentState, workingDir string)
 
 	timer.Configure(tui.TimerConfig{
 		Mode:       app.mode,
+		Modes:      methodology.All(app.config),
 		ModeLocked: modeFlag != "",
 		OnModeSelected: func(m domain.Methodology) {
-			app.methodology = m
-			app.mode = methodology.ForMethodology(m, app.config)
+			_ = setMethodology(m)
 			presets = app.mode.Presets()
+			setNextRotation(ctx, completion)
 			timer.SetMode(app.mode)
 		},
 		AutoBreak:            app.config.Pomodoro.AutoBreak,
@@ -96,9 +114,19 @@ func launchTUI(_ context.Context, state *domain.CurrentState, workingDir string)
 		CommandCallback: func(cmd ports.TimerCommand) error {
 			switch cmd {
 			case ports.CmdStart:
-				_, err := app.pomodoro.StartPomodoro(ctx, services.StartPomodoroRequest{
-					WorkingDir: workingDir,
-				})
+				req := services.StartPomodoroRequest{
+					WorkingDir:  workingDir,
+					Methodology: app.methodology,
+				}
+				// Inside a block of today's plan, the session follows it
+				if block := currentPlanBlock(ctx); block != nil {
+					var err error
+					if req, err = plannedSession(ctx, block, workingDir); err != nil {
+						return err
+					}
+				}
+				_, err := app.pomodoro.StartPomodoro(ctx, req)
+				setNextRotation(ctx, completion)
 				return err
 			case ports.CmdPause:
 				_, err := app.pomodoro.PauseSession(ctx)
@@ -116,6 +144,7 @@ func launchTUI(_ context.Context, state *domain.CurrentState, workingDir string)
 				return err
 			case ports.CmdBreak:
 				_, err := app.pomodoro.StartBreak(ctx, workingDir)
+				setNextRotation(ctx, completion)
 				return err
 			default:
 				return fmt.Errorf("unknown command: %v", cmd)
@@ -128,6 +157,7 @@ func launchTUI(_ context.Context, state *domain.CurrentState, workingDir string)
 			}
 			return app.pomodoro.LogDistraction(ctx, activeState.ActiveSession.ID, text, category)
 		},
+		DistractionCategories: app.config.Distractions.CategoryList(),
 		AccomplishmentCallback: func(text string) error {
 			recent, err := app.pomodoro.GetRecentSessions(ctx, 1)
 			if err != nil || len(recent) == 0 {
@@ -170,6 +200,10 @@ func launchTUI(_ context.Context, state *domain.CurrentState, workingDir string)
 			var err error
 			switch sessionType {
 			case domain.SessionTypeWork:
+				if completion.NextRotation != nil && completion.NextRotation.Driver != "" {
+					err = app.notifier.NotifyRotation(*completion.NextRotation)
+					break
+				}
 				err = app.notifier.NotifyPomodoroComplete(formatMinutes(shortBreakDur))
 			case domain.SessionTypeShortBreak:
 				err = app.notifier.NotifyBreakComplete("Short")
@@ -180,13 +214,7 @@ func launchTUI(_ context.Context, state *domain.CurrentState, workingDir string)
 				fmt.Fprintf(os.Stderr, "Warning: notification failed: %v\n", err)
 			}
 		},
-		CompletionInfo: &domain.CompletionInfo{
-			NextBreakType:      nextBreakType,
-			NextBreakDuration:  nextBreakDuration,
-			SessionsUntilLong:  sessionsUntilLong,
-			SessionsBeforeLong: sessionsBeforeLong,
-			DeepWorkStreak:     deepWorkStreak,
-		},
+		CompletionInfo: completion,
 		// Inline-specific fields (zero/nil values are ignored by fullscreen mode).
 		Presets:   presets,
 		BreakInfo: breakInfo,
@@ -203,12 +231,16 @@ func launchTUI(_ context.Context, state *domain.CurrentState, workingDir string)
 		},
 		OnStartSession: func(presetIndex int, taskName string, intendedOutcome string) error {
 			currentMode := app.mode
-			currentPresets := currentMode.Presets()
+			preset := currentMode.Presets()[presetIndex]
 
 			var sessionTags []string
 			if taskName != "" {
 				taskName, sessionTags = domain.ParseTagsFromInput(taskName)
 			}
+			sessionTags = domain.NormalizeTags(append(slices.Clone(preset.Tags), sessionTags...))
+			if intendedOutcome == "" {
+				intendedOutcome = preset.IntendedOutcome
+			}
 
 			var taskID *string
 			if taskName != "" {
@@ -228,12 +260,33 @@ func launchTUI(_ context.Context, state *domain.CurrentState, workingDir string)
 			_, err := app.pomodoro.StartPomodoro(ctx, services.StartPomodoroRequest{
 				TaskID:          taskID,
 				WorkingDir:      workingDir,
-				Duration:        currentPresets[presetIndex].Duration,
+				Duration:        preset.Duration,
 				Methodology:     app.methodology,
 				Tags:            sessionTags,
 				IntendedOutcome: intendedOutcome,
+				BreakAfter:      preset.Break,
 			})
-			return err
+			if err != nil {
+				return err
+			}
+			completion.NextBreakDuration = nextBreakDuration
+			if preset.Break > 0 {
+				completion.NextBreakDuration = preset.Break
+			}
+			setNextRotation(ctx, completion)
+			return nil
+		},
+		OnFollowPlan: func(block *domain.PlanBlock) error {
+			req, err := plannedSession(ctx, block, workingDir)
+			if err != nil {
+				return err
+			}
+			if _, err := app.pomodoro.StartPomodoro(ctx, req); err != nil {
+				return err
+			}
+			completion.NextBreakDuration = nextBreakDuration
+			setNextRotation(ctx, completion)
+			return nil
 		},
 		FirstRun: firstRun,
 	})
@@ -244,3 +297,94 @@ func launchTUI(_ context.Context, state *domain.CurrentState, workingDir string)
 
 	return nil
 }
+
+// currentPlanBlock returns the block of today's plan under way, or nil.
+func currentPlanBlock(ctx context.Context) *domain.PlanBlock {
+	now := time.Now()
+	blocks, err := app.plans.Day(ctx, now)
+	if err != nil {
+		return nil
+	}
+	current, _ := domain.CurrentAndNextBlock(blocks, now)
+	return current
+}
+
+// followPlanMethodology switches to the methodology of block, unless it
+// has none or --mode chose one.
+func followPlanMethodology(block *domain.PlanBlock) error {
+	if block.Methodology == "" || modeFlag != "" || block.Methodology == app.methodology {
+		return nil
+	}
+	m, err := domain.ValidateMethodology(string(block.Methodology))
+	if err != nil {
+		return fmt.Errorf("plan block %s: %w", block, err)
+	}
+	return setMethodology(m)
+}
+
+// plannedSession returns the request that starts a session following
+// block: on its task, with its tags, for the time left in it. A mob
+// rotation keeps its length unless the block ends sooner.
+func plannedSession(ctx context.Context, block *domain.PlanBlock, workingDir string) (services.StartPomodoroRequest, error) {
+	task, err := app.tasks.FindOrAddTask(ctx, block.Task)
+	if err != nil {
+		return services.StartPomodoroRequest{}, err
+	}
+	duration := block.Remaining(time.Now())
+	if app.methodology == domain.MethodologyMob {
+		duration = min(duration, app.config.Mob.RotationLength())
+	}
+	return services.StartPomodoroRequest{
+		TaskID:      &task.ID,
+		WorkingDir:  workingDir,
+		Duration:    duration,
+		Methodology: app.methodology,
+		Tags:        slices.Clone(block.Tags),
+	}, nil
+}
+
+// setNextRotation tells completion who takes over from the mob rotation
+// running or just finished, and how many rotations are left before the
+// mob's break. Outside Mob mode it clears the rotation.
+func setNextRotation(ctx context.Context, completion *domain.CompletionInfo) {
+	if app.methodology != domain.MethodologyMob {
+		completion.NextRotation = nil
+		return
+	}
+	next, rotations := app.pomodoro.NextRotation(ctx)
+	perBreak := app.config.GetSessionsBeforeLong(domain.MethodologyMob)
+	untilBreak := perBreak - rotations%perBreak
+	if untilBreak == perBreak && rotations > 0 {
+		untilBreak = 0
+	}
+	breakType := domain.SessionTypeShortBreak
+	if untilBreak == 0 {
+		breakType = domain.SessionTypeLongBreak
+	}
+	breakDur, _ := app.config.GetBreakDurations(domain.MethodologyMob)
+
+	completion.NextRotation = &next
+	completion.SessionsBeforeLong = perBreak
+	completion.SessionsUntilLong = untilBreak
+	completion.NextBreakType = breakType
+	completion.NextBreakDuration = breakDur
+}
+
+// breakSummary describes the breaks of methodology m under the duration
+// picker.
+func breakSummary(m domain.Methodology) string {
+	short, long := app.config.GetBreakDurations(m)
+	switch {
+	case m.CountsUp():
+		policy, _ := app.config.Flowtime.BreakPolicy()
+		return fmt.Sprintf("Break: %s · \"flow config\" to customize", policy)
+	case m == domain.MethodologyMob:
+		return fmt.Sprintf("Break: %s every %d rotations · \"flow config\" to customize",
+			formatMinutes(short), app.config.GetSessionsBeforeLong(m))
+	case m == domain.MethodologyPomodoro || short != long:
+		return fmt.Sprintf("Breaks: %s short / %s long (every %d) · \"flow config\" to customize",
+			formatMinutes(short), formatMinutes(long), app.config.GetSessionsBeforeLong(m))
+	default:
+		return fmt.Sprintf("Break: %s · \"flow config\" to customize", formatMinutes(short))
+	}
+}
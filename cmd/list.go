@@ -37,23 +37,11 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to list tasks: %w", err)
 		}
 
+		if formatTemplate != "" {
+			return printTemplate(formatTemplate, taskListData(tasks))
+		}
 		if jsonOutput {
-			var taskList []map[string]interface{}
-			for _, task := range tasks {
-				taskList = append(taskList, map[string]interface{}{
-					"id":          task.ID,
-					"title":       task.Title,
-					"description": task.Description,
-					"status":      string(task.Status),
-					"tags":        task.Tags,
-					"created_at":  task.CreatedAt.Format("2006-01-02T15:04:05"),
-				})
-			}
-			data := map[string]interface{}{
-				"tasks": taskList,
-				"count": len(taskList),
-			}
-			jsonData, err := json.MarshalIndent(data, "", "  ")
+			jsonData, err := json.MarshalIndent(taskListData(tasks), "", "  ")
 			if err != nil {
 				return fmt.Errorf("failed to marshal tasks: %w", err)
 			}
@@ -82,6 +70,26 @@ var listCmd = &cobra.Command{
 func init() {
 	listCmd.Flags().StringVarP(&listStatus, "status", "s", "", "Filter by status (pending, in_progress, completed, cancelled)")
 	listCmd.Flags().BoolVarP(&listAll, "all", "a", false, "List all tasks (default: pending only)")
+	listCmd.Flags().StringVar(&formatTemplate, "format", "", formatFlagUsage)
+}
+
+// taskListData builds the task list model shared by --json and --format.
+func taskListData(tasks []*domain.Task) map[string]interface{} {
+	taskList := make([]map[string]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		taskList = append(taskList, map[string]interface{}{
+			"id":          task.ID,
+			"title":       task.Title,
+			"description": task.Description,
+			"status":      string(task.Status),
+			"tags":        task.Tags,
+			"created_at":  task.CreatedAt.Format("2006-01-02T15:04:05"),
+		})
+	}
+	return map[string]interface{}{
+		"tasks": taskList,
+		"count": len(taskList),
+	}
 }
 
 func getStatusIcon(status domain.TaskStatus) string {
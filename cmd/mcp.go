@@ -21,6 +21,9 @@ The server provides tools for querying Flow state and task history.`,
 
 		ctx := context.Background()
 
+		// Sessions started over MCP have no timer watching them
+		ensureDaemon()
+
 		// Create and start the MCP server
 		server := mcp.NewServer(app.state)
 		if err := server.Start(ctx); err != nil {
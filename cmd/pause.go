@@ -20,7 +20,7 @@ var pauseCmd = &cobra.Command{
 			return fmt.Errorf("failed to pause session: %w", err)
 		}
 
-		fmt.Printf("⏸️  Session paused. Remaining: %s\n", session.RemainingTime())
+		fmt.Printf("⏸️  Session paused. %s\n", sessionClockLabel(session))
 		return nil
 	},
 }
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/adapters/tui"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan [date]",
+	Short: "Lay out the time blocks of a day",
+	Long: `Open a planner to lay out a day in time blocks: a start and end time, a task,
+and optionally a methodology and tags for each block. The date is today by
+default, or tomorrow, yesterday or a date like 2026-03-09.
+
+The planner lists the day's blocks: [a] adds one, [e] edits the selected
+block, [d] deletes it and [s] saves the plan. Blocks may not overlap.
+
+The timer and "flow status" show the block under way and the next one.
+Starting a session inside a block offers to follow the plan: the block's
+task, its methodology and tags, and the time left in it as the duration.
+"flow reflect --today" compares the time planned and worked in each block.
+
+--json prints the day's plan, with the time worked so far in each block, in
+the layout "flow schema plan" describes, without opening the planner.
+
+  flow plan
+  flow plan tomorrow
+  flow plan 2026-03-09 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		now := time.Now()
+
+		day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if len(args) == 1 {
+			var err error
+			if day, err = parsePlanDay(args[0], day); err != nil {
+				return err
+			}
+		}
+
+		if formatTemplate != "" || jsonOutput {
+			plan, err := app.reports.DayPlan(ctx, day, now)
+			if err != nil {
+				return err
+			}
+			if formatTemplate != "" {
+				return printTemplate(formatTemplate, plan)
+			}
+			return printReportJSON(plan)
+		}
+
+		blocks, err := app.plans.Day(ctx, day)
+		if err != nil {
+			return err
+		}
+		result := tui.RunPlanner(day, blocks, &app.config.Theme)
+		if !result.Saved {
+			return nil
+		}
+		if err := app.plans.SaveDay(ctx, day, result.Blocks); err != nil {
+			return err
+		}
+
+		fmt.Printf("📅 Saved %d blocks for %s\n", len(result.Blocks), day.Format("Mon Jan 2"))
+		return nil
+	},
+}
+
+// parsePlanDay accepts today, tomorrow, yesterday or a date (2006-01-02)
+// and returns the start of that day.
+func parsePlanDay(value string, today time.Time) (time.Time, error) {
+	switch value {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, today.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: use today, tomorrow, yesterday or a date like 2006-01-02", value)
+	}
+	return day, nil
+}
+
+// renderPlanReview prints the planned and worked time of each block.
+func renderPlanReview(plan *ports.DayPlan, dimStyle, valueStyle lipgloss.Style) {
+	fmt.Printf("  %s\n", dimStyle.Render("— Plan vs actual —"))
+	fmt.Println()
+	for _, b := range plan.Blocks {
+		fmt.Printf("  %s %-24s %s %s\n",
+			dimStyle.Render(b.Start+"–"+b.End),
+			b.Task,
+			valueStyle.Render(formatMinutes(time.Duration(b.ActualSeconds)*time.Second)),
+			dimStyle.Render("/ "+formatMinutes(time.Duration(b.PlannedSeconds)*time.Second)),
+		)
+	}
+	fmt.Printf("  %s %s %s\n",
+		dimStyle.Render(fmt.Sprintf("%-36s", "Total")),
+		valueStyle.Render(formatMinutes(time.Duration(plan.ActualSeconds)*time.Second)),
+		dimStyle.Render("/ "+formatMinutes(time.Duration(plan.PlannedSeconds)*time.Second)),
+	)
+	if plan.UnplannedSeconds > 0 {
+		fmt.Printf("  %s %s\n",
+			dimStyle.Render(fmt.Sprintf("%-36s", "Outside the plan")),
+			valueStyle.Render(formatMinutes(time.Duration(plan.UnplannedSeconds)*time.Second)),
+		)
+	}
+	fmt.Println()
+}
+
+func init() {
+	planCmd.Flags().StringVar(&formatTemplate, "format", "", formatFlagUsage)
+	rootCmd.AddCommand(planCmd)
+}
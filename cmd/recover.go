@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/xvierd/flow-cli/internal/adapters/tui"
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/methodology"
+)
+
+// recoverStaleSession looks for a session left running after flow exited
+// (a closed terminal, a sleeping laptop) and asks whether to keep its full
+// time, credit only part of it, or void it. Without a terminal to ask on,
+// the session is left to the configured recovery.stale_policy.
+func recoverStaleSession(ctx context.Context) error {
+	session, err := app.pomodoro.FindStaleSession(ctx)
+	if err != nil || session == nil {
+		return err
+	}
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return nil
+	}
+
+	label := domain.GetSessionTypeLabel(session.Type)
+	if session.TaskID != nil {
+		if task, err := app.storage.Tasks().FindByID(ctx, *session.TaskID); err == nil {
+			label = fmt.Sprintf("%s for \"%s\"", label, task.Title)
+		}
+	}
+	title := fmt.Sprintf("%s started %s was left running:", label, session.StartedAt.Format("Mon 15:04"))
+	items := staleSessionItems(session)
+	footer := fmt.Sprintf("It should have ended %s ago · [recovery] stale_policy applies when flow can't ask",
+		formatMinutes(time.Since(session.PlannedEnd())))
+
+	fmt.Println()
+	result := tui.RunPicker(title, items, footer, &app.config.Theme)
+	if result.Aborted {
+		return nil
+	}
+
+	var credit time.Duration
+	action := []domain.StaleAction{domain.StaleActionKeep, domain.StaleActionCredit, domain.StaleActionVoid}[result.Index]
+	if action == domain.StaleActionCredit {
+		var ok bool
+		if credit, ok = promptCreditedMinutes(session.PlannedDuration()); !ok {
+			return nil
+		}
+	}
+
+	if err := app.pomodoro.RecoverSession(ctx, session, action, credit); err != nil {
+		return fmt.Errorf("failed to recover session: %w", err)
+	}
+	if action == domain.StaleActionVoid {
+		fmt.Println("  Session voided.")
+		fmt.Println()
+		return nil
+	}
+	fmt.Printf("  Credited %s.\n", formatMinutes(session.Duration))
+
+	offerSkippedPrompts(ctx, session)
+	fmt.Println()
+	return nil
+}
+
+// staleSessionItems lists the ways to recover session. An open-ended
+// session is kept as MaxOpenEnded, its planned length, not its Duration.
+func staleSessionItems(session *domain.PomodoroSession) []tui.PickerItem {
+	return []tui.PickerItem{
+		{Label: "Keep", Desc: fmt.Sprintf("Count the full %s", formatMinutes(session.PlannedDuration()))},
+		{Label: "Credit", Desc: "Count only the minutes you actually worked"},
+		{Label: "Void", Desc: "Leave it out of your stats"},
+	}
+}
+
+// promptCreditedMinutes asks how many minutes of a session to credit, up to
+// its planned duration. It reports false if the user aborted.
+func promptCreditedMinutes(limit time.Duration) (time.Duration, bool) {
+	title := "Minutes worked:"
+	for {
+		input := tui.RunTextPrompt(title, fmt.Sprintf("0-%d", int(limit.Minutes())), &app.config.Theme)
+		if input.Aborted {
+			return 0, false
+		}
+		minutes, err := strconv.Atoi(strings.TrimSpace(input.Value))
+		if err == nil && minutes >= 0 && time.Duration(minutes)*time.Minute <= limit {
+			return time.Duration(minutes) * time.Minute, true
+		}
+		title = fmt.Sprintf("Minutes worked (a number from 0 to %d):", int(limit.Minutes()))
+	}
+}
+
+// offerSkippedPrompts asks the completion prompts the TUI never got to show
+// for a recovered work session: the shutdown ritual and outcome review in
+// Deep Work, and the focus score in Make Time. Each can be skipped with Esc.
+func offerSkippedPrompts(ctx context.Context, session *domain.PomodoroSession) {
+	if !session.IsWorkSession() {
+		return
+	}
+	mode := methodology.ForMethodology(session.Methodology, app.config)
+
+	if mode.HasShutdownRitual() && session.ShutdownRitual == nil && session.Accomplishment == "" {
+		steps := []string{
+			"Review pending tasks — anything urgent?",
+			"Review tomorrow's calendar — any conflicts?",
+			"Plan for tomorrow",
+			"Closing phrase (e.g. 'Shutdown complete')",
+		}
+		var answers [4]string
+		completed := true
+		for i, step := range steps {
+			input := tui.RunTextPrompt(fmt.Sprintf("Shutdown ritual (%d/4):", i+1), step, &app.config.Theme)
+			if input.Aborted {
+				completed = false
+				break
+			}
+			answers[i] = input.Value
+		}
+		if completed {
+			_ = app.pomodoro.SetShutdownRitual(ctx, session.ID, domain.ShutdownRitual{
+				PendingTasksReview: answers[0],
+				CalendarReview:     answers[1],
+				TomorrowPlan:       answers[2],
+				ClosingPhrase:      answers[3],
+			})
+		}
+	}
+
+	if session.IntendedOutcome != "" && session.OutcomeAchieved == "" {
+		result := tui.RunPicker(fmt.Sprintf("Did you achieve \"%s\"?", session.IntendedOutcome), []tui.PickerItem{
+			{Label: "Yes", Desc: "Outcome achieved"},
+			{Label: "Partially", Desc: "Made progress"},
+			{Label: "No", Desc: "Didn't get there"},
+		}, "", &app.config.Theme)
+		if !result.Aborted {
+			_ = app.pomodoro.SetOutcomeAchieved(ctx, session.ID, []string{"y", "p", "n"}[result.Index])
+		}
+	}
+
+	if mode.HasFocusScore() && session.FocusScore == nil {
+		var items []tui.PickerItem
+		for score := 1; score <= 5; score++ {
+			items = append(items, tui.PickerItem{Label: strconv.Itoa(score), Desc: focusScoreLabels[score-1]})
+		}
+		result := tui.RunPicker("How focused were you?", items, "", &app.config.Theme)
+		if !result.Aborted {
+			_ = app.pomodoro.SetFocusScore(ctx, session.ID, result.Index+1)
+		}
+	}
+}
+
+// focusScoreLabels describes each focus score from 1 to 5.
+var focusScoreLabels = []string{"Scattered", "Distracted", "Okay", "Focused", "Laser-focused"}
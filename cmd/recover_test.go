@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+func TestStaleSessionItems(t *testing.T) {
+	timed := domain.NewPomodoroSession(domain.PomodoroConfig{WorkDuration: 50 * time.Minute}, nil)
+	if got, want := staleSessionItems(timed)[0].Desc, "Count the full "+formatMinutes(50*time.Minute); got != want {
+		t.Errorf("Keep = %q, want %q", got, want)
+	}
+
+	flowtime := domain.NewPomodoroSession(domain.PomodoroConfig{}, nil)
+	flowtime.Methodology = domain.MethodologyFlowtime
+	flowtime.OpenEnded = true
+	if got, want := staleSessionItems(flowtime)[0].Desc, "Count the full "+formatMinutes(domain.MaxOpenEnded); got != want {
+		t.Errorf("Keep for an open-ended session = %q, want %q", got, want)
+	}
+	if flowtime.PlannedDuration() != domain.MaxOpenEnded {
+		t.Errorf("credit limit = %v, want MaxOpenEnded", flowtime.PlannedDuration())
+	}
+}
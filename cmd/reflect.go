@@ -11,6 +11,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
 )
 
 var reflectTodayFlag bool
@@ -18,7 +19,14 @@ var reflectTodayFlag bool
 var reflectCmd = &cobra.Command{
 	Use:   "reflect",
 	Short: "Show a weekly reflection dashboard",
-	Long:  `Display a reflection of your week: daily sessions, highlights, focus scores, and distraction trends.`,
+	Long: `Display a reflection of your week: daily sessions, highlights, focus scores, and distraction trends.
+
+--today shows today's summary instead, with the time planned and worked in
+each block when the day has a "flow plan".
+
+--json prints the reflection in the layout "flow schema reflect" describes
+("flow schema reflect-today" with --today), with durations in seconds.
+--today skips its prompts when --json or --format is given.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 		now := time.Now()
@@ -27,145 +35,119 @@ var reflectCmd = &cobra.Command{
 			return runReflectToday(ctx, now)
 		}
 
-		// Compute week start (Monday)
-		weekday := int(now.Weekday())
-		if weekday == 0 {
-			weekday = 7
+		week := app.reports.WeeklyReflection(ctx, now)
+		if formatTemplate != "" {
+			return printTemplate(formatTemplate, week)
 		}
-		weekStart := time.Date(now.Year(), now.Month(), now.Day()-(weekday-1), 0, 0, 0, 0, now.Location())
-		weekEnd := weekStart.AddDate(0, 0, 7)
+		if jsonOutput {
+			return printReportJSON(week)
+		}
+		renderWeeklyReflection(week, now)
+		return nil
+	},
+}
 
-		titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C6FE0"))
-		dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
-		valueStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
-		accentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#34D399"))
+// renderWeeklyReflection prints the weekly reflection dashboard.
+func renderWeeklyReflection(week *ports.WeeklyReflection, now time.Time) {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C6FE0"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	valueStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
+	accentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#34D399"))
 
-		fmt.Println()
-		fmt.Printf("  %s\n", titleStyle.Render(fmt.Sprintf("Weekly Reflection — %s", weekStart.Format("Jan 2"))))
-		fmt.Printf("  %s\n\n", dimStyle.Render(strings.Repeat("─", 45)))
+	weekStart, _ := time.ParseInLocation("2006-01-02", week.WeekStart, now.Location())
+	today := now.Format("2006-01-02")
 
-		// Day-by-day breakdown
-		dayNames := []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
-		fmt.Printf("  %s\n", dimStyle.Render("Day       Sessions   Work Time"))
-		fmt.Printf("  %s\n", dimStyle.Render(strings.Repeat("─", 35)))
+	fmt.Println()
+	fmt.Printf("  %s\n", titleStyle.Render(fmt.Sprintf("Weekly Reflection — %s", weekStart.Format("Jan 2"))))
+	fmt.Printf("  %s\n\n", dimStyle.Render(strings.Repeat("─", 45)))
 
-		totalSessions := 0
-		var totalWork time.Duration
-
-		for i := 0; i < 7; i++ {
-			day := weekStart.AddDate(0, 0, i)
-			if day.After(now) {
-				break
-			}
-
-			stats, err := app.storage.Sessions().GetDailyStats(ctx, day)
-			if err != nil {
-				continue
-			}
-
-			sessionsStr := fmt.Sprintf("%d", stats.WorkSessions)
-			workStr := formatMinutes(stats.TotalWorkTime)
-			if stats.WorkSessions == 0 {
-				sessionsStr = "-"
-				workStr = "-"
-			}
-
-			isToday := day.Day() == now.Day() && day.Month() == now.Month()
-			dayLabel := dayNames[i]
-			if isToday {
-				dayLabel = dayLabel + "*"
-			}
-
-			fmt.Printf("  %-10s %s    %s\n",
-				dimStyle.Render(fmt.Sprintf("%-6s", dayLabel)),
-				valueStyle.Render(fmt.Sprintf("%-8s", sessionsStr)),
-				valueStyle.Render(workStr),
-			)
+	// Day-by-day breakdown
+	fmt.Printf("  %s\n", dimStyle.Render("Day       Sessions   Work Time"))
+	fmt.Printf("  %s\n", dimStyle.Render(strings.Repeat("─", 35)))
 
-			totalSessions += stats.WorkSessions
-			totalWork += stats.TotalWorkTime
+	for _, day := range week.Days {
+		sessionsStr := fmt.Sprintf("%d", day.WorkSessions)
+		workStr := formatMinutes(time.Duration(day.WorkSeconds) * time.Second)
+		if day.WorkSessions == 0 {
+			sessionsStr = "-"
+			workStr = "-"
 		}
 
-		fmt.Printf("  %s\n", dimStyle.Render(strings.Repeat("─", 35)))
-		fmt.Printf("  %-10s %s    %s\n\n",
-			dimStyle.Render("Total"),
-			valueStyle.Render(fmt.Sprintf("%-8d", totalSessions)),
-			valueStyle.Render(formatMinutes(totalWork)),
+		dayLabel := day.Day
+		if day.Date == today {
+			dayLabel = dayLabel + "*"
+		}
+
+		fmt.Printf("  %-10s %s    %s\n",
+			dimStyle.Render(fmt.Sprintf("%-6s", dayLabel)),
+			valueStyle.Render(fmt.Sprintf("%-8s", sessionsStr)),
+			valueStyle.Render(workStr),
 		)
+	}
 
-		// Period stats for focus score and distractions
-		periodStats, err := app.storage.Sessions().GetPeriodStats(ctx, weekStart, weekEnd)
-		if err == nil {
-			if periodStats.FocusScoreCount > 0 {
-				fmt.Printf("  %s  %s  %s\n",
-					dimStyle.Render("Avg focus score:"),
-					valueStyle.Render(fmt.Sprintf("%.1f/5", periodStats.AvgFocusScore)),
-					dimStyle.Render(fmt.Sprintf("(%d sessions)", periodStats.FocusScoreCount)),
-				)
-			}
-
-			if periodStats.DistractionCount > 0 {
-				fmt.Printf("  %s  %s\n",
-					dimStyle.Render("Distractions:"),
-					valueStyle.Render(fmt.Sprintf("%d", periodStats.DistractionCount)),
-				)
-			}
-
-			if periodStats.FocusScoreCount > 0 || periodStats.DistractionCount > 0 {
-				fmt.Println()
-			}
-		}
+	fmt.Printf("  %s\n", dimStyle.Render(strings.Repeat("─", 35)))
+	fmt.Printf("  %-10s %s    %s\n\n",
+		dimStyle.Render("Total"),
+		valueStyle.Render(fmt.Sprintf("%-8d", week.TotalSessions)),
+		valueStyle.Render(formatMinutes(time.Duration(week.WorkSeconds)*time.Second)),
+	)
 
-		// Highlights for the week
-		fmt.Printf("  %s\n", dimStyle.Render("Highlights this week"))
-		foundHighlight := false
-		for i := 0; i < 7; i++ {
-			day := weekStart.AddDate(0, 0, i)
-			if day.After(now) {
-				break
-			}
-			highlight, err := app.storage.Tasks().FindTodayHighlight(ctx, day)
-			if err != nil || highlight == nil {
-				continue
-			}
-			foundHighlight = true
-			status := dimStyle.Render("  ")
-			if highlight.Status == "completed" {
-				status = accentStyle.Render("  ")
-			}
-			fmt.Printf("  %s %s %s\n",
-				dimStyle.Render(day.Format("Mon")),
-				status,
-				valueStyle.Render(highlight.Title),
-			)
-		}
-		if !foundHighlight {
-			fmt.Printf("  %s\n", dimStyle.Render("No highlights set this week."))
-		}
+	// Focus score and distractions
+	if week.AvgFocusScore != nil {
+		fmt.Printf("  %s  %s  %s\n",
+			dimStyle.Render("Avg focus score:"),
+			valueStyle.Render(fmt.Sprintf("%.1f/5", *week.AvgFocusScore)),
+			dimStyle.Render(fmt.Sprintf("(%d sessions)", week.FocusScoreCount)),
+		)
+	}
+	if week.Distractions > 0 {
+		fmt.Printf("  %s  %s\n",
+			dimStyle.Render("Distractions:"),
+			valueStyle.Render(fmt.Sprintf("%d", week.Distractions)),
+		)
+	}
+	if week.FocusScoreCount > 0 || week.Distractions > 0 {
 		fmt.Println()
+	}
 
-		// Energize correlation
-		energizeStats, err := app.storage.Sessions().GetEnergizeStats(ctx, weekStart, weekEnd)
-		if err == nil && len(energizeStats) > 0 {
-			fmt.Printf("  %s\n", dimStyle.Render("Energize vs Focus"))
-			fmt.Printf("  %s\n", dimStyle.Render(strings.Repeat("─", 35)))
-			for _, es := range energizeStats {
-				fmt.Printf("  %-12s %s  %s\n",
-					dimStyle.Render(es.Activity),
-					valueStyle.Render(fmt.Sprintf("%.1f/5", es.AvgFocusScore)),
-					dimStyle.Render(fmt.Sprintf("(%d sessions)", es.SessionCount)),
-				)
-			}
-			fmt.Println()
+	// Highlights for the week
+	fmt.Printf("  %s\n", dimStyle.Render("Highlights this week"))
+	for _, highlight := range week.Highlights {
+		status := dimStyle.Render("  ")
+		if highlight.Status == string(domain.StatusCompleted) {
+			status = accentStyle.Render("  ")
 		}
+		day, _ := time.ParseInLocation("2006-01-02", highlight.Date, now.Location())
+		fmt.Printf("  %s %s %s\n",
+			dimStyle.Render(day.Format("Mon")),
+			status,
+			valueStyle.Render(highlight.Title),
+		)
+	}
+	if len(week.Highlights) == 0 {
+		fmt.Printf("  %s\n", dimStyle.Render("No highlights set this week."))
+	}
+	fmt.Println()
 
-		return nil
-	},
+	// Energize correlation
+	if len(week.Energize) > 0 {
+		fmt.Printf("  %s\n", dimStyle.Render("Energize vs Focus"))
+		fmt.Printf("  %s\n", dimStyle.Render(strings.Repeat("─", 35)))
+		for _, es := range week.Energize {
+			fmt.Printf("  %-12s %s  %s\n",
+				dimStyle.Render(es.Activity),
+				valueStyle.Render(fmt.Sprintf("%.1f/5", es.AvgFocusScore)),
+				dimStyle.Render(fmt.Sprintf("(%d sessions)", es.Sessions)),
+			)
+		}
+		fmt.Println()
+	}
 }
 
 func init() {
 	rootCmd.AddCommand(reflectCmd)
 	reflectCmd.Flags().BoolVar(&reflectTodayFlag, "today", false, "Show today's reflection summary")
+	reflectCmd.Flags().StringVar(&formatTemplate, "format", "", formatFlagUsage)
 }
 
 // runReflectToday displays a methodology-aware summary of today's sessions with interactive prompts.
@@ -175,20 +157,12 @@ func runReflectToday(ctx context.Context, now time.Time) error {
 	valueStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
 	accentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#34D399"))
 
-	fmt.Println()
-	fmt.Printf("  %s\n", titleStyle.Render(fmt.Sprintf("Today's Reflection — %s", now.Format("Mon Jan 2"))))
-	fmt.Printf("  %s\n\n", dimStyle.Render(strings.Repeat("─", 45)))
-
 	// Today's stats (common to all methodologies)
 	stats, err := app.storage.Sessions().GetDailyStats(ctx, now)
 	if err != nil {
 		return fmt.Errorf("failed to get today's stats: %w", err)
 	}
 
-	fmt.Printf("  %s  %s\n", dimStyle.Render("Sessions:"), valueStyle.Render(fmt.Sprintf("%d", stats.WorkSessions)))
-	fmt.Printf("  %s  %s\n", dimStyle.Render("Work time:"), valueStyle.Render(formatMinutes(stats.TotalWorkTime)))
-	fmt.Println()
-
 	// Fetch today's sessions for methodology-specific stats and persistence
 	todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	todayEnd := todayStart.AddDate(0, 0, 1)
@@ -202,6 +176,31 @@ func runReflectToday(ctx context.Context, now time.Time) error {
 		}
 	}
 
+	// Machine-readable output gets the numbers without the interactive prompts.
+	if formatTemplate != "" || jsonOutput {
+		report, err := app.reports.DailyReflection(ctx, now, app.methodology, todaySessions)
+		if err != nil {
+			return err
+		}
+		if formatTemplate != "" {
+			return printTemplate(formatTemplate, report)
+		}
+		return printReportJSON(report)
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s\n", titleStyle.Render(fmt.Sprintf("Today's Reflection — %s", now.Format("Mon Jan 2"))))
+	fmt.Printf("  %s\n\n", dimStyle.Render(strings.Repeat("─", 45)))
+
+	fmt.Printf("  %s  %s\n", dimStyle.Render("Sessions:"), valueStyle.Render(fmt.Sprintf("%d", stats.WorkSessions)))
+	fmt.Printf("  %s  %s\n", dimStyle.Render("Work time:"), valueStyle.Render(formatMinutes(stats.TotalWorkTime)))
+	fmt.Println()
+
+	// Planned against worked time, when the day was planned
+	if plan, err := app.reports.DayPlan(ctx, now, now); err == nil && len(plan.Blocks) > 0 {
+		renderPlanReview(plan, dimStyle, valueStyle)
+	}
+
 	// Find today's last work session (for persistence)
 	var lastWorkSession *domain.PomodoroSession
 	for i := len(todaySessions) - 1; i >= 0; i-- {
@@ -20,7 +20,7 @@ var resumeCmd = &cobra.Command{
 			return fmt.Errorf("failed to resume session: %w", err)
 		}
 
-		fmt.Printf("▶️  Session resumed. Remaining: %s\n", session.RemainingTime())
+		fmt.Printf("▶️  Session resumed. %s\n", sessionClockLabel(session))
 		return nil
 	},
 }
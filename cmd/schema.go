@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// schemaFiles holds the JSON Schema of each command's --json output.
+//
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [name]",
+	Short: "Print the JSON Schema of a command's --json output",
+	Long: `Print the JSON Schema describing a command's --json output, which is also
+what --format templates see. Without a name, list the available schemas.
+
+These layouts are stable: fields may be added in later versions, but are
+never renamed or removed.
+
+  flow schema          # list schemas
+  flow schema stats    # print the schema of "flow stats --json"`,
+	Args: cobra.MaximumNArgs(1),
+	// Schemas are embedded, so there is no need to open the database.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			for _, name := range schemaNames() {
+				fmt.Println(name)
+			}
+			return nil
+		}
+		data, err := readSchema(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(data))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+// schemaNames returns the names of the embedded schemas, sorted.
+func schemaNames() []string {
+	entries, _ := schemaFiles.ReadDir("schemas")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// readSchema returns the named schema.
+func readSchema(name string) ([]byte, error) {
+	data, err := schemaFiles.ReadFile(path.Join("schemas", name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown schema %q: use one of %s", name, strings.Join(schemaNames(), ", "))
+	}
+	return data, nil
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/adapters/storage"
+	"github.com/xvierd/flow-cli/internal/config"
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+	"github.com/xvierd/flow-cli/internal/services"
+)
+
+// TestJSONOutputMatchesSchemas checks each --json layout against the schema
+// "flow schema" prints for it.
+func TestJSONOutputMatchesSchemas(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.New(filepath.Join(t.TempDir(), "flow.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	saved := app
+	defer func() { app = saved }()
+	app.storage = store
+	app.config = config.DefaultConfig()
+
+	now := time.Now()
+	task := &domain.Task{ID: "task-1", Title: "Write docs", Status: domain.StatusInProgress, CreatedAt: now, UpdatedAt: now}
+	if err := store.Tasks().Save(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	score := 4
+	ended := now.Add(-time.Minute)
+	session := &domain.PomodoroSession{
+		ID:           "session-1",
+		TaskID:       &task.ID,
+		Type:         domain.SessionTypeWork,
+		Status:       domain.SessionStatusCompleted,
+		Methodology:  domain.MethodologyMakeTime,
+		Duration:     25 * time.Minute,
+		StartedAt:    now.Add(-26 * time.Minute),
+		CompletedAt:  &ended,
+		FocusScore:   &score,
+		Tags:         []string{"docs"},
+		Distractions: []domain.Distraction{{Text: "chat", Category: "external", At: now.Add(-10 * time.Minute)}},
+	}
+	if err := store.Sessions().Save(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+
+	block, _ := domain.NewPlanBlock(now.Add(-time.Hour), now.Add(time.Hour), "Write docs")
+	block.Tags = []string{"docs"}
+	next, _ := domain.NewPlanBlock(now.Add(time.Hour), now.Add(2*time.Hour), "Review")
+	if err := store.Plans().ReplaceDay(ctx, now, []*domain.PlanBlock{block, next}); err != nil {
+		t.Fatal(err)
+	}
+
+	reports := services.NewReportService(store)
+	start, end, label := domain.PeriodWeek.Bounds(now)
+	stats, err := reports.Stats(ctx, ports.StatsQuery{Start: start, End: end, Label: label, ByTag: true, Philosophy: "rhythmic"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	daily, err := reports.DailyReflection(ctx, now, domain.MethodologyMakeTime, []*domain.PomodoroSession{session})
+	if err != nil {
+		t.Fatal(err)
+	}
+	export, err := exportData(ctx, now.AddDate(0, 0, -7), []sessionGroup{{Sessions: []*domain.PomodoroSession{session}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	heatmap, err := reports.Heatmap(ctx, now, ports.HeatmapMetricTotal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	distractions, err := reports.Distractions(ctx, ports.DistractionQuery{Start: start, End: end, Label: label, Categories: []string{"internal", "external"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	insights, err := reports.Insights(ctx, ports.InsightsQuery{Start: start, End: end, Label: label, MinSessions: 1, Top: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := reports.DayPlan(ctx, now, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := &domain.CurrentState{ActiveTask: task, ActiveSession: session, CurrentBlock: block, NextBlock: next}
+
+	outputs := map[string]interface{}{
+		"status":        statusData(ctx, state),
+		"list":          taskListData([]*domain.Task{task}),
+		"stats":         stats,
+		"heatmap":       heatmap,
+		"distractions":  distractions,
+		"insights":      insights,
+		"reflect":       reports.WeeklyReflection(ctx, now),
+		"reflect-today": daily,
+		"export":        export,
+		"plan":          plan,
+	}
+	if len(outputs) != len(schemaNames()) {
+		t.Errorf("checked %d outputs, but there are %d schemas", len(outputs), len(schemaNames()))
+	}
+	for name, output := range outputs {
+		raw, err := readSchema(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var schema map[string]interface{}
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			t.Fatalf("schema %s: %v", name, err)
+		}
+		data, err := json.Marshal(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			t.Fatal(err)
+		}
+		for _, problem := range validateSchema(schema, value, name) {
+			t.Error(problem)
+		}
+	}
+}
+
+func TestReadSchemaUnknown(t *testing.T) {
+	if _, err := readSchema("nope"); err == nil {
+		t.Error("readSchema(nope) = nil error, want one naming the schemas")
+	}
+}
+
+// validateSchema checks value against the subset of JSON Schema the
+// embedded schemas use: type, required, properties and items. Every key
+// the value has must be described, so fields can't drift from the docs.
+func validateSchema(schema map[string]interface{}, value interface{}, at string) []string {
+	var problems []string
+	if !schemaTypeMatches(schema["type"], value) {
+		return []string{at + ": type does not match the schema"}
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		props, _ := schema["properties"].(map[string]interface{})
+		required, _ := schema["required"].([]interface{})
+		for _, key := range required {
+			if _, ok := v[key.(string)]; !ok {
+				problems = append(problems, at+"."+key.(string)+": missing")
+			}
+		}
+		for key, field := range v {
+			sub, ok := props[key].(map[string]interface{})
+			if !ok {
+				problems = append(problems, at+"."+key+": not in the schema")
+				continue
+			}
+			problems = append(problems, validateSchema(sub, field, at+"."+key)...)
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for _, item := range v {
+				problems = append(problems, validateSchema(items, item, at+"[]")...)
+			}
+		}
+	}
+	return problems
+}
+
+func schemaTypeMatches(want interface{}, value interface{}) bool {
+	var types []string
+	switch w := want.(type) {
+	case string:
+		types = []string{w}
+	case []interface{}:
+		for _, t := range w {
+			types = append(types, t.(string))
+		}
+	default:
+		return true
+	}
+	for _, typ := range types {
+		switch v := value.(type) {
+		case nil:
+			if typ == "null" {
+				return true
+			}
+		case bool:
+			if typ == "boolean" {
+				return true
+			}
+		case float64:
+			if typ == "number" || (typ == "integer" && v == float64(int64(v))) {
+				return true
+			}
+		case string:
+			if typ == "string" {
+				return true
+			}
+		case []interface{}:
+			if typ == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if typ == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
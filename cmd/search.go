@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+var (
+	searchSince string
+	searchTag   string
+	searchLimit int
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search tasks, notes, accomplishments and distractions",
+	Long: `Full-text search across task titles and descriptions and session notes,
+intended outcomes, accomplishments, distractions and shutdown rituals.
+
+Every word must match; words also match as prefixes ("refact" finds
+"refactoring"). --since takes a date (2006-01-02) or a relative span
+like 7d, 2w or 3m.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := ports.SearchQuery{
+			Text:  strings.Join(args, " "),
+			Tag:   searchTag,
+			Limit: searchLimit,
+		}
+		if searchSince != "" {
+			since, err := parseSince(searchSince, time.Now())
+			if err != nil {
+				return err
+			}
+			query.Since = since
+		}
+
+		results, err := app.state.Search(cmd.Context(), query)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			return printReportJSON(ports.NewSearchReport(query.Text, results))
+		}
+
+		if len(results) == 0 {
+			fmt.Printf("No matches for %q.\n", query.Text)
+			return nil
+		}
+
+		dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		valueStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
+		matchStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#34D399"))
+
+		fmt.Println()
+		for _, r := range results {
+			title := r.Title
+			if title == "" {
+				title = "(untitled session)"
+			}
+			id := r.ID
+			if len(id) > 8 {
+				id = id[:8]
+			}
+			fmt.Printf("  %s  %s  %s %s\n",
+				dimStyle.Render(r.Date.Local().Format("2006-01-02")),
+				dimStyle.Render(fmt.Sprintf("%-7s", r.Kind)),
+				valueStyle.Render(title),
+				dimStyle.Render("("+id+")"),
+			)
+			if r.Snippet != "" {
+				fmt.Printf("      %s\n", highlightSnippet(r.Snippet, matchStyle))
+			}
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+// highlightSnippet renders the terms wrapped in search match markers with style.
+func highlightSnippet(snippet string, style lipgloss.Style) string {
+	snippet = strings.ReplaceAll(snippet, "\n", " ")
+	parts := strings.Split(snippet, ports.SearchMatchMarker)
+	var b strings.Builder
+	for i, p := range parts {
+		if i%2 == 1 {
+			b.WriteString(style.Render(p))
+		} else {
+			b.WriteString(p)
+		}
+	}
+	return b.String()
+}
+
+// parseSince accepts a date (2006-01-02) or a relative span such as 7d, 2w or 3m.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, ok := parseSpan(value, now); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a date like 2006-01-02 or a span like 7d, 2w, 3m", value)
+}
+
+// parseSpan returns the day a relative span such as 7d, 2w, 3m or 1y
+// before today starts.
+func parseSpan(value string, now time.Time) (time.Time, bool) {
+	if len(value) < 2 {
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return time.Time{}, false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch value[len(value)-1] {
+	case 'd':
+		return today.AddDate(0, 0, -n), true
+	case 'w':
+		return today.AddDate(0, 0, -7*n), true
+	case 'm':
+		return today.AddDate(0, -n, 0), true
+	case 'y':
+		return today.AddDate(-n, 0, 0), true
+	}
+	return time.Time{}, false
+}
+
+func init() {
+	searchCmd.Flags().StringVar(&searchSince, "since", "", "Only show results on or after this date or span (e.g. 2025-01-31, 7d, 2w)")
+	searchCmd.Flags().StringVar(&searchTag, "tag", "", "Only show results with this tag")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "Maximum number of results")
+	rootCmd.AddCommand(searchCmd)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 3, 15, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"2025-01-31", "2025-01-31", false},
+		{"7d", "2025-03-08", false},
+		{"2w", "2025-03-01", false},
+		{"1m", "2025-02-15", false},
+		{"1y", "2024-03-15", false},
+		{"yesterday", "", true},
+		{"d", "", true},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.in, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSince(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got.Format("2006-01-02") != tt.want {
+			t.Errorf("parseSince(%q) = %s, want %s", tt.in, got.Format("2006-01-02"), tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/adapters/httpapi"
+)
+
+var serveAddr string
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a local HTTP API",
+	Long: `Serve the Flow state as a REST/JSON API for editor plugins, desktop widgets
+and dashboards, with the same operations as the MCP server:
+
+  GET  /state                      current session, task and today's stats
+  GET  /tasks?status=pending       list tasks
+  POST /tasks                      {"title", "description", "tags"}
+  POST /tasks/{id}/complete
+  GET  /tasks/{id}/sessions
+  GET  /sessions?limit=10          recent sessions
+  POST /session/start              {"task_id", "duration_minutes"}, both optional
+  POST /session/stop|pause|resume
+  POST /sessions/{id}/notes        {"notes"}
+  POST /sessions/{id}/distractions {"text"}
+  POST /sessions/{id}/focus-score  {"score"}
+  GET  /highlight, PUT /highlight  {"task_id"}
+  GET  /search?q=&since=&tag=&limit=
+  GET  /events                     Server-Sent Events: state, started, tick,
+                                   paused, resumed, completed, stopped
+
+Requests with a body must send Content-Type: application/json, and every
+request's Host must be localhost, 127.0.0.1, [::1] or the --addr host. The API
+has no authentication, so keep it on a loopback address.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if host, _, err := net.SplitHostPort(serveAddr); err != nil {
+			return fmt.Errorf("invalid address %q: %w", serveAddr, err)
+		} else if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			fmt.Fprintf(os.Stderr, "Warning: %s is reachable from other machines and the API has no authentication\n", serveAddr)
+		}
+
+		// Sessions started over the API have no timer watching them
+		ensureDaemon()
+
+		listener, err := net.Listen("tcp", serveAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", serveAddr, err)
+		}
+		// Requests share the signal context, so open /events streams end
+		// on Ctrl+C instead of holding up the shutdown.
+		ctx := setupSignalHandler()
+		srv := &http.Server{
+			Handler:           httpapi.NewServer(app.state, serveAddr, time.Second),
+			ReadHeaderTimeout: 5 * time.Second,
+			BaseContext:       func(net.Listener) context.Context { return ctx },
+		}
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = srv.Shutdown(shutdownCtx)
+		}()
+
+		fmt.Printf("Flow API listening on http://%s (Ctrl+C to stop)\n", listener.Addr())
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("HTTP server error: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", httpapi.DefaultAddr, "Address to listen on")
+	rootCmd.AddCommand(serveCmd)
+}
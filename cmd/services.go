@@ -5,11 +5,16 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 
+	"github.com/xvierd/flow-cli/internal/adapters/daemon"
 	"github.com/xvierd/flow-cli/internal/adapters/git"
+	"github.com/xvierd/flow-cli/internal/adapters/hooks"
 	"github.com/xvierd/flow-cli/internal/adapters/notification"
 	"github.com/xvierd/flow-cli/internal/adapters/storage"
+	"github.com/xvierd/flow-cli/internal/adapters/webhook"
 	"github.com/xvierd/flow-cli/internal/config"
 	"github.com/xvierd/flow-cli/internal/domain"
 	"github.com/xvierd/flow-cli/internal/methodology"
@@ -23,6 +28,10 @@ type appDeps struct {
 	tasks       *services.TaskService
 	pomodoro    *services.PomodoroService
 	state       *services.StateService
+	archive     *services.ArchiveService
+	reports     *services.ReportService
+	plans       *services.PlanService
+	webhooks    *services.WebhookService
 	git         ports.GitDetector
 	notifier    *notification.Notifier
 	config      *config.Config
@@ -37,33 +46,27 @@ var app appDeps
 // initializeServices sets up all the required services and adapters.
 func initializeServices() error {
 	// Load configuration
-	var err error
-	app.config, err = config.Load()
-	if err != nil {
-		// If config loading fails, use defaults
-		app.config = config.DefaultConfig()
-	}
+	loadConfig()
 
 	// Initialize notifier
 	app.notifier = notification.New(&app.config.Notifications)
 
-	// Determine database path
-	if dbPath == "" {
-		dbPath = config.GetDBPath(app.config)
-	}
-
-	// Ensure directory exists
-	dbDir := getDir(dbPath)
-	if err := os.MkdirAll(dbDir, 0750); err != nil {
-		return fmt.Errorf("failed to create database directory: %w", err)
+	// Determine database path and ensure its directory exists
+	if err := resolveDBPath(); err != nil {
+		return err
 	}
 
 	// Initialize storage
+	var err error
 	app.storage, err = storage.New(dbPath)
 	if err != nil {
 		return fmt.Errorf("failed to initialize storage: %w", err)
 	}
 
+	// Take the first snapshot of the day. A failed backup must never stop
+	// a session from starting, so errors are ignored here.
+	_, _ = storage.DailySnapshot(context.Background(), app.storage, backupDir(), app.config.Storage.BackupKeep, time.Now())
+
 	// Initialize git detector
 	app.git = git.NewDetector()
 
@@ -71,24 +74,14 @@ func initializeServices() error {
 	app.tasks = services.NewTaskService(app.storage)
 	app.pomodoro = services.NewPomodoroService(app.storage, app.git)
 	app.state = services.NewStateService(app.storage)
+	app.archive = services.NewArchiveService(app.storage)
+	app.reports = services.NewReportService(app.storage)
+	app.plans = services.NewPlanService(app.storage)
 
-	// Configure pomodoro service from config
-	workDur, _, _, sessionsBeforeLong := app.config.ToPomodoroDomainConfig()
-	shortBreakDur, longBreakDur := app.config.GetBreakDurations(app.methodology)
-	// Pomodoro Technique rule: long break always after every 4 work sessions.
-	if app.methodology == domain.MethodologyPomodoro {
-		sessionsBeforeLong = 4
+	// User-defined [[methodologies]] are valid modes from here on
+	if err := methodology.Register(app.config); err != nil {
+		return err
 	}
-	app.pomodoro.SetConfig(domain.PomodoroConfig{
-		WorkDuration:       workDur,
-		ShortBreakDuration: shortBreakDur,
-		LongBreakDuration:  longBreakDur,
-		SessionsBeforeLong: sessionsBeforeLong,
-	})
-
-	// Wire up services for state service
-	app.state.SetTaskService(app.tasks)
-	app.state.SetPomodoroService(app.pomodoro)
 
 	// Resolve effective methodology: --mode flag > config > default
 	modeStr := app.config.Methodology
@@ -102,14 +95,132 @@ func initializeServices() error {
 	if err != nil {
 		return fmt.Errorf("invalid mode: %w", err)
 	}
+	if err := setMethodology(m); err != nil {
+		return err
+	}
+
+	// Run the user's [hooks] commands on session lifecycle events
+	if commands := app.config.Hooks.Commands(); len(commands) > 0 {
+		app.pomodoro.AddHooks(hooks.NewRunner(commands, time.Duration(app.config.Hooks.Timeout), hooksLogPath()))
+	}
+
+	// POST session events to the [[webhooks]]
+	webhooks, err := webhooksFromConfig(app.config.Webhooks)
+	if err != nil {
+		return err
+	}
+	app.webhooks = services.NewWebhookService(app.storage, webhook.NewSender(), webhooks)
+	if len(webhooks) > 0 {
+		app.pomodoro.AddHooks(app.webhooks)
+	}
+
+	// Wire up services for state service
+	app.state.SetTaskService(app.tasks)
+	app.state.SetPomodoroService(app.pomodoro)
+	// A running daemon completes sessions; readers of the state leave them to it
+	app.state.SetDaemonCheck(func() bool {
+		_, err := daemon.Query(socketPath())
+		return err == nil
+	})
+
+	// Stale sessions found by scripts and MCP calls follow the config policy;
+	// interactive launches ask instead (see recoverStaleSession).
+	policy, err := domain.ValidateStaleAction(app.config.Recovery.StalePolicy)
+	if err != nil || policy == domain.StaleActionCredit {
+		return fmt.Errorf("invalid recovery.stale_policy %q: use ask, keep or void", app.config.Recovery.StalePolicy)
+	}
+	app.state.SetStalePolicy(policy)
+
+	return nil
+}
+
+// setMethodology switches the app to methodology m and gives the pomodoro
+// service that methodology's session and break lengths.
+func setMethodology(m domain.Methodology) error {
 	app.methodology = m
-	app.mode = methodology.ForMethodology(app.methodology, app.config)
+	app.mode = methodology.ForMethodology(m, app.config)
 
+	workDur, _, _, _ := app.config.ToPomodoroDomainConfig()
+	if m == domain.MethodologyMob {
+		workDur = app.config.Mob.RotationLength()
+	}
+	shortBreakDur, longBreakDur := app.config.GetBreakDurations(m)
+	earnedBreaks, err := app.config.Flowtime.BreakPolicy()
+	if err != nil {
+		return err
+	}
+	app.pomodoro.SetConfig(domain.PomodoroConfig{
+		WorkDuration:       workDur,
+		ShortBreakDuration: shortBreakDur,
+		LongBreakDuration:  longBreakDur,
+		SessionsBeforeLong: app.config.GetSessionsBeforeLong(m),
+		EarnedBreaks:       earnedBreaks,
+		Roster:             app.config.Mob.Roster,
+	})
 	return nil
 }
 
+// loadConfig loads the configuration, falling back to defaults on error.
+func loadConfig() {
+	var err error
+	app.config, err = config.Load()
+	if err != nil {
+		app.config = config.DefaultConfig()
+		// Without an expanded data dir the database would land in a
+		// directory literally named ~ under the working directory.
+		_ = app.config.ExpandDataDir()
+	}
+}
+
+// resolveDBPath fills in the default database path when --db was not given
+// and makes sure the containing directory exists.
+func resolveDBPath() error {
+	if dbPath == "" {
+		dbPath = config.GetDBPath(app.config)
+	}
+
+	dbDir := getDir(dbPath)
+	if err := os.MkdirAll(dbDir, 0750); err != nil {
+		return fmt.Errorf("failed to create database directory: %w", err)
+	}
+	return nil
+}
+
+// backupDir returns the directory holding database snapshots, next to the database.
+func backupDir() string {
+	return filepath.Join(getDir(dbPath), "backups")
+}
+
+// webhooksFromConfig validates the [[webhooks]] entries.
+func webhooksFromConfig(entries []config.WebhookConfig) ([]services.Webhook, error) {
+	webhooks := make([]services.Webhook, 0, len(entries))
+	for i, entry := range entries {
+		if entry.URL == "" {
+			return nil, fmt.Errorf("webhooks entry %d has no url", i+1)
+		}
+		w := services.Webhook{URL: entry.URL, Secret: entry.Secret}
+		for _, name := range entry.Events {
+			event, err := domain.ValidateHookEvent(name)
+			if err != nil {
+				return nil, fmt.Errorf("webhook %s: %w", entry.URL, err)
+			}
+			w.Events = append(w.Events, event)
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, nil
+}
+
+// hooksLogPath returns where hook output and failures are logged, next to the database.
+func hooksLogPath() string {
+	return filepath.Join(getDir(dbPath), "hooks.log")
+}
+
 // cleanupServices closes all resources.
 func cleanupServices() error {
+	if app.webhooks != nil {
+		app.webhooks.Wait(webhookFlushTimeout)
+	}
 	if app.storage != nil {
 		return app.storage.Close()
 	}
@@ -21,7 +21,11 @@ var startCmd = &cobra.Command{
 	Short: "Start a pomodoro session",
 	Long: `Start a new pomodoro work session. Optionally specify a task ID
 to associate with the session. If no task ID is provided and there is
-an active task, that task will be used.`,
+an active task, that task will be used.
+
+Without a task ID, a session started inside a block of today's plan (see
+"flow plan") follows it: it runs on the block's task, with its tags and
+methodology, until the block ends.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 
@@ -36,6 +40,10 @@ an active task, that task will be used.`,
 			taskID = &args[0]
 		}
 
+		if err := recoverStaleSession(ctx); err != nil {
+			return err
+		}
+
 		// Check for active session and prompt user
 		state, err := app.state.GetCurrentState(ctx)
 		if err != nil {
@@ -44,12 +52,11 @@ an active task, that task will be used.`,
 
 		if state.ActiveSession != nil {
 			active := state.ActiveSession
-			remaining := active.RemainingTime()
 			sessionType := domain.GetSessionTypeLabel(active.Type)
-			sessionInfo := fmt.Sprintf("%s session (%s remaining)", sessionType, formatCmdDuration(remaining))
+			sessionInfo := fmt.Sprintf("%s session (%s)", sessionType, sessionClock(active))
 
 			if state.ActiveTask != nil {
-				sessionInfo = fmt.Sprintf("%s for task \"%s\" (%s remaining)", sessionType, state.ActiveTask.Title, formatCmdDuration(remaining))
+				sessionInfo = fmt.Sprintf("%s for task \"%s\" (%s)", sessionType, state.ActiveTask.Title, sessionClock(active))
 			}
 
 			fmt.Printf("⚠️  A %s is already running: %s\n", strings.ToLower(sessionType), sessionInfo)
@@ -85,9 +92,23 @@ an active task, that task will be used.`,
 
 		// Start the pomodoro session
 		req := services.StartPomodoroRequest{
-			TaskID:     taskID,
-			WorkingDir: workingDir,
-			Tags:       tags,
+			TaskID:      taskID,
+			WorkingDir:  workingDir,
+			Methodology: app.methodology,
+			Tags:        tags,
+		}
+
+		// Without a task, a session inside a block of today's plan follows it
+		if block := state.CurrentBlock; taskID == nil && block != nil {
+			if err := followPlanMethodology(block); err != nil {
+				return err
+			}
+			if req, err = plannedSession(ctx, block, workingDir); err != nil {
+				return err
+			}
+			req.Tags = domain.NormalizeTags(append(req.Tags, tags...))
+			taskID = req.TaskID
+			fmt.Printf("📅 Following the plan: %s\n", block)
 		}
 
 		session, err := app.pomodoro.StartPomodoro(ctx, req)
@@ -95,7 +116,11 @@ an active task, that task will be used.`,
 			return fmt.Errorf("failed to start pomodoro: %w", err)
 		}
 
-		fmt.Printf("🍅 Pomodoro started! Duration: %s\n", session.Duration)
+		if session.IsOpenEnded() {
+			fmt.Println("🍅 Flowtime session started, counting up. Stop when your focus fades.")
+		} else {
+			fmt.Printf("🍅 Pomodoro started! Duration: %s\n", session.Duration)
+		}
 		if taskID != nil {
 			fmt.Printf("   Task ID: %s\n", *taskID)
 		}
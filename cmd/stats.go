@@ -4,190 +4,394 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 	"github.com/xvierd/flow-cli/internal/domain"
-	"sort"
+	"github.com/xvierd/flow-cli/internal/ports"
 )
 
-var statsPeriod string
+var (
+	statsPeriod  string
+	statsOffset  int
+	statsFrom    string
+	statsTo      string
+	statsLast    string
+	statsTag     string
+	statsByTag   bool
+	statsHeatmap bool
+	statsMetric  string
+)
 
 var statsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "Show a dashboard of session statistics",
-	Long:  `Display a terminal dashboard with session counts, deep work hours, focus scores, and distraction trends.`,
+	Long: `Display a terminal dashboard with session counts, deep work hours, focus scores, and distraction trends.
+
+The dashboard covers the current week by default. --period picks a month,
+quarter or year instead, and --offset steps back (--offset -1 is the previous
+one). --from/--to (YYYY-MM-DD, both inclusive) and --last (e.g. 30d, 6w, 3m,
+1y, ending today) report on any range of days instead. Sessions, work time,
+focus score and distractions are compared with the previous equivalent
+period, or the same number of days before a custom range.
+
+--tag limits the dashboard to sessions tagged with that tag or a tag nested
+beneath it (--tag client includes client/acme), whether the tag is on the
+session or its task. --by-tag adds a breakdown of time per tag, with nested
+tags rolled up into their parents.
+
+--heatmap shows the last 12 months instead, as a GitHub-style grid of daily
+focus time with streaks and monthly totals. --metric deepwork counts only Deep
+Work sessions, the default in Deep Work mode.
+
+--json prints the dashboard's numbers in the layout "flow schema stats"
+describes ("flow schema heatmap" with --heatmap), with durations in seconds.
+
+  flow stats --period month --offset -1   # last month
+  flow stats --last 30d
+  flow stats --from 2026-01-01 --to 2026-03-31
+  flow stats --heatmap`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		now := time.Now()
 
-		var start, end time.Time
-		var label string
-
-		switch statsPeriod {
-		case "month":
-			start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-			end = start.AddDate(0, 1, 0)
-			label = now.Format("January 2006")
-		default:
-			// Default to current week (Monday start)
-			weekday := int(now.Weekday())
-			if weekday == 0 {
-				weekday = 7
-			}
-			start = time.Date(now.Year(), now.Month(), now.Day()-(weekday-1), 0, 0, 0, 0, now.Location())
-			end = start.AddDate(0, 0, 7)
-			label = fmt.Sprintf("Week of %s", start.Format("Jan 2"))
+		if statsHeatmap {
+			return runHeatmap(ctx, cmd)
 		}
 
-		stats, err := app.storage.Sessions().GetPeriodStats(ctx, start, end)
+		current, previous, err := statsRange(time.Now(), cmd.Flags().Changed("period"))
 		if err != nil {
-			return fmt.Errorf("failed to get stats: %w", err)
+			return err
 		}
-		stats.Label = label
-
-		// Fetch hourly productivity (last 30 days)
-		hourly, err := app.storage.Sessions().GetHourlyProductivity(ctx, 30)
-		if err != nil {
-			hourly = nil // non-fatal
+		label := current.Label
+		if statsTag != "" {
+			label = fmt.Sprintf("%s · #%s", label, domain.NormalizeTag(statsTag))
 		}
 
-		// Fetch energize stats — only relevant for Make Time methodology
-		var energize []domain.EnergizeStat
-		if app.methodology == domain.MethodologyMakeTime {
-			energize, err = app.storage.Sessions().GetEnergizeStats(ctx, start, end)
-			if err != nil {
-				energize = nil // non-fatal
+		query := ports.StatsQuery{
+			Start:   current.Start,
+			End:     current.End,
+			Label:   label,
+			Tag:     statsTag,
+			ByTag:   statsByTag,
+			Compare: &previous,
+		}
+		// Deep Work dashboards of the current period add context for the
+		// configured philosophy
+		isCurrent := statsFrom == "" && statsLast == "" && statsOffset == 0
+		if app.methodology == domain.MethodologyDeepWork && isCurrent {
+			query.Philosophy = app.config.DeepWork.Philosophy
+			if query.Philosophy == "" {
+				query.Philosophy = "rhythmic"
 			}
 		}
 
-		// Fetch Deep Work philosophy-specific stats
-		var philosophy string
-		var streak int
-		var prevWeekHours time.Duration
-		var monthHours time.Duration
-		if app.methodology == domain.MethodologyDeepWork {
-			philosophy = app.config.DeepWork.Philosophy
-			if philosophy == "" {
-				philosophy = "rhythmic"
-			}
+		report, err := app.reports.Stats(ctx, query)
+		if err != nil {
+			return err
+		}
 
-			switch philosophy {
-			case "rhythmic":
-				// Get streak (consecutive days with deep work)
-				streak, _ = app.storage.Sessions().GetDeepWorkStreak(ctx, 1*time.Minute)
-			case "bimodal", "journalistic":
-				// Get previous week hours for comparison
-				prevWeekStart := start.AddDate(0, 0, -7)
-				prevWeekHours, _ = app.storage.Sessions().GetDeepWorkHours(ctx, prevWeekStart, start)
-			case "monastic":
-				// Get current month hours
-				monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-				monthEnd := monthStart.AddDate(0, 1, 0)
-				monthHours, _ = app.storage.Sessions().GetDeepWorkHours(ctx, monthStart, monthEnd)
-			}
+		if formatTemplate != "" {
+			return printTemplate(formatTemplate, report)
+		}
+		if jsonOutput {
+			return printReportJSON(report)
 		}
 
 		fmt.Println()
-		renderDashboard(stats, hourly, energize, philosophy, streak, prevWeekHours, monthHours)
+		renderDashboard(report)
 		return nil
 	},
 }
 
 func init() {
-	statsCmd.Flags().StringVarP(&statsPeriod, "period", "p", "week", "Time period: week or month")
+	statsCmd.Flags().StringVarP(&statsPeriod, "period", "p", "week", "Time period: week, month, quarter or year")
+	statsCmd.Flags().IntVar(&statsOffset, "offset", 0, "Periods to step back from the current one (e.g. -1 for the previous)")
+	statsCmd.Flags().StringVar(&statsFrom, "from", "", "First day of a custom range (YYYY-MM-DD)")
+	statsCmd.Flags().StringVar(&statsTo, "to", "", "Last day of a custom range (YYYY-MM-DD, default today)")
+	statsCmd.Flags().StringVar(&statsLast, "last", "", "Custom range ending today (e.g. 30d, 6w, 3m, 1y)")
+	statsCmd.Flags().StringVar(&statsTag, "tag", "", "Only count sessions with this tag or a tag nested beneath it")
+	statsCmd.Flags().BoolVar(&statsByTag, "by-tag", false, "Break down time by tag")
+	statsCmd.Flags().BoolVar(&statsHeatmap, "heatmap", false, "Show a calendar heatmap of the last 12 months")
+	statsCmd.Flags().StringVar(&statsMetric, "metric", "", "Heatmap metric: total or deepwork (default deepwork in Deep Work mode, else total)")
+	statsCmd.Flags().StringVar(&formatTemplate, "format", "", formatFlagUsage)
 	rootCmd.AddCommand(statsCmd)
 }
 
-func renderDashboard(stats *domain.PeriodStats, hourly map[int]time.Duration, energize []domain.EnergizeStat, philosophy string, streak int, prevWeekHours, monthHours time.Duration) {
+// runHeatmap shows the calendar heatmap of the last 12 months.
+func runHeatmap(ctx context.Context, cmd *cobra.Command) error {
+	for _, name := range []string{"period", "offset", "from", "to", "last", "tag", "by-tag"} {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--%s can't be combined with --heatmap, which always covers the last 12 months", name)
+		}
+	}
+
+	metric := statsMetric
+	if metric == "" {
+		metric = ports.HeatmapMetricTotal
+		if app.methodology == domain.MethodologyDeepWork {
+			metric = ports.HeatmapMetricDeepWork
+		}
+	}
+	if metric != ports.HeatmapMetricTotal && metric != ports.HeatmapMetricDeepWork {
+		return fmt.Errorf("invalid --metric %q: use total or deepwork", metric)
+	}
+
+	heatmap, err := app.reports.Heatmap(ctx, time.Now(), metric)
+	if err != nil {
+		return err
+	}
+
+	if formatTemplate != "" {
+		return printTemplate(formatTemplate, heatmap)
+	}
+	if jsonOutput {
+		return printReportJSON(heatmap)
+	}
+
+	fmt.Println()
+	renderHeatmap(heatmap, app.config.Theme)
+	return nil
+}
+
+// statsRange resolves the range flags to the range to report on and the one
+// to compare it with. periodSet is whether --period was given explicitly.
+func statsRange(now time.Time, periodSet bool) (current, previous domain.DateRange, err error) {
+	custom := statsFrom != "" || statsTo != "" || statsLast != ""
+	switch {
+	case statsFrom != "" && statsLast != "":
+		return current, previous, fmt.Errorf("use either --from/--to or --last, not both")
+	case custom && periodSet:
+		return current, previous, fmt.Errorf("--period can't be combined with --from, --to or --last")
+	case custom && statsOffset != 0:
+		return current, previous, fmt.Errorf("--offset only applies to --period")
+	case statsTo != "" && statsFrom == "":
+		return current, previous, fmt.Errorf("--to needs --from")
+	}
+
+	switch {
+	case statsFrom != "":
+		from, err := time.ParseInLocation("2006-01-02", statsFrom, now.Location())
+		if err != nil {
+			return current, previous, fmt.Errorf("invalid --from %q: use a date like 2006-01-02", statsFrom)
+		}
+		to := now
+		if statsTo != "" {
+			if to, err = time.ParseInLocation("2006-01-02", statsTo, now.Location()); err != nil {
+				return current, previous, fmt.Errorf("invalid --to %q: use a date like 2006-01-02", statsTo)
+			}
+		}
+		if to.Before(from) {
+			return current, previous, fmt.Errorf("--to %s is before --from %s", to.Format("2006-01-02"), statsFrom)
+		}
+		current = domain.NewDateRange(from, to)
+		return current, current.Previous(), nil
+
+	case statsLast != "":
+		// 30d is today and the 29 days before it
+		since, ok := parseSpan(statsLast, now)
+		if !ok || since.AddDate(0, 0, 1).After(now) {
+			return current, previous, fmt.Errorf("invalid --last %q: use a span like 30d, 6w, 3m or 1y", statsLast)
+		}
+		current = domain.NewDateRange(since.AddDate(0, 0, 1), now)
+		return current, current.Previous(), nil
+
+	default:
+		period, err := domain.ValidatePeriod(statsPeriod)
+		if err != nil {
+			return current, previous, err
+		}
+		return period.Range(now, statsOffset), period.Range(now, statsOffset-1), nil
+	}
+}
+
+func renderDashboard(report *ports.StatsReport) {
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C6FE0"))
 	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
 	valueStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
 	barColor := lipgloss.NewStyle().Foreground(lipgloss.Color("#7C6FE0"))
 
 	// Header
-	fmt.Printf("  %s\n", titleStyle.Render(stats.Label))
+	fmt.Printf("  %s\n", titleStyle.Render(report.Label))
 	fmt.Printf("  %s\n\n", dimStyle.Render(strings.Repeat("─", 40)))
 
 	// Summary line
-	hours := stats.TotalWorkTime.Hours()
 	fmt.Printf("  Total: %s sessions, %s deep work\n\n",
-		valueStyle.Render(fmt.Sprintf("%d", stats.TotalSessions)),
-		valueStyle.Render(formatHours(hours)),
+		valueStyle.Render(fmt.Sprintf("%d", report.TotalSessions)),
+		valueStyle.Render(formatHours(secondsToHours(report.WorkSeconds))),
 	)
 
-	if stats.TotalSessions == 0 {
+	// Change against the previous period
+	renderComparison(report, dimStyle, valueStyle)
+
+	if report.TotalSessions == 0 {
 		fmt.Printf("  %s\n\n", dimStyle.Render("No completed sessions in this period."))
 		return
 	}
 
 	// Philosophy-specific context for Deep Work
-	if philosophy != "" {
-		renderPhilosophyContext(philosophy, stats, streak, prevWeekHours, monthHours, dimStyle, valueStyle)
+	if report.DeepWork != nil {
+		renderPhilosophyContext(report, dimStyle, valueStyle)
 	}
 
 	// Bar chart: sessions per methodology
 	fmt.Printf("  %s\n", dimStyle.Render("Sessions by mode"))
 	maxCount := 0
-	for _, m := range stats.ByMethodology {
-		if m.SessionCount > maxCount {
-			maxCount = m.SessionCount
+	for _, m := range report.ByMethodology {
+		if m.Sessions > maxCount {
+			maxCount = m.Sessions
 		}
 	}
 
 	maxBarWidth := 30
-	for _, m := range stats.ByMethodology {
+	for _, m := range report.ByMethodology {
 		barWidth := 0
 		if maxCount > 0 {
-			barWidth = int(math.Round(float64(m.SessionCount) / float64(maxCount) * float64(maxBarWidth)))
+			barWidth = int(math.Round(float64(m.Sessions) / float64(maxCount) * float64(maxBarWidth)))
 		}
-		if barWidth < 1 && m.SessionCount > 0 {
+		if barWidth < 1 && m.Sessions > 0 {
 			barWidth = 1
 		}
 		bar := buildBar(barWidth)
-		methodLabel := fmt.Sprintf("%-10s", m.Methodology.Label())
+		meth := domain.Methodology(m.Methodology)
+		methodLabel := fmt.Sprintf("%-10s", meth.Label())
+		workTime := formatHours(secondsToHours(m.WorkSeconds))
+		// Sessions that count up vary in length, so their average says more
+		if meth.CountsUp() && m.Sessions > 0 {
+			workTime += ", avg " + formatMinutes(time.Duration(m.WorkSeconds/m.Sessions)*time.Second)
+		}
 		fmt.Printf("  %s %s %d (%s)\n",
 			dimStyle.Render(methodLabel),
 			barColor.Render(bar),
-			m.SessionCount,
-			formatHours(m.TotalTime.Hours()),
+			m.Sessions,
+			workTime,
 		)
 	}
 	fmt.Println()
 
+	// Time per tag, nested tags indented under their parents
+	renderTagBreakdown(report.Tags, dimStyle, barColor)
+
 	// Focus score (Make Time)
-	if stats.FocusScoreCount > 0 {
+	if report.AvgFocusScore != nil {
 		fmt.Printf("  %s  %s  %s\n",
 			dimStyle.Render("Avg focus score:"),
-			valueStyle.Render(fmt.Sprintf("%.1f/5", stats.AvgFocusScore)),
-			dimStyle.Render(fmt.Sprintf("(%d sessions)", stats.FocusScoreCount)),
+			valueStyle.Render(fmt.Sprintf("%.1f/5", *report.AvgFocusScore)),
+			dimStyle.Render(fmt.Sprintf("(%d sessions)", report.FocusScoreCount)),
 		)
 	}
 
 	// Distraction count (Deep Work)
-	if stats.DistractionCount > 0 {
+	if report.Distractions > 0 {
 		fmt.Printf("  %s  %s\n",
 			dimStyle.Render("Distractions:"),
-			valueStyle.Render(fmt.Sprintf("%d", stats.DistractionCount)),
+			valueStyle.Render(fmt.Sprintf("%d", report.Distractions)),
+		)
+	}
+
+	// Pauses, from each session's event history
+	if report.Pauses > 0 {
+		fmt.Printf("  %s  %s  %s\n",
+			dimStyle.Render("Pauses:"),
+			valueStyle.Render(fmt.Sprintf("%.1f per session", float64(report.Pauses)/float64(report.TotalSessions))),
+			dimStyle.Render(fmt.Sprintf("(%s lost to pauses)", formatHours(secondsToHours(report.PausedSeconds)))),
 		)
 	}
 
-	if stats.FocusScoreCount > 0 || stats.DistractionCount > 0 {
+	if report.FocusScoreCount > 0 || report.Distractions > 0 || report.Pauses > 0 {
 		fmt.Println()
 	}
 
 	// Hourly productivity heatmap
-	renderHourlyProductivity(hourly, dimStyle, valueStyle, barColor)
+	renderHourlyProductivity(report.Hourly, dimStyle, valueStyle)
+
+	// Energize Insights — only relevant for Make Time methodology
+	if app.methodology == domain.MethodologyMakeTime {
+		renderEnergizeInsights(report.Energize, dimStyle, valueStyle, titleStyle)
+	}
 
-	// Energize Insights (Make Time)
-	renderEnergizeInsights(energize, dimStyle, valueStyle, titleStyle)
+	// Time at the keyboard per mob participant
+	renderDriverTime(report.Drivers, dimStyle, barColor)
+}
+
+// renderDriverTime displays how long each participant drove mob rotations.
+func renderDriverTime(drivers []ports.DriverTime, dimStyle, barColor lipgloss.Style) {
+	if len(drivers) == 0 {
+		return
+	}
+
+	// Drivers come sorted by time, most first
+	maxTime := drivers[0].WorkSeconds
+	fmt.Printf("  %s\n", dimStyle.Render("Time per driver"))
+	maxBarWidth := 20
+	for _, d := range drivers {
+		barWidth := 0
+		if maxTime > 0 {
+			barWidth = int(math.Round(float64(d.WorkSeconds) / float64(maxTime) * float64(maxBarWidth)))
+		}
+		if barWidth < 1 && d.WorkSeconds > 0 {
+			barWidth = 1
+		}
+		plural := "s"
+		if d.Rotations == 1 {
+			plural = ""
+		}
+		fmt.Printf("  %s %s %d rotation%s (%s)\n",
+			dimStyle.Render(fmt.Sprintf("%-12s", d.Driver)),
+			barColor.Render(buildBar(barWidth)),
+			d.Rotations,
+			plural,
+			formatHours(secondsToHours(d.WorkSeconds)),
+		)
+	}
+	fmt.Println()
+}
+
+// renderTagBreakdown displays time per tag as a tree. tags must be sorted
+// by name so nested tags follow their parent.
+func renderTagBreakdown(tags []ports.TagTime, dimStyle, barColor lipgloss.Style) {
+	if len(tags) == 0 {
+		return
+	}
+
+	maxTime := 0
+	for _, t := range tags {
+		if t.WorkSeconds > maxTime {
+			maxTime = t.WorkSeconds
+		}
+	}
+
+	fmt.Printf("  %s\n", dimStyle.Render("Time by tag"))
+	maxBarWidth := 20
+	for _, t := range tags {
+		depth := strings.Count(t.Tag, domain.TagSeparator)
+		name := t.Tag
+		if i := strings.LastIndex(name, domain.TagSeparator); i >= 0 {
+			name = name[i+1:]
+		}
+		barWidth := 0
+		if maxTime > 0 {
+			barWidth = int(math.Round(float64(t.WorkSeconds) / float64(maxTime) * float64(maxBarWidth)))
+		}
+		if barWidth < 1 && t.WorkSeconds > 0 {
+			barWidth = 1
+		}
+		label := fmt.Sprintf("%-18s", strings.Repeat("  ", depth)+"#"+name)
+		fmt.Printf("  %s %s %d (%s)\n",
+			dimStyle.Render(label),
+			barColor.Render(buildBar(barWidth)),
+			t.Sessions,
+			formatHours(secondsToHours(t.WorkSeconds)),
+		)
+	}
+	fmt.Println()
 }
 
 // renderEnergizeInsights displays a table of energize activities vs avg focus score (Make Time).
 // Results are sorted by avg focus score descending.
-func renderEnergizeInsights(energize []domain.EnergizeStat, dimStyle, valueStyle, titleStyle lipgloss.Style) {
+func renderEnergizeInsights(energize []ports.EnergizeStat, dimStyle, valueStyle, titleStyle lipgloss.Style) {
 	if len(energize) == 0 {
 		return
 	}
@@ -195,36 +399,27 @@ func renderEnergizeInsights(energize []domain.EnergizeStat, dimStyle, valueStyle
 	fmt.Printf("  %s\n", titleStyle.Render("Energize → Focus correlation"))
 	for _, e := range energize {
 		plural := "s"
-		if e.SessionCount == 1 {
+		if e.Sessions == 1 {
 			plural = ""
 		}
 		fmt.Printf("  %-12s  %-15s  avg focus %s\n",
 			dimStyle.Render(e.Activity),
-			dimStyle.Render(fmt.Sprintf("%d session%s", e.SessionCount, plural)),
+			dimStyle.Render(fmt.Sprintf("%d session%s", e.Sessions, plural)),
 			valueStyle.Render(fmt.Sprintf("%.1f/5", e.AvgFocusScore)),
 		)
 	}
 	fmt.Println()
 }
 
-// hourEntry pairs an hour with its total duration for sorting.
-type hourEntry struct {
-	Hour     int
-	Duration time.Duration
-}
-
-func renderHourlyProductivity(hourly map[int]time.Duration, dimStyle, valueStyle, barColor lipgloss.Style) {
+func renderHourlyProductivity(hourly []ports.HourStat, dimStyle, valueStyle lipgloss.Style) {
 	if len(hourly) == 0 {
 		return
 	}
 
 	// Sort hours by total duration descending to find top 3
-	entries := make([]hourEntry, 0, len(hourly))
-	for h, d := range hourly {
-		entries = append(entries, hourEntry{Hour: h, Duration: d})
-	}
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].Duration > entries[j].Duration
+	entries := append([]ports.HourStat(nil), hourly...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].WorkSeconds > entries[j].WorkSeconds
 	})
 
 	fmt.Printf("  %s\n", dimStyle.Render("Your most productive hours (last 30 days)"))
@@ -236,24 +431,27 @@ func renderHourlyProductivity(hourly map[int]time.Duration, dimStyle, valueStyle
 		hourLabel := fmt.Sprintf("%2d:00-%d:00", e.Hour, e.Hour+1)
 		fmt.Printf("  %s  %s\n",
 			dimStyle.Render(hourLabel),
-			valueStyle.Render(formatHours(e.Duration.Hours())),
+			valueStyle.Render(formatHours(secondsToHours(e.WorkSeconds))),
 		)
 	}
 	fmt.Println()
 }
 
 // renderPhilosophyContext displays Deep Work philosophy-specific stats.
-func renderPhilosophyContext(philosophy string, stats *domain.PeriodStats, streak int, prevWeekHours, monthHours time.Duration, dimStyle, valueStyle lipgloss.Style) {
+func renderPhilosophyContext(report *ports.StatsReport, dimStyle, valueStyle lipgloss.Style) {
+	dw := report.DeepWork
+
 	// Find Deep Work hours in this period
 	var dwHours float64
-	for _, m := range stats.ByMethodology {
-		if m.Methodology == domain.MethodologyDeepWork {
-			dwHours = m.TotalTime.Hours()
+	for _, m := range report.ByMethodology {
+		if m.Methodology == string(domain.MethodologyDeepWork) {
+			dwHours = secondsToHours(m.WorkSeconds)
 			break
 		}
 	}
+	prevWeekHours := secondsToHours(dw.PreviousWeekSeconds)
 
-	switch philosophy {
+	switch dw.Philosophy {
 	case "rhythmic":
 		// Show streak and daily goal progress.
 		// goalHours is a daily target (e.g. 4h/day). Expected hours = goalHours × workdays elapsed.
@@ -276,7 +474,7 @@ func renderPhilosophyContext(philosophy string, stats *domain.PeriodStats, strea
 			progress = (dwHours / expectedHours) * 100
 		}
 
-		fmt.Printf("  %s  %s", dimStyle.Render("Streak:"), valueStyle.Render(fmt.Sprintf("%d days", streak)))
+		fmt.Printf("  %s  %s", dimStyle.Render("Streak:"), valueStyle.Render(fmt.Sprintf("%d days", dw.StreakDays)))
 		if progress > 0 {
 			fmt.Printf("  %s  %s", dimStyle.Render("Weekly progress:"), valueStyle.Render(fmt.Sprintf("%.0f%%", progress)))
 		}
@@ -287,18 +485,10 @@ func renderPhilosophyContext(philosophy string, stats *domain.PeriodStats, strea
 		// Show this week vs last week — no daily pressure, just totals.
 		fmt.Printf("  %s  %s", dimStyle.Render("This week:"), valueStyle.Render(formatHours(dwHours)))
 		if prevWeekHours > 0 {
-			change := ""
-			if dwHours > prevWeekHours.Hours() {
-				change = fmt.Sprintf("↑ %.0f%%", ((dwHours-prevWeekHours.Hours())/prevWeekHours.Hours())*100)
-			} else if dwHours < prevWeekHours.Hours() {
-				change = fmt.Sprintf("↓ %.0f%%", ((prevWeekHours.Hours()-dwHours)/prevWeekHours.Hours())*100)
-			} else {
-				change = "—"
-			}
 			fmt.Printf("  %s  %s  %s",
 				dimStyle.Render("Last week:"),
-				valueStyle.Render(formatHours(prevWeekHours.Hours())),
-				valueStyle.Render(change),
+				valueStyle.Render(formatHours(prevWeekHours)),
+				valueStyle.Render(weekChange(dwHours, prevWeekHours)),
 			)
 		}
 		fmt.Printf("  %s\n\n", dimStyle.Render("(grab depth when you can)"))
@@ -307,18 +497,10 @@ func renderPhilosophyContext(philosophy string, stats *domain.PeriodStats, strea
 		// Show this week vs last week
 		fmt.Printf("  %s  %s", dimStyle.Render("This week:"), valueStyle.Render(formatHours(dwHours)))
 		if prevWeekHours > 0 {
-			change := ""
-			if dwHours > prevWeekHours.Hours() {
-				change = fmt.Sprintf("↑ %.0f%%", ((dwHours-prevWeekHours.Hours())/prevWeekHours.Hours())*100)
-			} else if dwHours < prevWeekHours.Hours() {
-				change = fmt.Sprintf("↓ %.0f%%", ((prevWeekHours.Hours()-dwHours)/prevWeekHours.Hours())*100)
-			} else {
-				change = "—"
-			}
 			fmt.Printf("  %s  %s  %s",
 				dimStyle.Render("Last week:"),
-				valueStyle.Render(formatHours(prevWeekHours.Hours())),
-				valueStyle.Render(change),
+				valueStyle.Render(formatHours(prevWeekHours)),
+				valueStyle.Render(weekChange(dwHours, prevWeekHours)),
 			)
 		}
 		fmt.Println()
@@ -328,12 +510,105 @@ func renderPhilosophyContext(philosophy string, stats *domain.PeriodStats, strea
 		// Show monthly hours
 		fmt.Printf("  %s  %s  %s\n\n",
 			dimStyle.Render("Monthly Deep Work:"),
-			valueStyle.Render(formatHours(monthHours.Hours())),
+			valueStyle.Render(formatHours(secondsToHours(dw.MonthSeconds))),
 			dimStyle.Render("(monastic view)"),
 		)
 	}
 }
 
+// renderComparison displays sessions, work time, focus score and
+// distractions next to the previous period's and the change between them.
+func renderComparison(report *ports.StatsReport, dimStyle, valueStyle lipgloss.Style) {
+	prev := report.Previous
+	if prev == nil || (report.TotalSessions == 0 && prev.TotalSessions == 0) {
+		return
+	}
+	better := lipgloss.NewStyle().Foreground(lipgloss.Color("#34D399"))
+	worse := lipgloss.NewStyle().Foreground(lipgloss.Color("#E74C3C"))
+
+	// change renders a delta, green when it moves the right way.
+	change := func(delta float64, text string, lowerIsBetter bool) string {
+		switch {
+		case delta == 0:
+			return dimStyle.Render("—")
+		case (delta > 0) != lowerIsBetter:
+			return better.Render(text)
+		default:
+			return worse.Render(text)
+		}
+	}
+	signed := func(delta float64, text string) string {
+		if delta > 0 {
+			return "+" + text
+		}
+		if delta < 0 {
+			return "-" + text
+		}
+		return text
+	}
+	score := func(avg *float64) string {
+		if avg == nil {
+			return "—"
+		}
+		return fmt.Sprintf("%.1f", *avg)
+	}
+
+	row := func(name, current, previous, delta string) {
+		fmt.Printf("  %s %s %s %s\n",
+			dimStyle.Render(fmt.Sprintf("%-14s", name)),
+			valueStyle.Render(fmt.Sprintf("%-9s", current)),
+			dimStyle.Render(fmt.Sprintf("%-9s", previous)),
+			delta,
+		)
+	}
+
+	fmt.Printf("  %s\n", dimStyle.Render("Compared with "+prev.Label))
+
+	sessionDelta := report.TotalSessions - prev.TotalSessions
+	row("Sessions", fmt.Sprintf("%d", report.TotalSessions), fmt.Sprintf("%d", prev.TotalSessions),
+		change(float64(sessionDelta), signed(float64(sessionDelta), fmt.Sprintf("%d", abs(sessionDelta))), false))
+
+	workDelta := report.WorkSeconds - prev.WorkSeconds
+	row("Work time", formatHours(secondsToHours(report.WorkSeconds)), formatHours(secondsToHours(prev.WorkSeconds)),
+		change(float64(workDelta), signed(float64(workDelta), formatHours(secondsToHours(abs(workDelta)))), false))
+
+	focusDelta := "—"
+	if report.AvgFocusScore != nil && prev.AvgFocusScore != nil {
+		d := *report.AvgFocusScore - *prev.AvgFocusScore
+		focusDelta = change(math.Round(d*10)/10, signed(d, fmt.Sprintf("%.1f", math.Abs(d))), false)
+	}
+	row("Avg focus", score(report.AvgFocusScore), score(prev.AvgFocusScore), focusDelta)
+
+	distractionDelta := report.Distractions - prev.Distractions
+	row("Distractions", fmt.Sprintf("%d", report.Distractions), fmt.Sprintf("%d", prev.Distractions),
+		change(float64(distractionDelta), signed(float64(distractionDelta), fmt.Sprintf("%d", abs(distractionDelta))), true))
+	fmt.Println()
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// weekChange formats this week's hours against last week's, e.g. "↑ 20%".
+func weekChange(thisWeek, lastWeek float64) string {
+	switch {
+	case thisWeek > lastWeek:
+		return fmt.Sprintf("↑ %.0f%%", ((thisWeek-lastWeek)/lastWeek)*100)
+	case thisWeek < lastWeek:
+		return fmt.Sprintf("↓ %.0f%%", ((lastWeek-thisWeek)/lastWeek)*100)
+	default:
+		return "—"
+	}
+}
+
+// secondsToHours converts a report's whole seconds to hours.
+func secondsToHours(seconds int) float64 {
+	return float64(seconds) / 3600
+}
+
 // buildBar creates a horizontal bar using block characters.
 func buildBar(width int) string {
 	if width <= 0 {
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatsRange(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name                 string
+		period, from, to     string
+		last                 string
+		offset               int
+		periodSet            bool
+		start, end, prevFrom string
+		wantErr              bool
+	}{
+		{name: "default week", period: "week", start: "2026-10-12", end: "2026-10-19", prevFrom: "2026-10-05"},
+		{name: "previous quarter", period: "quarter", offset: -1, periodSet: true, start: "2026-07-01", end: "2026-10-01", prevFrom: "2026-04-01"},
+		{name: "last 30 days", period: "week", last: "30d", start: "2026-09-17", end: "2026-10-17", prevFrom: "2026-08-18"},
+		{name: "from only", period: "week", from: "2026-10-01", start: "2026-10-01", end: "2026-10-17", prevFrom: "2026-09-15"},
+		{name: "from and to", period: "week", from: "2026-01-01", to: "2026-01-31", start: "2026-01-01", end: "2026-02-01", prevFrom: "2025-12-01"},
+		{name: "to before from", period: "week", from: "2026-02-01", to: "2026-01-01", wantErr: true},
+		{name: "to without from", period: "week", to: "2026-01-01", wantErr: true},
+		{name: "from and last", period: "week", from: "2026-01-01", last: "7d", wantErr: true},
+		{name: "period and last", period: "month", last: "7d", periodSet: true, wantErr: true},
+		{name: "offset and last", period: "week", last: "7d", offset: -1, wantErr: true},
+		{name: "empty span", period: "week", last: "0d", wantErr: true},
+		{name: "unknown period", period: "decade", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statsPeriod, statsFrom, statsTo, statsLast, statsOffset = tt.period, tt.from, tt.to, tt.last, tt.offset
+			defer func() { statsPeriod, statsFrom, statsTo, statsLast, statsOffset = "week", "", "", "", 0 }()
+
+			current, previous, err := statsRange(now, tt.periodSet)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("statsRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := current.Start.Format("2006-01-02"); got != tt.start {
+				t.Errorf("start = %s, want %s", got, tt.start)
+			}
+			if got := current.End.Format("2006-01-02"); got != tt.end {
+				t.Errorf("end = %s, want %s", got, tt.end)
+			}
+			if got := previous.Start.Format("2006-01-02"); got != tt.prevFrom {
+				t.Errorf("previous start = %s, want %s", got, tt.prevFrom)
+			}
+			if !previous.End.Equal(current.Start) {
+				t.Errorf("previous ends %s, want it to end where the range starts", previous.End)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/adapters/daemon"
 	"github.com/xvierd/flow-cli/internal/adapters/tui"
 	"github.com/xvierd/flow-cli/internal/domain"
 )
@@ -24,6 +25,9 @@ var statusCmd = &cobra.Command{
 			return fmt.Errorf("failed to get current state: %w", err)
 		}
 
+		if formatTemplate != "" {
+			return printTemplate(formatTemplate, statusData(ctx, state))
+		}
 		if jsonOutput {
 			return outputStatusJSON(state)
 		}
@@ -31,6 +35,10 @@ var statusCmd = &cobra.Command{
 		// Use the TUI to display the status
 		tui.ShowStatus(state, &app.config.Theme)
 
+		if status, err := daemon.Query(socketPath()); err == nil {
+			fmt.Printf("\nDaemon: running (pid %d)\n", status.PID)
+		}
+
 		// Show today's Highlight for Make Time mode
 		if app.config.Methodology == "maketime" || app.config.Methodology == "make_time" {
 			highlight, _ := app.storage.Tasks().FindTodayHighlight(ctx, time.Now())
@@ -45,14 +53,29 @@ var statusCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	statusCmd.Flags().StringVar(&formatTemplate, "format", "", formatFlagUsage)
+}
+
 // outputStatusJSON outputs the status in JSON format
 func outputStatusJSON(state *domain.CurrentState) error {
-	ctx := context.Background()
+	jsonData, err := json.MarshalIndent(statusData(context.Background(), state), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal status: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
 
+// statusData builds the status model shared by --json and --format.
+func statusData(ctx context.Context, state *domain.CurrentState) map[string]interface{} {
 	result := map[string]interface{}{
 		"active_task":    nil,
 		"active_session": nil,
 		"highlight":      nil,
+		"current_block":  planBlockData(state.CurrentBlock),
+		"next_block":     planBlockData(state.NextBlock),
+		"daemon":         nil,
 		"today_stats": map[string]interface{}{
 			"work_sessions":   state.TodayStats.WorkSessions,
 			"breaks_taken":    state.TodayStats.BreaksTaken,
@@ -78,6 +101,7 @@ func outputStatusJSON(state *domain.CurrentState) error {
 			"status":         string(session.Status),
 			"duration":       session.Duration.String(),
 			"remaining_time": session.RemainingTime().String(),
+			"elapsed_time":   session.ElapsedTime().Round(time.Second).String(),
 			"progress":       session.Progress(),
 			"started_at":     session.StartedAt.Format("2006-01-02T15:04:05"),
 			"git_branch":     session.GitBranch,
@@ -90,6 +114,10 @@ func outputStatusJSON(state *domain.CurrentState) error {
 		result["active_session"] = sessionData
 	}
 
+	if status, err := daemon.Query(socketPath()); err == nil {
+		result["daemon"] = daemonStatusJSON(status)
+	}
+
 	// Include highlight for Make Time mode
 	if app.config.Methodology == "maketime" || app.config.Methodology == "make_time" {
 		highlight, _ := app.storage.Tasks().FindTodayHighlight(ctx, time.Now())
@@ -102,12 +130,22 @@ func outputStatusJSON(state *domain.CurrentState) error {
 		}
 	}
 
-	jsonData, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal status: %w", err)
+	return result
+}
+
+// planBlockData lays out a plan block for status JSON, or nil for none.
+func planBlockData(block *domain.PlanBlock) interface{} {
+	if block == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"id":          block.ID,
+		"start":       block.Start.Format("2006-01-02T15:04:05"),
+		"end":         block.End.Format("2006-01-02T15:04:05"),
+		"task":        block.Task,
+		"methodology": string(block.Methodology),
+		"tags":        block.Tags,
 	}
-	fmt.Println(string(jsonData))
-	return nil
 }
 
 // printStatusText prints the status in plain text format
@@ -116,8 +154,12 @@ func printStatusText(state *domain.CurrentState) {
 		session := state.ActiveSession
 		fmt.Println("🍅 Active Pomodoro Session")
 		fmt.Printf("   Status: %s (%s)\n", domain.GetStatusLabel(session.Status), domain.GetSessionTypeLabel(session.Type))
-		fmt.Printf("   Remaining: %s\n", session.RemainingTime())
-		fmt.Printf("   Progress: %.0f%%\n", session.Progress()*100)
+		if session.IsOpenEnded() {
+			fmt.Printf("   Elapsed: %s (counting up)\n", session.ElapsedTime().Round(time.Second))
+		} else {
+			fmt.Printf("   Remaining: %s\n", session.RemainingTime())
+			fmt.Printf("   Progress: %.0f%%\n", session.Progress()*100)
+		}
 		if session.GitBranch != "" {
 			fmt.Printf("   Git: %s (%s)\n", session.GitBranch, session.GitCommit[:7])
 		}
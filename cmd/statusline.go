@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/adapters/statusline"
+	"github.com/xvierd/flow-cli/internal/adapters/storage"
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+// statuslineStdinTimeout bounds the wait for status JSON on stdin.
+const statuslineStdinTimeout = 200 * time.Millisecond
+
+var (
+	statuslineFormat string
+	statuslineTarget string
+)
+
+var statuslineCmd = &cobra.Command{
+	Use:   "statusline",
+	Short: "Print the session as a one-line status",
+	Long: `Print the active session as a single line for a status bar.
+
+With the default "claude" target, flow reads Claude Code's status JSON on
+stdin and prefixes the model name and context usage. Other targets shape
+the output for tmux, starship, polybar and waybar (which gets JSON).
+
+--format takes a Go template. Fields: .Active .Paused .Break .Icon .Color
+.Remaining .Elapsed .Clock .CountsUp .Progress .Task .Type .Status .Mode
+.Model .Context and .Theme (the [theme] config). .Clock is .Elapsed while a
+Flowtime session counts up, else .Remaining. {{.Bar 5}} draws a 5-cell
+progress bar and {{fg .Color "text"}} colors text in the target's syntax.
+
+Example:
+  flow statusline --target tmux --format '{{.Icon}} {{.Remaining}} {{.Bar 5}} {{.Task}}'`,
+	Args: cobra.NoArgs,
+	// Overrides the root pre-run: status bars run this every few seconds, so
+	// it skips the daily backup and the hook and webhook setup.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		loadConfig()
+		if err := resolveDBPath(); err != nil {
+			return err
+		}
+		var err error
+		app.storage, err = storage.New(dbPath)
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, err := statusline.ParseTarget(statuslineTarget)
+		if err != nil {
+			return err
+		}
+		format := statuslineFormat
+		if format == "" {
+			format = target.DefaultFormat()
+		}
+
+		var input statusline.Input
+		if target == statusline.TargetClaude {
+			input = readStatuslineInput()
+		}
+
+		session, task := activeSession(cmd.Context())
+		line, err := statusline.Render(target, format, statusline.NewData(session, task, app.config.Theme, input))
+		if err != nil {
+			return err
+		}
+		fmt.Println(line)
+		return nil
+	},
+}
+
+// activeSession loads the running or paused session and its task. A status
+// line shows nothing rather than failing, so lookup errors are ignored.
+func activeSession(ctx context.Context) (*domain.PomodoroSession, *domain.Task) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	session, err := app.storage.Sessions().FindActive(ctx)
+	if err != nil || session == nil {
+		return nil, nil
+	}
+	if session.TaskID == nil {
+		return session, nil
+	}
+	task, err := app.storage.Tasks().FindByID(ctx, *session.TaskID)
+	if err != nil {
+		return session, nil
+	}
+	return session, task
+}
+
+// readStatuslineInput reads the assistant's status JSON from stdin. It
+// gives up after statuslineStdinTimeout so a status bar that leaves stdin
+// open, or a terminal, never blocks the line.
+func readStatuslineInput() statusline.Input {
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice != 0 {
+		return statusline.Input{}
+	}
+
+	done := make(chan statusline.Input, 1)
+	go func() {
+		input, err := statusline.ReadInput(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		done <- input
+	}()
+	select {
+	case input := <-done:
+		return input
+	case <-time.After(statuslineStdinTimeout):
+		return statusline.Input{}
+	}
+}
+
+func init() {
+	statuslineCmd.Flags().StringVar(&statuslineFormat, "format", "", "Go template for the line (default depends on --target)")
+	statuslineCmd.Flags().StringVar(&statuslineTarget, "target", string(statusline.TargetClaude), "Output for: claude, tmux, starship, polybar, waybar")
+	rootCmd.AddCommand(statuslineCmd)
+}
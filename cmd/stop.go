@@ -150,6 +150,11 @@ var stopCmd = &cobra.Command{
 		if session.Notes != "" {
 			fmt.Printf("   Notes: %s\n", session.Notes)
 		}
+		if session.IsWorkSession() && session.Methodology.CountsUp() {
+			if policy, err := app.config.Flowtime.BreakPolicy(); err == nil {
+				fmt.Printf("   Earned break: %s (\"flow break\" to take it)\n", formatMinutes(policy.BreakFor(session.Duration)))
+			}
+		}
 
 		return nil
 	},
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+var tagsDeleteForce bool
+
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "List and manage tags",
+	Long: `List and manage the tags on tasks and sessions.
+
+Tags can be nested with a slash: #client/acme is a child of #client. Renames,
+merges and deletes apply to a tag and everything nested beneath it, and
+rewrite every task and session that uses them.`,
+}
+
+var tagsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tags with how many tasks and sessions use them",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tags, err := app.storage.Tags().List(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			list := make([]map[string]interface{}, 0, len(tags))
+			for _, t := range tags {
+				list = append(list, map[string]interface{}{
+					"name":     t.Name,
+					"tasks":    t.Tasks,
+					"sessions": t.Sessions,
+				})
+			}
+			data, err := json.MarshalIndent(map[string]interface{}{
+				"tags":  list,
+				"count": len(list),
+			}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(tags) == 0 {
+			fmt.Println("No tags yet. Add one with #tag when starting a session or adding a task.")
+			return nil
+		}
+
+		dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		valueStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
+
+		fmt.Println()
+		for _, t := range tags {
+			fmt.Printf("  %s  %s\n",
+				valueStyle.Render(fmt.Sprintf("%-24s", "#"+t.Name)),
+				dimStyle.Render(fmt.Sprintf("%d tasks, %d sessions", t.Tasks, t.Sessions)),
+			)
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+var tagsRenameCmd = &cobra.Command{
+	Use:   "rename <tag> <new-name>",
+	Short: "Rename a tag and the tags nested beneath it",
+	Long: `Renames a tag everywhere it is used. Nested tags move with it, so
+renaming client to customer turns client/acme into customer/acme. If the new
+name is already in use, the two tags are merged.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n, err := app.storage.Tags().Rename(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Renamed #%s to #%s (%s).\n",
+			domain.NormalizeTag(args[0]), domain.NormalizeTag(args[1]), pluralTags(n))
+		return nil
+	},
+}
+
+var tagsMergeCmd = &cobra.Command{
+	Use:   "merge <tag>... <into>",
+	Short: "Merge tags into another tag",
+	Long: `Merges one or more tags into the last tag given. Everything tagged with
+a source tag is tagged with the target instead, and the source tags are removed.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sources, target := args[:len(args)-1], args[len(args)-1]
+		n, err := app.storage.Tags().Merge(cmd.Context(), sources, target)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Merged %s into #%s.\n", pluralTags(n), domain.NormalizeTag(target))
+		return nil
+	},
+}
+
+var tagsDeleteCmd = &cobra.Command{
+	Use:   "delete <tag>",
+	Short: "Remove a tag from every task and session",
+	Long: `Removes a tag, and every tag nested beneath it, from all tasks and
+sessions. The tasks and sessions themselves are kept. Use --force to skip the
+confirmation prompt.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tag := domain.NormalizeTag(args[0])
+
+		if !tagsDeleteForce {
+			tags, err := app.storage.Tags().List(cmd.Context())
+			if err != nil {
+				return err
+			}
+			var tasks, sessions, matched int
+			for _, t := range tags {
+				if domain.TagMatches(t.Name, tag) {
+					matched++
+					tasks += t.Tasks
+					sessions += t.Sessions
+				}
+			}
+			if matched == 0 {
+				return fmt.Errorf("%w: %s", domain.ErrTagNotFound, tag)
+			}
+
+			fmt.Printf("This will remove %s (%s) from %d tasks and %d sessions.\n",
+				"#"+tag, pluralTags(matched), tasks, sessions)
+			fmt.Print("Are you sure? Type 'yes' to confirm: ")
+			reader := bufio.NewReader(os.Stdin)
+			input, _ := reader.ReadString('\n')
+			input = strings.TrimSpace(strings.ToLower(input))
+			if input != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
+		n, err := app.storage.Tags().Delete(cmd.Context(), tag)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Deleted #%s (%s).\n", tag, pluralTags(n))
+		return nil
+	},
+}
+
+// pluralTags formats a tag count, e.g. "1 tag" or "3 tags".
+func pluralTags(n int) string {
+	if n == 1 {
+		return "1 tag"
+	}
+	return fmt.Sprintf("%d tags", n)
+}
+
+func init() {
+	tagsDeleteCmd.Flags().BoolVarP(&tagsDeleteForce, "force", "f", false, "Skip confirmation prompt")
+	tagsCmd.AddCommand(tagsListCmd, tagsRenameCmd, tagsMergeCmd, tagsDeleteCmd)
+	rootCmd.AddCommand(tagsCmd)
+}
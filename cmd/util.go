@@ -1,10 +1,46 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"time"
+
+	"github.com/xvierd/flow-cli/internal/adapters/templates"
+	"github.com/xvierd/flow-cli/internal/config"
+	"github.com/xvierd/flow-cli/internal/domain"
 )
 
+// formatTemplate is the --format flag shared by status, list, stats and
+// reflect: an inline Go template or the name of one in ~/.flow/templates/.
+var formatTemplate string
+
+// formatFlagUsage is the help text of the shared --format flag.
+const formatFlagUsage = "Go template, inline or named from ~/.flow/templates/ (sees the --json data)"
+
+// printTemplate renders data through the template given by spec.
+func printTemplate(spec string, data interface{}) error {
+	dir, err := config.GetTemplatesDir()
+	if err != nil {
+		return err
+	}
+	tmpl, err := templates.Load(spec, dir)
+	if err != nil {
+		return err
+	}
+	return templates.Execute(os.Stdout, tmpl, data)
+}
+
+// printReportJSON prints a report as indented JSON.
+func printReportJSON(report interface{}) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
 // formatMinutes formats a duration as a human-friendly string like "25m" or "1h30m".
 func formatMinutes(d time.Duration) string {
 	if d.Minutes() == float64(int(d.Minutes())) && int(d.Minutes())%60 == 0 && d >= time.Hour {
@@ -28,6 +64,24 @@ func formatWizardDuration(d time.Duration) string {
 	return fmt.Sprintf("%02d:%02d", m, s)
 }
 
+// sessionClock describes where a session stands, e.g. "12:34 remaining",
+// or "12:34 elapsed" for an open-ended session that counts up.
+func sessionClock(session *domain.PomodoroSession) string {
+	if session.IsOpenEnded() {
+		return formatWizardDuration(session.ElapsedTime()) + " elapsed"
+	}
+	return formatWizardDuration(session.RemainingTime()) + " remaining"
+}
+
+// sessionClockLabel is sessionClock for pause and resume messages, e.g.
+// "Remaining: 12m34s" or "Elapsed: 12m34s".
+func sessionClockLabel(session *domain.PomodoroSession) string {
+	if session.IsOpenEnded() {
+		return fmt.Sprintf("Elapsed: %s", session.ElapsedTime().Round(time.Second))
+	}
+	return fmt.Sprintf("Remaining: %s", session.RemainingTime())
+}
+
 // getDir returns the directory of a file path.
 func getDir(path string) string {
 	lastSep := 0
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+var webhooksLogLimit int
+
+var webhooksCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Test and inspect outbound webhooks",
+	Long: `Flow POSTs a JSON payload to each [[webhooks]] entry in the config on
+session events. With a secret, the body's HMAC-SHA256 is sent as
+"sha256=<hex>" in the X-Flow-Signature-256 header.
+
+Failed deliveries are queued and retried with backoff, from 30 seconds up
+to an hour, for up to 8 attempts. Retries run on the next session event and
+every 30 seconds while "flow daemon" is running.`,
+}
+
+var webhooksTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Send a sample event to every webhook",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(app.webhooks.Webhooks()) == 0 {
+			return fmt.Errorf("no webhooks configured; add a [[webhooks]] entry to ~/.flow/config.toml")
+		}
+
+		results := app.webhooks.Test(cmd.Context())
+		if jsonOutput {
+			list := make([]map[string]interface{}, 0, len(results))
+			for _, r := range results {
+				entry := map[string]interface{}{"url": r.URL, "ok": r.Err == nil, "error": nil}
+				if r.Err != nil {
+					entry["error"] = r.Err.Error()
+				}
+				list = append(list, entry)
+			}
+			data, err := json.MarshalIndent(map[string]interface{}{"results": list}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		failed := 0
+		for _, r := range results {
+			if r.Err != nil {
+				failed++
+				fmt.Printf("✗ %s: %v\n", r.URL, r.Err)
+				continue
+			}
+			fmt.Printf("✓ %s\n", r.URL)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d webhooks failed", failed, len(results))
+		}
+		return nil
+	},
+}
+
+var webhooksLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show recent webhook deliveries",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deliveries, err := app.webhooks.Log(cmd.Context(), webhooksLogLimit)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			list := make([]map[string]interface{}, 0, len(deliveries))
+			for _, d := range deliveries {
+				entry := map[string]interface{}{
+					"id":              d.ID,
+					"url":             d.URL,
+					"event":           string(d.Event),
+					"status":          string(d.Status),
+					"attempts":        d.Attempts,
+					"last_error":      d.LastError,
+					"created_at":      d.CreatedAt.Format("2006-01-02T15:04:05"),
+					"next_attempt_at": nil,
+					"delivered_at":    nil,
+				}
+				if d.Status == domain.WebhookPending {
+					entry["next_attempt_at"] = d.NextAttemptAt.Format("2006-01-02T15:04:05")
+				}
+				if d.DeliveredAt != nil {
+					entry["delivered_at"] = d.DeliveredAt.Format("2006-01-02T15:04:05")
+				}
+				list = append(list, entry)
+			}
+			data, err := json.MarshalIndent(map[string]interface{}{
+				"deliveries": list,
+				"count":      len(list),
+			}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(deliveries) == 0 {
+			fmt.Println("No webhook deliveries yet.")
+			return nil
+		}
+
+		dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#2ECC71"))
+		pendingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F1C40F"))
+		failedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#E74C3C"))
+
+		fmt.Println()
+		for _, d := range deliveries {
+			var status string
+			switch d.Status {
+			case domain.WebhookDelivered:
+				status = okStyle.Render(fmt.Sprintf("%-9s", d.Status))
+			case domain.WebhookPending:
+				status = pendingStyle.Render(fmt.Sprintf("%-9s", d.Status))
+			default:
+				status = failedStyle.Render(fmt.Sprintf("%-9s", d.Status))
+			}
+			fmt.Printf("  %s  %s  %-15s %s\n",
+				dimStyle.Render(d.CreatedAt.Format("2006-01-02 15:04")), status, d.Event, d.URL)
+
+			var details []string
+			if d.Attempts > 1 || d.Status != domain.WebhookDelivered {
+				details = append(details, pluralAttempts(d.Attempts))
+			}
+			if d.LastError != "" {
+				details = append(details, d.LastError)
+			}
+			if d.Status == domain.WebhookPending {
+				details = append(details, "next try "+d.NextAttemptAt.Format("15:04:05"))
+			}
+			if len(details) > 0 {
+				fmt.Printf("  %s\n", dimStyle.Render(strings.Repeat(" ", 16)+strings.Join(details, " · ")))
+			}
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+// pluralAttempts formats an attempt count, e.g. "1 attempt" or "3 attempts".
+func pluralAttempts(n int) string {
+	if n == 1 {
+		return "1 attempt"
+	}
+	return fmt.Sprintf("%d attempts", n)
+}
+
+func init() {
+	webhooksLogCmd.Flags().IntVarP(&webhooksLogLimit, "limit", "n", 20, "Number of deliveries to show")
+	webhooksCmd.AddCommand(webhooksTestCmd, webhooksLogCmd)
+	rootCmd.AddCommand(webhooksCmd)
+}
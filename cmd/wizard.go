@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -34,6 +35,11 @@ func runWizard(cmd *cobra.Command, args []string) error {
 		_ = config.Save(app.config)
 	}
 
+	// Offer to recover a session left running by a previous launch
+	if err := recoverStaleSession(ctx); err != nil {
+		return err
+	}
+
 	// Check for active session
 	state, err := app.state.GetCurrentState(ctx)
 	if err != nil {
@@ -48,12 +54,11 @@ func runWizard(cmd *cobra.Command, args []string) error {
 	// Fullscreen mode: wizard prompts then TUI
 	if state.ActiveSession != nil {
 		active := state.ActiveSession
-		remaining := active.RemainingTime()
 		sessionType := domain.GetSessionTypeLabel(active.Type)
-		sessionInfo := fmt.Sprintf("%s (%s remaining)", sessionType, formatWizardDuration(remaining))
+		sessionInfo := fmt.Sprintf("%s (%s)", sessionType, sessionClock(active))
 
 		if state.ActiveTask != nil {
-			sessionInfo = fmt.Sprintf("%s for \"%s\" (%s remaining)", sessionType, state.ActiveTask.Title, formatWizardDuration(remaining))
+			sessionInfo = fmt.Sprintf("%s for \"%s\" (%s)", sessionType, state.ActiveTask.Title, sessionClock(active))
 		}
 
 		resumeItems := []tui.PickerItem{
@@ -101,18 +106,18 @@ func runWizard(cmd *cobra.Command, args []string) error {
 
 	// Mode picker (skip if --mode was explicitly passed)
 	if modeFlag == "" {
-		modeItems := []tui.PickerItem{
-			{Label: "Pomodoro", Desc: "Classic 25/5 timer"},
-			{Label: "Deep Work", Desc: "Longer sessions, distraction tracking"},
-			{Label: "Make Time", Desc: "Daily Highlight, focus scoring"},
+		modes := methodology.All(app.config)
+		modeItems := make([]tui.PickerItem, len(modes))
+		for i, mode := range modes {
+			modeItems[i] = tui.PickerItem{Label: mode.Name().Label(), Desc: tui.ModeSummary(mode)}
 		}
 		modeResult := tui.RunPicker("Mode:", modeItems, "", &app.config.Theme)
 		if modeResult.Aborted {
 			return nil
 		}
-		methodologies := []domain.Methodology{domain.MethodologyPomodoro, domain.MethodologyDeepWork, domain.MethodologyMakeTime}
-		app.methodology = methodologies[modeResult.Index]
-		app.mode = methodology.ForMethodology(app.methodology, app.config)
+		if err := setMethodology(modes[modeResult.Index].Name()); err != nil {
+			return err
+		}
 		fmt.Println()
 	}
 
@@ -140,6 +145,22 @@ func runWizard(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Mob: ask who is in the mob the first time
+	if mode.HasRotation() && len(app.config.Mob.Roster) == 0 {
+		result := tui.RunTextPrompt("Who's in the mob? (names in rotation order, comma-separated)", "e.g. Ana, Ben, Cy", &app.config.Theme)
+		if result.Aborted {
+			return nil
+		}
+		if roster := splitNames(result.Value); len(roster) > 0 {
+			app.config.Mob.Roster = roster
+			_ = config.Save(app.config)
+			if err := setMethodology(app.methodology); err != nil {
+				return err
+			}
+		}
+		fmt.Println()
+	}
+
 	// Session chaining loop: runs once normally, then repeats if user selects "new session"
 	for {
 		// Make Time: check for existing highlight or carry-over from yesterday
@@ -164,32 +185,59 @@ func runWizard(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		// A block of today's plan under way can start the session on its own
+		if block := currentPlanBlock(ctx); block != nil {
+			planResult := tui.RunPicker("Planned now: "+block.String(), []tui.PickerItem{
+				{Label: "Follow the plan", Desc: planBlockSummary(block, time.Now())},
+				{Label: "Something else", Desc: "Pick a duration and task"},
+			}, "", &app.config.Theme)
+			if planResult.Aborted {
+				return nil
+			}
+			fmt.Println()
+			if planResult.Index == 0 {
+				if err := followPlanMethodology(block); err != nil {
+					return err
+				}
+				mode = app.mode
+				req, err := plannedSession(ctx, block, workingDir)
+				if err != nil {
+					return err
+				}
+				again, err := runWizardSession(ctx, req, workingDir)
+				if err != nil {
+					return err
+				}
+				if !again {
+					break
+				}
+				continue
+			}
+		}
+
 		// 1. Pick duration with arrow-key picker (mode-specific presets)
 		presets := mode.Presets()
-		shortBreak, longBreak := app.config.GetBreakDurations(app.methodology)
 
 		var items []tui.PickerItem
 		for _, p := range presets {
+			desc := formatMinutes(p.Duration)
+			if p.Duration == 0 {
+				desc = "counts up"
+			}
 			items = append(items, tui.PickerItem{
 				Label: p.Name,
-				Desc:  formatMinutes(p.Duration),
+				Desc:  desc,
 			})
 		}
 
-		var footer string
-		if app.methodology == domain.MethodologyPomodoro {
-			footer = fmt.Sprintf("Breaks: %s short / %s long (every %d) · \"flow config\" to customize",
-				formatMinutes(shortBreak), formatMinutes(longBreak), app.config.Pomodoro.SessionsBeforeLong)
-		} else {
-			footer = fmt.Sprintf("Break: %s · \"flow config\" to customize", formatMinutes(shortBreak))
-		}
+		footer := breakSummary(app.methodology)
 
 		result := tui.RunPicker("Duration:", items, footer, &app.config.Theme)
 		if result.Aborted {
 			return nil
 		}
 
-		customDuration := presets[result.Index].Duration
+		preset := presets[result.Index]
 
 		// Laser checklist (Make Time only)
 		if mode.HasLaserChecklist() {
@@ -260,6 +308,7 @@ func runWizard(cmd *cobra.Command, args []string) error {
 		if taskName != "" {
 			taskName, sessionTags = domain.ParseTagsFromInput(taskName)
 		}
+		sessionTags = domain.NormalizeTags(append(slices.Clone(preset.Tags), sessionTags...))
 
 		if taskName != "" && taskID == nil {
 			task, err := app.tasks.AddTask(ctx, services.AddTaskRequest{
@@ -277,47 +326,40 @@ func runWizard(cmd *cobra.Command, args []string) error {
 			}
 		}
 
-		// 3. Deep Work: ask for intended outcome
-		var intendedOutcome string
+		// 3. Deep Work: ask for intended outcome, defaulting to the preset's
+		intendedOutcome := preset.IntendedOutcome
 		if mode.OutcomePrompt() != "" {
-			outcomeResult := tui.RunTextPrompt(mode.OutcomePrompt(), "Enter to skip", &app.config.Theme)
+			placeholder := "Enter to skip"
+			if intendedOutcome != "" {
+				placeholder = intendedOutcome
+			}
+			outcomeResult := tui.RunTextPrompt(mode.OutcomePrompt(), placeholder, &app.config.Theme)
 			if outcomeResult.Aborted {
 				return nil
 			}
-			intendedOutcome = outcomeResult.Value
+			if outcomeResult.Value != "" {
+				intendedOutcome = outcomeResult.Value
+			}
 		}
 
 		// Start the session
 		req := services.StartPomodoroRequest{
 			TaskID:          taskID,
 			WorkingDir:      workingDir,
-			Duration:        customDuration,
+			Duration:        preset.Duration,
 			Methodology:     app.methodology,
 			IntendedOutcome: intendedOutcome,
 			Tags:            sessionTags,
+			BreakAfter:      preset.Break,
 		}
 
-		_, err = app.pomodoro.StartPomodoro(ctx, req)
-		if err != nil {
-			return fmt.Errorf("failed to start pomodoro: %w", err)
-		}
-
-		// Refresh state and launch TUI
-		state, err = app.state.GetCurrentState(ctx)
+		again, err := runWizardSession(ctx, req, workingDir)
 		if err != nil {
-			return fmt.Errorf("failed to get current state: %w", err)
-		}
-
-		if err := launchTUI(ctx, state, workingDir); err != nil {
 			return err
 		}
-
-		// Check if user wants to chain another session (fullscreen only)
-		if lastFullscreenTimer == nil || !lastFullscreenTimer.WantsNewSession {
+		if !again {
 			break
 		}
-		// Reset for next iteration
-		lastFullscreenTimer.WantsNewSession = false
 	}
 
 	// After quitting, in Make Time mode, prompt for tomorrow's highlight
@@ -338,6 +380,40 @@ func runWizard(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runWizardSession starts the session req describes and runs the timer on
+// it. It reports whether the user asked to chain another session.
+func runWizardSession(ctx context.Context, req services.StartPomodoroRequest, workingDir string) (bool, error) {
+	if _, err := app.pomodoro.StartPomodoro(ctx, req); err != nil {
+		return false, fmt.Errorf("failed to start pomodoro: %w", err)
+	}
+
+	// Refresh state and launch TUI
+	state, err := app.state.GetCurrentState(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current state: %w", err)
+	}
+	if err := launchTUI(ctx, state, workingDir); err != nil {
+		return false, err
+	}
+
+	// Check if user wants to chain another session (fullscreen only)
+	if lastFullscreenTimer == nil || !lastFullscreenTimer.WantsNewSession {
+		return false, nil
+	}
+	lastFullscreenTimer.WantsNewSession = false
+	return true, nil
+}
+
+// planBlockSummary describes what following block starts, e.g.
+// "Deep Work · 45m left".
+func planBlockSummary(block *domain.PlanBlock, now time.Time) string {
+	summary := formatMinutes(block.Remaining(now)) + " left"
+	if block.Methodology != "" {
+		summary = block.Methodology.Label() + " · " + summary
+	}
+	return summary
+}
+
 // launchInlineTUI launches the inline TUI and handles post-exit actions (stats/reflect).
 func launchInlineTUI(cmd *cobra.Command, ctx context.Context, state *domain.CurrentState, workingDir string) error {
 	if err := launchTUI(ctx, state, workingDir); err != nil {
@@ -356,12 +432,12 @@ func launchInlineTUI(cmd *cobra.Command, ctx context.Context, state *domain.Curr
 	return nil
 }
 
-// printWelcome shows the first-run welcome screen explaining the three methodologies.
+// printWelcome shows the first-run welcome screen explaining the methodologies.
 func printWelcome() {
 	fmt.Println()
 	fmt.Println("  Welcome to Flow!")
 	fmt.Println()
-	fmt.Println("  Flow supports three productivity methodologies:")
+	fmt.Println("  Flow supports four productivity methodologies:")
 	fmt.Println()
 	fmt.Println("    Pomodoro    Classic 25-minute focus sprints with short breaks.")
 	fmt.Println("                Great for staying fresh across many tasks.")
@@ -374,6 +450,10 @@ func printWelcome() {
 	fmt.Println("                your focus after each session and log how you'll")
 	fmt.Println("                recharge (Knapp & Zeratsky).")
 	fmt.Println()
+	fmt.Println("    Flowtime    The timer counts up while you're in flow. Stop when")
+	fmt.Println("                your focus fades; the break is earned from the time")
+	fmt.Println("                you worked.")
+	fmt.Println()
 	fmt.Println("  You can change methodology anytime with \"flow config\".")
 	fmt.Println()
 }
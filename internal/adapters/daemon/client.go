@@ -0,0 +1,77 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// Query asks the daemon listening at path for its status.
+func Query(path string) (*Status, error) {
+	resp, err := call(path, cmdStatus)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status == nil {
+		return nil, errors.New("daemon sent no status")
+	}
+	return resp.Status, nil
+}
+
+// Stop asks the daemon listening at path to shut down.
+func Stop(path string) error {
+	_, err := call(path, cmdStop)
+	return err
+}
+
+// Attach registers the caller as watching the session itself, so the
+// daemon leaves notifications and auto-breaks to it. The registration
+// lasts until the returned connection is closed or the process exits.
+func Attach(path string) (io.Closer, error) {
+	conn, err := dial(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := roundTrip(conn, cmdAttach); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// call sends a single command and closes the connection.
+func call(path, command string) (*response, error) {
+	conn, err := dial(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	return roundTrip(conn, command)
+}
+
+func dial(path string) (net.Conn, error) {
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		return nil, ErrNotRunning
+	}
+	_ = conn.SetDeadline(time.Now().Add(dialTimeout))
+	return conn, nil
+}
+
+func roundTrip(conn net.Conn, command string) (*response, error) {
+	if err := json.NewEncoder(conn).Encode(request{Command: command}); err != nil {
+		return nil, fmt.Errorf("failed to send %s request: %w", command, err)
+	}
+	var resp response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", command, err)
+	}
+	if !resp.OK {
+		return nil, fmt.Errorf("daemon: %s", resp.Error)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,150 @@
+// Package daemon provides the Unix control socket of the flow background
+// daemon, and the client other commands use to talk to it.
+//
+// The protocol is one JSON request per connection, answered with one JSON
+// response. An "attach" request keeps the connection open: the daemon
+// counts the client as attached until it disconnects.
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// SocketName is the file name of the control socket, kept next to the database.
+const SocketName = "daemon.sock"
+
+// dialTimeout bounds how long clients wait for the daemon to answer.
+const dialTimeout = 500 * time.Millisecond
+
+var (
+	// ErrNotRunning is returned by clients when no daemon is listening.
+	ErrNotRunning = errors.New("daemon is not running")
+	// ErrAlreadyRunning is returned by Listen when another daemon owns the socket.
+	ErrAlreadyRunning = errors.New("daemon is already running")
+)
+
+// Control commands understood by the daemon.
+const (
+	cmdStatus = "status"
+	cmdStop   = "stop"
+	cmdAttach = "attach"
+)
+
+// Status describes a running daemon and the session it is watching.
+type Status struct {
+	PID       int        `json:"pid"`
+	StartedAt time.Time  `json:"started_at"`
+	SessionID string     `json:"session_id,omitempty"`
+	EndsAt    *time.Time `json:"ends_at,omitempty"`
+	Attached  int        `json:"attached"`
+}
+
+type request struct {
+	Command string `json:"command"`
+}
+
+type response struct {
+	OK     bool    `json:"ok"`
+	Error  string  `json:"error,omitempty"`
+	Status *Status `json:"status,omitempty"`
+}
+
+// Server answers control requests on a Unix socket.
+type Server struct {
+	path     string
+	listener net.Listener
+	status   func() Status
+	stop     func()
+	attached atomic.Int32
+}
+
+// Listen creates the control socket at path. status reports the daemon's
+// state; stop is called when a client asks the daemon to shut down. A
+// socket left behind by a daemon that died is replaced.
+func Listen(path string, status func() Status, stop func()) (*Server, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, dialTimeout); err == nil {
+			_ = conn.Close()
+			return nil, ErrAlreadyRunning
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to secure socket: %w", err)
+	}
+	return &Server{path: path, listener: listener, status: status, stop: stop}, nil
+}
+
+// Attached returns how many clients are attached.
+func (s *Server) Attached() int {
+	return int(s.attached.Load())
+}
+
+// Serve accepts connections until Close is called.
+func (s *Server) Serve() error {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+// Close stops accepting connections and removes the socket file. Attached
+// clients are not waited for.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	_ = os.Remove(s.path)
+	return err
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetReadDeadline(time.Now().Add(dialTimeout))
+
+	var req request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		_ = json.NewEncoder(conn).Encode(response{Error: "invalid request"})
+		return
+	}
+
+	switch req.Command {
+	case cmdStatus:
+		status := s.status()
+		status.Attached = s.Attached()
+		_ = json.NewEncoder(conn).Encode(response{OK: true, Status: &status})
+	case cmdStop:
+		_ = json.NewEncoder(conn).Encode(response{OK: true})
+		s.stop()
+	case cmdAttach:
+		s.attached.Add(1)
+		defer s.attached.Add(-1)
+		if err := json.NewEncoder(conn).Encode(response{OK: true}); err != nil {
+			return
+		}
+		// Hold the connection until the client goes away.
+		_ = conn.SetReadDeadline(time.Time{})
+		_, _ = io.Copy(io.Discard, conn)
+	default:
+		_ = json.NewEncoder(conn).Encode(response{Error: fmt.Sprintf("unknown command %q", req.Command)})
+	}
+}
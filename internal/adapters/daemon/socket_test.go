@@ -0,0 +1,89 @@
+package daemon
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// waitFor polls cond for up to a second.
+func waitFor(t *testing.T, cond func() bool) bool {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestServer_StatusAttachStop(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SocketName)
+	if _, err := Query(path); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("Query() before Listen error = %v, want ErrNotRunning", err)
+	}
+
+	started := time.Now().Round(time.Second)
+	stopped := make(chan struct{})
+	srv, err := Listen(path, func() Status {
+		return Status{PID: 42, StartedAt: started, SessionID: "abc"}
+	}, func() { close(stopped) })
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	go func() { _ = srv.Serve() }()
+	defer func() { _ = srv.Close() }()
+
+	if _, err := Listen(path, nil, nil); !errors.Is(err, ErrAlreadyRunning) {
+		t.Errorf("second Listen() error = %v, want ErrAlreadyRunning", err)
+	}
+
+	status, err := Query(path)
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if status.PID != 42 || status.SessionID != "abc" || !status.StartedAt.Equal(started) || status.Attached != 0 {
+		t.Errorf("Query() = %+v", status)
+	}
+
+	conn, err := Attach(path)
+	if err != nil {
+		t.Fatalf("Attach() error = %v", err)
+	}
+	if !waitFor(t, func() bool { return srv.Attached() == 1 }) {
+		t.Errorf("Attached() = %d after Attach, want 1", srv.Attached())
+	}
+	_ = conn.Close()
+	if !waitFor(t, func() bool { return srv.Attached() == 0 }) {
+		t.Errorf("Attached() = %d after detaching, want 0", srv.Attached())
+	}
+
+	if err := Stop(path); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("Stop() did not reach the stop callback")
+	}
+}
+
+func TestListen_ReplacesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SocketName)
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	srv, err := Listen(path, func() Status { return Status{} }, func() {})
+	if err != nil {
+		t.Fatalf("Listen() over a stale socket error = %v", err)
+	}
+	if err := srv.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("socket file left after Close(): %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+// Package hooks runs the user's [hooks] shell commands on session lifecycle
+// events, e.g. to toggle Do Not Disturb or set a chat status.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// DefaultTimeout bounds hooks when no timeout is configured.
+const DefaultTimeout = 10 * time.Second
+
+// Runner implements ports.HookRunner by running each command with "sh -c".
+//
+// Commands get the session in FLOW_* environment variables and as JSON on
+// stdin. Their output, and any failure, is appended to a log file, since
+// the terminal may belong to the TUI.
+type Runner struct {
+	commands map[domain.HookEvent]string
+	timeout  time.Duration
+	logPath  string
+}
+
+// Ensure Runner implements ports.HookRunner.
+var _ ports.HookRunner = (*Runner)(nil)
+
+// NewRunner creates a hook runner. logPath may be empty to discard output.
+func NewRunner(commands map[domain.HookEvent]string, timeout time.Duration, logPath string) *Runner {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Runner{commands: commands, timeout: timeout, logPath: logPath}
+}
+
+// Run runs the command configured for event and waits for it to exit or
+// time out. Commands that should outlive the timeout, like a music player,
+// must background themselves.
+func (r *Runner) Run(ctx context.Context, event domain.HookEvent, session *domain.PomodoroSession, task *domain.Task) error {
+	command := r.commands[event]
+	if command == "" || session == nil {
+		return nil
+	}
+
+	input, err := json.Marshal(payload(event, session, task))
+	if err != nil {
+		return fmt.Errorf("failed to encode hook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // the user's own configured command
+	cmd.Env = append(os.Environ(), env(event, session, task)...)
+	cmd.Stdin = bytes.NewReader(input)
+	// Run the hook in its own process group so a timeout kills everything
+	// it started, not just the shell.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = time.Second
+
+	logFile := r.openLog()
+	if logFile != nil {
+		defer func() { _ = logFile.Close() }()
+		cmd.Stdout = logFile
+		cmd.Stderr = logFile
+	}
+
+	err = cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", r.timeout)
+	}
+	if err != nil {
+		err = fmt.Errorf("hook %s failed: %w", event, err)
+		if logFile != nil {
+			_, _ = fmt.Fprintf(logFile, "%s %v\n", time.Now().Format("2006-01-02T15:04:05"), err)
+		}
+	}
+	return err
+}
+
+func (r *Runner) openLog() *os.File {
+	if r.logPath == "" {
+		return nil
+	}
+	f, err := os.OpenFile(r.logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil
+	}
+	return f
+}
+
+// env returns the FLOW_* environment variables describing a session.
+func env(event domain.HookEvent, session *domain.PomodoroSession, task *domain.Task) []string {
+	title := ""
+	if task != nil {
+		title = task.Title
+	}
+	return []string{
+		"FLOW_EVENT=" + string(event),
+		"FLOW_SESSION_ID=" + session.ID,
+		"FLOW_SESSION_TYPE=" + string(session.Type),
+		"FLOW_SESSION_STATUS=" + string(session.Status),
+		"FLOW_TASK_TITLE=" + title,
+		"FLOW_METHODOLOGY=" + string(session.Methodology),
+		"FLOW_DURATION=" + strconv.Itoa(int(session.Duration.Seconds())),
+		"FLOW_TAGS=" + strings.Join(session.Tags, ","),
+		"FLOW_GIT_BRANCH=" + session.GitBranch,
+	}
+}
+
+// payload returns the JSON document a hook reads on stdin.
+func payload(event domain.HookEvent, session *domain.PomodoroSession, task *domain.Task) map[string]interface{} {
+	sessionData := map[string]interface{}{
+		"id":               session.ID,
+		"type":             string(session.Type),
+		"status":           string(session.Status),
+		"duration":         int(session.Duration.Seconds()),
+		"started_at":       session.StartedAt.Format("2006-01-02T15:04:05"),
+		"completed_at":     nil,
+		"methodology":      string(session.Methodology),
+		"tags":             session.Tags,
+		"intended_outcome": session.IntendedOutcome,
+		"git_branch":       session.GitBranch,
+		"git_commit":       session.GitCommit,
+		"task_id":          session.TaskID,
+	}
+	if session.CompletedAt != nil {
+		sessionData["completed_at"] = session.CompletedAt.Format("2006-01-02T15:04:05")
+	}
+
+	result := map[string]interface{}{
+		"event":   string(event),
+		"session": sessionData,
+		"task":    nil,
+	}
+	if task != nil {
+		result["task"] = map[string]interface{}{
+			"id":    task.ID,
+			"title": task.Title,
+			"tags":  task.Tags,
+		}
+	}
+	return result
+}
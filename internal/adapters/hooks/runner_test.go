@@ -0,0 +1,115 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+func testSession(t *testing.T) (*domain.PomodoroSession, *domain.Task) {
+	t.Helper()
+	task, err := domain.NewTask("Write docs")
+	if err != nil {
+		t.Fatalf("NewTask() error = %v", err)
+	}
+	session := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), &task.ID)
+	session.Methodology = domain.MethodologyDeepWork
+	session.Tags = []string{"docs", "api"}
+	session.GitBranch = "main"
+	return session, task
+}
+
+func TestRunner_EnvAndStdin(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	runner := NewRunner(map[domain.HookEvent]string{
+		domain.HookStart: `env | grep '^FLOW_' | sort > "` + out + `.env"; cat > "` + out + `.json"`,
+	}, time.Second, filepath.Join(dir, "hooks.log"))
+
+	session, task := testSession(t)
+	if err := runner.Run(context.Background(), domain.HookStart, session, task); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	envData, err := os.ReadFile(out + ".env")
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	for _, want := range []string{
+		"FLOW_EVENT=on_start",
+		"FLOW_SESSION_ID=" + session.ID,
+		"FLOW_TASK_TITLE=Write docs",
+		"FLOW_METHODOLOGY=deepwork",
+		"FLOW_DURATION=1500",
+		"FLOW_TAGS=docs,api",
+		"FLOW_GIT_BRANCH=main",
+	} {
+		if !strings.Contains(string(envData), want+"\n") {
+			t.Errorf("hook environment is missing %q:\n%s", want, envData)
+		}
+	}
+
+	jsonData, err := os.ReadFile(out + ".json")
+	if err != nil {
+		t.Fatalf("failed to read hook stdin: %v", err)
+	}
+	var got struct {
+		Event   string `json:"event"`
+		Session struct {
+			ID string `json:"id"`
+		} `json:"session"`
+		Task struct {
+			Title string `json:"title"`
+		} `json:"task"`
+	}
+	if err := json.Unmarshal(jsonData, &got); err != nil {
+		t.Fatalf("hook stdin is not JSON: %v\n%s", err, jsonData)
+	}
+	if got.Event != "on_start" || got.Session.ID != session.ID || got.Task.Title != "Write docs" {
+		t.Errorf("hook stdin = %+v, want the start event for the session", got)
+	}
+}
+
+func TestRunner_SkipsUnconfiguredEvents(t *testing.T) {
+	runner := NewRunner(map[domain.HookEvent]string{domain.HookStart: "exit 1"}, time.Second, "")
+	session, _ := testSession(t)
+	if err := runner.Run(context.Background(), domain.HookPause, session, nil); err != nil {
+		t.Errorf("Run() for an event without a hook = %v, want nil", err)
+	}
+}
+
+func TestRunner_FailuresAndTimeout(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "hooks.log")
+	runner := NewRunner(map[domain.HookEvent]string{
+		domain.HookVoid:     "echo oops >&2; exit 3",
+		domain.HookComplete: "sleep 5",
+	}, 200*time.Millisecond, logPath)
+	session, _ := testSession(t)
+
+	if err := runner.Run(context.Background(), domain.HookVoid, session, nil); err == nil {
+		t.Error("Run() of a failing hook = nil, want an error")
+	}
+
+	start := time.Now()
+	err := runner.Run(context.Background(), domain.HookComplete, session, nil)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Run() of a slow hook = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("slow hook ran for %v, want it killed at the timeout", elapsed)
+	}
+
+	logData, _ := os.ReadFile(logPath)
+	for _, want := range []string{"oops", "hook on_void failed", "hook on_complete failed: timed out"} {
+		if !strings.Contains(string(logData), want) {
+			t.Errorf("hooks.log is missing %q:\n%s", want, logData)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+// Event names sent on /events.
+const (
+	EventState     = "state"
+	EventTick      = "tick"
+	EventStarted   = "started"
+	EventPaused    = "paused"
+	EventResumed   = "resumed"
+	EventCompleted = "completed"
+	EventStopped   = "stopped"
+)
+
+// keepaliveEvery is how many idle ticks pass between keepalive comments,
+// which stop proxies and clients from timing the stream out.
+const keepaliveEvery = 15
+
+// event is one Server-Sent Event.
+type event struct {
+	name string
+	data map[string]interface{}
+}
+
+// sessionEvents compares the active session across two polls and returns
+// the events describing the change. ended is the final state of prev when
+// it is no longer active, or nil if it could not be found.
+func sessionEvents(prev, next, ended *domain.PomodoroSession) []event {
+	var events []event
+	if prev != nil && (next == nil || next.ID != prev.ID) {
+		final := prev
+		if ended != nil {
+			final = ended
+		}
+		name := EventCompleted
+		if final.Status != domain.SessionStatusCompleted && final.Status != domain.SessionStatusRunning {
+			name = EventStopped
+		}
+		events = append(events, event{name, sessionJSON(final)})
+		prev = nil
+	}
+	if next == nil {
+		return events
+	}
+
+	switch {
+	case prev == nil:
+		events = append(events, event{EventStarted, sessionJSON(next)})
+	case prev.Status == domain.SessionStatusRunning && next.Status == domain.SessionStatusPaused:
+		events = append(events, event{EventPaused, sessionJSON(next)})
+	case prev.Status == domain.SessionStatusPaused && next.Status == domain.SessionStatusRunning:
+		events = append(events, event{EventResumed, sessionJSON(next)})
+	}
+	if next.Status == domain.SessionStatusRunning {
+		events = append(events, event{EventTick, tickJSON(next)})
+	}
+	return events
+}
+
+func tickJSON(session *domain.PomodoroSession) map[string]interface{} {
+	remaining := session.RemainingTime().Round(time.Second)
+	elapsed := session.ElapsedTime().Round(time.Second)
+	return map[string]interface{}{
+		"id":                session.ID,
+		"type":              string(session.Type),
+		"remaining_seconds": int(remaining.Seconds()),
+		"remaining_time":    remaining.String(),
+		"elapsed_seconds":   int(elapsed.Seconds()),
+		"progress":          session.Progress(),
+	}
+}
+
+// handleEvents streams timer events until the client disconnects. It opens
+// with a "state" event carrying the same body as GET /state.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("streaming is not supported"))
+		return
+	}
+	ctx := r.Context()
+
+	state, err := s.provider.GetCurrentState(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeEvent(w, event{EventState, stateJSON(state)}); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	prev := state.ActiveSession
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+	idle := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		state, err := s.provider.GetCurrentState(ctx)
+		if err != nil {
+			continue
+		}
+		next := state.ActiveSession
+		var ended *domain.PomodoroSession
+		if prev != nil && (next == nil || next.ID != prev.ID) {
+			ended = s.findSession(ctx, prev.ID)
+		}
+
+		events := sessionEvents(prev, next, ended)
+		prev = next
+		if len(events) == 0 {
+			if idle++; idle%keepaliveEvery != 0 {
+				continue
+			}
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		}
+		for _, e := range events {
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// findSession looks up the final state of a session that just ended.
+func (s *Server) findSession(ctx context.Context, id string) *domain.PomodoroSession {
+	sessions, err := s.provider.GetRecentSessions(ctx, 20)
+	if err != nil {
+		return nil
+	}
+	for _, session := range sessions {
+		if session.ID == id {
+			return session
+		}
+	}
+	return nil
+}
+
+func writeEvent(w http.ResponseWriter, e event) error {
+	data, err := json.Marshal(e.data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.name, data)
+	return err
+}
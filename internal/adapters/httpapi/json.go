@@ -0,0 +1,83 @@
+package httpapi
+
+import (
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+// The response shapes below mirror the MCP server's, so scripts can move
+// between the two without remapping fields.
+
+func stateJSON(state *domain.CurrentState) map[string]interface{} {
+	result := map[string]interface{}{
+		"active_task":    nil,
+		"active_session": nil,
+		"today_stats": map[string]interface{}{
+			"work_sessions":   state.TodayStats.WorkSessions,
+			"breaks_taken":    state.TodayStats.BreaksTaken,
+			"total_work_time": state.TodayStats.TotalWorkTime.String(),
+		},
+	}
+	if state.ActiveTask != nil {
+		result["active_task"] = taskJSON(state.ActiveTask)
+	}
+	if state.ActiveSession != nil {
+		result["active_session"] = sessionJSON(state.ActiveSession)
+	}
+	return result
+}
+
+func taskJSON(task *domain.Task) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":          task.ID,
+		"title":       task.Title,
+		"description": task.Description,
+		"status":      string(task.Status),
+		"tags":        task.Tags,
+		"created_at":  task.CreatedAt.Format(timeLayout),
+	}
+	if task.CompletedAt != nil {
+		result["completed_at"] = task.CompletedAt.Format(timeLayout)
+	}
+	return result
+}
+
+func sessionJSON(session *domain.PomodoroSession) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":               session.ID,
+		"type":             string(session.Type),
+		"status":           string(session.Status),
+		"duration":         session.Duration.String(),
+		"remaining_time":   session.RemainingTime().String(),
+		"elapsed_time":     session.ElapsedTime().Round(time.Second).String(),
+		"progress":         session.Progress(),
+		"started_at":       session.StartedAt.Format(timeLayout),
+		"git_branch":       session.GitBranch,
+		"git_commit":       session.GitCommit,
+		"notes":            session.Notes,
+		"methodology":      string(session.Methodology),
+		"distractions":     session.Distractions,
+		"accomplishment":   session.Accomplishment,
+		"intended_outcome": session.IntendedOutcome,
+		"session_tags":     session.Tags,
+	}
+	if session.TaskID != nil {
+		result["task_id"] = *session.TaskID
+	}
+	if session.CompletedAt != nil {
+		result["completed_at"] = session.CompletedAt.Format(timeLayout)
+	}
+	if session.FocusScore != nil {
+		result["focus_score"] = *session.FocusScore
+	}
+	return result
+}
+
+func sessionsJSON(sessions []*domain.PomodoroSession) map[string]interface{} {
+	list := make([]map[string]interface{}, 0, len(sessions))
+	for _, s := range sessions {
+		list = append(list, sessionJSON(s))
+	}
+	return map[string]interface{}{"sessions": list, "total_count": len(list)}
+}
@@ -0,0 +1,368 @@
+// Package httpapi serves the Flow state over a local REST/JSON API, with a
+// Server-Sent Events stream of timer events, for editor plugins, widgets
+// and dashboards.
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// DefaultAddr is the address flow serve listens on unless told otherwise.
+const DefaultAddr = "127.0.0.1:7777"
+
+// timeLayout formats timestamps in responses, like the rest of flow's JSON.
+const timeLayout = "2006-01-02T15:04:05"
+
+// errBadRequest marks request errors answered with 400 Bad Request.
+var errBadRequest = errors.New("bad request")
+
+// Server exposes a ports.MCPStateProvider over HTTP.
+type Server struct {
+	provider ports.MCPStateProvider
+	mux      *http.ServeMux
+	tick     time.Duration
+	hosts    map[string]bool // Host header names requests may use
+}
+
+// NewServer creates an HTTP API server for a listener on addr. tick is how
+// often /events checks the timer; zero means once a second.
+func NewServer(provider ports.MCPStateProvider, addr string, tick time.Duration) *Server {
+	if tick <= 0 {
+		tick = time.Second
+	}
+	s := &Server{
+		provider: provider,
+		mux:      http.NewServeMux(),
+		tick:     tick,
+		hosts:    map[string]bool{"localhost": true, "127.0.0.1": true, "::1": true},
+	}
+	if host, _, err := net.SplitHostPort(addr); err == nil && host != "" {
+		s.hosts[host] = true
+	}
+	s.routes()
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// A page whose domain is rebound to this address is same-origin with
+	// the API, but still sends its own domain as the Host.
+	if !s.allowedHost(r.Host) {
+		writeError(w, http.StatusForbidden, fmt.Errorf("host %q is not allowed", r.Host))
+		return
+	}
+	// Requests with a body must be JSON. Browsers can't send that cross-site
+	// without a CORS preflight, which this server never approves, so web
+	// pages can't drive the timer.
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+			writeError(w, http.StatusUnsupportedMediaType, errors.New("requests must have Content-Type: application/json"))
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// allowedHost reports whether host, with or without a port, names the
+// loopback interface or the address the server listens on.
+func (s *Server) allowedHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return s.hosts[strings.Trim(host, "[]")]
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /state", s.handleState)
+	s.mux.HandleFunc("GET /tasks", s.handleListTasks)
+	s.mux.HandleFunc("POST /tasks", s.handleCreateTask)
+	s.mux.HandleFunc("POST /tasks/{id}/complete", s.handleCompleteTask)
+	s.mux.HandleFunc("GET /tasks/{id}/sessions", s.handleTaskHistory)
+	s.mux.HandleFunc("GET /sessions", s.handleRecentSessions)
+	s.mux.HandleFunc("POST /sessions/{id}/notes", s.handleNotes)
+	s.mux.HandleFunc("POST /sessions/{id}/distractions", s.handleDistraction)
+	s.mux.HandleFunc("POST /sessions/{id}/focus-score", s.handleFocusScore)
+	s.mux.HandleFunc("POST /session/start", s.handleStart)
+	s.mux.HandleFunc("POST /session/stop", s.sessionAction(s.provider.StopPomodoro))
+	s.mux.HandleFunc("POST /session/pause", s.sessionAction(s.provider.PausePomodoro))
+	s.mux.HandleFunc("POST /session/resume", s.sessionAction(s.provider.ResumePomodoro))
+	s.mux.HandleFunc("GET /highlight", s.handleGetHighlight)
+	s.mux.HandleFunc("PUT /highlight", s.handleSetHighlight)
+	s.mux.HandleFunc("GET /search", s.handleSearch)
+	s.mux.HandleFunc("GET /events", s.handleEvents)
+}
+
+func (s *Server) handleState(w http.ResponseWriter, r *http.Request) {
+	state, err := s.provider.GetCurrentState(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, stateJSON(state))
+}
+
+func (s *Server) handleListTasks(w http.ResponseWriter, r *http.Request) {
+	var status *domain.TaskStatus
+	if v := r.URL.Query().Get("status"); v != "" {
+		st := domain.TaskStatus(v)
+		status = &st
+	}
+	tasks, err := s.provider.ListTasks(r.Context(), status)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	list := make([]map[string]interface{}, 0, len(tasks))
+	for _, t := range tasks {
+		list = append(list, taskJSON(t))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"tasks": list, "total_count": len(list)})
+}
+
+func (s *Server) handleCreateTask(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Title       string   `json:"title"`
+		Description *string  `json:"description"`
+		Tags        []string `json:"tags"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	task, err := s.provider.CreateTask(r.Context(), body.Title, body.Description, body.Tags)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, taskJSON(task))
+}
+
+func (s *Server) handleCompleteTask(w http.ResponseWriter, r *http.Request) {
+	task, err := s.provider.CompleteTask(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, taskJSON(task))
+}
+
+func (s *Server) handleTaskHistory(w http.ResponseWriter, r *http.Request) {
+	sessions, err := s.provider.GetTaskHistory(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sessionsJSON(sessions))
+}
+
+func (s *Server) handleRecentSessions(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, errors.New("limit must be a positive number"))
+			return
+		}
+		limit = n
+	}
+	sessions, err := s.provider.GetRecentSessions(r.Context(), limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sessionsJSON(sessions))
+}
+
+func (s *Server) handleNotes(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Notes string `json:"notes"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	session, err := s.provider.AddSessionNotes(r.Context(), r.PathValue("id"), body.Notes)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sessionJSON(session))
+}
+
+func (s *Server) handleDistraction(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Text string `json:"text"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Text == "" {
+		writeError(w, http.StatusBadRequest, errors.New("text is required"))
+		return
+	}
+	if err := s.provider.LogDistraction(r.Context(), r.PathValue("id"), body.Text); err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"session_id": r.PathValue("id"), "distraction": body.Text})
+}
+
+func (s *Server) handleFocusScore(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Score int `json:"score"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Score < 1 || body.Score > 5 {
+		writeError(w, http.StatusBadRequest, errors.New("score must be between 1 and 5"))
+		return
+	}
+	if err := s.provider.SetFocusScore(r.Context(), r.PathValue("id"), body.Score); err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"session_id": r.PathValue("id"), "focus_score": body.Score})
+}
+
+func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		TaskID          *string `json:"task_id"`
+		DurationMinutes *int    `json:"duration_minutes"`
+	}
+	if r.ContentLength != 0 && !decodeBody(w, r, &body) {
+		return
+	}
+	if body.DurationMinutes != nil && *body.DurationMinutes < 1 {
+		writeError(w, http.StatusBadRequest, errors.New("duration_minutes must be positive"))
+		return
+	}
+	session, err := s.provider.StartPomodoro(r.Context(), body.TaskID, body.DurationMinutes)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, sessionJSON(session))
+}
+
+// sessionAction handles the body-less stop, pause and resume endpoints.
+func (s *Server) sessionAction(action func(context.Context) (*domain.PomodoroSession, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		session, err := action(r.Context())
+		if err != nil {
+			writeError(w, statusFor(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, sessionJSON(session))
+	}
+}
+
+func (s *Server) handleGetHighlight(w http.ResponseWriter, r *http.Request) {
+	task, err := s.provider.GetTodayHighlight(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	var highlight interface{}
+	if task != nil {
+		highlight = taskJSON(task)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"highlight": highlight})
+}
+
+func (s *Server) handleSetHighlight(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		TaskID string `json:"task_id"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.TaskID == "" {
+		writeError(w, http.StatusBadRequest, errors.New("task_id is required"))
+		return
+	}
+	task, err := s.provider.SetHighlight(r.Context(), body.TaskID)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"highlight": taskJSON(task)})
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := ports.SearchQuery{Text: q.Get("q"), Tag: q.Get("tag")}
+	if query.Text == "" {
+		writeError(w, http.StatusBadRequest, errors.New("q is required"))
+		return
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, errors.New("limit must be a positive number"))
+			return
+		}
+		query.Limit = n
+	}
+	if v := q.Get("since"); v != "" {
+		since, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.New("since must be a date like 2006-01-02"))
+			return
+		}
+		query.Since = since
+	}
+	results, err := s.provider.Search(r.Context(), query)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ports.NewSearchReport(query.Text, results))
+}
+
+// decodeBody parses a JSON request body, answering 400 if it is invalid.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w: invalid JSON body: %v", errBadRequest, err))
+		return false
+	}
+	return true
+}
+
+// statusFor maps service errors to HTTP status codes.
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrTaskNotFound), errors.Is(err, domain.ErrTagNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrNoActiveSession), errors.Is(err, domain.ErrSessionAlreadyActive):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrEmptyTaskTitle), errors.Is(err, domain.ErrInvalidDuration), errors.Is(err, errBadRequest):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]interface{}{"error": err.Error()})
+}
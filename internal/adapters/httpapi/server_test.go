@@ -0,0 +1,351 @@
+package httpapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// fakeProvider is an in-memory ports.MCPStateProvider with one active session.
+type fakeProvider struct {
+	mu      sync.Mutex
+	tasks   []*domain.Task
+	active  *domain.PomodoroSession
+	history []*domain.PomodoroSession
+}
+
+func (f *fakeProvider) GetCurrentState(ctx context.Context) (*domain.CurrentState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	state := &domain.CurrentState{}
+	if f.active != nil {
+		session := *f.active
+		state.ActiveSession = &session
+	}
+	return state, nil
+}
+
+func (f *fakeProvider) ListTasks(ctx context.Context, status *domain.TaskStatus) ([]*domain.Task, error) {
+	return f.tasks, nil
+}
+
+func (f *fakeProvider) GetTaskHistory(ctx context.Context, taskID string) ([]*domain.PomodoroSession, error) {
+	return nil, nil
+}
+
+func (f *fakeProvider) GetRecentSessions(ctx context.Context, limit int) ([]*domain.PomodoroSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.history, nil
+}
+
+func (f *fakeProvider) StartPomodoro(ctx context.Context, taskID *string, durationMinutes *int) (*domain.PomodoroSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.active != nil {
+		return nil, domain.ErrSessionAlreadyActive
+	}
+	config := domain.DefaultPomodoroConfig()
+	if durationMinutes != nil {
+		config.WorkDuration = time.Duration(*durationMinutes) * time.Minute
+	}
+	f.active = domain.NewPomodoroSession(config, taskID)
+	return f.active, nil
+}
+
+func (f *fakeProvider) StopPomodoro(ctx context.Context) (*domain.PomodoroSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.active == nil {
+		return nil, domain.ErrNoActiveSession
+	}
+	session := f.active
+	session.Complete()
+	f.active = nil
+	f.history = append([]*domain.PomodoroSession{session}, f.history...)
+	return session, nil
+}
+
+func (f *fakeProvider) PausePomodoro(ctx context.Context) (*domain.PomodoroSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.active == nil {
+		return nil, domain.ErrNoActiveSession
+	}
+	f.active.Pause()
+	return f.active, nil
+}
+
+func (f *fakeProvider) ResumePomodoro(ctx context.Context) (*domain.PomodoroSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.active == nil {
+		return nil, domain.ErrNoActiveSession
+	}
+	f.active.Resume()
+	return f.active, nil
+}
+
+func (f *fakeProvider) CreateTask(ctx context.Context, title string, description *string, tags []string) (*domain.Task, error) {
+	task, err := domain.NewTask(title)
+	if err != nil {
+		return nil, err
+	}
+	f.tasks = append(f.tasks, task)
+	return task, nil
+}
+
+func (f *fakeProvider) CompleteTask(ctx context.Context, taskID string) (*domain.Task, error) {
+	return nil, domain.ErrTaskNotFound
+}
+
+func (f *fakeProvider) AddSessionNotes(ctx context.Context, sessionID string, notes string) (*domain.PomodoroSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.active == nil || f.active.ID != sessionID {
+		return nil, domain.ErrNoActiveSession
+	}
+	f.active.AddNotes(notes)
+	return f.active, nil
+}
+
+func (f *fakeProvider) LogDistraction(ctx context.Context, sessionID string, text string) error {
+	return nil
+}
+
+func (f *fakeProvider) SetFocusScore(ctx context.Context, sessionID string, score int) error {
+	return nil
+}
+
+func (f *fakeProvider) GetTodayHighlight(ctx context.Context) (*domain.Task, error) {
+	return nil, nil
+}
+
+func (f *fakeProvider) SetHighlight(ctx context.Context, taskID string) (*domain.Task, error) {
+	for _, t := range f.tasks {
+		if t.ID == taskID {
+			t.SetAsHighlight()
+			return t, nil
+		}
+	}
+	return nil, domain.ErrTaskNotFound
+}
+
+func (f *fakeProvider) Search(ctx context.Context, query ports.SearchQuery) ([]ports.SearchResult, error) {
+	return nil, nil
+}
+
+func (f *fakeProvider) GetStats(ctx context.Context, query ports.StatsQuery) (*ports.StatsReport, error) {
+	return &ports.StatsReport{}, nil
+}
+
+// do sends a request to srv and decodes the JSON response body.
+func do(t *testing.T, srv http.Handler, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Host = DefaultAddr
+	if method != http.MethodGet {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("%s %s: invalid JSON %q: %v", method, path, rec.Body.String(), err)
+	}
+	return rec.Code, result
+}
+
+func TestServer_SessionLifecycle(t *testing.T) {
+	srv := NewServer(&fakeProvider{}, DefaultAddr, 0)
+
+	code, state := do(t, srv, http.MethodGet, "/state", "")
+	if code != http.StatusOK || state["active_session"] != nil {
+		t.Fatalf("GET /state = %d %v, want 200 with no session", code, state)
+	}
+
+	code, session := do(t, srv, http.MethodPost, "/session/start", `{"duration_minutes": 15}`)
+	if code != http.StatusCreated || session["duration"] != "15m0s" {
+		t.Fatalf("POST /session/start = %d %v, want 201 with a 15m session", code, session)
+	}
+	id := session["id"].(string)
+
+	if code, body := do(t, srv, http.MethodPost, "/session/start", ""); code != http.StatusConflict {
+		t.Errorf("second start = %d %v, want 409", code, body)
+	}
+	if code, body := do(t, srv, http.MethodPost, "/session/pause", ""); code != http.StatusOK || body["status"] != "paused" {
+		t.Errorf("pause = %d %v, want 200 paused", code, body)
+	}
+	if code, body := do(t, srv, http.MethodPost, "/session/resume", ""); code != http.StatusOK || body["status"] != "running" {
+		t.Errorf("resume = %d %v, want 200 running", code, body)
+	}
+	if code, body := do(t, srv, http.MethodPost, "/sessions/"+id+"/notes", `{"notes": "wrote tests"}`); code != http.StatusOK || body["notes"] != "wrote tests" {
+		t.Errorf("notes = %d %v, want the notes saved", code, body)
+	}
+	if code, body := do(t, srv, http.MethodPost, "/session/stop", ""); code != http.StatusOK || body["status"] != "completed" {
+		t.Errorf("stop = %d %v, want 200 completed", code, body)
+	}
+	if code, body := do(t, srv, http.MethodPost, "/session/stop", ""); code != http.StatusConflict {
+		t.Errorf("stop with no session = %d %v, want 409", code, body)
+	}
+}
+
+func TestServer_TasksAndHighlight(t *testing.T) {
+	srv := NewServer(&fakeProvider{}, DefaultAddr, 0)
+
+	code, task := do(t, srv, http.MethodPost, "/tasks", `{"title": "Write docs", "tags": ["docs"]}`)
+	if code != http.StatusCreated || task["title"] != "Write docs" {
+		t.Fatalf("POST /tasks = %d %v, want 201", code, task)
+	}
+	if code, body := do(t, srv, http.MethodPost, "/tasks", `{"title": ""}`); code != http.StatusBadRequest {
+		t.Errorf("empty title = %d %v, want 400", code, body)
+	}
+	if code, body := do(t, srv, http.MethodGet, "/tasks", ""); code != http.StatusOK || body["total_count"] != 1.0 {
+		t.Errorf("GET /tasks = %d %v, want one task", code, body)
+	}
+	if code, body := do(t, srv, http.MethodPut, "/highlight", `{"task_id": "`+task["id"].(string)+`"}`); code != http.StatusOK || body["highlight"] == nil {
+		t.Errorf("PUT /highlight = %d %v, want the task", code, body)
+	}
+	if code, body := do(t, srv, http.MethodPut, "/highlight", `{"task_id": "nope"}`); code != http.StatusNotFound {
+		t.Errorf("PUT /highlight unknown task = %d %v, want 404", code, body)
+	}
+	if code, body := do(t, srv, http.MethodPost, "/tasks", `{"title": "x", "priority": 1}`); code != http.StatusBadRequest {
+		t.Errorf("unknown field = %d %v, want 400", code, body)
+	}
+}
+
+func TestServer_RequiresJSONContentType(t *testing.T) {
+	srv := NewServer(&fakeProvider{}, DefaultAddr, 0)
+
+	req := httptest.NewRequest(http.MethodPost, "/session/start", strings.NewReader(""))
+	req.Host = DefaultAddr
+	req.Header.Set("Content-Type", "text/plain")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("text/plain POST = %d, want 415", rec.Code)
+	}
+}
+
+func TestServer_RejectsForeignHost(t *testing.T) {
+	srv := NewServer(&fakeProvider{}, "192.168.1.5:7777", 0)
+
+	for host, want := range map[string]int{
+		"evil.example":      http.StatusForbidden,
+		"evil.example:7777": http.StatusForbidden,
+		"localhost:7777":    http.StatusOK,
+		"[::1]:7777":        http.StatusOK,
+		"192.168.1.5:7777":  http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/state", nil)
+		req.Host = host
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("GET /state with Host %s = %d, want %d", host, rec.Code, want)
+		}
+	}
+}
+
+func TestSessionEvents(t *testing.T) {
+	running := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), nil)
+	paused := *running
+	paused.Status = domain.SessionStatusPaused
+	completed := *running
+	completed.Status = domain.SessionStatusCompleted
+	cancelled := *running
+	cancelled.Status = domain.SessionStatusCancelled
+
+	names := func(events []event) string {
+		var out []string
+		for _, e := range events {
+			out = append(out, e.name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		name             string
+		prev, next, done *domain.PomodoroSession
+		want             string
+	}{
+		{"idle", nil, nil, nil, ""},
+		{"started", nil, running, nil, "started,tick"},
+		{"ticking", running, running, nil, "tick"},
+		{"paused", running, &paused, nil, "paused"},
+		{"still paused", &paused, &paused, nil, ""},
+		{"resumed", &paused, running, nil, "resumed,tick"},
+		{"completed", running, nil, &completed, "completed"},
+		{"stopped", running, nil, &cancelled, "stopped"},
+		{"expired unseen", running, nil, nil, "completed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(sessionEvents(tt.prev, tt.next, tt.done)); got != tt.want {
+				t.Errorf("sessionEvents() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_EventStream(t *testing.T) {
+	provider := &fakeProvider{}
+	ts := httptest.NewServer(NewServer(provider, DefaultAddr, 10*time.Millisecond))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/events")
+	if err != nil {
+		t.Fatalf("GET /events error = %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	events := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if name, ok := strings.CutPrefix(scanner.Text(), "event: "); ok {
+				events <- name
+			}
+		}
+		close(events)
+	}()
+	expect := func(want string) {
+		t.Helper()
+		timeout := time.After(2 * time.Second)
+		for {
+			select {
+			case got, ok := <-events:
+				if !ok {
+					t.Fatalf("stream closed waiting for %q", want)
+				}
+				if got == want {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("timed out waiting for %q", want)
+			}
+		}
+	}
+
+	expect(EventState)
+	_, _ = provider.StartPomodoro(context.Background(), nil, nil)
+	expect(EventStarted)
+	expect(EventTick)
+	_, _ = provider.PausePomodoro(context.Background())
+	expect(EventPaused)
+	_, _ = provider.StopPomodoro(context.Background())
+	expect(EventCompleted)
+}
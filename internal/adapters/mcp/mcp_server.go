@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/xvierd/flow-cli/internal/domain"
 	"github.com/xvierd/flow-cli/internal/ports"
 )
 
@@ -220,6 +222,53 @@ func (s *Server) registerTools() {
 		),
 	)
 	s.server.AddTool(addNotesTool, s.handleAddSessionNotes)
+
+	// Tool: search
+	searchTool := mcp.NewTool(
+		"search",
+		mcp.WithDescription("Full-text search across task titles and descriptions and session notes, outcomes, accomplishments, distractions and shutdown rituals"),
+		mcp.WithString(
+			"query",
+			mcp.Required(),
+			mcp.Description("Words to search for; each word also matches as a prefix"),
+		),
+		mcp.WithString(
+			"since",
+			mcp.Description("Optional date (YYYY-MM-DD); only return results on or after it"),
+		),
+		mcp.WithString(
+			"tag",
+			mcp.Description("Optional tag; only return results with this tag"),
+		),
+		mcp.WithNumber(
+			"limit",
+			mcp.Description("Maximum number of results (default: 20)"),
+		),
+	)
+	s.server.AddTool(searchTool, s.handleSearch)
+
+	// Tool: get_stats
+	statsTool := mcp.NewTool(
+		"get_stats",
+		mcp.WithDescription("Get session statistics for a week, month, quarter or year: sessions and work time per methodology, focus scores, distractions, pauses, productive hours, optionally time per tag, and the previous period's numbers for comparison. Same data as `flow stats --json`"),
+		mcp.WithString(
+			"period",
+			mcp.Description("Period to report on: week (default), month, quarter or year"),
+		),
+		mcp.WithNumber(
+			"offset",
+			mcp.Description("Periods to step back from the current one, e.g. -1 for the previous (default 0)"),
+		),
+		mcp.WithString(
+			"tag",
+			mcp.Description("Optional tag; only count sessions with this tag or one nested beneath it"),
+		),
+		mcp.WithBoolean(
+			"by_tag",
+			mcp.Description("Include a breakdown of time per tag"),
+		),
+	)
+	s.server.AddTool(statsTool, s.handleGetStats)
 }
 
 // Start begins serving MCP requests via stdio.
@@ -284,6 +333,7 @@ func (s *Server) handleGetCurrentState(ctx context.Context, request mcp.CallTool
 			"status":           string(session.Status),
 			"duration":         session.Duration.String(),
 			"remaining_time":   session.RemainingTime().String(),
+			"elapsed_time":     session.ElapsedTime().Round(time.Second).String(),
 			"progress":         session.Progress(),
 			"started_at":       session.StartedAt.Format("2006-01-02T15:04:05"),
 			"git_branch":       session.GitBranch,
@@ -774,3 +824,65 @@ func (s *Server) handleAddSessionNotes(ctx context.Context, request mcp.CallTool
 
 	return mcp.NewToolResultText(string(jsonData)), nil
 }
+
+// handleSearch handles the search tool.
+func (s *Server) handleSearch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text, err := request.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultError("query is required: " + err.Error()), nil
+	}
+
+	query := ports.SearchQuery{
+		Text:  text,
+		Tag:   request.GetString("tag", ""),
+		Limit: int(request.GetFloat("limit", 0)),
+	}
+	if since := request.GetString("since", ""); since != "" {
+		query.Since, err = time.ParseInLocation("2006-01-02", since, time.Local)
+		if err != nil {
+			return mcp.NewToolResultError("since must be a date like 2006-01-02"), nil
+		}
+	}
+
+	results, err := s.stateProvider.Search(ctx, query)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to search: %v", err)), nil
+	}
+
+	jsonData, err := json.MarshalIndent(ports.NewSearchReport(text, results), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search results: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+// handleGetStats handles the get_stats tool.
+func (s *Server) handleGetStats(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	period, err := domain.ValidatePeriod(request.GetString("period", string(domain.PeriodWeek)))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	offset := request.GetInt("offset", 0)
+	current := period.Range(time.Now(), offset)
+	previous := period.Range(time.Now(), offset-1)
+
+	report, err := s.stateProvider.GetStats(ctx, ports.StatsQuery{
+		Start:   current.Start,
+		End:     current.End,
+		Label:   current.Label,
+		Tag:     request.GetString("tag", ""),
+		ByTag:   request.GetBool("by_tag", false),
+		Compare: &previous,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get stats: %v", err)), nil
+	}
+
+	jsonData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stats: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
@@ -2,11 +2,13 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
 )
 
 // mockStateProvider is a mock implementation of ports.MCPStateProvider for testing.
@@ -15,6 +17,9 @@ type mockStateProvider struct {
 	tasks          []*domain.Task
 	taskHistory    map[string][]*domain.PomodoroSession
 	recentSessions []*domain.PomodoroSession
+	searchResults  []ports.SearchResult
+	lastSearch     ports.SearchQuery
+	lastStats      ports.StatsQuery
 }
 
 func (m *mockStateProvider) GetCurrentState(ctx context.Context) (*domain.CurrentState, error) {
@@ -88,6 +93,16 @@ func (m *mockStateProvider) SetHighlight(ctx context.Context, taskID string) (*d
 	return nil, nil
 }
 
+func (m *mockStateProvider) Search(ctx context.Context, query ports.SearchQuery) ([]ports.SearchResult, error) {
+	m.lastSearch = query
+	return m.searchResults, nil
+}
+
+func (m *mockStateProvider) GetStats(ctx context.Context, query ports.StatsQuery) (*ports.StatsReport, error) {
+	m.lastStats = query
+	return &ports.StatsReport{Label: query.Label, TotalSessions: 3, WorkSeconds: 4500}, nil
+}
+
 func TestNewServer(t *testing.T) {
 	mock := &mockStateProvider{}
 	server := NewServer(mock)
@@ -289,3 +304,120 @@ func TestServer_Stop(t *testing.T) {
 		t.Errorf("Stop() error = %v", err)
 	}
 }
+
+func TestServer_handleSearch(t *testing.T) {
+	mock := &mockStateProvider{
+		searchResults: []ports.SearchResult{{
+			Kind:    "session",
+			ID:      "session-1",
+			Title:   "Write report",
+			Date:    time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC),
+			Snippet: "fixed the **flaky** test",
+		}},
+	}
+
+	server := NewServer(mock)
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"query": "flaky",
+				"since": "2025-01-01",
+				"tag":   "work",
+				"limit": float64(5),
+			},
+		},
+	}
+
+	result, err := server.handleSearch(context.Background(), request)
+	if err != nil {
+		t.Fatalf("handleSearch() error = %v", err)
+	}
+	if result == nil || len(result.Content) == 0 {
+		t.Fatal("handleSearch() returned empty result")
+	}
+	if result.IsError {
+		t.Fatalf("handleSearch() returned tool error: %+v", result.Content)
+	}
+	if mock.lastSearch.Text != "flaky" || mock.lastSearch.Tag != "work" || mock.lastSearch.Limit != 5 {
+		t.Errorf("search query = %+v", mock.lastSearch)
+	}
+	if mock.lastSearch.Since.Format("2006-01-02") != "2025-01-01" {
+		t.Errorf("since = %v, want 2025-01-01", mock.lastSearch.Since)
+	}
+}
+
+func TestServer_handleSearch_NoMatches(t *testing.T) {
+	server := NewServer(&mockStateProvider{})
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]interface{}{"query": "nothing"}},
+	}
+
+	result, err := server.handleSearch(context.Background(), request)
+	if err != nil || result.IsError {
+		t.Fatalf("handleSearch() = %+v, %v", result, err)
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		t.Fatalf("handleSearch() content = %T, want text", result.Content[0])
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(text.Text), &body); err != nil {
+		t.Fatal(err)
+	}
+	if results, ok := body["results"].([]interface{}); !ok || len(results) != 0 {
+		t.Errorf("results = %v, want []", body["results"])
+	}
+	if body["total_count"] != 0.0 {
+		t.Errorf("total_count = %v, want 0", body["total_count"])
+	}
+}
+
+func TestServer_handleSearch_InvalidSince(t *testing.T) {
+	server := NewServer(&mockStateProvider{})
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"query": "flaky",
+				"since": "last tuesday",
+			},
+		},
+	}
+
+	result, err := server.handleSearch(context.Background(), request)
+	if err != nil {
+		t.Fatalf("handleSearch() error = %v", err)
+	}
+	if !result.IsError {
+		t.Error("handleSearch() should return a tool error for an invalid since date")
+	}
+}
+
+func TestServer_handleGetStats(t *testing.T) {
+	mock := &mockStateProvider{}
+	server := NewServer(mock)
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"period": "month", "tag": "client", "by_tag": true},
+		},
+	}
+
+	result, err := server.handleGetStats(context.Background(), request)
+	if err != nil {
+		t.Fatalf("handleGetStats() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handleGetStats() returned tool error: %+v", result.Content)
+	}
+	if mock.lastStats.Start.Day() != 1 || mock.lastStats.Tag != "client" || !mock.lastStats.ByTag {
+		t.Errorf("stats query = %+v, want this month for #client by tag", mock.lastStats)
+	}
+	if c := mock.lastStats.Compare; c == nil || !c.End.Equal(mock.lastStats.Start) {
+		t.Errorf("stats query compares with %+v, want the previous month", c)
+	}
+
+	request.Params.Arguments = map[string]interface{}{"period": "decade"}
+	result, _ = server.handleGetStats(context.Background(), request)
+	if !result.IsError {
+		t.Error("handleGetStats() should return a tool error for an unknown period")
+	}
+}
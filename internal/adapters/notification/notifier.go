@@ -6,6 +6,8 @@ import (
 
 	"github.com/gen2brain/beeep"
 	"github.com/xvierd/flow-cli/internal/config"
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
 )
 
 // Notifier handles desktop notifications.
@@ -49,6 +51,14 @@ func (n *Notifier) NotifyBreakComplete(breakType string) error {
 	return n.Notify(title, message)
 }
 
+// NotifyRotation displays a notification when a mob rotation ends, naming
+// who takes over.
+func (n *Notifier) NotifyRotation(next domain.Rotation) error {
+	title := "🔄 Rotate!"
+	message := fmt.Sprintf("Time to switch: %s.", next)
+	return n.Notify(title, message)
+}
+
 // IsEnabled returns true if notifications are enabled.
 func (n *Notifier) IsEnabled() bool {
 	return n.cfg != nil && n.cfg.Enabled
@@ -60,3 +70,6 @@ func (n *Notifier) SetEnabled(enabled bool) {
 		n.cfg.Enabled = enabled
 	}
 }
+
+// Ensure Notifier implements ports.Notifier.
+var _ ports.Notifier = (*Notifier)(nil)
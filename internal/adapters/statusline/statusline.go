@@ -0,0 +1,286 @@
+// Package statusline renders the active session as a one-line status for
+// an AI assistant's status bar, tmux, starship and polybar/waybar.
+package statusline
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/config"
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+// Target is a program that displays the status line.
+type Target string
+
+const (
+	// TargetClaude colors the line with ANSI escapes for Claude Code.
+	TargetClaude Target = "claude"
+	// TargetTmux uses tmux #[fg=...] styles, for status-right.
+	TargetTmux Target = "tmux"
+	// TargetStarship prints plain text; the module's style sets the color.
+	TargetStarship Target = "starship"
+	// TargetPolybar uses polybar %{F...} tags.
+	TargetPolybar Target = "polybar"
+	// TargetWaybar prints the JSON a waybar custom module reads, with Pango
+	// markup in its text.
+	TargetWaybar Target = "waybar"
+)
+
+// Targets lists the supported targets.
+var Targets = []Target{TargetClaude, TargetTmux, TargetStarship, TargetPolybar, TargetWaybar}
+
+// ParseTarget validates a target name.
+func ParseTarget(name string) (Target, error) {
+	for _, t := range Targets {
+		if string(t) == name {
+			return t, nil
+		}
+	}
+	names := make([]string, len(Targets))
+	for i, t := range Targets {
+		names[i] = string(t)
+	}
+	return "", fmt.Errorf("unknown target %q: use %s", name, strings.Join(names, ", "))
+}
+
+// DefaultFormat returns the template used when no --format is given.
+func (t Target) DefaultFormat() string {
+	if t == TargetClaude {
+		return `{{if .Model}}[{{.Model}}] {{.Context}}% ctx{{if .Active}} | {{end}}{{end}}` +
+			`{{if .Active}}{{fg .Color (printf "%s %s %s" .Icon .Clock (.Bar 5))}}{{with .Task}} {{fg $.Theme.ColorTask .}}{{end}}{{end}}`
+	}
+	return `{{if .Active}}{{fg .Color (printf "%s %s" .Icon .Clock)}}{{with .Task}} {{.}}{{end}}{{end}}`
+}
+
+// Input is the status JSON an assistant pipes to its status line command.
+type Input struct {
+	Model struct {
+		DisplayName string `json:"display_name"`
+	} `json:"model"`
+	ContextWindow struct {
+		UsedPercentage float64 `json:"used_percentage"`
+	} `json:"context_window"`
+}
+
+// ReadInput parses the assistant's status JSON. Empty input is not an error.
+func ReadInput(r io.Reader) (Input, error) {
+	var in Input
+	data, err := io.ReadAll(r)
+	if err != nil || len(bytes.TrimSpace(data)) == 0 {
+		return in, err
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return in, fmt.Errorf("invalid status JSON: %w", err)
+	}
+	return in, nil
+}
+
+// Data is what a --format template sees.
+type Data struct {
+	Active    bool   // a session is running or paused
+	Paused    bool   // the session is paused
+	Break     bool   // the session is a break
+	Icon      string // theme icon for the session state
+	Color     string // theme color for the session state
+	Remaining string // time left, e.g. "18:32"
+	Elapsed   string // time spent, e.g. "06:28"
+	Clock     string // what the timer shows: Remaining, or Elapsed when counting up
+	CountsUp  bool   // the session is open-ended (Flowtime) and counts up
+	Progress  int    // percent done, 0-100
+	Task      string // title of the session's task
+	Type      string // work, short_break or long_break
+	Status    string // running or paused
+	Mode      string // methodology, e.g. pomodoro
+	Model     string // assistant model name, from stdin
+	Context   int    // assistant context window used, in percent, from stdin
+	Theme     config.ThemeConfig
+
+	progress float64
+}
+
+// NewData describes the active session; session and task may be nil.
+func NewData(session *domain.PomodoroSession, task *domain.Task, theme config.ThemeConfig, in Input) Data {
+	data := Data{
+		Theme:   theme,
+		Model:   in.Model.DisplayName,
+		Context: int(in.ContextWindow.UsedPercentage),
+	}
+	if session == nil {
+		return data
+	}
+
+	data.Active = true
+	data.Paused = session.Status == domain.SessionStatusPaused
+	data.Break = session.Type != domain.SessionTypeWork
+	data.Remaining = clock(session.RemainingTime())
+	data.Elapsed = clock(session.ElapsedTime())
+	data.CountsUp = session.IsOpenEnded()
+	data.Clock = data.Remaining
+	if data.CountsUp {
+		data.Clock = data.Elapsed
+	}
+	data.progress = session.Progress()
+	data.Progress = int(math.Round(data.progress * 100))
+	data.Type = string(session.Type)
+	data.Status = string(session.Status)
+	data.Mode = string(session.Methodology)
+	if task != nil {
+		data.Task = task.Title
+	}
+
+	switch {
+	case data.Paused:
+		data.Icon, data.Color = theme.IconPaused, theme.ColorPaused
+	case data.Break:
+		data.Icon, data.Color = "☕", theme.ColorBreak
+	default:
+		data.Icon, data.Color = theme.IconApp, theme.ColorWork
+	}
+	return data
+}
+
+// Bar draws a progress bar width cells wide, e.g. "███░░".
+func (d Data) Bar(width int) string {
+	if width <= 0 {
+		return ""
+	}
+	filled := int(math.Round(d.progress * float64(width)))
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// Render executes format against data and shapes the result for target.
+func Render(target Target, format string, data Data) (string, error) {
+	tmpl, err := template.New("statusline").Funcs(template.FuncMap{
+		"fg": target.fg,
+	}).Parse(format)
+	if err != nil {
+		return "", fmt.Errorf("invalid format: %w", err)
+	}
+
+	// Escape free text so a task title cannot inject markup.
+	data.Task = target.escape(data.Task)
+	data.Model = target.escape(data.Model)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("invalid format: %w", err)
+	}
+	text := strings.TrimSpace(buf.String())
+
+	if target != TargetWaybar {
+		return text, nil
+	}
+	buf.Reset()
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false) // keep the Pango markup readable
+	if err := enc.Encode(waybarOutput{
+		Text:       text,
+		Tooltip:    waybarTooltip(data),
+		Class:      waybarClass(data),
+		Percentage: data.Progress,
+	}); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// fg colors text with a "#RRGGBB" theme color in the target's syntax.
+func (t Target) fg(color, text string) string {
+	r, g, b, ok := parseHex(color)
+	if !ok || text == "" {
+		return text
+	}
+	switch t {
+	case TargetClaude:
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm%s\x1b[0m", r, g, b, text)
+	case TargetTmux:
+		return "#[fg=" + color + "]" + text + "#[fg=default]"
+	case TargetPolybar:
+		return "%{F" + color + "}" + text + "%{F-}"
+	case TargetWaybar:
+		return `<span color="` + color + `">` + text + "</span>"
+	default:
+		return text
+	}
+}
+
+// escape quotes the characters the target would read as markup.
+func (t Target) escape(s string) string {
+	switch t {
+	case TargetTmux:
+		return strings.ReplaceAll(s, "#", "##")
+	case TargetPolybar:
+		return strings.ReplaceAll(s, "%", "%%")
+	case TargetWaybar:
+		return html.EscapeString(s)
+	default:
+		return s
+	}
+}
+
+type waybarOutput struct {
+	Text       string `json:"text"`
+	Tooltip    string `json:"tooltip"`
+	Class      string `json:"class"`
+	Percentage int    `json:"percentage"`
+}
+
+func waybarClass(d Data) string {
+	switch {
+	case !d.Active:
+		return "idle"
+	case d.Paused:
+		return "paused"
+	case d.Break:
+		return "break"
+	default:
+		return "work"
+	}
+}
+
+func waybarTooltip(d Data) string {
+	if !d.Active {
+		return "No active session"
+	}
+	tooltip := d.Remaining + " left"
+	if d.CountsUp {
+		tooltip = d.Elapsed + " elapsed"
+	}
+	if d.Paused {
+		tooltip += " (paused)"
+	}
+	if d.Task != "" {
+		tooltip = d.Task + " · " + tooltip
+	}
+	return tooltip
+}
+
+// clock formats a duration as "mm:ss", or "h:mm:ss" from an hour up.
+func clock(d time.Duration) string {
+	total := int(d.Round(time.Second).Seconds())
+	h, m, s := total/3600, total/60%60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
+
+func parseHex(color string) (r, g, b uint64, ok bool) {
+	if len(color) != 7 || color[0] != '#' {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(color[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return v >> 16, v >> 8 & 0xFF, v & 0xFF, true
+}
@@ -0,0 +1,128 @@
+package statusline
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/config"
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+func testData(t *testing.T, in Input) Data {
+	t.Helper()
+	task, err := domain.NewTask("Write <API> docs #1")
+	if err != nil {
+		t.Fatalf("NewTask() error = %v", err)
+	}
+	session := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), &task.ID)
+	session.StartedAt = time.Now().Add(-10 * time.Minute)
+	return NewData(session, task, config.DefaultThemeConfig(), in)
+}
+
+func TestReadInput(t *testing.T) {
+	in, err := ReadInput(strings.NewReader(`{"model":{"display_name":"Opus"},"context_window":{"used_percentage":12.7}}`))
+	if err != nil {
+		t.Fatalf("ReadInput() error = %v", err)
+	}
+	if in.Model.DisplayName != "Opus" || in.ContextWindow.UsedPercentage != 12.7 {
+		t.Errorf("ReadInput() = %+v", in)
+	}
+
+	if _, err := ReadInput(strings.NewReader("  \n")); err != nil {
+		t.Errorf("ReadInput(empty) error = %v, want nil", err)
+	}
+	if _, err := ReadInput(strings.NewReader("{")); err == nil {
+		t.Error("ReadInput(bad JSON) = nil, want an error")
+	}
+}
+
+func TestNewData(t *testing.T) {
+	data := testData(t, Input{})
+	if !data.Active || data.Break || data.Paused {
+		t.Errorf("data = %+v, want an active work session", data)
+	}
+	if data.Remaining != "15:00" || data.Elapsed != "10:00" || data.Progress != 40 {
+		t.Errorf("Remaining, Elapsed, Progress = %q, %q, %d; want 15:00, 10:00, 40", data.Remaining, data.Elapsed, data.Progress)
+	}
+	if data.Bar(5) != "██░░░" {
+		t.Errorf("Bar(5) = %q, want 2 of 5 filled", data.Bar(5))
+	}
+	if data.Icon != "🍅" || data.Color != "#7C6FE0" {
+		t.Errorf("Icon, Color = %q, %q; want the work theme", data.Icon, data.Color)
+	}
+
+	idle := NewData(nil, nil, config.DefaultThemeConfig(), Input{})
+	if idle.Active || idle.Bar(5) != "░░░░░" {
+		t.Errorf("idle data = %+v, want inactive with an empty bar", idle)
+	}
+
+	if got := clock(90*time.Minute + 5*time.Second); got != "1:30:05" {
+		t.Errorf("clock(90m5s) = %q, want 1:30:05", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	in := Input{}
+	in.Model.DisplayName = "Opus"
+	in.ContextWindow.UsedPercentage = 12
+	data := testData(t, in)
+
+	tests := []struct {
+		target Target
+		format string
+		want   string
+	}{
+		{TargetClaude, "", "[Opus] 12% ctx | \x1b[38;2;124;111;224m🍅 15:00 ██░░░\x1b[0m \x1b[38;2;160;174;192mWrite <API> docs #1\x1b[0m"},
+		{TargetTmux, "", "#[fg=#7C6FE0]🍅 15:00#[fg=default] Write <API> docs ##1"},
+		{TargetStarship, "", "🍅 15:00 Write <API> docs #1"},
+		{TargetPolybar, `{{fg .Color .Remaining}} {{.Progress}}%`, "%{F#7C6FE0}15:00%{F-} 40%"},
+		{TargetStarship, `{{.Icon}} {{.Remaining}} {{.Bar 5}} {{.Task}}`, "🍅 15:00 ██░░░ Write <API> docs #1"},
+	}
+	for _, tt := range tests {
+		got, err := Render(tt.target, orDefault(tt.format, tt.target), data)
+		if err != nil {
+			t.Fatalf("Render(%s) error = %v", tt.target, err)
+		}
+		if got != tt.want {
+			t.Errorf("Render(%s, %q) = %q, want %q", tt.target, tt.format, got, tt.want)
+		}
+	}
+
+	if _, err := Render(TargetClaude, "{{.Nope}}", data); err == nil {
+		t.Error("Render() with an unknown field = nil, want an error")
+	}
+}
+
+func TestRender_Waybar(t *testing.T) {
+	got, err := Render(TargetWaybar, TargetWaybar.DefaultFormat(), testData(t, Input{}))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	var out waybarOutput
+	if err := json.Unmarshal([]byte(got), &out); err != nil {
+		t.Fatalf("waybar output is not JSON: %v\n%s", err, got)
+	}
+	want := waybarOutput{
+		Text:       `<span color="#7C6FE0">🍅 15:00</span> Write &lt;API&gt; docs #1`,
+		Tooltip:    "Write &lt;API&gt; docs #1 · 15:00 left",
+		Class:      "work",
+		Percentage: 40,
+	}
+	if out != want {
+		t.Errorf("waybar output = %+v, want %+v", out, want)
+	}
+
+	idle, _ := Render(TargetWaybar, TargetWaybar.DefaultFormat(), NewData(nil, nil, config.DefaultThemeConfig(), Input{}))
+	if idle != `{"text":"","tooltip":"No active session","class":"idle","percentage":0}` {
+		t.Errorf("idle waybar output = %s", idle)
+	}
+}
+
+func orDefault(format string, target Target) string {
+	if format == "" {
+		return target.DefaultFormat()
+	}
+	return format
+}
@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// dailySnapshotLayout names automatic snapshots so they sort chronologically
+// and can be told apart from manual backups, which are never pruned.
+const dailySnapshotLayout = "flow-2006-01-02.db"
+
+// ValidateSnapshot checks that path is an intact Flow database this build can
+// open, and returns its schema version (0 for databases that predate
+// versioned migrations).
+func ValidateSnapshot(path string) (int, error) {
+	if _, err := os.Stat(path); err != nil {
+		return 0, fmt.Errorf("cannot read snapshot: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var integrity string
+	if err := db.QueryRow("PRAGMA integrity_check").Scan(&integrity); err != nil {
+		return 0, fmt.Errorf("snapshot is not a valid database: %w", err)
+	}
+	if integrity != "ok" {
+		return 0, fmt.Errorf("snapshot failed integrity check: %s", integrity)
+	}
+
+	var tables int
+	err = db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('tasks', 'sessions')`).Scan(&tables)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect snapshot: %w", err)
+	}
+	if tables != 2 {
+		return 0, fmt.Errorf("snapshot is not a flow database")
+	}
+
+	var hasMigrations int
+	err = db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'`).Scan(&hasMigrations)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect snapshot: %w", err)
+	}
+	if hasMigrations == 0 {
+		return 0, nil
+	}
+
+	var version int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read snapshot schema version: %w", err)
+	}
+	if version > LatestSchemaVersion() {
+		return 0, fmt.Errorf("snapshot schema version %d is newer than this version of flow supports (%d); please upgrade flow", version, LatestSchemaVersion())
+	}
+	return version, nil
+}
+
+// RestoreSnapshot replaces the database at dbPath with a copy of snapshot.
+// The caller must close any open storage on dbPath first. Stale WAL files are
+// removed so SQLite does not replay them over the restored data.
+func RestoreSnapshot(snapshot, dbPath string) error {
+	src, err := os.Open(snapshot) //nolint:gosec // snapshot path is chosen by the user on the command line
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	tmpPath := dbPath + ".restore"
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600) //nolint:gosec // derived from the configured database path
+	if err != nil {
+		return fmt.Errorf("failed to create restore file: %w", err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to copy snapshot: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write restore file: %w", err)
+	}
+
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !os.IsNotExist(err) {
+			_ = os.Remove(tmpPath)
+			return fmt.Errorf("failed to remove %s: %w", dbPath+suffix, err)
+		}
+	}
+
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace database: %w", err)
+	}
+	return nil
+}
+
+// DailySnapshot writes today's automatic snapshot into dir unless it already
+// exists, then prunes automatic snapshots beyond the newest keep. It returns
+// the path of the snapshot it wrote, or "" when today's snapshot was present.
+func DailySnapshot(ctx context.Context, s ports.Storage, dir string, keep int, now time.Time) (string, error) {
+	if keep <= 0 {
+		return "", nil
+	}
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	path := filepath.Join(dir, now.Format(dailySnapshotLayout))
+	written := ""
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := s.Backup(ctx, path); err != nil {
+			return "", err
+		}
+		written = path
+	}
+
+	if err := pruneSnapshots(dir, keep); err != nil {
+		return written, err
+	}
+	return written, nil
+}
+
+// pruneSnapshots deletes all but the newest keep automatic snapshots in dir.
+func pruneSnapshots(dir string, keep int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	var snapshots []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if _, err := time.Parse(dailySnapshotLayout, e.Name()); err == nil {
+			snapshots = append(snapshots, e.Name())
+		}
+	}
+	if len(snapshots) <= keep {
+		return nil
+	}
+
+	// Names embed the date, so lexical order is chronological.
+	sort.Strings(snapshots)
+	for _, name := range snapshots[:len(snapshots)-keep] {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("failed to prune backup %s: %w", name, err)
+		}
+	}
+	return nil
+}
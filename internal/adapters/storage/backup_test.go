@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+func TestBackup_SnapshotRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	store, err := New(filepath.Join(dir, "flow.db"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	task, _ := domain.NewTask("Back me up")
+	if err := store.Tasks().Save(ctx, task); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	snapshot := filepath.Join(dir, "snapshot.db")
+	if err := store.Backup(ctx, snapshot); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+	if err := store.Backup(ctx, snapshot); err == nil {
+		t.Error("Backup() over an existing file should fail")
+	}
+	_ = store.Close()
+
+	version, err := ValidateSnapshot(snapshot)
+	if err != nil {
+		t.Fatalf("ValidateSnapshot() error = %v", err)
+	}
+	if version != LatestSchemaVersion() {
+		t.Errorf("snapshot version = %d, want %d", version, LatestSchemaVersion())
+	}
+
+	target := filepath.Join(dir, "restored.db")
+	if err := RestoreSnapshot(snapshot, target); err != nil {
+		t.Fatalf("RestoreSnapshot() error = %v", err)
+	}
+	restored, err := New(target)
+	if err != nil {
+		t.Fatalf("New() on restored database error = %v", err)
+	}
+	defer func() { _ = restored.Close() }()
+
+	found, err := restored.Tasks().FindByID(ctx, task.ID)
+	if err != nil || found == nil {
+		t.Fatalf("restored task missing: %v", err)
+	}
+	if found.Title != "Back me up" {
+		t.Errorf("restored title = %q, want 'Back me up'", found.Title)
+	}
+}
+
+func TestValidateSnapshot_RejectsNonFlowFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	garbage := filepath.Join(dir, "garbage.db")
+	if err := os.WriteFile(garbage, []byte("definitely not sqlite"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ValidateSnapshot(garbage); err == nil {
+		t.Error("ValidateSnapshot() accepted a non-database file")
+	}
+
+	if _, err := ValidateSnapshot(filepath.Join(dir, "missing.db")); err == nil {
+		t.Error("ValidateSnapshot() accepted a missing file")
+	}
+}
+
+func TestDailySnapshot_OncePerDayAndPrunes(t *testing.T) {
+	dir := t.TempDir()
+	backups := filepath.Join(dir, "backups")
+	ctx := context.Background()
+
+	store, err := New(filepath.Join(dir, "flow.db"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.Local)
+	for i := 0; i < 5; i++ {
+		path, err := DailySnapshot(ctx, store, backups, 3, start.AddDate(0, 0, i))
+		if err != nil {
+			t.Fatalf("DailySnapshot() day %d error = %v", i, err)
+		}
+		if path == "" {
+			t.Errorf("DailySnapshot() day %d wrote nothing", i)
+		}
+	}
+
+	// A second launch on the same day must not take another snapshot.
+	path, err := DailySnapshot(ctx, store, backups, 3, start.AddDate(0, 0, 4).Add(time.Hour))
+	if err != nil {
+		t.Fatalf("DailySnapshot() error = %v", err)
+	}
+	if path != "" {
+		t.Errorf("DailySnapshot() wrote %s twice in one day", path)
+	}
+
+	// Manual backups in the same directory are never pruned.
+	manual := filepath.Join(backups, "flow-20250301-090000.db")
+	if err := store.Backup(ctx, manual); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+	if _, err := DailySnapshot(ctx, store, backups, 3, start.AddDate(0, 0, 5)); err != nil {
+		t.Fatalf("DailySnapshot() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(backups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	want := []string{"flow-2025-03-04.db", "flow-2025-03-05.db", "flow-2025-03-06.db", "flow-20250301-090000.db"}
+	if len(names) != len(want) {
+		t.Fatalf("backups = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("backups = %v, want %v", names, want)
+			break
+		}
+	}
+}
+
+func TestDailySnapshot_DisabledWhenKeepIsZero(t *testing.T) {
+	dir := t.TempDir()
+	store, err := New(filepath.Join(dir, "flow.db"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	path, err := DailySnapshot(context.Background(), store, filepath.Join(dir, "backups"), 0, time.Now())
+	if err != nil || path != "" {
+		t.Errorf("DailySnapshot() = %q, %v; want no snapshot", path, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "backups")); !os.IsNotExist(err) {
+		t.Error("backups directory created although snapshots are disabled")
+	}
+}
@@ -0,0 +1,552 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// migration is a single, numbered schema change. Each migration runs in its own
+// transaction and is recorded in schema_migrations once it commits.
+type migration struct {
+	Version int
+	Name    string
+	Up      func(tx *sql.Tx) error
+}
+
+// migrations lists every schema change in the order it must be applied.
+// Append new entries at the end; never renumber or edit an applied migration.
+var migrations = []migration{
+	{
+		Version: 1,
+		Name:    "create_tasks_and_sessions",
+		Up: func(tx *sql.Tx) error {
+			return execAll(tx, `
+			CREATE TABLE IF NOT EXISTS tasks (
+				id TEXT PRIMARY KEY,
+				title TEXT NOT NULL,
+				description TEXT,
+				status TEXT NOT NULL,
+				tags TEXT,
+				created_at DATETIME NOT NULL,
+				updated_at DATETIME NOT NULL,
+				completed_at DATETIME
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
+			CREATE INDEX IF NOT EXISTS idx_tasks_updated ON tasks(updated_at);
+
+			CREATE TABLE IF NOT EXISTS sessions (
+				id TEXT PRIMARY KEY,
+				task_id TEXT,
+				type TEXT NOT NULL,
+				status TEXT NOT NULL,
+				duration_ms INTEGER NOT NULL,
+				started_at DATETIME NOT NULL,
+				paused_at DATETIME,
+				completed_at DATETIME,
+				git_branch TEXT,
+				git_commit TEXT,
+				git_modified TEXT,
+				notes TEXT,
+				FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE SET NULL
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_sessions_task ON sessions(task_id);
+			CREATE INDEX IF NOT EXISTS idx_sessions_started ON sessions(started_at);
+			CREATE INDEX IF NOT EXISTS idx_sessions_status ON sessions(status);
+			`)
+		},
+	},
+	{
+		Version: 2,
+		Name:    "add_methodology_fields",
+		Up: func(tx *sql.Tx) error {
+			// Databases created before versioned migrations may already have some
+			// of these columns, so each one is only added when missing.
+			columns := []struct{ table, column, definition string }{
+				{"sessions", "methodology", "TEXT DEFAULT 'pomodoro'"},
+				{"sessions", "focus_score", "INTEGER"},
+				{"sessions", "distractions", "TEXT"},
+				{"sessions", "accomplishment", "TEXT"},
+				{"sessions", "intended_outcome", "TEXT"},
+				{"tasks", "highlight_date", "DATETIME"},
+				{"sessions", "tags", "TEXT"},
+				{"sessions", "energize_activity", "TEXT"},
+				{"sessions", "shutdown_ritual", "TEXT"},
+				{"sessions", "outcome_achieved", "TEXT"},
+			}
+			for _, c := range columns {
+				if err := addColumnIfMissing(tx, c.table, c.column, c.definition); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 3,
+		Name:    "add_search_index",
+		Up: func(tx *sql.Tx) error {
+			if err := execAll(tx, `
+			CREATE VIRTUAL TABLE IF NOT EXISTS search_index USING fts5(
+				kind UNINDEXED,
+				ref_id UNINDEXED,
+				title,
+				body,
+				tokenize = 'porter unicode61'
+			);
+			`); err != nil {
+				return err
+			}
+			return backfillSearchIndex(tx)
+		},
+	},
+	{
+		Version: 4,
+		Name:    "normalize_tags",
+		Up: func(tx *sql.Tx) error {
+			if err := execAll(tx, `
+			CREATE TABLE IF NOT EXISTS tags (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL UNIQUE COLLATE NOCASE
+			);
+
+			CREATE TABLE IF NOT EXISTS task_tags (
+				task_id TEXT NOT NULL,
+				tag_id INTEGER NOT NULL,
+				position INTEGER NOT NULL,
+				PRIMARY KEY (task_id, tag_id),
+				FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+				FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+			);
+
+			CREATE TABLE IF NOT EXISTS session_tags (
+				session_id TEXT NOT NULL,
+				tag_id INTEGER NOT NULL,
+				position INTEGER NOT NULL,
+				PRIMARY KEY (session_id, tag_id),
+				FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE,
+				FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_task_tags_tag ON task_tags(tag_id);
+			CREATE INDEX IF NOT EXISTS idx_session_tags_tag ON session_tags(tag_id);
+			`); err != nil {
+				return err
+			}
+			for _, t := range []struct{ table, joinTable, ownerColumn string }{
+				{"tasks", "task_tags", "task_id"},
+				{"sessions", "session_tags", "session_id"},
+			} {
+				if err := moveTagColumn(tx, t.table, t.joinTable, t.ownerColumn); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 5,
+		Name:    "add_session_events",
+		Up: func(tx *sql.Tx) error {
+			// Older sessions only know their start, current pause and end.
+			// Their started_at was shifted forward on every resume, so past
+			// pauses cannot be recovered.
+			return execAll(tx, `
+			CREATE TABLE IF NOT EXISTS session_events (
+				session_id TEXT NOT NULL,
+				seq INTEGER NOT NULL,
+				event TEXT NOT NULL,
+				at DATETIME NOT NULL,
+				PRIMARY KEY (session_id, seq),
+				FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE
+			);
+
+			INSERT OR IGNORE INTO session_events (session_id, seq, event, at)
+			SELECT id, 0, 'start', started_at FROM sessions;
+
+			INSERT OR IGNORE INTO session_events (session_id, seq, event, at)
+			SELECT id, 1, 'pause', paused_at FROM sessions
+			WHERE status = 'paused' AND paused_at IS NOT NULL;
+
+			INSERT OR IGNORE INTO session_events (session_id, seq, event, at)
+			SELECT id, 1,
+				CASE status WHEN 'completed' THEN 'stop' WHEN 'interrupted' THEN 'void' ELSE 'cancel' END,
+				completed_at
+			FROM sessions
+			WHERE status IN ('completed', 'interrupted', 'cancelled') AND completed_at IS NOT NULL;
+			`)
+		},
+	},
+	{
+		Version: 6,
+		Name:    "add_webhook_deliveries",
+		Up: func(tx *sql.Tx) error {
+			return execAll(tx, `
+			CREATE TABLE IF NOT EXISTS webhook_deliveries (
+				id TEXT PRIMARY KEY,
+				url TEXT NOT NULL,
+				event TEXT NOT NULL,
+				payload BLOB NOT NULL,
+				status TEXT NOT NULL,
+				attempts INTEGER NOT NULL DEFAULT 0,
+				last_error TEXT,
+				created_at DATETIME NOT NULL,
+				next_attempt_at DATETIME NOT NULL,
+				delivered_at DATETIME
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(status, next_attempt_at);
+			CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created ON webhook_deliveries(created_at);
+			`)
+		},
+	},
+	{
+		Version: 7,
+		Name:    "add_daily_rollups",
+		Up: func(tx *sql.Tx) error {
+			// Days are local dates. A row with an empty tag counts every
+			// session of its day, methodology and task; tagged rows count
+			// the sessions under that tag, so tag filters need no joins.
+			if err := execAll(tx, `
+			CREATE TABLE IF NOT EXISTS daily_rollups (
+				day TEXT NOT NULL,
+				methodology TEXT NOT NULL,
+				task_id TEXT NOT NULL DEFAULT '',
+				tag TEXT NOT NULL DEFAULT '' COLLATE NOCASE,
+				work_sessions INTEGER NOT NULL DEFAULT 0,
+				work_ms INTEGER NOT NULL DEFAULT 0,
+				breaks INTEGER NOT NULL DEFAULT 0,
+				focus_score_sum INTEGER NOT NULL DEFAULT 0,
+				focus_score_count INTEGER NOT NULL DEFAULT 0,
+				distractions INTEGER NOT NULL DEFAULT 0,
+				pauses INTEGER NOT NULL DEFAULT 0,
+				paused_ms INTEGER NOT NULL DEFAULT 0,
+				PRIMARY KEY (tag, day, methodology, task_id)
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_daily_rollups_day ON daily_rollups(day);
+			CREATE INDEX IF NOT EXISTS idx_tasks_highlight ON tasks(highlight_date);
+			`); err != nil {
+				return err
+			}
+			_, err := rebuildRollups(context.Background(), tx)
+			return err
+		},
+	},
+	{
+		Version: 8,
+		Name:    "add_session_break_after",
+		Up: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "sessions", "break_after_ms", "INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+	{
+		Version: 9,
+		Name:    "add_session_rotation",
+		Up: func(tx *sql.Tx) error {
+			// Mob rotations: participants is the whole mob as a JSON array
+			for _, column := range []string{"driver", "navigator", "participants"} {
+				if err := addColumnIfMissing(tx, "sessions", column, "TEXT NOT NULL DEFAULT ''"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 10,
+		Name:    "add_plan_blocks",
+		Up: func(tx *sql.Tx) error {
+			// Time blocks of each day's plan; day is the local date of
+			// start_at and tags a JSON array.
+			return execAll(tx, `
+			CREATE TABLE IF NOT EXISTS plan_blocks (
+				id TEXT PRIMARY KEY,
+				day TEXT NOT NULL,
+				start_at DATETIME NOT NULL,
+				end_at DATETIME NOT NULL,
+				task TEXT NOT NULL,
+				methodology TEXT NOT NULL DEFAULT '',
+				tags TEXT NOT NULL DEFAULT ''
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_plan_blocks_day ON plan_blocks(day, start_at);
+			`)
+		},
+	},
+	{
+		Version: 11,
+		Name:    "add_session_open_ended",
+		Up: func(tx *sql.Tx) error {
+			if err := addColumnIfMissing(tx, "sessions", "open_ended", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+				return err
+			}
+			// Sessions still counting up were told apart by a zero duration
+			_, err := tx.Exec(`UPDATE sessions SET open_ended = 1
+				WHERE duration_ms = 0 AND status IN ('running', 'paused')`)
+			return err
+		},
+	},
+}
+
+// moveTagColumn copies the legacy comma-separated tags column of table into
+// joinTable and then drops the column.
+func moveTagColumn(tx *sql.Tx, table, joinTable, ownerColumn string) error {
+	exists, err := columnExists(tx, table, "tags")
+	if err != nil || !exists {
+		return err
+	}
+
+	rows, err := tx.Query(fmt.Sprintf(`SELECT id, tags FROM %s WHERE tags IS NOT NULL AND tags != ''`, table))
+	if err != nil {
+		return fmt.Errorf("failed to read %s tags: %w", table, err)
+	}
+	tagged := map[string][]string{}
+	for rows.Next() {
+		var id, tags string
+		if err := rows.Scan(&id, &tags); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan %s tags: %w", table, err)
+		}
+		tagged[id] = strings.Split(tags, ",")
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	for id, tags := range tagged {
+		if err := replaceTags(ctx, tx, joinTable, ownerColumn, id, tags); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE %s DROP COLUMN tags`, table))
+	return err
+}
+
+// backfillSearchIndex indexes every existing task and session.
+func backfillSearchIndex(tx *sql.Tx) error {
+	ctx := context.Background()
+
+	rows, err := tx.Query(`SELECT id, title, COALESCE(description, '') FROM tasks`)
+	if err != nil {
+		return fmt.Errorf("failed to read tasks: %w", err)
+	}
+	var tasks []*domain.Task
+	for rows.Next() {
+		var t domain.Task
+		if err := rows.Scan(&t.ID, &t.Title, &t.Description); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, &t)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = tx.Query(`
+		SELECT id, COALESCE(notes, ''), COALESCE(intended_outcome, ''), COALESCE(accomplishment, ''),
+		       COALESCE(energize_activity, ''), COALESCE(distractions, ''), COALESCE(shutdown_ritual, '')
+		FROM sessions
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to read sessions: %w", err)
+	}
+	var sessions []*domain.PomodoroSession
+	for rows.Next() {
+		var s domain.PomodoroSession
+		var distractions, ritual string
+		if err := rows.Scan(&s.ID, &s.Notes, &s.IntendedOutcome, &s.Accomplishment,
+			&s.EnergizeActivity, &distractions, &ritual); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan session: %w", err)
+		}
+		s.Distractions = unmarshalDistractions(distractions)
+		if ritual != "" {
+			var r domain.ShutdownRitual
+			if err := json.Unmarshal([]byte(ritual), &r); err == nil {
+				s.ShutdownRitual = &r
+			}
+		}
+		sessions = append(sessions, &s)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, t := range tasks {
+		if err := indexTask(ctx, tx, t); err != nil {
+			return err
+		}
+	}
+	for _, s := range sessions {
+		if err := indexSession(ctx, tx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LatestSchemaVersion returns the schema version this build migrates to.
+func LatestSchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// execAll executes a block of SQL statements inside a transaction.
+func execAll(tx *sql.Tx, query string) error {
+	_, err := tx.Exec(query)
+	return err
+}
+
+// addColumnIfMissing adds a column unless the table already has it.
+func addColumnIfMissing(tx *sql.Tx, table, column, definition string) error {
+	exists, err := columnExists(tx, table, column)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	_, err = tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
+// columnExists reports whether the table has a column with the given name.
+func columnExists(tx *sql.Tx, table, column string) (bool, error) {
+	var count int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+	return count > 0, nil
+}
+
+// ensureMigrationsTable creates the bookkeeping table for applied migrations.
+func ensureMigrationsTable(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at DATETIME NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// appliedMigrations returns the applied_at time of every recorded migration, keyed by version.
+func appliedMigrations(ctx context.Context, db *sql.DB) (map[int]time.Time, error) {
+	rows, err := db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schema_migrations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// runMigrations applies every pending migration in order, each in its own transaction.
+// It stops at the first failure so later migrations never run against a half-migrated schema.
+func runMigrations(ctx context.Context, db *sql.DB, list []migration) error {
+	if err := ensureMigrationsTable(ctx, db); err != nil {
+		return err
+	}
+
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	latest := 0
+	if len(list) > 0 {
+		latest = list[len(list)-1].Version
+	}
+	for version := range applied {
+		if version > latest {
+			return fmt.Errorf("database schema version %d is newer than this version of flow supports (%d); please upgrade flow", version, latest)
+		}
+	}
+
+	for _, m := range list {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		if err := applyMigration(ctx, db, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyMigration runs a single migration and records it, rolling back on any error.
+func applyMigration(ctx context.Context, db *sql.DB, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("migration %d (%s): failed to begin transaction: %w", m.Version, m.Name, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := m.Up(tx); err != nil {
+		return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+	}
+
+	if _, err := tx.Exec(
+		`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+		m.Version, m.Name, time.Now(),
+	); err != nil {
+		return fmt.Errorf("migration %d (%s): failed to record version: %w", m.Version, m.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("migration %d (%s): failed to commit: %w", m.Version, m.Name, err)
+	}
+	return nil
+}
+
+// migrationStatus reports every known migration along with when it was applied.
+func migrationStatus(ctx context.Context, db *sql.DB, list []migration) ([]ports.MigrationStatus, error) {
+	if err := ensureMigrationsTable(ctx, db); err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ports.MigrationStatus, 0, len(list))
+	for _, m := range list {
+		status := ports.MigrationStatus{Version: m.Version, Name: m.Name}
+		if at, ok := applied[m.Version]; ok {
+			appliedAt := at
+			status.AppliedAt = &appliedAt
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
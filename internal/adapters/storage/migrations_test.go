@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrate_RecordsEveryVersion(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "flow.db"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	// Running again must be a no-op.
+	if err := store.Migrate(); err != nil {
+		t.Fatalf("second Migrate() error = %v", err)
+	}
+
+	statuses, err := store.MigrationStatus(context.Background())
+	if err != nil {
+		t.Fatalf("MigrationStatus() error = %v", err)
+	}
+	if len(statuses) != len(migrations) {
+		t.Fatalf("got %d statuses, want %d", len(statuses), len(migrations))
+	}
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			t.Errorf("migration %d (%s) not applied", s.Version, s.Name)
+		}
+	}
+}
+
+func TestMigrate_UpgradesLegacyDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flow.db")
+
+	// Schema as created by releases before versioned migrations, with one of
+	// the later columns already added by the old ALTER TABLE loop.
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	_, err = db.Exec(`
+		CREATE TABLE tasks (
+			id TEXT PRIMARY KEY, title TEXT NOT NULL, description TEXT, status TEXT NOT NULL,
+			tags TEXT, created_at DATETIME NOT NULL, updated_at DATETIME NOT NULL, completed_at DATETIME
+		);
+		CREATE TABLE sessions (
+			id TEXT PRIMARY KEY, task_id TEXT, type TEXT NOT NULL, status TEXT NOT NULL,
+			duration_ms INTEGER NOT NULL, started_at DATETIME NOT NULL, paused_at DATETIME,
+			completed_at DATETIME, git_branch TEXT, git_commit TEXT, git_modified TEXT, notes TEXT,
+			methodology TEXT DEFAULT 'pomodoro'
+		);
+	`)
+	if err != nil {
+		t.Fatalf("create legacy schema error = %v", err)
+	}
+	_ = db.Close()
+
+	store, err := New(path)
+	if err != nil {
+		t.Fatalf("New() on legacy database error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	s := store.(*sqliteStorage)
+	tx, err := s.db.Begin()
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	for _, col := range []string{"outcome_achieved", "shutdown_ritual", "energize_activity"} {
+		ok, err := columnExists(tx, "sessions", col)
+		if err != nil {
+			t.Fatalf("columnExists() error = %v", err)
+		}
+		if !ok {
+			t.Errorf("sessions.%s missing after migration", col)
+		}
+	}
+}
+
+func TestRunMigrations_FailureRollsBackAndStops(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "flow.db"))
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	ranThird := false
+	list := []migration{
+		{Version: 1, Name: "create_widgets", Up: func(tx *sql.Tx) error {
+			return execAll(tx, `CREATE TABLE widgets (id TEXT PRIMARY KEY)`)
+		}},
+		{Version: 2, Name: "broken", Up: func(tx *sql.Tx) error {
+			if err := execAll(tx, `CREATE TABLE gadgets (id TEXT PRIMARY KEY)`); err != nil {
+				return err
+			}
+			return errors.New("boom")
+		}},
+		{Version: 3, Name: "never_runs", Up: func(tx *sql.Tx) error {
+			ranThird = true
+			return nil
+		}},
+	}
+
+	ctx := context.Background()
+	err = runMigrations(ctx, db, list)
+	if err == nil || !strings.Contains(err.Error(), "migration 2 (broken) failed") {
+		t.Fatalf("runMigrations() error = %v, want migration 2 failure", err)
+	}
+	if ranThird {
+		t.Error("migration 3 ran after migration 2 failed")
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'gadgets'`).Scan(&count); err != nil {
+		t.Fatalf("query error = %v", err)
+	}
+	if count != 0 {
+		t.Error("failed migration was not rolled back")
+	}
+
+	statuses, err := migrationStatus(ctx, db, list)
+	if err != nil {
+		t.Fatalf("migrationStatus() error = %v", err)
+	}
+	if statuses[0].AppliedAt == nil {
+		t.Error("migration 1 should be recorded as applied")
+	}
+	if statuses[1].AppliedAt != nil || statuses[2].AppliedAt != nil {
+		t.Error("migrations 2 and 3 should be pending")
+	}
+}
+
+func TestRunMigrations_RejectsNewerSchema(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "flow.db"))
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	if err := runMigrations(ctx, db, migrations); err != nil {
+		t.Fatalf("runMigrations() error = %v", err)
+	}
+
+	older := migrations[:len(migrations)-1]
+	err = runMigrations(ctx, db, older)
+	if err == nil || !strings.Contains(err.Error(), "newer than this version") {
+		t.Fatalf("runMigrations() error = %v, want newer schema error", err)
+	}
+}
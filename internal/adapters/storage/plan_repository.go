@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// planRepository implements ports.PlanRepository on the plan_blocks table.
+type planRepository struct {
+	db dbtx
+}
+
+// newPlanRepository creates a new day plan repository.
+func newPlanRepository(db dbtx) ports.PlanRepository {
+	return &planRepository{db: db}
+}
+
+// FindByDay returns the blocks planned for the local date of day, in start order.
+func (r *planRepository) FindByDay(ctx context.Context, day time.Time) ([]*domain.PlanBlock, error) {
+	return r.query(ctx, `
+		SELECT id, start_at, end_at, task, methodology, tags FROM plan_blocks
+		WHERE day = ? ORDER BY start_at
+	`, dayKey(day))
+}
+
+// FindAll returns the blocks of every day's plan, in start order.
+func (r *planRepository) FindAll(ctx context.Context) ([]*domain.PlanBlock, error) {
+	return r.query(ctx, `
+		SELECT id, start_at, end_at, task, methodology, tags FROM plan_blocks
+		ORDER BY start_at
+	`)
+}
+
+// FindByID returns the block with id, or nil if there is none.
+func (r *planRepository) FindByID(ctx context.Context, id string) (*domain.PlanBlock, error) {
+	blocks, err := r.query(ctx, `
+		SELECT id, start_at, end_at, task, methodology, tags FROM plan_blocks
+		WHERE id = ?
+	`, id)
+	if err != nil || len(blocks) == 0 {
+		return nil, err
+	}
+	return blocks[0], nil
+}
+
+// Save adds block to the plan of the local date it starts on.
+func (r *planRepository) Save(ctx context.Context, block *domain.PlanBlock) error {
+	return insertPlanBlock(ctx, r.db, dayKey(block.Start), block)
+}
+
+// query runs a plan_blocks select and scans the blocks it returns.
+func (r *planRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.PlanBlock, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query plan blocks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var blocks []*domain.PlanBlock
+	for rows.Next() {
+		var b domain.PlanBlock
+		var methodology, tags string
+		if err := rows.Scan(&b.ID, &b.Start, &b.End, &b.Task, &methodology, &tags); err != nil {
+			return nil, fmt.Errorf("failed to scan plan block: %w", err)
+		}
+		b.Start = b.Start.In(time.Local)
+		b.End = b.End.In(time.Local)
+		b.Methodology = domain.Methodology(methodology)
+		if tags != "" {
+			_ = json.Unmarshal([]byte(tags), &b.Tags)
+		}
+		blocks = append(blocks, &b)
+	}
+	return blocks, rows.Err()
+}
+
+// ReplaceDay replaces the plan for the local date of day with blocks.
+func (r *planRepository) ReplaceDay(ctx context.Context, day time.Time, blocks []*domain.PlanBlock) error {
+	key := dayKey(day)
+	return inTx(ctx, r.db, func(tx dbtx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM plan_blocks WHERE day = ?`, key); err != nil {
+			return fmt.Errorf("failed to clear plan: %w", err)
+		}
+		for _, b := range blocks {
+			if err := insertPlanBlock(ctx, tx, key, b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insertPlanBlock stores block under the plan of day.
+func insertPlanBlock(ctx context.Context, db execer, day string, b *domain.PlanBlock) error {
+	tags := ""
+	if len(b.Tags) > 0 {
+		data, _ := json.Marshal(b.Tags)
+		tags = string(data)
+	}
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO plan_blocks (id, day, start_at, end_at, task, methodology, tags)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, b.ID, day, b.Start, b.End, b.Task, string(b.Methodology), tags)
+	if err != nil {
+		return fmt.Errorf("failed to save plan block: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+func TestPlanRepository_ReplaceDay(t *testing.T) {
+	store, err := NewMemory()
+	if err != nil {
+		t.Fatalf("NewMemory() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+	repo := store.Plans()
+
+	day := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	write, _ := domain.NewPlanBlock(at(9, 0), at(10, 30), "Write API docs")
+	write.Methodology = domain.MethodologyDeepWork
+	write.Tags = []string{"docs", "writing"}
+	email, _ := domain.NewPlanBlock(at(11, 0), at(11, 30), "Email")
+	tomorrow, _ := domain.NewPlanBlock(at(33, 0), at(34, 0), "Review")
+
+	if err := repo.ReplaceDay(ctx, day, []*domain.PlanBlock{email, write}); err != nil {
+		t.Fatalf("ReplaceDay() error = %v", err)
+	}
+	if err := repo.ReplaceDay(ctx, day.AddDate(0, 0, 1), []*domain.PlanBlock{tomorrow}); err != nil {
+		t.Fatalf("ReplaceDay() tomorrow error = %v", err)
+	}
+
+	got, err := repo.FindByDay(ctx, at(15, 0))
+	if err != nil {
+		t.Fatalf("FindByDay() error = %v", err)
+	}
+	if len(got) != 2 || got[0].ID != write.ID || got[1].ID != email.ID {
+		t.Fatalf("FindByDay() = %v, want the day's two blocks in start order", got)
+	}
+	if !got[0].Start.Equal(write.Start) || !got[0].End.Equal(write.End) || got[0].Task != "Write API docs" ||
+		got[0].Methodology != domain.MethodologyDeepWork || !slices.Equal(got[0].Tags, write.Tags) {
+		t.Errorf("FindByDay()[0] = %+v, want the saved block", got[0])
+	}
+	if got[1].Methodology != "" || got[1].Tags != nil {
+		t.Errorf("FindByDay()[1] = %+v, want no mode or tags", got[1])
+	}
+
+	// Replacing the plan drops the blocks left out, and only that day's
+	if err := repo.ReplaceDay(ctx, day, []*domain.PlanBlock{email}); err != nil {
+		t.Fatalf("ReplaceDay() again error = %v", err)
+	}
+	if got, _ := repo.FindByDay(ctx, day); len(got) != 1 || got[0].ID != email.ID {
+		t.Errorf("FindByDay() after replace = %v, want only the email block", got)
+	}
+	if got, _ := repo.FindByDay(ctx, day.AddDate(0, 0, 1)); len(got) != 1 || got[0].ID != tomorrow.ID {
+		t.Errorf("FindByDay() tomorrow = %v, want its block untouched", got)
+	}
+
+	if all, err := repo.FindAll(ctx); err != nil || len(all) != 2 || all[0].ID != email.ID || all[1].ID != tomorrow.ID {
+		t.Errorf("FindAll() = %v, %v; want every day's blocks in start order", all, err)
+	}
+
+	// Save adds a block to the day it starts on without touching the rest.
+	lunch, _ := domain.NewPlanBlock(at(12, 0), at(13, 0), "Lunch")
+	if err := repo.Save(ctx, lunch); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got, _ := repo.FindByDay(ctx, day); len(got) != 2 || got[1].ID != lunch.ID {
+		t.Errorf("FindByDay() after save = %v, want email and lunch", got)
+	}
+	if got, err := repo.FindByID(ctx, lunch.ID); err != nil || got == nil || got.Task != "Lunch" {
+		t.Errorf("FindByID() = %v, %v; want the lunch block", got, err)
+	}
+	if got, err := repo.FindByID(ctx, "missing"); err != nil || got != nil {
+		t.Errorf("FindByID(missing) = %v, %v; want nil, nil", got, err)
+	}
+}
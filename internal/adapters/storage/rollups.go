@@ -0,0 +1,359 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+// dayKeyFormat is the layout of daily_rollups.day.
+const dayKeyFormat = "2006-01-02"
+
+// queryExecer is implemented by both *sql.DB and *sql.Tx.
+type queryExecer interface {
+	execer
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// rollupKey identifies one row of daily_rollups. An empty tag is the row
+// counting every session of the day; any other tag counts the sessions
+// carrying it, directly, through their task or through a nested tag.
+type rollupKey struct {
+	day         string
+	methodology string
+	taskID      string
+	tag         string
+}
+
+// rollupRow holds the aggregates of one daily_rollups row.
+type rollupRow struct {
+	workSessions    int
+	workMs          int64
+	breaks          int
+	focusScoreSum   int64
+	focusScoreCount int
+	distractions    int
+	pauses          int
+	pausedMs        int64
+}
+
+// dayKey returns the rollup day of t: its date in the local time zone.
+func dayKey(t time.Time) string {
+	return t.In(time.Local).Format(dayKeyFormat)
+}
+
+// rollupDays returns the rollup days of [start, end) when both are local
+// midnights, which is when the range can be answered from daily_rollups.
+// Other ranges fall back to aggregating the sessions themselves.
+func rollupDays(start, end time.Time) (from, to string, ok bool) {
+	if !isLocalMidnight(start) || !isLocalMidnight(end) {
+		return "", "", false
+	}
+	return dayKey(start), dayKey(end), true
+}
+
+func isLocalMidnight(t time.Time) bool {
+	if t.Location() != time.Local {
+		return false
+	}
+	h, m, s := t.Clock()
+	return h == 0 && m == 0 && s == 0 && t.Nanosecond() == 0
+}
+
+// refreshRollups recomputes the rollups of the given days from their
+// completed sessions, in one transaction.
+func refreshRollups(ctx context.Context, db dbtx, days ...string) error {
+	days = uniqueDays(days)
+	if len(days) == 0 {
+		return nil
+	}
+	return inTx(ctx, db, func(tx dbtx) error {
+		for _, day := range days {
+			date, err := time.ParseInLocation(dayKeyFormat, day, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid rollup day %q: %w", day, err)
+			}
+			// started_at keeps the offset it was saved with, so a day's
+			// sessions are looked up with a day of margin on either side and
+			// then bucketed in Go.
+			rows, err := aggregateRollups(ctx, tx,
+				`s.started_at >= ? AND s.started_at < ?`,
+				[]interface{}{date.AddDate(0, 0, -1), date.AddDate(0, 0, 2)},
+			)
+			if err != nil {
+				return err
+			}
+			for key := range rows {
+				if key.day != day {
+					delete(rows, key)
+				}
+			}
+			if _, err := tx.ExecContext(ctx, `DELETE FROM daily_rollups WHERE day = ?`, day); err != nil {
+				return fmt.Errorf("failed to clear rollups: %w", err)
+			}
+			if err := insertRollups(ctx, tx, rows); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// rebuildRollups recomputes every rollup from scratch and returns how many
+// days have any.
+func rebuildRollups(ctx context.Context, db queryExecer) (int, error) {
+	rows, err := aggregateRollups(ctx, db, `1 = 1`, nil)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM daily_rollups`); err != nil {
+		return 0, fmt.Errorf("failed to clear rollups: %w", err)
+	}
+	if err := insertRollups(ctx, db, rows); err != nil {
+		return 0, err
+	}
+	days := map[string]bool{}
+	for key := range rows {
+		days[key.day] = true
+	}
+	return len(days), nil
+}
+
+// taskRollupDays returns the days holding completed sessions of a task.
+func taskRollupDays(ctx context.Context, db queryExecer, taskID string) ([]string, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT started_at FROM sessions WHERE task_id = ? AND status = 'completed'`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task sessions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var days []string
+	for rows.Next() {
+		var startedAt time.Time
+		if err := rows.Scan(&startedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan task session: %w", err)
+		}
+		days = append(days, dayKey(startedAt))
+	}
+	return uniqueDays(days), rows.Err()
+}
+
+// aggregateRollups computes the rollup rows of the completed sessions
+// matched by filter, a condition on the sessions table aliased s.
+func aggregateRollups(ctx context.Context, db queryExecer, filter string, args []interface{}) (map[rollupKey]*rollupRow, error) {
+	type completed struct {
+		id, taskID, kind, methodology string
+		day                           string
+		durationMs                    int64
+		focusScore                    sql.NullInt64
+		distractions                  int
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT s.id, COALESCE(s.task_id, ''), s.type, COALESCE(NULLIF(s.methodology, ''), 'pomodoro'),
+		       s.duration_ms, s.started_at, s.focus_score, COALESCE(s.distractions, '')
+		FROM sessions s
+		WHERE s.status = 'completed' AND `+filter, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions for rollups: %w", err)
+	}
+	var sessions []completed
+	for rows.Next() {
+		var c completed
+		var startedAt time.Time
+		var distractions string
+		if err := rows.Scan(&c.id, &c.taskID, &c.kind, &c.methodology,
+			&c.durationMs, &startedAt, &c.focusScore, &distractions); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan session for rollups: %w", err)
+		}
+		c.day = dayKey(startedAt)
+		c.distractions = len(unmarshalDistractions(distractions))
+		sessions = append(sessions, c)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sessionTags, err := queryTagNames(ctx, db, `
+		SELECT st.session_id, tg.name
+		FROM session_tags st
+		JOIN tags tg ON tg.id = st.tag_id
+		JOIN sessions s ON s.id = st.session_id
+		WHERE s.status = 'completed' AND `+filter, args)
+	if err != nil {
+		return nil, err
+	}
+	taskTags, err := queryTagNames(ctx, db, `
+		SELECT tt.task_id, tg.name
+		FROM task_tags tt
+		JOIN tags tg ON tg.id = tt.tag_id
+		WHERE tt.task_id IN (SELECT s.task_id FROM sessions s WHERE s.status = 'completed' AND `+filter+`)`, args)
+	if err != nil {
+		return nil, err
+	}
+	pauses, err := queryPauses(ctx, db, filter, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parents are derived from tag names, so "Client/acme" and
+	// "client/beta" share a parent spelled two ways. A tag's own spelling
+	// wins, then the first one seen.
+	spelling := map[string]string{}
+	for _, names := range []map[string][]string{sessionTags, taskTags} {
+		for _, list := range names {
+			for _, name := range list {
+				spelling[strings.ToLower(name)] = name
+			}
+		}
+	}
+	result := make(map[rollupKey]*rollupRow)
+	for _, c := range sessions {
+		tags := []string{""}
+		seen := map[string]bool{}
+		names := append(append([]string{}, sessionTags[c.id]...), taskTags[c.taskID]...)
+		for _, name := range names {
+			for _, tag := range domain.TagAncestors(name) {
+				key := strings.ToLower(tag)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				if _, ok := spelling[key]; !ok {
+					spelling[key] = tag
+				}
+				tags = append(tags, spelling[key])
+			}
+		}
+		for _, tag := range tags {
+			key := rollupKey{day: c.day, methodology: c.methodology, taskID: c.taskID, tag: tag}
+			row, ok := result[key]
+			if !ok {
+				row = &rollupRow{}
+				result[key] = row
+			}
+			if c.kind != string(domain.SessionTypeWork) {
+				row.breaks++
+				continue
+			}
+			row.workSessions++
+			row.workMs += c.durationMs
+			if c.focusScore.Valid {
+				row.focusScoreSum += c.focusScore.Int64
+				row.focusScoreCount++
+			}
+			row.distractions += c.distractions
+			p := pauses[c.id]
+			row.pauses += p.count
+			row.pausedMs += p.paused.Milliseconds()
+		}
+	}
+	return result, nil
+}
+
+// queryTagNames runs a query selecting an owner id and a tag name and
+// groups the names by owner.
+func queryTagNames(ctx context.Context, db queryExecer, query string, args []interface{}) (map[string][]string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags for rollups: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	names := map[string][]string{}
+	for rows.Next() {
+		var owner, name string
+		if err := rows.Scan(&owner, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan tag for rollups: %w", err)
+		}
+		names[owner] = append(names[owner], name)
+	}
+	return names, rows.Err()
+}
+
+type pauseSummary struct {
+	count  int
+	paused time.Duration
+}
+
+// queryPauses summarizes the pauses of the completed work sessions matched
+// by filter from their event history.
+func queryPauses(ctx context.Context, db queryExecer, filter string, args []interface{}) (map[string]pauseSummary, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT e.session_id, e.event, e.at
+		FROM session_events e
+		JOIN sessions s ON s.id = e.session_id
+		WHERE s.type = 'work' AND s.status = 'completed' AND `+filter+`
+		ORDER BY e.session_id, e.seq`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pauses for rollups: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	result := map[string]pauseSummary{}
+	var current string
+	var events []domain.SessionEvent
+	flush := func() {
+		if len(events) == 0 {
+			return
+		}
+		count, paused := domain.SummarizePauses(events, events[len(events)-1].At)
+		result[current] = pauseSummary{count: count, paused: paused}
+		events = events[:0]
+	}
+	for rows.Next() {
+		var id, event string
+		var at time.Time
+		if err := rows.Scan(&id, &event, &at); err != nil {
+			return nil, fmt.Errorf("failed to scan pauses for rollups: %w", err)
+		}
+		if id != current {
+			flush()
+			current = id
+		}
+		events = append(events, domain.SessionEvent{Type: domain.SessionEventType(event), At: at})
+	}
+	flush()
+	return result, rows.Err()
+}
+
+// insertRollups writes rollup rows.
+func insertRollups(ctx context.Context, db execer, rows map[rollupKey]*rollupRow) error {
+	for key, row := range rows {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO daily_rollups (
+				day, methodology, task_id, tag, work_sessions, work_ms, breaks,
+				focus_score_sum, focus_score_count, distractions, pauses, paused_ms
+			)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			key.day, key.methodology, key.taskID, key.tag, row.workSessions, row.workMs, row.breaks,
+			row.focusScoreSum, row.focusScoreCount, row.distractions, row.pauses, row.pausedMs,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save rollup: %w", err)
+		}
+	}
+	return nil
+}
+
+// uniqueDays returns the distinct non-empty days, sorted.
+func uniqueDays(days []string) []string {
+	seen := make(map[string]bool, len(days))
+	result := make([]string, 0, len(days))
+	for _, day := range days {
+		if day != "" && !seen[day] {
+			seen[day] = true
+			result = append(result, day)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,382 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// assertRollupsMatchRaw checks that the rollup readers agree with the raw
+// aggregates over the sessions table for [start, end).
+func assertRollupsMatchRaw(t *testing.T, store ports.Storage, start, end time.Time, tag string) {
+	t.Helper()
+	ctx := context.Background()
+	sessions := store.Sessions().(*sessionRepository)
+	tags := store.Tags().(*tagRepository)
+	from, to, ok := rollupDays(start, end)
+	if !ok {
+		t.Fatalf("rollupDays(%s, %s) not ok", start, end)
+	}
+
+	raw, err := sessions.rawPeriodStats(ctx, start, end, tag)
+	if err != nil {
+		t.Fatalf("rawPeriodStats() error = %v", err)
+	}
+	rolled, err := sessions.rollupPeriodStats(ctx, start, end, from, to, tag)
+	if err != nil {
+		t.Fatalf("rollupPeriodStats() error = %v", err)
+	}
+	if !reflect.DeepEqual(raw, rolled) {
+		t.Errorf("period stats (tag %q):\nrollups %+v\nraw     %+v", tag, rolled, raw)
+	}
+
+	rawTotals, err := sessions.rawDailyTotals(ctx, start, end)
+	if err != nil {
+		t.Fatalf("rawDailyTotals() error = %v", err)
+	}
+	rolledTotals, err := sessions.rollupDailyTotals(ctx, from, to)
+	if err != nil {
+		t.Fatalf("rollupDailyTotals() error = %v", err)
+	}
+	if len(rawTotals) != len(rolledTotals) {
+		t.Errorf("daily totals:\nrollups %+v\nraw     %+v", rolledTotals, rawTotals)
+	}
+	for i := range rawTotals {
+		if i < len(rolledTotals) && !rawTotals[i].Date.Equal(rolledTotals[i].Date) {
+			t.Errorf("daily total %d date = %s, want %s", i, rolledTotals[i].Date, rawTotals[i].Date)
+		}
+	}
+
+	rawTags, err := tags.rawStats(ctx, start, end)
+	if err != nil {
+		t.Fatalf("rawStats() error = %v", err)
+	}
+	rolledTags, err := tags.rollupStats(ctx, from, to)
+	if err != nil {
+		t.Fatalf("rollupStats() error = %v", err)
+	}
+	// A parent spelled two ways may be reported in either spelling.
+	sameTags := len(rawTags) == len(rolledTags)
+	for i := 0; sameTags && i < len(rawTags); i++ {
+		sameTags = strings.EqualFold(rawTags[i].Tag, rolledTags[i].Tag) &&
+			rawTags[i].SessionCount == rolledTags[i].SessionCount && rawTags[i].TotalTime == rolledTags[i].TotalTime
+	}
+	if !sameTags {
+		t.Errorf("tag stats:\nrollups %+v\nraw     %+v", rolledTags, rawTags)
+	}
+}
+
+func TestRollups_MatchRawAggregates(t *testing.T) {
+	store, err := NewMemory()
+	if err != nil {
+		t.Fatalf("NewMemory() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+
+	today := time.Date(2026, 3, 18, 0, 0, 0, 0, time.Local)
+	task, _ := domain.NewTask("Client work")
+	task.Tags = []string{"client/acme"}
+	if err := store.Tasks().Save(ctx, task); err != nil {
+		t.Fatalf("Save(task) error = %v", err)
+	}
+
+	save := func(daysAgo int, methodology domain.Methodology, taskID *string, tags []string, score *int, distractions int, pause time.Duration) *domain.PomodoroSession {
+		t.Helper()
+		startedAt := today.AddDate(0, 0, -daysAgo).Add(9 * time.Hour)
+		s := domain.NewPomodoroSession(domain.PomodoroConfig{WorkDuration: 50 * time.Minute}, taskID)
+		s.StartedAt = startedAt
+		s.Methodology = methodology
+		s.Tags = tags
+		s.FocusScore = score
+		for i := 0; i < distractions; i++ {
+			s.Distractions = append(s.Distractions, domain.Distraction{Text: "chat"})
+		}
+		s.Events = []domain.SessionEvent{{Type: domain.SessionEventStart, At: startedAt}}
+		if pause > 0 {
+			s.Events = append(s.Events,
+				domain.SessionEvent{Type: domain.SessionEventPause, At: startedAt.Add(10 * time.Minute)},
+				domain.SessionEvent{Type: domain.SessionEventResume, At: startedAt.Add(10*time.Minute + pause)},
+			)
+		}
+		completedAt := startedAt.Add(50*time.Minute + pause)
+		s.Status = domain.SessionStatusCompleted
+		s.CompletedAt = &completedAt
+		s.Events = append(s.Events, domain.SessionEvent{Type: domain.SessionEventStop, At: completedAt})
+		if err := store.Sessions().Save(ctx, s); err != nil {
+			t.Fatalf("Save(session) error = %v", err)
+		}
+		return s
+	}
+	score := 4
+	save(0, domain.MethodologyDeepWork, &task.ID, []string{"deep"}, nil, 2, 5*time.Minute)
+	save(0, domain.MethodologyMakeTime, nil, []string{"client/beta", "Client"}, &score, 0, 0)
+	moved := save(1, domain.MethodologyPomodoro, &task.ID, nil, nil, 1, 0)
+	save(3, domain.MethodologyPomodoro, nil, nil, nil, 0, 0)
+	running := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), &task.ID)
+	if err := store.Sessions().Save(ctx, running); err != nil {
+		t.Fatalf("Save(running) error = %v", err)
+	}
+
+	weekStart := today.AddDate(0, 0, -7)
+	tomorrow := today.AddDate(0, 0, 1)
+	for _, tag := range []string{"", "client", "client/acme", "deep"} {
+		assertRollupsMatchRaw(t, store, weekStart, tomorrow, tag)
+	}
+
+	t.Run("after moving a session to another day", func(t *testing.T) {
+		moved.StartedAt = moved.StartedAt.AddDate(0, 0, -1)
+		if err := store.Sessions().Update(ctx, moved); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		assertRollupsMatchRaw(t, store, weekStart, tomorrow, "")
+		stats, err := store.Sessions().GetDailyStats(ctx, today.AddDate(0, 0, -1))
+		if err != nil {
+			t.Fatalf("GetDailyStats() error = %v", err)
+		}
+		if stats.WorkSessions != 0 {
+			t.Errorf("old day WorkSessions = %d, want 0", stats.WorkSessions)
+		}
+	})
+
+	t.Run("after retagging the task", func(t *testing.T) {
+		task.Tags = []string{"internal"}
+		if err := store.Tasks().Update(ctx, task); err != nil {
+			t.Fatalf("Update(task) error = %v", err)
+		}
+		assertRollupsMatchRaw(t, store, weekStart, tomorrow, "internal")
+		assertRollupsMatchRaw(t, store, weekStart, tomorrow, "client")
+	})
+
+	t.Run("after renaming a tag", func(t *testing.T) {
+		if _, err := store.Tags().Rename(ctx, "client", "customer"); err != nil {
+			t.Fatalf("Rename() error = %v", err)
+		}
+		assertRollupsMatchRaw(t, store, weekStart, tomorrow, "customer")
+		assertRollupsMatchRaw(t, store, weekStart, tomorrow, "client")
+	})
+
+	t.Run("after deleting the task", func(t *testing.T) {
+		if err := store.Tasks().Delete(ctx, task.ID); err != nil {
+			t.Fatalf("Delete(task) error = %v", err)
+		}
+		assertRollupsMatchRaw(t, store, weekStart, tomorrow, "internal")
+		assertRollupsMatchRaw(t, store, weekStart, tomorrow, "")
+	})
+}
+
+func TestRollups_RebuildRestoresTable(t *testing.T) {
+	store, err := New(filepath.Join(t.TempDir(), "flow.db"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+
+	seedTagged(t, store, 25*time.Minute, []string{"client"}, []string{"deep"})
+	db := store.(*sqliteStorage).db
+	if _, err := db.Exec(`DELETE FROM daily_rollups`); err != nil {
+		t.Fatalf("clearing rollups: %v", err)
+	}
+
+	today := time.Now()
+	stats, _ := store.Sessions().GetDailyStats(ctx, today)
+	if stats.WorkSessions != 0 {
+		t.Fatalf("WorkSessions = %d with empty rollups, want 0", stats.WorkSessions)
+	}
+
+	days, err := store.RebuildRollups(ctx)
+	if err != nil {
+		t.Fatalf("RebuildRollups() error = %v", err)
+	}
+	if days != 1 {
+		t.Errorf("RebuildRollups() = %d days, want 1", days)
+	}
+	stats, _ = store.Sessions().GetDailyStats(ctx, today)
+	if stats.WorkSessions != 1 || stats.TotalWorkTime != 25*time.Minute {
+		t.Errorf("after rebuild = %d sessions, %s; want 1, 25m", stats.WorkSessions, stats.TotalWorkTime)
+	}
+	tagStats, _ := store.Tags().Stats(ctx, time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local),
+		time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.Local))
+	if len(tagStats) != 2 {
+		t.Errorf("tag stats after rebuild = %+v, want client and deep", tagStats)
+	}
+}
+
+func TestTaskRepository_FindHighlights(t *testing.T) {
+	store, err := NewMemory()
+	if err != nil {
+		t.Fatalf("NewMemory() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+
+	monday := time.Date(2026, 3, 16, 0, 0, 0, 0, time.Local)
+	for i, title := range []string{"Ship the release", "Write the report", "Next week"} {
+		task, _ := domain.NewTask(title)
+		day := monday.AddDate(0, 0, i*4).Add(8 * time.Hour)
+		task.HighlightDate = &day
+		if err := store.Tasks().Save(ctx, task); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	highlights, err := store.Tasks().FindHighlights(ctx, monday, monday.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("FindHighlights() error = %v", err)
+	}
+	if len(highlights) != 2 || highlights[0].Title != "Ship the release" || highlights[1].Title != "Write the report" {
+		t.Errorf("FindHighlights() = %v, want this week's two highlights in order", highlights)
+	}
+}
+
+// benchSessions is how many sessions BenchmarkRollups seeds.
+const benchSessions = 100_000
+
+// seedBenchStorage fills a database with benchSessions completed sessions
+// spread over the three years before today, a tenth of them tagged, and
+// rebuilds the rollups.
+func seedBenchStorage(b *testing.B) ports.Storage {
+	b.Helper()
+	store, err := New(filepath.Join(b.TempDir(), "flow.db"))
+	if err != nil {
+		b.Fatalf("New() error = %v", err)
+	}
+	db := store.(*sqliteStorage).db
+
+	tx, err := db.Begin()
+	if err != nil {
+		b.Fatalf("Begin() error = %v", err)
+	}
+	tags := []string{"client", "client/acme", "client/beta", "deep", "admin"}
+	for i, tag := range tags {
+		if _, err := tx.Exec(`INSERT INTO tags (id, name) VALUES (?, ?)`, i+1, tag); err != nil {
+			b.Fatalf("insert tag: %v", err)
+		}
+	}
+	now := time.Now()
+	for i := 0; i < 200; i++ {
+		id := fmt.Sprintf("task-%03d", i)
+		if _, err := tx.Exec(`INSERT INTO tasks (id, title, description, status, created_at, updated_at) VALUES (?, ?, '', 'pending', ?, ?)`,
+			id, fmt.Sprintf("Task %d", i), now, now); err != nil {
+			b.Fatalf("insert task: %v", err)
+		}
+		if _, err := tx.Exec(`INSERT INTO task_tags (task_id, tag_id, position) VALUES (?, ?, 0)`, id, i%len(tags)+1); err != nil {
+			b.Fatalf("insert task tag: %v", err)
+		}
+	}
+	methodologies := []string{"pomodoro", "deepwork", "maketime"}
+	for i := 0; i < benchSessions; i++ {
+		id := fmt.Sprintf("session-%06d", i)
+		startedAt := now.Add(-time.Duration(i) * 15 * time.Minute)
+		completedAt := startedAt.Add(25 * time.Minute)
+		var taskID interface{}
+		if i%3 == 0 {
+			taskID = fmt.Sprintf("task-%03d", i%200)
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO sessions (id, task_id, type, status, duration_ms, started_at, completed_at, methodology, focus_score, distractions)
+			VALUES (?, ?, 'work', 'completed', ?, ?, ?, ?, ?, '[{"Text":"chat"}]')`,
+			id, taskID, (25 * time.Minute).Milliseconds(), startedAt, completedAt, methodologies[i%3], i%5+1); err != nil {
+			b.Fatalf("insert session: %v", err)
+		}
+		if i%10 == 0 {
+			if _, err := tx.Exec(`INSERT INTO session_tags (session_id, tag_id, position) VALUES (?, ?, 0)`, id, i%len(tags)+1); err != nil {
+				b.Fatalf("insert session tag: %v", err)
+			}
+		}
+		for seq, e := range []struct {
+			event string
+			at    time.Time
+		}{{"start", startedAt}, {"stop", completedAt}} {
+			if _, err := tx.Exec(`INSERT INTO session_events (session_id, seq, event, at) VALUES (?, ?, ?, ?)`, id, seq, e.event, e.at); err != nil {
+				b.Fatalf("insert event: %v", err)
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		b.Fatalf("Commit() error = %v", err)
+	}
+	if _, err := store.RebuildRollups(context.Background()); err != nil {
+		b.Fatalf("RebuildRollups() error = %v", err)
+	}
+	return store
+}
+
+// BenchmarkRollups compares the rollup readers with the raw aggregates
+// over the sessions table they replace.
+func BenchmarkRollups(b *testing.B) {
+	store := seedBenchStorage(b)
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+	sessions := store.Sessions().(*sessionRepository)
+	tags := store.Tags().(*tagRepository)
+
+	year := domain.PeriodYear.Range(time.Now(), 0)
+	from, to, _ := rollupDays(year.Start, year.End)
+	weekStart, _, _ := domain.PeriodWeek.Bounds(time.Now())
+	heatmapStart := weekStart.AddDate(0, 0, -7*52)
+	heatmapFrom, heatmapTo, _ := rollupDays(heatmapStart, weekStart.AddDate(0, 0, 7))
+
+	benchmarks := []struct {
+		name string
+		fn   func() error
+	}{
+		{"PeriodStats/rollups", func() error {
+			_, err := sessions.rollupPeriodStats(ctx, year.Start, year.End, from, to, "")
+			return err
+		}},
+		{"PeriodStats/raw", func() error {
+			_, err := sessions.rawPeriodStats(ctx, year.Start, year.End, "")
+			return err
+		}},
+		{"TaggedPeriodStats/rollups", func() error {
+			_, err := sessions.rollupPeriodStats(ctx, year.Start, year.End, from, to, "client")
+			return err
+		}},
+		{"TaggedPeriodStats/raw", func() error {
+			_, err := sessions.rawPeriodStats(ctx, year.Start, year.End, "client")
+			return err
+		}},
+		{"DailyTotals/rollups", func() error {
+			_, err := sessions.rollupDailyTotals(ctx, heatmapFrom, heatmapTo)
+			return err
+		}},
+		{"DailyTotals/raw", func() error {
+			_, err := sessions.rawDailyTotals(ctx, heatmapStart, weekStart.AddDate(0, 0, 7))
+			return err
+		}},
+		{"TagStats/rollups", func() error {
+			_, err := tags.rollupStats(ctx, from, to)
+			return err
+		}},
+		{"TagStats/raw", func() error {
+			_, err := tags.rawStats(ctx, year.Start, year.End)
+			return err
+		}},
+		{"DeepWorkStreak", func() error {
+			_, err := sessions.GetDeepWorkStreak(ctx, time.Hour)
+			return err
+		}},
+		{"DailyStats", func() error {
+			_, err := sessions.GetDailyStats(ctx, time.Now())
+			return err
+		}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := bm.fn(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
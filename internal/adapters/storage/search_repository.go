@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// defaultSearchLimit caps results when the query does not set a limit.
+const defaultSearchLimit = 20
+
+// Search index document kinds.
+const (
+	searchKindTask    = "task"
+	searchKindSession = "session"
+)
+
+// execer is satisfied by both *sql.DB and *sql.Tx so the index helpers can
+// run inside a migration as well as from the repositories.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// searchRepository implements ports.SearchRepository on the FTS5 search_index table.
+type searchRepository struct {
+	db dbtx
+}
+
+// newSearchRepository creates a new search repository.
+func newSearchRepository(db dbtx) ports.SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// indexTask replaces the search document for a task.
+func indexTask(ctx context.Context, db execer, task *domain.Task) error {
+	return replaceSearchDocument(ctx, db, searchKindTask, task.ID, task.Title, task.Description)
+}
+
+// indexSession replaces the search document for a session.
+func indexSession(ctx context.Context, db execer, session *domain.PomodoroSession) error {
+	parts := []string{session.Notes, session.Accomplishment, session.EnergizeActivity}
+	for _, d := range session.Distractions {
+		parts = append(parts, d.Text)
+	}
+	if r := session.ShutdownRitual; r != nil {
+		parts = append(parts, r.PendingTasksReview, r.CalendarReview, r.TomorrowPlan, r.ClosingPhrase)
+	}
+	return replaceSearchDocument(ctx, db, searchKindSession, session.ID, session.IntendedOutcome, joinNonEmpty(parts))
+}
+
+// unindex removes the search document for a task or session.
+func unindex(ctx context.Context, db execer, kind, id string) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM search_index WHERE kind = ? AND ref_id = ?`, kind, id); err != nil {
+		return fmt.Errorf("failed to remove %s %s from search index: %w", kind, id, err)
+	}
+	return nil
+}
+
+func replaceSearchDocument(ctx context.Context, db execer, kind, id, title, body string) error {
+	if err := unindex(ctx, db, kind, id); err != nil {
+		return err
+	}
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO search_index (kind, ref_id, title, body) VALUES (?, ?, ?, ?)`,
+		kind, id, title, body,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to index %s %s: %w", kind, id, err)
+	}
+	return nil
+}
+
+// joinNonEmpty joins the non-blank parts with newlines.
+func joinNonEmpty(parts []string) string {
+	var kept []string
+	for _, p := range parts {
+		if strings.TrimSpace(p) != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// ftsQuery turns free text into an FTS5 query that matches every word as a
+// prefix. Each word is quoted so punctuation in user input is never parsed
+// as FTS5 syntax.
+func ftsQuery(text string) string {
+	words := strings.Fields(text)
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		terms = append(terms, `"`+strings.ReplaceAll(w, `"`, `""`)+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
+
+// Search returns the best matches for the query, most relevant first.
+func (r *searchRepository) Search(ctx context.Context, q ports.SearchQuery) ([]ports.SearchResult, error) {
+	match := ftsQuery(q.Text)
+	if match == "" {
+		return nil, nil
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	// Sessions are joined to their task so hits show the task title and can
+	// be filtered by either the session's or the task's tags.
+	query := `
+		SELECT
+			search_index.kind, search_index.ref_id, search_index.title,
+			snippet(search_index, -1, ?, ?, '…', 12),
+			s.started_at, t.created_at, t.title,
+			` + tagListSQL(sessionTagsTable, sessionTagsOwner, "s.id") + `,
+			` + tagListSQL(taskTagsTable, taskTagsOwner, "t.id") + `
+		FROM search_index
+		LEFT JOIN sessions s ON search_index.kind = 'session' AND s.id = search_index.ref_id
+		LEFT JOIN tasks t ON t.id = CASE WHEN search_index.kind = 'task' THEN search_index.ref_id ELSE s.task_id END
+		WHERE search_index MATCH ?
+	`
+	args := []interface{}{ports.SearchMatchMarker, ports.SearchMatchMarker, match}
+
+	if !q.Since.IsZero() {
+		query += ` AND ((search_index.kind = 'session' AND s.started_at >= ?) OR (search_index.kind = 'task' AND t.created_at >= ?))`
+		args = append(args, q.Since, q.Since)
+	}
+	if q.Tag != "" {
+		query += ` AND ` + sessionTagCondition("s.id", "t.id")
+		args = append(args, tagConditionArgs(q.Tag)...)
+	}
+	query += ` ORDER BY bm25(search_index) LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []ports.SearchResult
+	for rows.Next() {
+		var res ports.SearchResult
+		var docTitle string
+		var startedAt, createdAt sql.NullTime
+		var taskTitle, sessionTags, taskTags sql.NullString
+		if err := rows.Scan(
+			&res.Kind, &res.ID, &docTitle, &res.Snippet,
+			&startedAt, &createdAt, &taskTitle, &sessionTags, &taskTags,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+
+		tags := taskTags.String
+		var date time.Time
+		if res.Kind == searchKindSession {
+			tags = sessionTags.String
+			date = startedAt.Time
+		} else {
+			date = createdAt.Time
+		}
+		res.Date = date
+		res.Tags = decodeTags(tags)
+
+		res.Title = taskTitle.String
+		if res.Title == "" {
+			res.Title = docTitle
+		}
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
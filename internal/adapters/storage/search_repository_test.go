@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+func TestSearchRepository_IndexStaysInSync(t *testing.T) {
+	store, err := NewMemory()
+	if err != nil {
+		t.Fatalf("NewMemory() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+
+	task, _ := domain.NewTask("Refactor billing module")
+	task.Description = "Split invoices from payments"
+	if err := store.Tasks().Save(ctx, task); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	session := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), &task.ID)
+	session.IntendedOutcome = "Extract invoice renderer"
+	if err := store.Sessions().Save(ctx, session); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	results, err := store.Search().Search(ctx, ports.SearchQuery{Text: "invoice"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2 (task and session)", len(results))
+	}
+	for _, r := range results {
+		if r.Title != "Refactor billing module" {
+			t.Errorf("%s result title = %q, want the task title", r.Kind, r.Title)
+		}
+		if !strings.Contains(r.Snippet, ports.SearchMatchMarker) {
+			t.Errorf("%s snippet %q has no highlighted match", r.Kind, r.Snippet)
+		}
+	}
+
+	// Text added on update is searchable; text removed is not.
+	session.Distractions = []domain.Distraction{{Text: "pager alert"}}
+	session.ShutdownRitual = &domain.ShutdownRitual{TomorrowPlan: "deploy to staging"}
+	session.Accomplishment = "renderer extracted"
+	if err := store.Sessions().Update(ctx, session); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	for _, q := range []string{"pager", "staging", "extracted"} {
+		results, err := store.Search().Search(ctx, ports.SearchQuery{Text: q})
+		if err != nil {
+			t.Fatalf("Search(%q) error = %v", q, err)
+		}
+		if len(results) != 1 || results[0].ID != session.ID {
+			t.Errorf("Search(%q) = %+v, want the session", q, results)
+		}
+	}
+
+	if err := store.Tasks().Delete(ctx, task.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	results, err = store.Search().Search(ctx, ports.SearchQuery{Text: "billing"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("deleted task still found: %+v", results)
+	}
+}
+
+func TestSearchRepository_Filters(t *testing.T) {
+	store, err := NewMemory()
+	if err != nil {
+		t.Fatalf("NewMemory() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+	config := domain.DefaultPomodoroConfig()
+
+	old := domain.NewPomodoroSession(config, nil)
+	old.StartedAt = time.Now().AddDate(0, 0, -30)
+	old.Notes = "flaky test in CI"
+	old.Tags = []string{"backend"}
+	recent := domain.NewPomodoroSession(config, nil)
+	recent.Notes = "flaky test again"
+	recent.Tags = []string{"frontend"}
+	for _, s := range []*domain.PomodoroSession{old, recent} {
+		if err := store.Sessions().Save(ctx, s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query ports.SearchQuery
+		want  []string
+	}{
+		{"all", ports.SearchQuery{Text: "flaky"}, []string{old.ID, recent.ID}},
+		{"since", ports.SearchQuery{Text: "flaky", Since: time.Now().AddDate(0, 0, -7)}, []string{recent.ID}},
+		{"tag", ports.SearchQuery{Text: "flaky", Tag: "backend"}, []string{old.ID}},
+		{"prefix", ports.SearchQuery{Text: "fla"}, []string{old.ID, recent.ID}},
+		{"every word must match", ports.SearchQuery{Text: "flaky again"}, []string{recent.ID}},
+		{"punctuation is not syntax", ports.SearchQuery{Text: `flaky" OR (ci`}, nil},
+		{"limit", ports.SearchQuery{Text: "flaky", Limit: 1}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.Search().Search(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if tt.name == "limit" {
+				if len(results) != 1 {
+					t.Errorf("got %d results, want 1", len(results))
+				}
+				return
+			}
+			got := map[string]bool{}
+			for _, r := range results {
+				got[r.ID] = true
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d results, want %d", len(got), len(tt.want))
+			}
+			for _, id := range tt.want {
+				if !got[id] {
+					t.Errorf("missing result %s", id)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskRepository_FindByTitle(t *testing.T) {
+	store, err := NewMemory()
+	if err != nil {
+		t.Fatalf("NewMemory() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+
+	for _, title := range []string{"Write quarterly report", "Review pull requests"} {
+		task, _ := domain.NewTask(title)
+		if err := store.Tasks().Save(ctx, task); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	found, err := store.Tasks().FindByTitle(ctx, "quarter")
+	if err != nil {
+		t.Fatalf("FindByTitle() error = %v", err)
+	}
+	if len(found) != 1 || found[0].Title != "Write quarterly report" {
+		t.Errorf("FindByTitle(quarter) = %v, want the report task", found)
+	}
+
+	// Typos fall back to fuzzy matching.
+	found, err = store.Tasks().FindByTitle(ctx, "rvwpr")
+	if err != nil {
+		t.Fatalf("FindByTitle() error = %v", err)
+	}
+	if len(found) != 1 || found[0].Title != "Review pull requests" {
+		t.Errorf("FindByTitle(rvwpr) = %v, want the review task", found)
+	}
+}
+
+func TestMigrate_BackfillsSearchIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flow.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	ctx := context.Background()
+	if err := runMigrations(ctx, db, migrations[:2]); err != nil {
+		t.Fatalf("runMigrations() error = %v", err)
+	}
+	_, err = db.Exec(`
+		INSERT INTO sessions (id, type, status, duration_ms, started_at, notes, distractions)
+		VALUES ('s1', 'work', 'completed', 1500000, ?, 'old notes', '[{"Text":"doorbell","Category":"external"}]')
+	`, time.Now())
+	if err != nil {
+		t.Fatalf("insert error = %v", err)
+	}
+	_ = db.Close()
+
+	store, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	results, err := store.Search().Search(ctx, ports.SearchQuery{Text: "doorbell"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "s1" {
+		t.Errorf("Search(doorbell) = %+v, want backfilled session s1", results)
+	}
+}
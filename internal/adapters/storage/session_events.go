@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+// eventBatchSize bounds the number of session ids per events query.
+const eventBatchSize = 500
+
+// saveSessionEvents appends the session's events that are not stored yet.
+// Events are never rewritten: seq is the event's index in session.Events.
+func saveSessionEvents(ctx context.Context, db execer, session *domain.PomodoroSession) error {
+	for i, e := range session.Events {
+		_, err := db.ExecContext(ctx,
+			`INSERT OR IGNORE INTO session_events (session_id, seq, event, at) VALUES (?, ?, ?, ?)`,
+			session.ID, i, string(e.Type), e.At,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save session event: %w", err)
+		}
+	}
+	return nil
+}
+
+// attachSessionEvents loads the event history of each session.
+func attachSessionEvents(ctx context.Context, db queryExecer, sessions ...*domain.PomodoroSession) error {
+	byID := make(map[string]*domain.PomodoroSession, len(sessions))
+	ids := make([]interface{}, 0, len(sessions))
+	for _, s := range sessions {
+		if s == nil {
+			continue
+		}
+		byID[s.ID] = s
+		ids = append(ids, s.ID)
+	}
+
+	for start := 0; start < len(ids); start += eventBatchSize {
+		batch := ids[start:min(start+eventBatchSize, len(ids))]
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(batch)), ",")
+		err := scanSessionEvents(ctx, db, byID,
+			`SELECT session_id, event, at FROM session_events WHERE session_id IN (`+placeholders+`) ORDER BY session_id, seq`,
+			batch...,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attachAllSessionEvents loads the event history of sessions holding every
+// stored session, reading the events table in one pass instead of batches.
+func attachAllSessionEvents(ctx context.Context, db queryExecer, sessions []*domain.PomodoroSession) error {
+	byID := make(map[string]*domain.PomodoroSession, len(sessions))
+	for _, s := range sessions {
+		byID[s.ID] = s
+	}
+	return scanSessionEvents(ctx, db, byID, `SELECT session_id, event, at FROM session_events ORDER BY session_id, seq`)
+}
+
+// scanSessionEvents appends the events selected by query to their session
+// in byID, skipping events of other sessions.
+func scanSessionEvents(ctx context.Context, db queryExecer, byID map[string]*domain.PomodoroSession, query string, args ...interface{}) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query session events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id, event string
+		var at time.Time
+		if err := rows.Scan(&id, &event, &at); err != nil {
+			return fmt.Errorf("failed to scan session event: %w", err)
+		}
+		if s, ok := byID[id]; ok {
+			s.Events = append(s.Events, domain.SessionEvent{Type: domain.SessionEventType(event), At: at})
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+func eventTypes(events []domain.SessionEvent) []domain.SessionEventType {
+	var types []domain.SessionEventType
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestSessionRepository_EventsAndPauseStats(t *testing.T) {
+	store, err := NewMemory()
+	if err != nil {
+		t.Fatalf("NewMemory() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	session := domain.NewPomodoroSession(domain.PomodoroConfig{WorkDuration: 25 * time.Minute}, nil)
+	session.StartedAt = start
+	session.Events = []domain.SessionEvent{{Type: domain.SessionEventStart, At: start}}
+	if err := store.Sessions().Save(ctx, session); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Two pauses of 5 and 10 minutes, then the session completes.
+	session.Events = append(session.Events,
+		domain.SessionEvent{Type: domain.SessionEventPause, At: start.Add(5 * time.Minute)},
+		domain.SessionEvent{Type: domain.SessionEventResume, At: start.Add(10 * time.Minute)},
+		domain.SessionEvent{Type: domain.SessionEventPause, At: start.Add(20 * time.Minute)},
+		domain.SessionEvent{Type: domain.SessionEventResume, At: start.Add(30 * time.Minute)},
+		domain.SessionEvent{Type: domain.SessionEventStop, At: start.Add(40 * time.Minute)},
+	)
+	completed := start.Add(40 * time.Minute)
+	session.Status = domain.SessionStatusCompleted
+	session.CompletedAt = &completed
+	if err := store.Sessions().Update(ctx, session); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err := store.Sessions().FindByID(ctx, session.ID)
+	if err != nil || got == nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	want := []domain.SessionEventType{
+		domain.SessionEventStart, domain.SessionEventPause, domain.SessionEventResume,
+		domain.SessionEventPause, domain.SessionEventResume, domain.SessionEventStop,
+	}
+	if !reflect.DeepEqual(eventTypes(got.Events), want) {
+		t.Errorf("events = %v, want %v", eventTypes(got.Events), want)
+	}
+	if elapsed := got.ElapsedTime(); elapsed != 25*time.Minute {
+		t.Errorf("ElapsedTime() = %v, want 25m", elapsed)
+	}
+
+	recent, err := store.Sessions().FindRecent(ctx, start.Add(-time.Minute))
+	if err != nil || len(recent) != 1 {
+		t.Fatalf("FindRecent() = %d sessions, error = %v", len(recent), err)
+	}
+	if len(recent[0].Events) != len(want) {
+		t.Errorf("FindRecent() events = %d, want %d", len(recent[0].Events), len(want))
+	}
+
+	stats, err := store.Sessions().GetPeriodStats(ctx, start.Add(-time.Minute), time.Now())
+	if err != nil {
+		t.Fatalf("GetPeriodStats() error = %v", err)
+	}
+	if stats.PauseCount != 2 || stats.PausedTime != 15*time.Minute {
+		t.Errorf("GetPeriodStats() pauses = %d, %v; want 2, 15m", stats.PauseCount, stats.PausedTime)
+	}
+}
+
+func TestMigrate_BackfillsSessionEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flow.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	ctx := context.Background()
+	if err := runMigrations(ctx, db, migrations[:4]); err != nil {
+		t.Fatalf("runMigrations() error = %v", err)
+	}
+	now := time.Now().Truncate(time.Second)
+	_, err = db.Exec(`
+		INSERT INTO sessions (id, type, status, duration_ms, started_at, paused_at, completed_at, git_branch, git_commit, git_modified)
+		VALUES ('done', 'work', 'completed', 1500000, ?, NULL, ?, '', '', ''),
+		       ('held', 'work', 'paused', 1500000, ?, ?, NULL, '', '', '');
+	`, now.Add(-time.Hour), now.Add(-35*time.Minute), now.Add(-10*time.Minute), now.Add(-5*time.Minute))
+	if err != nil {
+		t.Fatalf("insert error = %v", err)
+	}
+	_ = db.Close()
+
+	store, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	done, err := store.Sessions().FindByID(ctx, "done")
+	if err != nil || done == nil {
+		t.Fatalf("FindByID(done) error = %v", err)
+	}
+	if want := []domain.SessionEventType{domain.SessionEventStart, domain.SessionEventStop}; !reflect.DeepEqual(eventTypes(done.Events), want) {
+		t.Errorf("completed session events = %v, want %v", eventTypes(done.Events), want)
+	}
+
+	held, err := store.Sessions().FindByID(ctx, "held")
+	if err != nil || held == nil {
+		t.Fatalf("FindByID(held) error = %v", err)
+	}
+	if want := []domain.SessionEventType{domain.SessionEventStart, domain.SessionEventPause}; !reflect.DeepEqual(eventTypes(held.Events), want) {
+		t.Errorf("paused session events = %v, want %v", eventTypes(held.Events), want)
+	}
+	if elapsed := held.ElapsedTime(); elapsed != 5*time.Minute {
+		t.Errorf("paused session ElapsedTime() = %v, want 5m", elapsed)
+	}
+}
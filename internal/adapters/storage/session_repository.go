@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,13 +13,22 @@ import (
 	"github.com/xvierd/flow-cli/internal/ports"
 )
 
+// sessionColumns is the select list read by scanSession and scanSessions.
+// Tags come from the session_tags join table as a JSON array.
+var sessionColumns = `id, task_id, type, status, duration_ms, started_at, paused_at,
+			completed_at, git_branch, git_commit, git_modified, notes,
+			methodology, focus_score, distractions, accomplishment, intended_outcome, ` +
+	tagListSQL(sessionTagsTable, sessionTagsOwner, "sessions.id") + `,
+			energize_activity, shutdown_ritual, outcome_achieved, break_after_ms,
+			driver, navigator, participants, open_ended`
+
 // sessionRepository implements ports.SessionRepository using SQLite.
 type sessionRepository struct {
-	db *sql.DB
+	db dbtx
 }
 
 // newSessionRepository creates a new session repository.
-func newSessionRepository(db *sql.DB) ports.SessionRepository {
+func newSessionRepository(db dbtx) ports.SessionRepository {
 	return &sessionRepository{db: db}
 }
 
@@ -28,10 +38,11 @@ func (r *sessionRepository) Save(ctx context.Context, session *domain.PomodoroSe
 		INSERT INTO sessions (
 			id, task_id, type, status, duration_ms, started_at, paused_at,
 			completed_at, git_branch, git_commit, git_modified, notes,
-			methodology, focus_score, distractions, accomplishment, intended_outcome, tags,
-			energize_activity, shutdown_ritual, outcome_achieved
+			methodology, focus_score, distractions, accomplishment, intended_outcome,
+			energize_activity, shutdown_ritual, outcome_achieved, break_after_ms,
+			driver, navigator, participants, open_ended
 		)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	modified := strings.Join(session.GitModified, ",")
@@ -40,42 +51,59 @@ func (r *sessionRepository) Save(ctx context.Context, session *domain.PomodoroSe
 	if methodology == "" {
 		methodology = string(domain.MethodologyPomodoro)
 	}
-	tags := strings.Join(session.Tags, ",")
 
 	var shutdownRitualJSON []byte
 	if session.ShutdownRitual != nil {
 		shutdownRitualJSON, _ = json.Marshal(session.ShutdownRitual)
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
-		session.ID,
-		session.TaskID,
-		string(session.Type),
-		string(session.Status),
-		session.Duration.Milliseconds(),
-		session.StartedAt,
-		session.PausedAt,
-		session.CompletedAt,
-		session.GitBranch,
-		session.GitCommit,
-		modified,
-		session.Notes,
-		methodology,
-		session.FocusScore,
-		string(distractionsJSON),
-		session.Accomplishment,
-		session.IntendedOutcome,
-		tags,
-		session.EnergizeActivity,
-		nullableString(shutdownRitualJSON),
-		session.OutcomeAchieved,
-	)
+	return inTx(ctx, r.db, func(tx dbtx) error {
+		_, err := tx.ExecContext(ctx, query,
+			session.ID,
+			session.TaskID,
+			string(session.Type),
+			string(session.Status),
+			session.Duration.Milliseconds(),
+			session.StartedAt,
+			session.PausedAt,
+			session.CompletedAt,
+			session.GitBranch,
+			session.GitCommit,
+			modified,
+			session.Notes,
+			methodology,
+			session.FocusScore,
+			string(distractionsJSON),
+			session.Accomplishment,
+			session.IntendedOutcome,
+			session.EnergizeActivity,
+			nullableString(shutdownRitualJSON),
+			session.OutcomeAchieved,
+			session.BreakAfter.Milliseconds(),
+			session.Driver,
+			session.Navigator,
+			encodeParticipants(session.Participants),
+			session.OpenEnded,
+		)
 
-	if err != nil {
-		return fmt.Errorf("failed to save session: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("failed to save session: %w", err)
+		}
 
-	return nil
+		if err := replaceTags(ctx, tx, sessionTagsTable, sessionTagsOwner, session.ID, session.Tags); err != nil {
+			return err
+		}
+		if err := saveSessionEvents(ctx, tx, session); err != nil {
+			return err
+		}
+		if err := indexSession(ctx, tx, session); err != nil {
+			return err
+		}
+		if session.Status == domain.SessionStatusCompleted {
+			return refreshRollups(ctx, tx, dayKey(session.StartedAt))
+		}
+		return nil
+	})
 }
 
 // nullableString returns a *string from bytes, or nil if empty.
@@ -87,6 +115,27 @@ func nullableString(b []byte) *string {
 	return &s
 }
 
+// encodeParticipants stores a mob roster as a JSON array, or "" for none.
+func encodeParticipants(participants []string) string {
+	if len(participants) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(participants)
+	return string(data)
+}
+
+// decodeParticipants reads a roster stored by encodeParticipants.
+func decodeParticipants(data string) []string {
+	if data == "" {
+		return nil
+	}
+	var participants []string
+	if err := json.Unmarshal([]byte(data), &participants); err != nil {
+		return nil
+	}
+	return participants
+}
+
 // unmarshalDistractions deserializes distractions with backward compat for old string format.
 func unmarshalDistractions(data string) []domain.Distraction {
 	if data == "" {
@@ -112,66 +161,78 @@ func unmarshalDistractions(data string) []domain.Distraction {
 func (r *sessionRepository) FindByID(ctx context.Context, id string) (*domain.PomodoroSession, error) {
 	query := `
 		SELECT
-			id, task_id, type, status, duration_ms, started_at, paused_at,
-			completed_at, git_branch, git_commit, git_modified, notes,
-			methodology, focus_score, distractions, accomplishment, intended_outcome, tags,
-			energize_activity, shutdown_ritual, outcome_achieved
+			` + sessionColumns + `
 		FROM sessions
 		WHERE id = ?
 	`
 
-	return r.scanSession(r.db.QueryRowContext(ctx, query, id))
+	return r.scanSessionWithEvents(ctx, r.db.QueryRowContext(ctx, query, id))
 }
 
 // FindActive retrieves the currently running or paused session.
 func (r *sessionRepository) FindActive(ctx context.Context) (*domain.PomodoroSession, error) {
 	query := `
 		SELECT
-			id, task_id, type, status, duration_ms, started_at, paused_at,
-			completed_at, git_branch, git_commit, git_modified, notes,
-			methodology, focus_score, distractions, accomplishment, intended_outcome, tags,
-			energize_activity, shutdown_ritual, outcome_achieved
+			` + sessionColumns + `
 		FROM sessions
 		WHERE status IN (?, ?)
 		ORDER BY started_at DESC
 		LIMIT 1
 	`
 
-	return r.scanSession(r.db.QueryRowContext(ctx, query,
+	return r.scanSessionWithEvents(ctx, r.db.QueryRowContext(ctx, query,
 		string(domain.SessionStatusRunning),
 		string(domain.SessionStatusPaused)))
 }
 
-// FindRecent retrieves sessions within a time range.
+// scanSessionWithEvents scans a single session row and loads its event history.
+func (r *sessionRepository) scanSessionWithEvents(ctx context.Context, row *sql.Row) (*domain.PomodoroSession, error) {
+	session, err := r.scanSession(row)
+	if err != nil || session == nil {
+		return session, err
+	}
+	if err := attachSessionEvents(ctx, r.db, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// FindRecent retrieves sessions within a time range. A zero since returns
+// every session.
 func (r *sessionRepository) FindRecent(ctx context.Context, since time.Time) ([]*domain.PomodoroSession, error) {
+	filter, args := "WHERE started_at >= ?", []interface{}{since}
+	if since.IsZero() {
+		filter, args = "", nil
+	}
 	query := `
 		SELECT
-			id, task_id, type, status, duration_ms, started_at, paused_at,
-			completed_at, git_branch, git_commit, git_modified, notes,
-			methodology, focus_score, distractions, accomplishment, intended_outcome, tags,
-			energize_activity, shutdown_ritual, outcome_achieved
+			` + sessionColumns + `
 		FROM sessions
-		WHERE started_at >= ?
+		` + filter + `
 		ORDER BY started_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, since)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query recent sessions: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	return r.scanSessions(rows)
+	sessions, err := r.scanSessions(rows)
+	if err != nil {
+		return nil, err
+	}
+	if since.IsZero() {
+		return sessions, attachAllSessionEvents(ctx, r.db, sessions)
+	}
+	return sessions, attachSessionEvents(ctx, r.db, sessions...)
 }
 
 // FindByTask retrieves all sessions associated with a task.
 func (r *sessionRepository) FindByTask(ctx context.Context, taskID string) ([]*domain.PomodoroSession, error) {
 	query := `
 		SELECT
-			id, task_id, type, status, duration_ms, started_at, paused_at,
-			completed_at, git_branch, git_commit, git_modified, notes,
-			methodology, focus_score, distractions, accomplishment, intended_outcome, tags,
-			energize_activity, shutdown_ritual, outcome_achieved
+			` + sessionColumns + `
 		FROM sessions
 		WHERE task_id = ?
 		ORDER BY started_at DESC
@@ -183,19 +244,43 @@ func (r *sessionRepository) FindByTask(ctx context.Context, taskID string) ([]*d
 	}
 	defer func() { _ = rows.Close() }()
 
-	return r.scanSessions(rows)
+	sessions, err := r.scanSessions(rows)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, attachSessionEvents(ctx, r.db, sessions...)
 }
 
 // Update modifies an existing session.
 func (r *sessionRepository) Update(ctx context.Context, session *domain.PomodoroSession) error {
+	updated, err := r.update(ctx, session, false)
+	if err == nil && !updated {
+		err = fmt.Errorf("session not found: %s", session.ID)
+	}
+	return err
+}
+
+// Finish saves a session that has just ended, if it is still active in
+// storage.
+func (r *sessionRepository) Finish(ctx context.Context, session *domain.PomodoroSession) (bool, error) {
+	return r.update(ctx, session, true)
+}
+
+// update writes session over its stored row, only while that row is
+// running or paused when activeOnly is set, and reports whether it did.
+func (r *sessionRepository) update(ctx context.Context, session *domain.PomodoroSession, activeOnly bool) (bool, error) {
 	query := `
 		UPDATE sessions
 		SET task_id = ?, type = ?, status = ?, duration_ms = ?, started_at = ?,
 		    paused_at = ?, completed_at = ?, git_branch = ?, git_commit = ?, git_modified = ?, notes = ?,
 		    methodology = ?, focus_score = ?, distractions = ?, accomplishment = ?, intended_outcome = ?,
-		    tags = ?, energize_activity = ?, shutdown_ritual = ?, outcome_achieved = ?
+		    energize_activity = ?, shutdown_ritual = ?, outcome_achieved = ?, break_after_ms = ?,
+		    driver = ?, navigator = ?, participants = ?, open_ended = ?
 		WHERE id = ?
 	`
+	if activeOnly {
+		query += ` AND status IN ('running', 'paused')`
+	}
 
 	modified := strings.Join(session.GitModified, ",")
 	distractionsJSON, _ := json.Marshal(session.Distractions)
@@ -203,53 +288,84 @@ func (r *sessionRepository) Update(ctx context.Context, session *domain.Pomodoro
 	if methodology == "" {
 		methodology = string(domain.MethodologyPomodoro)
 	}
-	tags := strings.Join(session.Tags, ",")
 
 	var shutdownRitualJSON []byte
 	if session.ShutdownRitual != nil {
 		shutdownRitualJSON, _ = json.Marshal(session.ShutdownRitual)
 	}
 
-	result, err := r.db.ExecContext(ctx, query,
-		session.TaskID,
-		session.Type,
-		session.Status,
-		session.Duration.Milliseconds(),
-		session.StartedAt,
-		session.PausedAt,
-		session.CompletedAt,
-		session.GitBranch,
-		session.GitCommit,
-		modified,
-		session.Notes,
-		methodology,
-		session.FocusScore,
-		string(distractionsJSON),
-		session.Accomplishment,
-		session.IntendedOutcome,
-		tags,
-		session.EnergizeActivity,
-		nullableString(shutdownRitualJSON),
-		session.OutcomeAchieved,
-		session.ID,
-	)
+	var updated bool
+	err := inTx(ctx, r.db, func(tx dbtx) error {
+		// A completed session moved to another day leaves its old day's
+		// rollups behind, so both days are refreshed.
+		var stale []string
+		var prevStartedAt time.Time
+		var prevStatus string
+		err := tx.QueryRowContext(ctx, `SELECT started_at, status FROM sessions WHERE id = ?`, session.ID).
+			Scan(&prevStartedAt, &prevStatus)
+		if err == nil && domain.SessionStatus(prevStatus) == domain.SessionStatusCompleted {
+			stale = append(stale, dayKey(prevStartedAt))
+		}
 
-	if err != nil {
-		return fmt.Errorf("failed to update session: %w", err)
-	}
+		result, err := tx.ExecContext(ctx, query,
+			session.TaskID,
+			session.Type,
+			session.Status,
+			session.Duration.Milliseconds(),
+			session.StartedAt,
+			session.PausedAt,
+			session.CompletedAt,
+			session.GitBranch,
+			session.GitCommit,
+			modified,
+			session.Notes,
+			methodology,
+			session.FocusScore,
+			string(distractionsJSON),
+			session.Accomplishment,
+			session.IntendedOutcome,
+			session.EnergizeActivity,
+			nullableString(shutdownRitualJSON),
+			session.OutcomeAchieved,
+			session.BreakAfter.Milliseconds(),
+			session.Driver,
+			session.Navigator,
+			encodeParticipants(session.Participants),
+			session.OpenEnded,
+			session.ID,
+		)
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("session not found: %s", session.ID)
-	}
+		if err != nil {
+			return fmt.Errorf("failed to update session: %w", err)
+		}
+
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected == 0 {
+			return nil
+		}
 
-	return nil
+		if err := replaceTags(ctx, tx, sessionTagsTable, sessionTagsOwner, session.ID, session.Tags); err != nil {
+			return err
+		}
+		if err := saveSessionEvents(ctx, tx, session); err != nil {
+			return err
+		}
+		if err := indexSession(ctx, tx, session); err != nil {
+			return err
+		}
+		if session.Status == domain.SessionStatusCompleted {
+			stale = append(stale, dayKey(session.StartedAt))
+		}
+		updated = true
+		return refreshRollups(ctx, tx, stale...)
+	})
+	return updated, err
 }
 
 // GetDailyStats returns aggregated statistics for a specific date.
 func (r *sessionRepository) GetDailyStats(ctx context.Context, date time.Time) (*domain.DailyStats, error) {
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
+	endOfDay := startOfDay.AddDate(0, 0, 1)
 
 	query := `
 		SELECT 
@@ -259,13 +375,22 @@ func (r *sessionRepository) GetDailyStats(ctx context.Context, date time.Time) (
 		FROM sessions
 		WHERE started_at >= ? AND started_at < ?
 	`
+	args := []interface{}{startOfDay, endOfDay}
+	if from, to, ok := rollupDays(startOfDay, endOfDay); ok {
+		query = `
+			SELECT COALESCE(SUM(work_sessions), 0), COALESCE(SUM(breaks), 0), COALESCE(SUM(work_ms), 0)
+			FROM daily_rollups
+			WHERE tag = '' AND day >= ? AND day < ?
+		`
+		args = []interface{}{from, to}
+	}
 
 	stats := &domain.DailyStats{
 		Date: startOfDay,
 	}
 
 	var totalWorkMs int64
-	err := r.db.QueryRowContext(ctx, query, startOfDay, endOfDay).Scan(
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
 		&stats.WorkSessions,
 		&stats.BreaksTaken,
 		&totalWorkMs,
@@ -282,11 +407,93 @@ func (r *sessionRepository) GetDailyStats(ctx context.Context, date time.Time) (
 
 // GetPeriodStats returns aggregated statistics for a time range.
 func (r *sessionRepository) GetPeriodStats(ctx context.Context, start, end time.Time) (*domain.PeriodStats, error) {
+	return r.periodStats(ctx, start, end, "")
+}
+
+// GetTaggedPeriodStats returns aggregated statistics for a time range,
+// counting only sessions tagged with tag or a tag nested beneath it,
+// either directly or through their task.
+func (r *sessionRepository) GetTaggedPeriodStats(ctx context.Context, start, end time.Time, tag string) (*domain.PeriodStats, error) {
+	return r.periodStats(ctx, start, end, tag)
+}
+
+// periodStats aggregates completed work sessions in [start, end), optionally
+// restricted to a tag.
+func (r *sessionRepository) periodStats(ctx context.Context, start, end time.Time, tag string) (*domain.PeriodStats, error) {
+	if from, to, ok := rollupDays(start, end); ok {
+		return r.rollupPeriodStats(ctx, start, end, from, to, tag)
+	}
+	return r.rawPeriodStats(ctx, start, end, tag)
+}
+
+// rollupPeriodStats reads period stats from daily_rollups for the days
+// from (inclusive) to to (exclusive).
+func (r *sessionRepository) rollupPeriodStats(ctx context.Context, start, end time.Time, from, to, tag string) (*domain.PeriodStats, error) {
+	stats := &domain.PeriodStats{
+		Start: start,
+		End:   end,
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT methodology, SUM(work_sessions), SUM(work_ms), SUM(focus_score_sum), SUM(focus_score_count),
+		       SUM(distractions), SUM(pauses), SUM(paused_ms)
+		FROM daily_rollups
+		WHERE tag = ? AND day >= ? AND day < ?
+		GROUP BY methodology
+		HAVING SUM(work_sessions) > 0
+		ORDER BY methodology
+	`, domain.NormalizeTag(tag), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query period stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var scoreSum int64
+	for rows.Next() {
+		var methodology string
+		var cnt, scoreCount, distractions, pauses int
+		var workMs, methScoreSum, pausedMs int64
+		if err := rows.Scan(&methodology, &cnt, &workMs, &methScoreSum, &scoreCount,
+			&distractions, &pauses, &pausedMs); err != nil {
+			return nil, fmt.Errorf("failed to scan period stats: %w", err)
+		}
+		stats.ByMethodology = append(stats.ByMethodology, domain.MethodologyBreakdown{
+			Methodology:  domain.Methodology(methodology),
+			SessionCount: cnt,
+			TotalTime:    time.Duration(workMs) * time.Millisecond,
+		})
+		stats.TotalSessions += cnt
+		stats.TotalWorkTime += time.Duration(workMs) * time.Millisecond
+		scoreSum += methScoreSum
+		stats.FocusScoreCount += scoreCount
+		stats.DistractionCount += distractions
+		stats.PauseCount += pauses
+		stats.PausedTime += time.Duration(pausedMs) * time.Millisecond
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if stats.FocusScoreCount > 0 {
+		stats.AvgFocusScore = float64(scoreSum) / float64(stats.FocusScoreCount)
+	}
+	return stats, nil
+}
+
+// rawPeriodStats aggregates period stats from the sessions table, for
+// ranges that don't fall on local day boundaries.
+func (r *sessionRepository) rawPeriodStats(ctx context.Context, start, end time.Time, tag string) (*domain.PeriodStats, error) {
 	stats := &domain.PeriodStats{
 		Start: start,
 		End:   end,
 	}
 
+	filter := ""
+	args := []interface{}{start, end}
+	if tag != "" {
+		filter = " AND " + sessionTagCondition("sessions.id", "sessions.task_id")
+		args = append(args, tagConditionArgs(tag)...)
+	}
+
 	// Aggregate totals by methodology
 	query := `
 		SELECT
@@ -295,10 +502,10 @@ func (r *sessionRepository) GetPeriodStats(ctx context.Context, start, end time.
 			COALESCE(SUM(duration_ms), 0) as total_ms
 		FROM sessions
 		WHERE type = 'work' AND status = 'completed'
-		  AND started_at >= ? AND started_at < ?
+		  AND started_at >= ? AND started_at < ?` + filter + `
 		GROUP BY meth
 	`
-	rows, err := r.db.QueryContext(ctx, query, start, end)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query period stats: %w", err)
 	}
@@ -329,11 +536,11 @@ func (r *sessionRepository) GetPeriodStats(ctx context.Context, start, end time.
 		FROM sessions
 		WHERE type = 'work' AND status = 'completed'
 		  AND focus_score IS NOT NULL
-		  AND started_at >= ? AND started_at < ?
+		  AND started_at >= ? AND started_at < ?` + filter + `
 	`
 	var avgScore sql.NullFloat64
 	var scoreCount int
-	if err := r.db.QueryRowContext(ctx, scoreQuery, start, end).Scan(&avgScore, &scoreCount); err != nil {
+	if err := r.db.QueryRowContext(ctx, scoreQuery, args...).Scan(&avgScore, &scoreCount); err != nil {
 		// Non-fatal
 		avgScore.Float64 = 0
 	}
@@ -343,65 +550,213 @@ func (r *sessionRepository) GetPeriodStats(ctx context.Context, start, end time.
 	stats.FocusScoreCount = scoreCount
 
 	// Count distractions (Deep Work sessions).
-	// Distractions are stored as JSON arrays (new format) or newline-separated strings (legacy).
+	// Distractions are stored as JSON arrays (new format) or newline-separated strings (legacy);
+	// a session saved without any holds "null".
 	distractQuery := `
 		SELECT COALESCE(SUM(
 			CASE
-				WHEN distractions IS NULL OR distractions = '' THEN 0
+				WHEN distractions IS NULL OR distractions IN ('', 'null') THEN 0
 				WHEN distractions LIKE '[%' THEN json_array_length(distractions)
 				ELSE LENGTH(distractions) - LENGTH(REPLACE(distractions, CHAR(10), '')) + 1
 			END
 		), 0)
 		FROM sessions
 		WHERE type = 'work' AND status = 'completed'
-		  AND started_at >= ? AND started_at < ?
+		  AND started_at >= ? AND started_at < ?` + filter + `
 	`
-	if err := r.db.QueryRowContext(ctx, distractQuery, start, end).Scan(&stats.DistractionCount); err != nil {
+	if err := r.db.QueryRowContext(ctx, distractQuery, args...).Scan(&stats.DistractionCount); err != nil {
 		stats.DistractionCount = 0
 	}
 
+	if err := r.addPauseStats(ctx, stats, filter, args); err != nil {
+		return nil, err
+	}
+
 	return stats, nil
 }
 
+// addPauseStats counts the pauses of the completed work sessions matched by
+// filter and the time they took, from each session's event history.
+func (r *sessionRepository) addPauseStats(ctx context.Context, stats *domain.PeriodStats, filter string, args []interface{}) error {
+	query := `
+		SELECT e.session_id, e.event, e.at
+		FROM session_events e
+		JOIN sessions ON sessions.id = e.session_id
+		WHERE sessions.type = 'work' AND sessions.status = 'completed'
+		  AND sessions.started_at >= ? AND sessions.started_at < ?` + filter + `
+		ORDER BY e.session_id, e.seq
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query pause stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var current string
+	var events []domain.SessionEvent
+	flush := func() {
+		if len(events) == 0 {
+			return
+		}
+		count, paused := domain.SummarizePauses(events, events[len(events)-1].At)
+		stats.PauseCount += count
+		stats.PausedTime += paused
+		events = events[:0]
+	}
+	for rows.Next() {
+		var id, event string
+		var at time.Time
+		if err := rows.Scan(&id, &event, &at); err != nil {
+			return fmt.Errorf("failed to scan pause stats: %w", err)
+		}
+		if id != current {
+			flush()
+			current = id
+		}
+		events = append(events, domain.SessionEvent{Type: domain.SessionEventType(event), At: at})
+	}
+	flush()
+	return rows.Err()
+}
+
 // GetDeepWorkStreak returns consecutive days (ending today) with >= threshold deep work hours.
 func (r *sessionRepository) GetDeepWorkStreak(ctx context.Context, threshold time.Duration) (int, error) {
-	thresholdMs := threshold.Milliseconds()
-	streak := 0
-
-	// Walk backward from today, checking each day
+	// Only the last year is considered, in a single query over the rollups.
 	now := time.Now()
-	for i := 0; i < 365; i++ {
-		day := now.AddDate(0, 0, -i)
-		startOfDay := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
-		endOfDay := startOfDay.Add(24 * time.Hour)
-
-		query := `
-			SELECT COALESCE(SUM(duration_ms), 0)
-			FROM sessions
-			WHERE type = 'work' AND status = 'completed'
-			  AND methodology = 'deepwork'
-			  AND started_at >= ? AND started_at < ?
-		`
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT day
+		FROM daily_rollups
+		WHERE tag = '' AND methodology = 'deepwork' AND day > ?
+		GROUP BY day
+		HAVING SUM(work_ms) >= ?
+	`, dayKey(today.AddDate(0, 0, -365)), threshold.Milliseconds())
+	if err != nil {
+		return 0, fmt.Errorf("failed to query deep work streak: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
 
-		var totalMs int64
-		if err := r.db.QueryRowContext(ctx, query, startOfDay, endOfDay).Scan(&totalMs); err != nil {
-			return 0, fmt.Errorf("failed to query deep work streak: %w", err)
+	met := map[string]bool{}
+	for rows.Next() {
+		var day string
+		if err := rows.Scan(&day); err != nil {
+			return 0, fmt.Errorf("failed to scan deep work streak: %w", err)
 		}
+		met[day] = true
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
 
-		if totalMs >= thresholdMs {
+	// Walk backward from today. A today without deep work yet doesn't
+	// break the streak.
+	streak := 0
+	for i := 0; i < 365; i++ {
+		if met[dayKey(today.AddDate(0, 0, -i))] {
 			streak++
-		} else {
-			// If today has no sessions yet, skip it (don't break streak for today)
-			if i == 0 {
-				continue
-			}
+		} else if i > 0 {
 			break
 		}
 	}
-
 	return streak, nil
 }
 
+// GetDailyTotals returns the completed work of each day in [start, end)
+// that has any, in date order.
+func (r *sessionRepository) GetDailyTotals(ctx context.Context, start, end time.Time) ([]domain.DayTotal, error) {
+	if from, to, ok := rollupDays(start, end); ok {
+		return r.rollupDailyTotals(ctx, from, to)
+	}
+	return r.rawDailyTotals(ctx, start, end)
+}
+
+// rollupDailyTotals reads the daily totals of the days from (inclusive) to
+// to (exclusive) from daily_rollups.
+func (r *sessionRepository) rollupDailyTotals(ctx context.Context, from, to string) ([]domain.DayTotal, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT day, SUM(work_sessions), SUM(work_ms),
+		       SUM(CASE WHEN methodology = 'deepwork' THEN work_ms ELSE 0 END)
+		FROM daily_rollups
+		WHERE tag = '' AND day >= ? AND day < ?
+		GROUP BY day
+		HAVING SUM(work_sessions) > 0
+		ORDER BY day
+	`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily totals: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var totals []domain.DayTotal
+	for rows.Next() {
+		var day string
+		var total domain.DayTotal
+		var workMs, deepWorkMs int64
+		if err := rows.Scan(&day, &total.WorkSessions, &workMs, &deepWorkMs); err != nil {
+			return nil, fmt.Errorf("failed to scan daily total: %w", err)
+		}
+		total.Date, err = time.ParseInLocation(dayKeyFormat, day, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rollup day %q: %w", day, err)
+		}
+		total.WorkTime = time.Duration(workMs) * time.Millisecond
+		total.DeepWorkTime = time.Duration(deepWorkMs) * time.Millisecond
+		totals = append(totals, total)
+	}
+	return totals, rows.Err()
+}
+
+// rawDailyTotals buckets the completed work sessions of [start, end) by day.
+func (r *sessionRepository) rawDailyTotals(ctx context.Context, start, end time.Time) ([]domain.DayTotal, error) {
+	// started_at keeps the offset it was saved with, so days are bucketed
+	// here in start's time zone rather than by the stored date.
+	query := `
+		SELECT started_at, duration_ms, COALESCE(methodology, '')
+		FROM sessions
+		WHERE type = 'work' AND status = 'completed'
+		  AND started_at >= ? AND started_at < ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily totals: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	byDay := make(map[time.Time]*domain.DayTotal)
+	for rows.Next() {
+		var startedAt time.Time
+		var durationMs int64
+		var methodology string
+		if err := rows.Scan(&startedAt, &durationMs, &methodology); err != nil {
+			return nil, fmt.Errorf("failed to scan daily total: %w", err)
+		}
+		local := startedAt.In(start.Location())
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, start.Location())
+		total, ok := byDay[day]
+		if !ok {
+			total = &domain.DayTotal{Date: day}
+			byDay[day] = total
+		}
+		duration := time.Duration(durationMs) * time.Millisecond
+		total.WorkSessions++
+		total.WorkTime += duration
+		if domain.Methodology(methodology) == domain.MethodologyDeepWork {
+			total.DeepWorkTime += duration
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	totals := make([]domain.DayTotal, 0, len(byDay))
+	for _, total := range byDay {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].Date.Before(totals[j].Date) })
+	return totals, nil
+}
+
 // GetHourlyProductivity returns total work minutes per hour-of-day for the last N days.
 func (r *sessionRepository) GetHourlyProductivity(ctx context.Context, days int) (map[int]time.Duration, error) {
 	since := time.Now().AddDate(0, 0, -days)
@@ -473,6 +828,50 @@ func (r *sessionRepository) GetEnergizeStats(ctx context.Context, start, end tim
 	return stats, rows.Err()
 }
 
+// GetDriverStats returns the rotations and time each participant drove
+// in a time range, most time first. A tag keeps only the rotations tagged
+// with it, directly or through their task, like GetTaggedPeriodStats.
+func (r *sessionRepository) GetDriverStats(ctx context.Context, start, end time.Time, tag string) ([]domain.DriverStat, error) {
+	filter := ""
+	args := []interface{}{start, end}
+	if tag != "" {
+		filter = " AND " + sessionTagCondition("sessions.id", "sessions.task_id")
+		args = append(args, tagConditionArgs(tag)...)
+	}
+
+	query := `
+		SELECT
+			driver,
+			COUNT(*) as rotations,
+			COALESCE(SUM(duration_ms), 0) as total_ms
+		FROM sessions
+		WHERE type = 'work' AND status = 'completed'
+		  AND driver != ''
+		  AND started_at >= ? AND started_at < ?` + filter + `
+		GROUP BY driver
+		ORDER BY total_ms DESC, driver
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query driver stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var stats []domain.DriverStat
+	for rows.Next() {
+		var s domain.DriverStat
+		var totalMs int64
+		if err := rows.Scan(&s.Driver, &s.Rotations, &totalMs); err != nil {
+			return nil, fmt.Errorf("failed to scan driver stat: %w", err)
+		}
+		s.TotalTime = time.Duration(totalMs) * time.Millisecond
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
 // GetDeepWorkHours returns total deep work hours for a date range.
 func (r *sessionRepository) GetDeepWorkHours(ctx context.Context, start, end time.Time) (time.Duration, error) {
 	query := `
@@ -482,9 +881,18 @@ func (r *sessionRepository) GetDeepWorkHours(ctx context.Context, start, end tim
 		  AND methodology = 'deepwork'
 		  AND started_at >= ? AND started_at < ?
 	`
+	args := []interface{}{start, end}
+	if from, to, ok := rollupDays(start, end); ok {
+		query = `
+			SELECT COALESCE(SUM(work_ms), 0)
+			FROM daily_rollups
+			WHERE tag = '' AND methodology = 'deepwork' AND day >= ? AND day < ?
+		`
+		args = []interface{}{from, to}
+	}
 
 	var totalMs int64
-	if err := r.db.QueryRowContext(ctx, query, start, end).Scan(&totalMs); err != nil {
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&totalMs); err != nil {
 		return 0, fmt.Errorf("failed to get deep work hours: %w", err)
 	}
 
@@ -509,6 +917,8 @@ func (r *sessionRepository) scanSession(row *sql.Row) (*domain.PomodoroSession,
 	var energizeActivity sql.NullString
 	var shutdownRitualStr sql.NullString
 	var outcomeAchieved sql.NullString
+	var breakAfterMs int64
+	var participantsStr string
 
 	err := row.Scan(
 		&session.ID,
@@ -532,6 +942,11 @@ func (r *sessionRepository) scanSession(row *sql.Row) (*domain.PomodoroSession,
 		&energizeActivity,
 		&shutdownRitualStr,
 		&outcomeAchieved,
+		&breakAfterMs,
+		&session.Driver,
+		&session.Navigator,
+		&participantsStr,
+		&session.OpenEnded,
 	)
 
 	if err == sql.ErrNoRows {
@@ -542,6 +957,8 @@ func (r *sessionRepository) scanSession(row *sql.Row) (*domain.PomodoroSession,
 	}
 
 	session.Duration = time.Duration(durationMs) * time.Millisecond
+	session.BreakAfter = time.Duration(breakAfterMs) * time.Millisecond
+	session.Participants = decodeParticipants(participantsStr)
 
 	if taskID.Valid {
 		session.TaskID = &taskID.String
@@ -576,8 +993,8 @@ func (r *sessionRepository) scanSession(row *sql.Row) (*domain.PomodoroSession,
 	if intendedOutcome.Valid {
 		session.IntendedOutcome = intendedOutcome.String
 	}
-	if tagsStr.Valid && tagsStr.String != "" {
-		session.Tags = strings.Split(tagsStr.String, ",")
+	if tags := decodeTags(tagsStr.String); len(tags) > 0 {
+		session.Tags = tags
 	}
 	if energizeActivity.Valid {
 		session.EnergizeActivity = energizeActivity.String
@@ -616,6 +1033,8 @@ func (r *sessionRepository) scanSessions(rows *sql.Rows) ([]*domain.PomodoroSess
 		var energizeActivity sql.NullString
 		var shutdownRitualStr sql.NullString
 		var outcomeAchieved sql.NullString
+		var breakAfterMs int64
+		var participantsStr string
 
 		err := rows.Scan(
 			&session.ID,
@@ -639,12 +1058,19 @@ func (r *sessionRepository) scanSessions(rows *sql.Rows) ([]*domain.PomodoroSess
 			&energizeActivity,
 			&shutdownRitualStr,
 			&outcomeAchieved,
+			&breakAfterMs,
+			&session.Driver,
+			&session.Navigator,
+			&participantsStr,
+			&session.OpenEnded,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan session: %w", err)
 		}
 
 		session.Duration = time.Duration(durationMs) * time.Millisecond
+		session.BreakAfter = time.Duration(breakAfterMs) * time.Millisecond
+		session.Participants = decodeParticipants(participantsStr)
 
 		if taskID.Valid {
 			session.TaskID = &taskID.String
@@ -679,8 +1105,8 @@ func (r *sessionRepository) scanSessions(rows *sql.Rows) ([]*domain.PomodoroSess
 		if intendedOutcome.Valid {
 			session.IntendedOutcome = intendedOutcome.String
 		}
-		if tagsStr.Valid && tagsStr.String != "" {
-			session.Tags = strings.Split(tagsStr.String, ",")
+		if tags := decodeTags(tagsStr.String); len(tags) > 0 {
+			session.Tags = tags
 		}
 		if energizeActivity.Valid {
 			session.EnergizeActivity = energizeActivity.String
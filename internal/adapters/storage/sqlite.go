@@ -2,8 +2,10 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"os"
 
 	"github.com/xvierd/flow-cli/internal/ports"
 	_ "modernc.org/sqlite"
@@ -12,15 +14,65 @@ import (
 // sqliteStorage implements the ports.Storage interface using SQLite.
 type sqliteStorage struct {
 	db          *sql.DB
+	conn        dbtx // db, or the transaction the repositories run in
 	taskRepo    ports.TaskRepository
 	sessionRepo ports.SessionRepository
+	searchRepo  ports.SearchRepository
+	tagRepo     ports.TagRepository
+	webhookRepo ports.WebhookRepository
+	planRepo    ports.PlanRepository
 }
 
 // Ensure sqliteStorage implements ports.Storage.
 var _ ports.Storage = (*sqliteStorage)(nil)
 
-// New creates a new SQLite storage instance.
+// dbtx is satisfied by both *sql.DB and *sql.Tx, so a write spanning
+// several statements can run in a transaction of its own or in one the
+// caller already started.
+type dbtx interface {
+	queryExecer
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// inTx runs fn in a transaction on db: a new one, committed if fn succeeds
+// and rolled back otherwise, or db itself when it already is one.
+func inTx(ctx context.Context, db dbtx, fn func(tx dbtx) error) error {
+	sqlDB, ok := db.(*sql.DB)
+	if !ok {
+		return fn(db)
+	}
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// New creates a new SQLite storage instance and applies any pending migrations.
+// A failed migration is returned as an error so startup aborts instead of
+// running against a half-migrated schema.
 func New(dbPath string) (ports.Storage, error) {
+	storage, err := Open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.Migrate(); err != nil {
+		_ = storage.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return storage, nil
+}
+
+// Open opens a SQLite storage instance without running migrations.
+// Used by "flow db" to inspect and migrate the schema explicitly.
+func Open(dbPath string) (ports.Storage, error) {
 	db, err := sql.Open("sqlite", dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
@@ -34,17 +86,22 @@ func New(dbPath string) (ports.Storage, error) {
 		return nil, fmt.Errorf("failed to set WAL mode: %w", err)
 	}
 
-	storage := &sqliteStorage{
-		db:          db,
-		taskRepo:    newTaskRepository(db),
-		sessionRepo: newSessionRepository(db),
-	}
+	return newSQLiteStorage(db, db), nil
+}
 
-	if err := storage.Migrate(); err != nil {
-		return nil, fmt.Errorf("failed to migrate database: %w", err)
+// newSQLiteStorage builds a storage whose repositories run their
+// statements on conn.
+func newSQLiteStorage(db *sql.DB, conn dbtx) *sqliteStorage {
+	return &sqliteStorage{
+		db:          db,
+		conn:        conn,
+		taskRepo:    newTaskRepository(conn),
+		sessionRepo: newSessionRepository(conn),
+		searchRepo:  newSearchRepository(conn),
+		tagRepo:     newTagRepository(conn),
+		webhookRepo: newWebhookRepository(conn),
+		planRepo:    newPlanRepository(conn),
 	}
-
-	return storage, nil
 }
 
 // NewMemory creates a new in-memory SQLite storage instance for testing.
@@ -62,72 +119,72 @@ func (s *sqliteStorage) Sessions() ports.SessionRepository {
 	return s.sessionRepo
 }
 
+// Search returns the full-text search repository.
+func (s *sqliteStorage) Search() ports.SearchRepository {
+	return s.searchRepo
+}
+
+// Tags returns the tag repository.
+func (s *sqliteStorage) Tags() ports.TagRepository {
+	return s.tagRepo
+}
+
+// Webhooks returns the webhook delivery repository.
+func (s *sqliteStorage) Webhooks() ports.WebhookRepository {
+	return s.webhookRepo
+}
+
+// Plans returns the day plan repository.
+func (s *sqliteStorage) Plans() ports.PlanRepository {
+	return s.planRepo
+}
+
+// WithTx runs fn with a storage whose repositories all write in one
+// transaction, committed if fn succeeds and rolled back otherwise.
+func (s *sqliteStorage) WithTx(ctx context.Context, fn func(ports.Storage) error) error {
+	return inTx(ctx, s.conn, func(tx dbtx) error {
+		return fn(newSQLiteStorage(s.db, tx))
+	})
+}
+
 // Close closes the database connection.
 func (s *sqliteStorage) Close() error {
 	return s.db.Close()
 }
 
-// Migrate creates the database schema.
+// Migrate applies any pending schema migrations.
 func (s *sqliteStorage) Migrate() error {
-	schema := `
-	CREATE TABLE IF NOT EXISTS tasks (
-		id TEXT PRIMARY KEY,
-		title TEXT NOT NULL,
-		description TEXT,
-		status TEXT NOT NULL,
-		tags TEXT,
-		created_at DATETIME NOT NULL,
-		updated_at DATETIME NOT NULL,
-		completed_at DATETIME
-	);
-
-	CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
-	CREATE INDEX IF NOT EXISTS idx_tasks_updated ON tasks(updated_at);
-
-	CREATE TABLE IF NOT EXISTS sessions (
-		id TEXT PRIMARY KEY,
-		task_id TEXT,
-		type TEXT NOT NULL,
-		status TEXT NOT NULL,
-		duration_ms INTEGER NOT NULL,
-		started_at DATETIME NOT NULL,
-		paused_at DATETIME,
-		completed_at DATETIME,
-		git_branch TEXT,
-		git_commit TEXT,
-		git_modified TEXT,
-		notes TEXT,
-		FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE SET NULL
-	);
-
-	CREATE INDEX IF NOT EXISTS idx_sessions_task ON sessions(task_id);
-	CREATE INDEX IF NOT EXISTS idx_sessions_started ON sessions(started_at);
-	CREATE INDEX IF NOT EXISTS idx_sessions_status ON sessions(status);
-	`
-
-	_, err := s.db.Exec(schema)
-	if err != nil {
-		return fmt.Errorf("failed to execute schema: %w", err)
-	}
+	return runMigrations(context.Background(), s.db, migrations)
+}
 
-	// Run ALTER TABLE migrations for methodology fields
-	migrations := []string{
-		"ALTER TABLE sessions ADD COLUMN methodology TEXT DEFAULT 'pomodoro'",
-		"ALTER TABLE sessions ADD COLUMN focus_score INTEGER",
-		"ALTER TABLE sessions ADD COLUMN distractions TEXT",
-		"ALTER TABLE sessions ADD COLUMN accomplishment TEXT",
-		"ALTER TABLE sessions ADD COLUMN intended_outcome TEXT",
-		"ALTER TABLE tasks ADD COLUMN highlight_date DATETIME",
-		"ALTER TABLE sessions ADD COLUMN tags TEXT",
-		"ALTER TABLE sessions ADD COLUMN energize_activity TEXT",
-		"ALTER TABLE sessions ADD COLUMN shutdown_ritual TEXT",
-		"ALTER TABLE sessions ADD COLUMN outcome_achieved TEXT",
-	}
+// MigrationStatus reports every known schema migration and whether it has been applied.
+func (s *sqliteStorage) MigrationStatus(ctx context.Context) ([]ports.MigrationStatus, error) {
+	return migrationStatus(ctx, s.db, migrations)
+}
 
-	for _, m := range migrations {
-		// Ignore errors — column may already exist
-		_, _ = s.db.Exec(m)
+// Backup writes a consistent, self-contained copy of the database to destPath
+// using VACUUM INTO, which is safe while other connections use WAL mode.
+func (s *sqliteStorage) Backup(ctx context.Context, destPath string) error {
+	if _, err := os.Stat(destPath); err == nil {
+		return fmt.Errorf("backup destination already exists: %s", destPath)
+	}
+	if _, err := s.db.ExecContext(ctx, "VACUUM INTO ?", destPath); err != nil {
+		return fmt.Errorf("failed to back up database: %w", err)
 	}
-
 	return nil
 }
+
+// RebuildRollups recomputes daily_rollups from every session, for example
+// after the local time zone changed.
+func (s *sqliteStorage) RebuildRollups(ctx context.Context) (int, error) {
+	var days int
+	err := inTx(ctx, s.conn, func(tx dbtx) error {
+		var err error
+		days, err = rebuildRollups(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild rollups: %w", err)
+		}
+		return nil
+	})
+	return days, err
+}
@@ -576,6 +576,50 @@ func TestSessionRepository_GetHourlyProductivity(t *testing.T) {
 	})
 }
 
+func TestSessionRepository_GetDailyTotals(t *testing.T) {
+	storage, _ := NewMemory()
+	defer func() { _ = storage.Close() }()
+
+	ctx := context.Background()
+	sessionRepo := storage.Sessions()
+
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	save := func(id string, startedAt time.Time, methodology domain.Methodology, status domain.SessionStatus) {
+		t.Helper()
+		session := &domain.PomodoroSession{
+			ID:          id,
+			Type:        domain.SessionTypeWork,
+			Status:      status,
+			Methodology: methodology,
+			Duration:    30 * time.Minute,
+			StartedAt:   startedAt,
+		}
+		if err := sessionRepo.Save(ctx, session); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	save("a", day.Add(9*time.Hour), domain.MethodologyDeepWork, domain.SessionStatusCompleted)
+	save("b", day.Add(23*time.Hour), domain.MethodologyPomodoro, domain.SessionStatusCompleted)
+	save("c", day.Add(33*time.Hour), domain.MethodologyPomodoro, domain.SessionStatusCompleted)
+	save("d", day.Add(34*time.Hour), domain.MethodologyPomodoro, domain.SessionStatusInterrupted)
+	save("e", day.AddDate(0, 0, 5), domain.MethodologyPomodoro, domain.SessionStatusCompleted)
+
+	totals, err := sessionRepo.GetDailyTotals(ctx, day, day.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("GetDailyTotals() error = %v", err)
+	}
+	if len(totals) != 2 {
+		t.Fatalf("len(totals) = %d, want 2 days", len(totals))
+	}
+	first, second := totals[0], totals[1]
+	if !first.Date.Equal(day) || first.WorkSessions != 2 || first.WorkTime != time.Hour || first.DeepWorkTime != 30*time.Minute {
+		t.Errorf("first day = %+v, want 2 sessions, 1h, 30m deep work", first)
+	}
+	if !second.Date.Equal(day.AddDate(0, 0, 1)) || second.WorkSessions != 1 || second.DeepWorkTime != 0 {
+		t.Errorf("second day = %+v, want 1 completed session and no deep work", second)
+	}
+}
+
 func TestSessionRepository_GetEnergizeStats(t *testing.T) {
 	storage, _ := NewMemory()
 	defer func() { _ = storage.Close() }()
@@ -919,3 +963,143 @@ func TestStorage_DistractionPersistence(t *testing.T) {
 		t.Errorf("second distraction = %+v, want {Text:random thought Category:internal}", found.Distractions[1])
 	}
 }
+
+func TestSessionRepository_WritesAtomically(t *testing.T) {
+	store, _ := NewMemory()
+	defer func() { _ = store.Close() }()
+
+	ctx := context.Background()
+	db := store.(*sqliteStorage).db
+	sessionRepo := store.Sessions()
+
+	session := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), nil)
+	if err := sessionRepo.Save(ctx, session); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Recording the stop event fails after the session row is written
+	if _, err := db.Exec(`CREATE TRIGGER fail_stop BEFORE INSERT ON session_events
+		WHEN NEW.event = 'stop' BEGIN SELECT RAISE(ABORT, 'disk full'); END`); err != nil {
+		t.Fatal(err)
+	}
+	session.Complete()
+	if err := sessionRepo.Update(ctx, session); err == nil {
+		t.Fatal("Update() error = nil, want the failed event insert")
+	}
+	got, _ := sessionRepo.FindByID(ctx, session.ID)
+	if got.Status != domain.SessionStatusRunning || len(got.Events) != 1 {
+		t.Errorf("after a failed Update: status %v, %d events; want it left running with its start event", got.Status, len(got.Events))
+	}
+
+	other := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), nil)
+	other.Complete()
+	if err := sessionRepo.Save(ctx, other); err == nil {
+		t.Fatal("Save() error = nil, want the failed event insert")
+	}
+	if got, _ := sessionRepo.FindByID(ctx, other.ID); got != nil {
+		t.Errorf("after a failed Save: found %v, want no row", got)
+	}
+}
+
+func TestSessionRepository_OpenEnded(t *testing.T) {
+	storage, _ := NewMemory()
+	defer func() { _ = storage.Close() }()
+
+	ctx := context.Background()
+	sessionRepo := storage.Sessions()
+
+	session := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), nil)
+	session.Duration, session.OpenEnded = 0, true
+	session.Methodology = domain.MethodologyFlowtime
+	if err := sessionRepo.Save(ctx, session); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	active, err := sessionRepo.FindActive(ctx)
+	if err != nil || active == nil {
+		t.Fatalf("FindActive() = %v, %v", active, err)
+	}
+	if !active.IsOpenEnded() {
+		t.Error("loaded session is not open-ended")
+	}
+
+	active.Complete()
+	if err := sessionRepo.Update(ctx, active); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err := sessionRepo.FindByID(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.IsOpenEnded() || found.Duration != 0 {
+		t.Errorf("stopped session: open-ended %v, Duration %v, want a fixed 0", found.IsOpenEnded(), found.Duration)
+	}
+}
+
+func TestSessionRepository_GetDriverStats(t *testing.T) {
+	storage, _ := NewMemory()
+	defer func() { _ = storage.Close() }()
+
+	ctx := context.Background()
+	sessionRepo := storage.Sessions()
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	roster := []string{"Ana", "Ben"}
+	save := func(id string, hour int, driver string, status domain.SessionStatus, tags ...string) {
+		t.Helper()
+		started := start.Add(time.Duration(hour) * time.Hour)
+		ended := started.Add(10 * time.Minute)
+		session := &domain.PomodoroSession{
+			ID:          id,
+			Type:        domain.SessionTypeWork,
+			Status:      status,
+			Duration:    10 * time.Minute,
+			StartedAt:   started,
+			CompletedAt: &ended,
+			Methodology: domain.MethodologyMob,
+			Tags:        tags,
+		}
+		session.SetRotation(domain.NextRotation(roster, driver), roster)
+		if err := sessionRepo.Save(ctx, session); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	save("r1", 9, "Ben", domain.SessionStatusCompleted)  // Ana drives
+	save("r2", 10, "Ana", domain.SessionStatusCompleted) // Ben drives
+	save("r3", 11, "Ben", domain.SessionStatusCompleted) // Ana drives
+	save("r4", 12, "Ben", domain.SessionStatusInterrupted)
+	save("r5", -2, "Ana", domain.SessionStatusCompleted, "client/acme") // Ben drives, yesterday
+
+	got, err := sessionRepo.FindByID(ctx, "r2")
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if got.Driver != "Ben" || got.Navigator != "Ana" || len(got.Participants) != 2 {
+		t.Errorf("loaded rotation = %s/%s %v, want Ben driving, Ana navigating, both participants", got.Driver, got.Navigator, got.Participants)
+	}
+
+	stats, err := sessionRepo.GetDriverStats(ctx, start, start.Add(24*time.Hour), "")
+	if err != nil {
+		t.Fatalf("GetDriverStats() error = %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("len(stats) = %d, want 2 drivers", len(stats))
+	}
+	if stats[0].Driver != "Ana" || stats[0].Rotations != 2 || stats[0].TotalTime != 20*time.Minute {
+		t.Errorf("stats[0] = %+v, want Ana with 2 rotations, 20m", stats[0])
+	}
+	if stats[1].Driver != "Ben" || stats[1].Rotations != 1 {
+		t.Errorf("stats[1] = %+v, want Ben with 1 completed rotation", stats[1])
+	}
+
+	tagged, err := sessionRepo.GetDriverStats(ctx, start.AddDate(0, 0, -1), start.Add(24*time.Hour), "client")
+	if err != nil {
+		t.Fatalf("GetDriverStats(client) error = %v", err)
+	}
+	if len(tagged) != 1 || tagged[0].Driver != "Ben" || tagged[0].Rotations != 1 {
+		t.Errorf("GetDriverStats(client) = %+v, want only Ben's tagged rotation", tagged)
+	}
+	if today, _ := sessionRepo.GetDriverStats(ctx, start, start.Add(24*time.Hour), "client"); len(today) != 0 {
+		t.Errorf("GetDriverStats(client) today = %+v, want none", today)
+	}
+}
@@ -0,0 +1,400 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// Join tables linking tags to tasks and sessions, with their owner column.
+const (
+	taskTagsTable    = "task_tags"
+	taskTagsOwner    = "task_id"
+	sessionTagsTable = "session_tags"
+	sessionTagsOwner = "session_id"
+	likeEscape       = `\`
+)
+
+// tagListSQL returns a column expression yielding the owner's tags, in the
+// order they were given, as a JSON array.
+func tagListSQL(joinTable, ownerColumn, ownerRef string) string {
+	return fmt.Sprintf(
+		`(SELECT json_group_array(tg.name ORDER BY jt.position) FROM %s jt JOIN tags tg ON tg.id = jt.tag_id WHERE jt.%s = %s)`,
+		joinTable, ownerColumn, ownerRef,
+	)
+}
+
+// sessionTagCondition returns a WHERE condition matching sessions whose own
+// tags, or whose task's tags, are the filter tag or nested beneath it. It
+// takes the arguments returned by tagConditionArgs.
+func sessionTagCondition(sessionRef, taskRef string) string {
+	match := `(tg.name = ? OR tg.name LIKE ? ESCAPE '` + likeEscape + `')`
+	return fmt.Sprintf(`(EXISTS (SELECT 1 FROM session_tags st JOIN tags tg ON tg.id = st.tag_id WHERE st.session_id = %s AND %s)
+		OR EXISTS (SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = %s AND %s))`,
+		sessionRef, match, taskRef, match)
+}
+
+// tagConditionArgs returns the arguments for sessionTagCondition.
+func tagConditionArgs(tag string) []interface{} {
+	tag = domain.NormalizeTag(tag)
+	pattern := subtreePattern(tag)
+	return []interface{}{tag, pattern, tag, pattern}
+}
+
+// subtreePattern returns a LIKE pattern matching every tag nested under tag.
+func subtreePattern(tag string) string {
+	r := strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_")
+	return r.Replace(tag) + domain.TagSeparator + "%"
+}
+
+// decodeTags parses a tag list column produced by tagListSQL.
+func decodeTags(data string) []string {
+	var tags []string
+	if data == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(data), &tags); err != nil {
+		return nil
+	}
+	return tags
+}
+
+// replaceTags sets the tags of one task or session, creating tags as needed
+// and dropping tags nothing refers to any more.
+func replaceTags(ctx context.Context, db execer, joinTable, ownerColumn, id string, tags []string) error {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s = ?`, joinTable, ownerColumn), id); err != nil {
+		return fmt.Errorf("failed to clear tags: %w", err)
+	}
+	for i, tag := range domain.NormalizeTags(tags) {
+		if _, err := db.ExecContext(ctx, `INSERT INTO tags (name) VALUES (?) ON CONFLICT (name) DO NOTHING`, tag); err != nil {
+			return fmt.Errorf("failed to save tag %q: %w", tag, err)
+		}
+		_, err := db.ExecContext(ctx, fmt.Sprintf(
+			`INSERT OR IGNORE INTO %s (%s, tag_id, position) SELECT ?, id, ? FROM tags WHERE name = ?`,
+			joinTable, ownerColumn,
+		), id, i, tag)
+		if err != nil {
+			return fmt.Errorf("failed to link tag %q: %w", tag, err)
+		}
+	}
+	return pruneTags(ctx, db)
+}
+
+// deleteTagLinks removes every tag link of a deleted task or session.
+func deleteTagLinks(ctx context.Context, db execer, joinTable, ownerColumn, id string) error {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s = ?`, joinTable, ownerColumn), id); err != nil {
+		return fmt.Errorf("failed to remove tags: %w", err)
+	}
+	return pruneTags(ctx, db)
+}
+
+// pruneTags deletes tags no task or session uses.
+func pruneTags(ctx context.Context, db execer) error {
+	_, err := db.ExecContext(ctx, `
+		DELETE FROM tags
+		WHERE id NOT IN (SELECT tag_id FROM task_tags)
+		  AND id NOT IN (SELECT tag_id FROM session_tags)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prune tags: %w", err)
+	}
+	return nil
+}
+
+// tagRepository implements ports.TagRepository using SQLite.
+type tagRepository struct {
+	db dbtx
+}
+
+// newTagRepository creates a new tag repository.
+func newTagRepository(db dbtx) ports.TagRepository {
+	return &tagRepository{db: db}
+}
+
+// List returns every tag with how many tasks and sessions use it.
+func (r *tagRepository) List(ctx context.Context) ([]domain.TagUsage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			tg.name,
+			(SELECT COUNT(*) FROM task_tags WHERE tag_id = tg.id),
+			(SELECT COUNT(*) FROM session_tags WHERE tag_id = tg.id)
+		FROM tags tg
+		ORDER BY tg.name COLLATE NOCASE
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var tags []domain.TagUsage
+	for rows.Next() {
+		var t domain.TagUsage
+		if err := rows.Scan(&t.Name, &t.Tasks, &t.Sessions); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// Rename renames a tag and every tag nested beneath it.
+func (r *tagRepository) Rename(ctx context.Context, from, to string) (int, error) {
+	var renamed int
+	err := inTx(ctx, r.db, func(tx dbtx) error {
+		var err error
+		renamed, err = renameTag(ctx, tx, from, to)
+		if err != nil {
+			return err
+		}
+		_, err = rebuildRollups(ctx, tx)
+		return err
+	})
+	return renamed, err
+}
+
+// Merge folds each source tag, and the tags nested beneath it, into target.
+func (r *tagRepository) Merge(ctx context.Context, sources []string, target string) (int, error) {
+	var merged int
+	err := inTx(ctx, r.db, func(tx dbtx) error {
+		for _, source := range sources {
+			if strings.EqualFold(domain.NormalizeTag(source), domain.NormalizeTag(target)) {
+				continue
+			}
+			n, err := renameTag(ctx, tx, source, target)
+			if err != nil {
+				return err
+			}
+			merged += n
+		}
+		_, err := rebuildRollups(ctx, tx)
+		return err
+	})
+	return merged, err
+}
+
+// Delete removes a tag and every tag nested beneath it from all tasks and sessions.
+func (r *tagRepository) Delete(ctx context.Context, tag string) (int, error) {
+	var deleted int
+	err := inTx(ctx, r.db, func(tx dbtx) error {
+		ids, _, err := findSubtree(ctx, tx, tag)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := dropTag(ctx, tx, id); err != nil {
+				return err
+			}
+		}
+		deleted = len(ids)
+		_, err = rebuildRollups(ctx, tx)
+		return err
+	})
+	return deleted, err
+}
+
+// Stats returns completed work per tag for a time range. A session counts
+// toward its own tags, its task's tags and every parent of those tags, but
+// only once per tag.
+func (r *tagRepository) Stats(ctx context.Context, start, end time.Time) ([]domain.TagStat, error) {
+	if from, to, ok := rollupDays(start, end); ok {
+		return r.rollupStats(ctx, from, to)
+	}
+	return r.rawStats(ctx, start, end)
+}
+
+// rollupStats reads tag stats for the days from (inclusive) to to
+// (exclusive) from daily_rollups, whose tagged rows already count each
+// session once per tag and parent.
+func (r *tagRepository) rollupStats(ctx context.Context, from, to string) ([]domain.TagStat, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT MIN(tag), SUM(work_sessions), SUM(work_ms)
+		FROM daily_rollups
+		WHERE tag != '' AND day >= ? AND day < ?
+		GROUP BY tag
+		HAVING SUM(work_sessions) > 0
+		ORDER BY lower(MIN(tag))
+	`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tag stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	stats := []domain.TagStat{}
+	for rows.Next() {
+		var stat domain.TagStat
+		var workMs int64
+		if err := rows.Scan(&stat.Tag, &stat.SessionCount, &workMs); err != nil {
+			return nil, fmt.Errorf("failed to scan tag stat: %w", err)
+		}
+		stat.TotalTime = time.Duration(workMs) * time.Millisecond
+		stats = append(stats, stat)
+	}
+	return stats, rows.Err()
+}
+
+// rawStats aggregates tag stats from the sessions table, for ranges that
+// don't fall on local day boundaries.
+func (r *tagRepository) rawStats(ctx context.Context, start, end time.Time) ([]domain.TagStat, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT s.id, s.duration_ms, tg.name
+		FROM sessions s
+		JOIN session_tags st ON st.session_id = s.id
+		JOIN tags tg ON tg.id = st.tag_id
+		WHERE s.type = 'work' AND s.status = 'completed'
+		  AND s.started_at >= ? AND s.started_at < ?
+		UNION
+		SELECT s.id, s.duration_ms, tg.name
+		FROM sessions s
+		JOIN task_tags tt ON tt.task_id = s.task_id
+		JOIN tags tg ON tg.id = tt.tag_id
+		WHERE s.type = 'work' AND s.status = 'completed'
+		  AND s.started_at >= ? AND s.started_at < ?
+	`, start, end, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tag stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	byTag := map[string]*domain.TagStat{}
+	counted := map[string]bool{}
+	for rows.Next() {
+		var sessionID, name string
+		var durationMs int64
+		if err := rows.Scan(&sessionID, &durationMs, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan tag stat: %w", err)
+		}
+		for _, tag := range domain.TagAncestors(name) {
+			key := strings.ToLower(tag)
+			if counted[sessionID+"\x00"+key] {
+				continue
+			}
+			counted[sessionID+"\x00"+key] = true
+			stat, ok := byTag[key]
+			if !ok {
+				stat = &domain.TagStat{Tag: tag}
+				byTag[key] = stat
+			}
+			stat.SessionCount++
+			stat.TotalTime += time.Duration(durationMs) * time.Millisecond
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	stats := make([]domain.TagStat, 0, len(byTag))
+	for _, stat := range byTag {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return strings.ToLower(stats[i].Tag) < strings.ToLower(stats[j].Tag)
+	})
+	return stats, nil
+}
+
+// findSubtree returns the ids and names of tag and every tag nested beneath
+// it. It returns domain.ErrTagNotFound when there are none.
+func findSubtree(ctx context.Context, tx dbtx, tag string) ([]int64, []string, error) {
+	tag = domain.NormalizeTag(tag)
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, name FROM tags WHERE name = ? OR name LIKE ? ESCAPE '`+likeEscape+`' ORDER BY length(name)`,
+		tag, subtreePattern(tag),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find tag %q: %w", tag, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []int64
+	var names []string
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		ids = append(ids, id)
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s", domain.ErrTagNotFound, tag)
+	}
+	return ids, names, nil
+}
+
+// renameTag moves the subtree rooted at from to to. When a destination name
+// is already taken, the two tags are merged.
+func renameTag(ctx context.Context, tx dbtx, from, to string) (int, error) {
+	from, to = domain.NormalizeTag(from), domain.NormalizeTag(to)
+	if to == "" {
+		return 0, fmt.Errorf("invalid tag name %q", to)
+	}
+	if domain.TagMatches(to, from) && !strings.EqualFold(to, from) {
+		return 0, fmt.Errorf("cannot move tag %q beneath itself", from)
+	}
+
+	ids, names, err := findSubtree(ctx, tx, from)
+	if err != nil {
+		return 0, err
+	}
+	for i, id := range ids {
+		newName := to + names[i][len(from):]
+
+		var existing int64
+		err := tx.QueryRowContext(ctx, `SELECT id FROM tags WHERE name = ?`, newName).Scan(&existing)
+		switch {
+		case err == sql.ErrNoRows || existing == id:
+			if _, err := tx.ExecContext(ctx, `UPDATE tags SET name = ? WHERE id = ?`, newName, id); err != nil {
+				return 0, fmt.Errorf("failed to rename tag %q: %w", names[i], err)
+			}
+		case err != nil:
+			return 0, fmt.Errorf("failed to look up tag %q: %w", newName, err)
+		default:
+			if err := mergeTagInto(ctx, tx, id, existing); err != nil {
+				return 0, fmt.Errorf("failed to merge tag %q into %q: %w", names[i], newName, err)
+			}
+		}
+	}
+	return len(ids), nil
+}
+
+// mergeTagInto relinks everything tagged with source to target and drops source.
+func mergeTagInto(ctx context.Context, tx dbtx, source, target int64) error {
+	for _, t := range []struct{ table, owner string }{
+		{taskTagsTable, taskTagsOwner},
+		{sessionTagsTable, sessionTagsOwner},
+	} {
+		_, err := tx.ExecContext(ctx, fmt.Sprintf(
+			`INSERT OR IGNORE INTO %[1]s (%[2]s, tag_id, position) SELECT %[2]s, ?, position FROM %[1]s WHERE tag_id = ?`,
+			t.table, t.owner,
+		), target, source)
+		if err != nil {
+			return err
+		}
+	}
+	return dropTag(ctx, tx, source)
+}
+
+// dropTag deletes a tag and its links.
+func dropTag(ctx context.Context, tx dbtx, id int64) error {
+	for _, query := range []string{
+		`DELETE FROM task_tags WHERE tag_id = ?`,
+		`DELETE FROM session_tags WHERE tag_id = ?`,
+		`DELETE FROM tags WHERE id = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return fmt.Errorf("failed to delete tag: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// seedTagged saves a task tagged taskTags and a completed work session of
+// length d tagged sessionTags on that task.
+func seedTagged(t *testing.T, store ports.Storage, d time.Duration, taskTags, sessionTags []string) (*domain.Task, *domain.PomodoroSession) {
+	t.Helper()
+	ctx := context.Background()
+
+	task, _ := domain.NewTask("Tagged task")
+	task.Tags = taskTags
+	if err := store.Tasks().Save(ctx, task); err != nil {
+		t.Fatalf("Save(task) error = %v", err)
+	}
+	session := domain.NewPomodoroSession(domain.PomodoroConfig{WorkDuration: d}, &task.ID)
+	session.Tags = sessionTags
+	session.Complete()
+	if err := store.Sessions().Save(ctx, session); err != nil {
+		t.Fatalf("Save(session) error = %v", err)
+	}
+	return task, session
+}
+
+func tagNames(t *testing.T, store ports.Storage) []string {
+	t.Helper()
+	tags, err := store.Tags().List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var names []string
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func TestTagRepository_RoundTrip(t *testing.T) {
+	store, err := NewMemory()
+	if err != nil {
+		t.Fatalf("NewMemory() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+
+	task, session := seedTagged(t, store, 25*time.Minute,
+		[]string{"#client/acme", "needs,review", "Client/Acme"}, []string{"deep"})
+
+	got, err := store.Tasks().FindByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if want := []string{"client/acme", "needs,review"}; !reflect.DeepEqual(got.Tags, want) {
+		t.Errorf("task tags = %v, want %v", got.Tags, want)
+	}
+
+	// Updating replaces the tag set and drops tags nothing uses any more.
+	got.Tags = []string{"needs,review"}
+	if err := store.Tasks().Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if want := []string{"deep", "needs,review"}; !reflect.DeepEqual(tagNames(t, store), want) {
+		t.Errorf("tags after update = %v, want %v", tagNames(t, store), want)
+	}
+
+	gotSession, err := store.Sessions().FindByID(ctx, session.ID)
+	if err != nil || gotSession == nil {
+		t.Fatalf("FindByID(session) error = %v", err)
+	}
+	if want := []string{"deep"}; !reflect.DeepEqual(gotSession.Tags, want) {
+		t.Errorf("session tags = %v, want %v", gotSession.Tags, want)
+	}
+
+	if err := store.Tasks().Delete(ctx, task.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if want := []string{"deep"}; !reflect.DeepEqual(tagNames(t, store), want) {
+		t.Errorf("tags after delete = %v, want %v", tagNames(t, store), want)
+	}
+}
+
+func TestTagRepository_RenameMergeDelete(t *testing.T) {
+	store, err := NewMemory()
+	if err != nil {
+		t.Fatalf("NewMemory() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+
+	task, session := seedTagged(t, store, 25*time.Minute,
+		[]string{"client/acme", "client/acme/web"}, []string{"clients/acme", "ui"})
+
+	// Renaming moves the whole subtree; "client/acme" collides with the
+	// existing "clients/acme" once renamed and the two are merged.
+	n, err := store.Tags().Rename(ctx, "client", "clients")
+	if err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Rename() renamed %d tags, want 2", n)
+	}
+	if want := []string{"clients/acme", "clients/acme/web", "ui"}; !reflect.DeepEqual(tagNames(t, store), want) {
+		t.Errorf("tags after rename = %v, want %v", tagNames(t, store), want)
+	}
+	gotTask, _ := store.Tasks().FindByID(ctx, task.ID)
+	if want := []string{"clients/acme", "clients/acme/web"}; !reflect.DeepEqual(gotTask.Tags, want) {
+		t.Errorf("task tags after rename = %v, want %v", gotTask.Tags, want)
+	}
+
+	if _, err := store.Tags().Rename(ctx, "clients", "clients/old"); err == nil {
+		t.Error("Rename() into its own subtree succeeded")
+	}
+	if _, err := store.Tags().Rename(ctx, "nope", "x"); !errors.Is(err, domain.ErrTagNotFound) {
+		t.Errorf("Rename(missing) error = %v, want ErrTagNotFound", err)
+	}
+
+	if _, err := store.Tags().Merge(ctx, []string{"ui", "clients/acme/web"}, "frontend"); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	gotSession, _ := store.Sessions().FindByID(ctx, session.ID)
+	if want := []string{"clients/acme", "frontend"}; !reflect.DeepEqual(gotSession.Tags, want) {
+		t.Errorf("session tags after merge = %v, want %v", gotSession.Tags, want)
+	}
+
+	n, err = store.Tags().Delete(ctx, "clients")
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Delete() removed %d tags, want 1", n)
+	}
+	gotTask, _ = store.Tasks().FindByID(ctx, task.ID)
+	if want := []string{"frontend"}; !reflect.DeepEqual(gotTask.Tags, want) {
+		t.Errorf("task tags after delete = %v, want %v", gotTask.Tags, want)
+	}
+}
+
+func TestTagRepository_StatsRollUp(t *testing.T) {
+	store, err := NewMemory()
+	if err != nil {
+		t.Fatalf("NewMemory() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+
+	seedTagged(t, store, 30*time.Minute, []string{"client/acme"}, []string{"client/acme/web"})
+	seedTagged(t, store, 20*time.Minute, nil, []string{"client/globex"})
+	seedTagged(t, store, 10*time.Minute, nil, []string{"admin"})
+
+	start, end := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	stats, err := store.Tags().Stats(ctx, start, end)
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	got := map[string]domain.TagStat{}
+	for _, s := range stats {
+		got[s.Tag] = s
+	}
+	// The first session has both client/acme (via its task) and
+	// client/acme/web, but counts once toward client and client/acme.
+	want := map[string]struct {
+		sessions int
+		minutes  int
+	}{
+		"admin":           {1, 10},
+		"client":          {2, 50},
+		"client/acme":     {1, 30},
+		"client/acme/web": {1, 30},
+		"client/globex":   {1, 20},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Stats() = %+v, want %d tags", stats, len(want))
+	}
+	for tag, w := range want {
+		s := got[tag]
+		if s.SessionCount != w.sessions || s.TotalTime != time.Duration(w.minutes)*time.Minute {
+			t.Errorf("Stats()[%s] = %d sessions, %v; want %d, %dm", tag, s.SessionCount, s.TotalTime, w.sessions, w.minutes)
+		}
+	}
+
+	period, err := store.Sessions().GetTaggedPeriodStats(ctx, start, end, "#client")
+	if err != nil {
+		t.Fatalf("GetTaggedPeriodStats() error = %v", err)
+	}
+	if period.TotalSessions != 2 || period.TotalWorkTime != 50*time.Minute {
+		t.Errorf("GetTaggedPeriodStats(client) = %d sessions, %v; want 2, 50m", period.TotalSessions, period.TotalWorkTime)
+	}
+}
+
+func TestMigrate_MovesLegacyTagColumns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flow.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	ctx := context.Background()
+	if err := runMigrations(ctx, db, migrations[:3]); err != nil {
+		t.Fatalf("runMigrations() error = %v", err)
+	}
+	_, err = db.Exec(`
+		INSERT INTO tasks (id, title, description, status, tags, created_at, updated_at)
+		VALUES ('t1', 'Legacy', '', 'pending', 'work,#client/acme,work', ?, ?);
+		INSERT INTO sessions (id, task_id, type, status, duration_ms, started_at, git_branch, git_commit, git_modified, tags)
+		VALUES ('s1', 't1', 'work', 'completed', 1500000, ?, '', '', '', 'deep');
+	`, time.Now(), time.Now(), time.Now())
+	if err != nil {
+		t.Fatalf("insert error = %v", err)
+	}
+	_ = db.Close()
+
+	store, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	task, err := store.Tasks().FindByID(ctx, "t1")
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if want := []string{"work", "client/acme"}; !reflect.DeepEqual(task.Tags, want) {
+		t.Errorf("migrated task tags = %v, want %v", task.Tags, want)
+	}
+	session, err := store.Sessions().FindByID(ctx, "s1")
+	if err != nil || session == nil {
+		t.Fatalf("FindByID(session) error = %v", err)
+	}
+	if want := []string{"deep"}; !reflect.DeepEqual(session.Tags, want) {
+		t.Errorf("migrated session tags = %v, want %v", session.Tags, want)
+	}
+}
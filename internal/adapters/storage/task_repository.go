@@ -12,31 +12,34 @@ import (
 	"github.com/xvierd/flow-cli/internal/ports"
 )
 
+// taskColumns is the select list read by the task scanners. Tags come from
+// the task_tags join table as a JSON array.
+var taskColumns = `tasks.id, tasks.title, tasks.description, tasks.status, ` +
+	tagListSQL(taskTagsTable, taskTagsOwner, "tasks.id") +
+	`, tasks.created_at, tasks.updated_at, tasks.completed_at, tasks.highlight_date`
+
 // taskRepository implements ports.TaskRepository using SQLite.
 type taskRepository struct {
-	db *sql.DB
+	db dbtx
 }
 
 // newTaskRepository creates a new task repository.
-func newTaskRepository(db *sql.DB) ports.TaskRepository {
+func newTaskRepository(db dbtx) ports.TaskRepository {
 	return &taskRepository{db: db}
 }
 
 // Save persists a task to storage.
 func (r *taskRepository) Save(ctx context.Context, task *domain.Task) error {
 	query := `
-		INSERT INTO tasks (id, title, description, status, tags, created_at, updated_at, completed_at, highlight_date)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO tasks (id, title, description, status, created_at, updated_at, completed_at, highlight_date)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	tags := strings.Join(task.Tags, ",")
-
 	_, err := r.db.ExecContext(ctx, query,
 		task.ID,
 		task.Title,
 		task.Description,
 		string(task.Status),
-		tags,
 		task.CreatedAt,
 		task.UpdatedAt,
 		task.CompletedAt,
@@ -47,13 +50,16 @@ func (r *taskRepository) Save(ctx context.Context, task *domain.Task) error {
 		return fmt.Errorf("failed to save task: %w", err)
 	}
 
-	return nil
+	if err := replaceTags(ctx, r.db, taskTagsTable, taskTagsOwner, task.ID, task.Tags); err != nil {
+		return err
+	}
+	return indexTask(ctx, r.db, task)
 }
 
 // FindByID retrieves a task by its unique identifier.
 func (r *taskRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
 	query := `
-		SELECT id, title, description, status, tags, created_at, updated_at, completed_at, highlight_date
+		SELECT ` + taskColumns + `
 		FROM tasks
 		WHERE id = ?
 	`
@@ -89,8 +95,8 @@ func (r *taskRepository) FindByID(ctx context.Context, id string) (*domain.Task,
 		task.HighlightDate = &highlightDate.Time
 	}
 
-	if tagsStr != "" {
-		task.Tags = strings.Split(tagsStr, ",")
+	if tags := decodeTags(tagsStr); len(tags) > 0 {
+		task.Tags = tags
 	}
 
 	return &task, nil
@@ -103,7 +109,7 @@ func (r *taskRepository) FindAll(ctx context.Context, status *domain.TaskStatus)
 
 	if status != nil {
 		query = `
-			SELECT id, title, description, status, tags, created_at, updated_at, completed_at, highlight_date
+			SELECT ` + taskColumns + `
 			FROM tasks
 			WHERE status = ?
 			ORDER BY created_at DESC
@@ -111,7 +117,7 @@ func (r *taskRepository) FindAll(ctx context.Context, status *domain.TaskStatus)
 		args = append(args, string(*status))
 	} else {
 		query = `
-			SELECT id, title, description, status, tags, created_at, updated_at, completed_at, highlight_date
+			SELECT ` + taskColumns + `
 			FROM tasks
 			ORDER BY created_at DESC
 		`
@@ -129,7 +135,7 @@ func (r *taskRepository) FindAll(ctx context.Context, status *domain.TaskStatus)
 // FindPending returns all tasks that are not completed or cancelled.
 func (r *taskRepository) FindPending(ctx context.Context) ([]*domain.Task, error) {
 	query := `
-		SELECT id, title, description, status, tags, created_at, updated_at, completed_at, highlight_date
+		SELECT ` + taskColumns + `
 		FROM tasks
 		WHERE status NOT IN (?, ?)
 		ORDER BY 
@@ -153,7 +159,7 @@ func (r *taskRepository) FindPending(ctx context.Context) ([]*domain.Task, error
 // FindActive returns the currently active task (in_progress).
 func (r *taskRepository) FindActive(ctx context.Context) (*domain.Task, error) {
 	query := `
-		SELECT id, title, description, status, tags, created_at, updated_at, completed_at, highlight_date
+		SELECT ` + taskColumns + `
 		FROM tasks
 		WHERE status = ?
 		ORDER BY updated_at DESC
@@ -193,43 +199,109 @@ func (r *taskRepository) FindActive(ctx context.Context) (*domain.Task, error) {
 
 	// Initialize tags as empty slice to avoid null in JSON
 	task.Tags = []string{}
-	if tagsStr != "" {
-		task.Tags = strings.Split(tagsStr, ",")
+	if tags := decodeTags(tagsStr); len(tags) > 0 {
+		task.Tags = tags
 	}
 
 	return &task, nil
 }
 
-// FindByTitle does a fuzzy search for tasks by title.
+// FindByTitle searches task titles through the full-text index, falling back
+// to fuzzy matching (which tolerates typos) when the index has no match.
 func (r *taskRepository) FindByTitle(ctx context.Context, query string) ([]*domain.Task, error) {
-	// First get all tasks
-	tasks, err := r.FindAll(ctx, nil)
+	match := ftsQuery(query)
+	if match == "" {
+		return nil, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+taskColumns+`
+		FROM search_index
+		JOIN tasks ON tasks.id = search_index.ref_id
+		WHERE search_index MATCH ? AND search_index.kind = ?
+		ORDER BY bm25(search_index)
+	`, "title : ("+match+")", searchKindTask)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks for fuzzy search: %w", err)
+		return nil, fmt.Errorf("failed to search tasks by title: %w", err)
 	}
+	defer func() { _ = rows.Close() }()
 
-	// Prepare titles for fuzzy search
-	titles := make([]string, len(tasks))
-	for i, task := range tasks {
-		titles[i] = task.Title
+	tasks, err := r.scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) > 0 {
+		return tasks, nil
 	}
+	return r.fuzzyFindByTitle(ctx, query)
+}
 
-	// Perform fuzzy search
-	matches := fuzzy.Find(query, titles)
+// fuzzyFindByTitle fuzzy-matches the query against every task title. Only
+// ids and titles are read for matching; the matched tasks are loaded after.
+func (r *taskRepository) fuzzyFindByTitle(ctx context.Context, query string) ([]*domain.Task, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, title FROM tasks ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks for fuzzy search: %w", err)
+	}
+	var ids, titles []string
+	for rows.Next() {
+		var id, title string
+		if err := rows.Scan(&id, &title); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan task title: %w", err)
+		}
+		ids = append(ids, id)
+		titles = append(titles, title)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	// Collect matching tasks
-	var result []*domain.Task
-	for _, match := range matches {
+	// Perform fuzzy search
+	var matched []interface{}
+	for _, match := range fuzzy.Find(query, titles) {
 		if match.Score > 0 {
-			result = append(result, tasks[match.Index])
+			matched = append(matched, ids[match.Index])
 		}
 	}
+	if len(matched) == 0 {
+		return nil, nil
+	}
 
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(matched)), ",")
+	rows, err = r.db.QueryContext(ctx, `SELECT `+taskColumns+` FROM tasks WHERE id IN (`+placeholders+`)`, matched...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fuzzy matches: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	tasks, err := r.scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the fuzzy ranking
+	byID := make(map[string]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	result := make([]*domain.Task, 0, len(tasks))
+	for _, id := range matched {
+		if task, ok := byID[id.(string)]; ok {
+			result = append(result, task)
+		}
+	}
 	return result, nil
 }
 
 // Delete removes a task from storage.
 func (r *taskRepository) Delete(ctx context.Context, id string) error {
+	// The task's sessions are unlinked, which moves them in the rollups.
+	days, err := taskRollupDays(ctx, r.db, id)
+	if err != nil {
+		return err
+	}
+
 	query := `DELETE FROM tasks WHERE id = ?`
 
 	result, err := r.db.ExecContext(ctx, query, id)
@@ -242,25 +314,35 @@ func (r *taskRepository) Delete(ctx context.Context, id string) error {
 		return domain.ErrTaskNotFound
 	}
 
-	return nil
+	if err := deleteTagLinks(ctx, r.db, taskTagsTable, taskTagsOwner, id); err != nil {
+		return err
+	}
+	if err := unindex(ctx, r.db, searchKindTask, id); err != nil {
+		return err
+	}
+	return refreshRollups(ctx, r.db, days...)
 }
 
 // Update modifies an existing task.
 func (r *taskRepository) Update(ctx context.Context, task *domain.Task) error {
 	query := `
 		UPDATE tasks
-		SET title = ?, description = ?, status = ?, tags = ?, updated_at = ?, completed_at = ?, highlight_date = ?
+		SET title = ?, description = ?, status = ?, updated_at = ?, completed_at = ?, highlight_date = ?
 		WHERE id = ?
 	`
-
-	tags := strings.Join(task.Tags, ",")
 	task.UpdatedAt = time.Now()
 
+	// Task tags count toward the task's sessions, so retagging a task
+	// refreshes the rollups of the days it was worked on.
+	var prevTags string
+	_ = r.db.QueryRowContext(ctx, `SELECT `+tagListSQL(taskTagsTable, taskTagsOwner, "tasks.id")+` FROM tasks WHERE id = ?`, task.ID).
+		Scan(&prevTags)
+	retagged := !sameTags(decodeTags(prevTags), task.Tags)
+
 	result, err := r.db.ExecContext(ctx, query,
 		task.Title,
 		task.Description,
 		string(task.Status),
-		tags,
 		task.UpdatedAt,
 		task.CompletedAt,
 		task.HighlightDate,
@@ -276,7 +358,39 @@ func (r *taskRepository) Update(ctx context.Context, task *domain.Task) error {
 		return domain.ErrTaskNotFound
 	}
 
-	return nil
+	if err := replaceTags(ctx, r.db, taskTagsTable, taskTagsOwner, task.ID, task.Tags); err != nil {
+		return err
+	}
+	if err := indexTask(ctx, r.db, task); err != nil {
+		return err
+	}
+	if !retagged {
+		return nil
+	}
+	days, err := taskRollupDays(ctx, r.db, task.ID)
+	if err != nil {
+		return err
+	}
+	return refreshRollups(ctx, r.db, days...)
+}
+
+// sameTags reports whether two tag lists hold the same tags, ignoring case
+// and order.
+func sameTags(a, b []string) bool {
+	a, b = domain.NormalizeTags(a), domain.NormalizeTags(b)
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]bool, len(a))
+	for _, t := range a {
+		seen[strings.ToLower(t)] = true
+	}
+	for _, t := range b {
+		if !seen[strings.ToLower(t)] {
+			return false
+		}
+	}
+	return true
 }
 
 // scanTasks scans multiple task rows.
@@ -313,8 +427,8 @@ func (r *taskRepository) scanTasks(rows *sql.Rows) ([]*domain.Task, error) {
 
 		// Initialize tags as empty slice to avoid null in JSON
 		task.Tags = []string{}
-		if tagsStr != "" {
-			task.Tags = strings.Split(tagsStr, ",")
+		if tags := decodeTags(tagsStr); len(tags) > 0 {
+			task.Tags = tags
 		}
 
 		tasks = append(tasks, &task)
@@ -327,14 +441,14 @@ func (r *taskRepository) scanTasks(rows *sql.Rows) ([]*domain.Task, error) {
 // ordered by most recent session start time.
 func (r *taskRepository) FindRecentTasks(ctx context.Context, limit int) ([]*domain.Task, error) {
 	query := `
-		SELECT t.id, t.title, t.description, t.status, t.tags, t.created_at, t.updated_at, t.completed_at, t.highlight_date
-		FROM tasks t
+		SELECT ` + taskColumns + `
+		FROM tasks
 		INNER JOIN (
 			SELECT task_id, MAX(started_at) AS last_session
 			FROM sessions
 			WHERE task_id IS NOT NULL
 			GROUP BY task_id
-		) s ON t.id = s.task_id
+		) s ON tasks.id = s.task_id
 		ORDER BY s.last_session DESC
 		LIMIT ?
 	`
@@ -354,7 +468,7 @@ func (r *taskRepository) FindTodayHighlight(ctx context.Context, date time.Time)
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
 	query := `
-		SELECT id, title, description, status, tags, created_at, updated_at, completed_at, highlight_date
+		SELECT ` + taskColumns + `
 		FROM tasks
 		WHERE highlight_date >= ? AND highlight_date < ?
 		ORDER BY updated_at DESC
@@ -393,20 +507,37 @@ func (r *taskRepository) FindTodayHighlight(ctx context.Context, date time.Time)
 	}
 
 	task.Tags = []string{}
-	if tagsStr != "" {
-		task.Tags = strings.Split(tagsStr, ",")
+	if tags := decodeTags(tagsStr); len(tags) > 0 {
+		task.Tags = tags
 	}
 
 	return &task, nil
 }
 
+// FindHighlights returns the tasks highlighted for a day in [start, end),
+// ordered by highlight date and, within a day, most recently updated first.
+func (r *taskRepository) FindHighlights(ctx context.Context, start, end time.Time) ([]*domain.Task, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+taskColumns+`
+		FROM tasks
+		WHERE highlight_date >= ? AND highlight_date < ?
+		ORDER BY highlight_date, updated_at DESC
+	`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find highlights: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return r.scanTasks(rows)
+}
+
 // FindYesterdayHighlight returns yesterday's highlight task if it wasn't completed.
 func (r *taskRepository) FindYesterdayHighlight(ctx context.Context, today time.Time) (*domain.Task, error) {
 	yesterday := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location()).Add(-24 * time.Hour)
 	endOfYesterday := yesterday.Add(24 * time.Hour)
 
 	query := `
-		SELECT id, title, description, status, tags, created_at, updated_at, completed_at, highlight_date
+		SELECT ` + taskColumns + `
 		FROM tasks
 		WHERE highlight_date >= ? AND highlight_date < ?
 		  AND status != ?
@@ -446,8 +577,8 @@ func (r *taskRepository) FindYesterdayHighlight(ctx context.Context, today time.
 	}
 
 	task.Tags = []string{}
-	if tagsStr != "" {
-		task.Tags = strings.Split(tagsStr, ",")
+	if tags := decodeTags(tagsStr); len(tags) > 0 {
+		task.Tags = tags
 	}
 
 	return &task, nil
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// webhookRepository implements ports.WebhookRepository on the
+// webhook_deliveries table.
+type webhookRepository struct {
+	db dbtx
+}
+
+// newWebhookRepository creates a new webhook delivery repository.
+func newWebhookRepository(db dbtx) ports.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+const webhookColumns = `id, url, event, payload, status, attempts, COALESCE(last_error, ''), created_at, next_attempt_at, delivered_at`
+
+// Save inserts or updates a delivery.
+func (r *webhookRepository) Save(ctx context.Context, d *domain.WebhookDelivery) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (id, url, event, payload, status, attempts, last_error, created_at, next_attempt_at, delivered_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			status = excluded.status,
+			attempts = excluded.attempts,
+			last_error = excluded.last_error,
+			next_attempt_at = excluded.next_attempt_at,
+			delivered_at = excluded.delivered_at
+	`, d.ID, d.URL, string(d.Event), d.Payload, string(d.Status), d.Attempts, d.LastError, d.CreatedAt, d.NextAttemptAt, d.DeliveredAt)
+	if err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// FindDue returns pending deliveries due at or before now, oldest first.
+func (r *webhookRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	return r.query(ctx, `
+		SELECT `+webhookColumns+` FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at LIMIT ?
+	`, string(domain.WebhookPending), now, limit)
+}
+
+// FindRecent returns the latest deliveries, newest first.
+func (r *webhookRepository) FindRecent(ctx context.Context, limit int) ([]*domain.WebhookDelivery, error) {
+	return r.query(ctx, `
+		SELECT `+webhookColumns+` FROM webhook_deliveries
+		ORDER BY created_at DESC LIMIT ?
+	`, limit)
+}
+
+func (r *webhookRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		var event, status string
+		var deliveredAt sql.NullTime
+		if err := rows.Scan(&d.ID, &d.URL, &event, &d.Payload, &status, &d.Attempts, &d.LastError,
+			&d.CreatedAt, &d.NextAttemptAt, &deliveredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		d.Event = domain.HookEvent(event)
+		d.Status = domain.WebhookDeliveryStatus(status)
+		if deliveredAt.Valid {
+			d.DeliveredAt = &deliveredAt.Time
+		}
+		deliveries = append(deliveries, &d)
+	}
+	return deliveries, rows.Err()
+}
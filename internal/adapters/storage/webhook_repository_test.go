@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+func TestWebhookRepository_Queue(t *testing.T) {
+	store, err := NewMemory()
+	if err != nil {
+		t.Fatalf("NewMemory() error = %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+	repo := store.Webhooks()
+	now := time.Now()
+
+	due := domain.NewWebhookDelivery("http://example.com/a", domain.HookStart, []byte(`{"event":"on_start"}`))
+	due.CreatedAt = now.Add(-2 * time.Minute)
+	due.RecordFailure(errors.New("connection refused"), now.Add(-2*time.Minute))
+
+	later := domain.NewWebhookDelivery("http://example.com/b", domain.HookComplete, []byte(`{}`))
+	later.CreatedAt = now.Add(-time.Minute)
+	later.RecordFailure(errors.New("502 Bad Gateway"), now)
+
+	delivered := domain.NewWebhookDelivery("http://example.com/c", domain.HookPause, []byte(`{}`))
+	delivered.RecordSuccess(now)
+
+	for _, d := range []*domain.WebhookDelivery{due, later, delivered} {
+		if err := repo.Save(ctx, d); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	got, err := repo.FindDue(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("FindDue() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != due.ID {
+		t.Fatalf("FindDue() = %v, want only the delivery whose retry is due", got)
+	}
+	if got[0].Event != domain.HookStart || string(got[0].Payload) != `{"event":"on_start"}` ||
+		got[0].Attempts != 1 || got[0].LastError != "connection refused" {
+		t.Errorf("FindDue()[0] = %+v, want the saved delivery", got[0])
+	}
+
+	due.RecordSuccess(now)
+	if err := repo.Save(ctx, due); err != nil {
+		t.Fatalf("Save() update error = %v", err)
+	}
+	if got, _ := repo.FindDue(ctx, now.Add(time.Hour), 10); len(got) != 1 || got[0].ID != later.ID {
+		t.Errorf("FindDue() after delivery = %v, want only the later retry", got)
+	}
+
+	recent, err := repo.FindRecent(ctx, 10)
+	if err != nil {
+		t.Fatalf("FindRecent() error = %v", err)
+	}
+	if len(recent) != 3 || recent[0].ID != delivered.ID || recent[2].ID != due.ID {
+		t.Errorf("FindRecent() = %v, want newest first", recent)
+	}
+	if recent[2].Status != domain.WebhookDelivered || recent[2].DeliveredAt == nil {
+		t.Errorf("updated delivery = %+v, want delivered", recent[2])
+	}
+}
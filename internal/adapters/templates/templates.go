@@ -0,0 +1,131 @@
+// Package templates renders command output through the user's Go
+// text/templates, given inline or by name from ~/.flow/templates/.
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Ext is the file extension of named templates.
+const Ext = ".tmpl"
+
+// Funcs are available to every output template, on top of the text/template
+// builtins.
+var Funcs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join":  join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"pad": func(width int, v interface{}) string {
+		return fmt.Sprintf("%-*v", width, v)
+	},
+	"duration": duration,
+}
+
+// Load parses spec as an inline template when it contains "{{". Otherwise
+// spec names a file in dir, with or without the .tmpl extension.
+func Load(spec, dir string) (*template.Template, error) {
+	if strings.Contains(spec, "{{") {
+		tmpl, err := template.New("format").Funcs(Funcs).Parse(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+		return tmpl, nil
+	}
+
+	name := strings.TrimSuffix(spec, Ext)
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("invalid template name %q", spec)
+	}
+	path := filepath.Join(dir, name+Ext)
+	data, err := os.ReadFile(path) //nolint:gosec // path is confined to the templates directory
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no template %q: put a Go template in %s or pass one inline", name, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", path, err)
+	}
+	tmpl, err := template.New(name).Funcs(Funcs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+// Execute renders data through tmpl. The data goes through JSON first, so
+// templates see exactly the fields and values --json prints.
+func Execute(w io.Writer, tmpl *template.Template, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode template data: %w", err)
+	}
+	var model interface{}
+	if err := json.Unmarshal(encoded, &model); err != nil {
+		return fmt.Errorf("failed to decode template data: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, model); err != nil {
+		return fmt.Errorf("template failed: %w", err)
+	}
+	// Inline templates rarely spell out the final newline.
+	if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// join joins a list from the data model, e.g. {{join .tags ", "}}.
+func join(list interface{}, sep string) string {
+	switch l := list.(type) {
+	case []string:
+		return strings.Join(l, sep)
+	case []interface{}:
+		parts := make([]string, len(l))
+		for i, v := range l {
+			parts[i] = fmt.Sprint(v)
+		}
+		return strings.Join(parts, sep)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(l)
+	}
+}
+
+// duration formats a number of seconds, e.g. {{duration .work_seconds}}
+// prints "1h 30m".
+func duration(seconds interface{}) string {
+	var d time.Duration
+	switch s := seconds.(type) {
+	case float64:
+		d = time.Duration(s * float64(time.Second))
+	case int:
+		d = time.Duration(s) * time.Second
+	default:
+		return ""
+	}
+	d = d.Round(time.Minute)
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case h > 0 && m > 0:
+		return fmt.Sprintf("%dh %dm", h, m)
+	case h > 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dm", m)
+	}
+}
@@ -0,0 +1,60 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadInline(t *testing.T) {
+	tmpl, err := Load(`{{.count}} tasks: {{join .tags ", "}}`, t.TempDir())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var out strings.Builder
+	data := map[string]interface{}{"count": 2, "tags": []string{"docs", "api"}}
+	if err := Execute(&out, tmpl, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := out.String(); got != "2 tasks: docs, api\n" {
+		t.Errorf("output = %q, want the count, tags and a final newline", got)
+	}
+
+	if _, err := Load(`{{.count`, ""); err == nil {
+		t.Error("Load() of a broken template = nil, want an error")
+	}
+}
+
+func TestLoadNamed(t *testing.T) {
+	dir := t.TempDir()
+	body := "{{range .tasks}}- {{upper .title}} ({{duration .work_seconds}})\n{{end}}"
+	if err := os.WriteFile(filepath.Join(dir, "weekly.tmpl"), []byte(body), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"weekly", "weekly.tmpl"} {
+		tmpl, err := Load(name, dir)
+		if err != nil {
+			t.Fatalf("Load(%q) error = %v", name, err)
+		}
+		var out strings.Builder
+		data := struct {
+			Tasks []map[string]interface{} `json:"tasks"`
+		}{[]map[string]interface{}{{"title": "docs", "work_seconds": 5400}}}
+		if err := Execute(&out, tmpl, data); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if got := out.String(); got != "- DOCS (1h 30m)\n" {
+			t.Errorf("Load(%q) output = %q", name, got)
+		}
+	}
+
+	if _, err := Load("missing", dir); err == nil || !strings.Contains(err.Error(), "missing.tmpl") {
+		t.Errorf("Load(missing) error = %v, want the path it looked for", err)
+	}
+	if _, err := Load("../secrets", dir); err == nil {
+		t.Error("Load() of a path outside the templates directory = nil, want an error")
+	}
+}
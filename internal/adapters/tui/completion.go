@@ -10,7 +10,7 @@ import (
 // after a session completes. It is embedded in both Model and InlineModel to
 // eliminate field-level duplication between the two TUI implementations.
 type completionState struct {
-	// Deep Work: distraction log
+	// Distraction log (Deep Work, or opted into)
 	distractionMode         bool
 	distractionInput        textinput.Model
 	distractions            []string
@@ -77,6 +77,19 @@ func (c *completionState) reset() {
 	c.completedIntendedOutcome = ""
 }
 
+// syncDistractions picks up distractions logged on the active work session
+// from outside the timer, e.g. with "flow distract", so the counter and the
+// review include them.
+func (c *completionState) syncDistractions(session *domain.PomodoroSession) {
+	if session == nil || session.Type != domain.SessionTypeWork || len(session.Distractions) <= len(c.distractions) {
+		return
+	}
+	c.distractions = c.distractions[:0]
+	for _, d := range session.Distractions {
+		c.distractions = append(c.distractions, d.Text)
+	}
+}
+
 // promptsDone returns true when all mode-specific completion prompts are satisfied,
 // given the completed session type and current methodology mode.
 func (c *completionState) promptsDone(mode methodology.Mode, completedType domain.SessionType) bool {
@@ -98,7 +111,7 @@ func (c *completionState) promptsDone(mode methodology.Mode, completedType domai
 		return true
 	}
 	// Make Time: need focus score and energize activity logged
-	if mode.HasFocusScore() && completedType == domain.SessionTypeWork {
+	if mode.HasEnergizeReminder() && completedType == domain.SessionTypeWork {
 		return c.focusScoreSaved && c.energizeSaved
 	}
 	// Pomodoro or break: always ready
@@ -1,6 +1,10 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/xvierd/flow-cli/internal/domain"
@@ -10,6 +14,7 @@ import (
 // completionCallbacks holds the external callbacks used by completion input handlers.
 type completionCallbacks struct {
 	distractionCallback     func(string, string) error
+	distractionCategories   []string
 	accomplishmentCallback  func(string) error
 	shutdownRitualCallback  func(domain.ShutdownRitual) error
 	outcomeAchievedCallback func(string) error
@@ -22,35 +27,20 @@ type completionCallbacks struct {
 func handleDistractionInput(cs *completionState, cb *completionCallbacks, msg tea.Msg, doneCmd tea.Cmd) tea.Cmd {
 	// Category picker sub-mode
 	if cs.distractionCategoryMode {
-		switch msg := msg.(type) {
-		case tea.KeyMsg:
-			switch msg.String() {
-			case "i":
-				cs.distractions = append(cs.distractions, cs.distractionPendingText)
-				if cb.distractionCallback != nil {
-					_ = cb.distractionCallback(cs.distractionPendingText, "internal")
-				}
-				cs.distractionCategoryMode = false
-				cs.distractionMode = false
-				return doneCmd
-			case "e":
-				cs.distractions = append(cs.distractions, cs.distractionPendingText)
-				if cb.distractionCallback != nil {
-					_ = cb.distractionCallback(cs.distractionPendingText, "external")
-				}
-				cs.distractionCategoryMode = false
-				cs.distractionMode = false
-				return doneCmd
-			case "enter", "esc":
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			key := msg.String()
+			category, picked := distractionCategoryForKey(cb.distractionCategories, key)
+			switch {
+			case key == "ctrl+c":
+				return tea.Quit
+			case picked, key == "enter", key == "esc":
 				cs.distractions = append(cs.distractions, cs.distractionPendingText)
 				if cb.distractionCallback != nil {
-					_ = cb.distractionCallback(cs.distractionPendingText, "")
+					_ = cb.distractionCallback(cs.distractionPendingText, category)
 				}
 				cs.distractionCategoryMode = false
 				cs.distractionMode = false
 				return doneCmd
-			case "ctrl+c":
-				return tea.Quit
 			}
 		}
 		return nil
@@ -90,6 +80,53 @@ func handleDistractionInput(cs *completionState, cb *completionCallbacks, msg te
 	return cmd
 }
 
+// distractionCategoryForKey returns the category a key picks in the
+// category picker: its position, 1 to 9, or its first letter when no other
+// category starts with it. No categories means the default ones.
+func distractionCategoryForKey(categories []string, key string) (string, bool) {
+	if len(categories) == 0 {
+		categories = domain.DefaultDistractionCategories
+	}
+	if utf8.RuneCountInString(key) != 1 {
+		return "", false
+	}
+	if key[0] >= '1' && key[0] <= '9' {
+		if i := int(key[0] - '1'); i < len(categories) {
+			return categories[i], true
+		}
+		return "", false
+	}
+	match := ""
+	for _, c := range categories {
+		if strings.HasPrefix(c, key) {
+			if match != "" {
+				return "", false
+			}
+			match = c
+		}
+	}
+	return match, match != ""
+}
+
+// distractionCategoryHelp describes the category picker's keys, e.g.
+// "[i]nternal  [e]xternal  [enter] skip category".
+func distractionCategoryHelp(categories []string) string {
+	if len(categories) == 0 {
+		categories = domain.DefaultDistractionCategories
+	}
+	parts := make([]string, 0, len(categories)+1)
+	for i, c := range categories {
+		_, size := utf8.DecodeRuneInString(c)
+		if picked, ok := distractionCategoryForKey(categories, c[:size]); ok && picked == c {
+			parts = append(parts, "["+c[:size]+"]"+c[size:])
+		} else if i < 9 {
+			parts = append(parts, fmt.Sprintf("[%d] %s", i+1, c))
+		}
+	}
+	parts = append(parts, "[enter] skip category")
+	return strings.Join(parts, "  ")
+}
+
 // handleAccomplishmentInput processes messages while in accomplishment input mode.
 func handleAccomplishmentInput(cs *completionState, cb *completionCallbacks, msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
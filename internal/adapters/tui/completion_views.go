@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/xvierd/flow-cli/internal/domain"
@@ -48,6 +49,10 @@ type completionViewData struct {
 
 	// Make Time
 	hasHighlightTask bool
+
+	// Mob: who takes over, and whether the mob's break is due
+	nextRotation *domain.Rotation
+	breakDue     bool
 }
 
 // buildCompletionViewData derives all values needed for completion screens from
@@ -77,6 +82,14 @@ func buildCompletionViewData(
 		isLongBreak = completionInfo.NextBreakType == domain.SessionTypeLongBreak
 		sessionsBeforeLong = completionInfo.SessionsBeforeLong
 		sessionsUntilLong = completionInfo.SessionsUntilLong
+		// Flowtime: the break is earned from the time just worked, with no
+		// short/long cycle.
+		if mode != nil && mode.Name().CountsUp() {
+			breakLabel = "Earned Break"
+			breakDur = formatDuration(completionInfo.EarnedBreaks.BreakFor(completedElapsed))
+			isLongBreak = false
+			sessionsBeforeLong, sessionsUntilLong = 0, 0
+		}
 	}
 
 	streak := 0
@@ -104,5 +117,45 @@ func buildCompletionViewData(
 		statsBreaksTaken:   stats.BreaksTaken,
 		statsTotalWorkTime: stats.TotalWorkTime,
 		hasHighlightTask:   hasHighlight,
+		nextRotation:       upNext(completionInfo),
+		breakDue:           isLongBreak,
+	}
+}
+
+// upNext returns the mob's next rotation, or nil outside Mob mode or
+// without a roster.
+func upNext(info *domain.CompletionInfo) *domain.Rotation {
+	if info == nil || info.NextRotation == nil || info.NextRotation.Driver == "" {
+		return nil
+	}
+	return info.NextRotation
+}
+
+// rotationLines returns the lines a mob rotation's completion screen
+// announces the handover with: who takes the keyboard, then the break if
+// it is due or how many rotations remain until it.
+func rotationLines(vd completionViewData) (handover, breakLine string) {
+	handover = "Rotate! " + vd.nextRotation.String()
+	switch {
+	case vd.breakDue:
+		breakLine = fmt.Sprintf("Break time: [b] %s together", vd.breakDur)
+	case vd.sessionsBeforeLong > 0:
+		breakLine = fmt.Sprintf("%d of %d rotations until the break",
+			vd.sessionsBeforeLong-vd.sessionsUntilLong, vd.sessionsBeforeLong)
+	}
+	return handover, breakLine
+}
+
+// optionalFocusScoreLine returns the focus score line of the Pomodoro and
+// Deep Work completion screens when focus_score is on: the prompt until a
+// score is given, then the score. The score never locks [n]ew session
+// there; Make Time renders its own required prompt.
+func optionalFocusScoreLine(cs *completionState, mode methodology.Mode) (line string, saved bool) {
+	if mode == nil || !mode.HasFocusScore() {
+		return "", false
+	}
+	if cs.focusScoreSaved && cs.focusScore != nil {
+		return fmt.Sprintf("Focus score: %d/5", *cs.focusScore), true
 	}
+	return "How focused were you? [1]-[5] (optional)", false
 }
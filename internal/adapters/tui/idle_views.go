@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/xvierd/flow-cli/internal/domain"
 	"github.com/xvierd/flow-cli/internal/methodology"
@@ -22,6 +23,10 @@ func viewIdleMethodologyInfo(state *domain.CurrentState, mode methodology.Mode,
 		return viewIdleMakeTime(state)
 	case domain.MethodologyDeepWork:
 		return viewIdleDeepWork(state, mode, completionInfo)
+	case domain.MethodologyFlowtime:
+		return viewIdleFlowtime(state)
+	case domain.MethodologyMob:
+		return viewIdleMob(completionInfo)
 	default:
 		return ""
 	}
@@ -36,6 +41,23 @@ func viewIdlePomodoro(state *domain.CurrentState) string {
 	return fmt.Sprintf("  🍅 %d %s today", sessions, label)
 }
 
+func viewIdleFlowtime(state *domain.CurrentState) string {
+	stats := state.TodayStats
+	if stats.WorkSessions == 0 {
+		return "  Start counting up; stop when your focus fades"
+	}
+	return fmt.Sprintf("  %s in flow today, %s per session on average",
+		formatMinutesCompact(stats.TotalWorkTime), formatMinutesCompact(stats.TotalWorkTime/time.Duration(stats.WorkSessions)))
+}
+
+func viewIdleMob(completionInfo *domain.CompletionInfo) string {
+	next := upNext(completionInfo)
+	if next == nil {
+		return "  No mob yet: add names to roster under [mob] in ~/.flow/config.toml"
+	}
+	return "  Up next: " + next.String()
+}
+
 func viewIdleMakeTime(state *domain.CurrentState) string {
 	if state.ActiveTask != nil && state.ActiveTask.IsTodayHighlight() {
 		return fmt.Sprintf("  ★ Highlight: \"%s\"", state.ActiveTask.Title)
@@ -28,13 +28,16 @@ type InlineModel struct {
 
 	// Mode picker
 	modeCursor     int
+	modeOptions    []modeOption
 	modeLocked     bool
 	onModeSelected func(domain.Methodology)
 
 	// Setup: duration picker
-	presets      []config.SessionPreset
-	presetCursor int
-	breakInfo    string
+	presets         []config.SessionPreset
+	presetCursor    int    // index into presets
+	presetFilter    string // fuzzy filter typed after "/"
+	presetFiltering bool
+	breakInfo       string
 
 	// Setup: task select (recent tasks)
 	recentTasks      []*domain.Task
@@ -71,6 +74,7 @@ type InlineModel struct {
 	commandCallback         func(ports.TimerCommand) error
 	onSessionComplete       func(domain.SessionType)
 	distractionCallback     func(string, string) error
+	distractionCategories   []string
 	accomplishmentCallback  func(string) error
 	focusScoreCallback      func(int) error
 	energizeCallback        func(string) error
@@ -80,6 +84,7 @@ type InlineModel struct {
 
 	// Callbacks for session creation (called during setup phase)
 	onStartSession func(presetIndex int, taskName string, intendedOutcome string) error
+	onFollowPlan   func(*domain.PlanBlock) error
 
 	// Methodology mode
 	mode           methodology.Mode
@@ -137,6 +142,7 @@ func NewInlineModel(state *domain.CurrentState, info *domain.CompletionInfo, the
 		progress:       pbar,
 		width:          w,
 		completionInfo: info,
+		modeOptions:    modeOptionsFor(nil),
 		theme:          resolved,
 		taskInput:      ti,
 		outcomeInput:   oi,
@@ -196,8 +202,9 @@ func (m InlineModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m InlineModel) updateDistractionInput(msg tea.Msg) (tea.Model, tea.Cmd) {
 	cb := &completionCallbacks{
-		distractionCallback: m.distractionCallback,
-		mode:                m.mode,
+		distractionCallback:   m.distractionCallback,
+		distractionCategories: m.distractionCategories,
+		mode:                  m.mode,
 	}
 	return m, handleDistractionInput(&m.completionState, cb, msg, tickCmd())
 }
@@ -371,10 +378,17 @@ func (m InlineModel) updateTimer(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 			}
+			// Mob: the next driver takes over right away
+			if m.completed && m.mode != nil && m.mode.HasRotation() && m.commandCallback != nil {
+				_ = m.commandCallback(ports.CmdStart)
+				m.completed = false
+				m.notified = false
+				m.resetCompletionState()
+				return m, nil
+			}
 			// Session chaining: start new session
 			if m.completed && m.completionPromptsComplete() {
-				m.phase = phasePickDuration
-				m.presetCursor = 0
+				m = m.pickDuration()
 				m.completed = false
 				m.notified = false
 				m.resetCompletionState()
@@ -478,7 +492,7 @@ func (m InlineModel) updateTimer(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.state != nil {
 			if m.state.ActiveSession != nil && msg.state.ActiveSession == nil {
 				m.completedType = m.state.ActiveSession.Type
-				m.completedElapsed = m.state.ActiveSession.Duration
+				m.completedElapsed = workedTime(m.state.ActiveSession)
 				m.completedIntendedOutcome = m.state.ActiveSession.IntendedOutcome
 				m.completed = true
 				if !m.notified && m.onSessionComplete != nil {
@@ -499,6 +513,7 @@ func (m InlineModel) updateTimer(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.resetCompletionState()
 			}
 			m.state = msg.state
+			m.syncDistractions(msg.state.ActiveSession)
 		}
 
 	case *domain.CurrentState:
@@ -543,7 +558,7 @@ func (m InlineModel) completionPromptsComplete() bool {
 		return true
 	}
 	// Make Time: need focus score and energize activity
-	if m.mode.HasFocusScore() && m.completedType == domain.SessionTypeWork {
+	if m.mode.HasEnergizeReminder() && m.completedType == domain.SessionTypeWork {
 		return m.focusScoreSaved && m.energizeSaved
 	}
 	// Pomodoro or break: always ready
@@ -603,6 +618,10 @@ func (m InlineModel) viewTimer() string {
 			b.WriteString(dim.Render("  No active session"))
 			b.WriteString("\n")
 		}
+		if line := planLine(m.state); line != "" {
+			b.WriteString(dim.Render("  " + line))
+			b.WriteString("\n")
+		}
 		b.WriteString(dim.Render("  [s]tart  [m]ode  [c]lose"))
 		b.WriteString("\n")
 		return b.String()
@@ -632,8 +651,7 @@ func (m InlineModel) viewDailySummary(accent, dim lipgloss.Style) string {
 
 func (m InlineModel) viewInlineActive(accent, dim, pausedStyle lipgloss.Style) string {
 	session := m.state.ActiveSession
-	remaining := session.RemainingTime()
-	timeStr := formatDuration(remaining)
+	timeStr := formatDuration(clockTime(session))
 	prog := session.Progress()
 	typeLabel := domain.GetSessionTypeLabel(session.Type)
 
@@ -655,6 +673,10 @@ func (m InlineModel) viewInlineActive(accent, dim, pausedStyle lipgloss.Style) s
 	if m.state.ActiveTask != nil {
 		b.WriteString(dim.Render(fmt.Sprintf("  %s %s", m.theme.IconTask, m.state.ActiveTask.Title)))
 	}
+	if session.Driver != "" {
+		rotation := domain.Rotation{Driver: session.Driver, Navigator: session.Navigator}
+		b.WriteString(dim.Render("  " + rotation.String()))
+	}
 	if session.IntendedOutcome != "" {
 		b.WriteString(dim.Italic(true).Render(fmt.Sprintf("  Goal: %s", session.IntendedOutcome)))
 	}
@@ -666,6 +688,10 @@ func (m InlineModel) viewInlineActive(accent, dim, pausedStyle lipgloss.Style) s
 		b.WriteString(dim.Render(tagStr))
 	}
 	b.WriteString("\n")
+	if line := planLine(m.state); line != "" {
+		b.WriteString(dim.Render("  " + line))
+		b.WriteString("\n")
+	}
 
 	// Make Time: energize reminder
 	if m.energizeTicks > 0 {
@@ -681,7 +707,7 @@ func (m InlineModel) viewInlineActive(accent, dim, pausedStyle lipgloss.Style) s
 		if m.distractionCategoryMode {
 			b.WriteString(dim.Render(fmt.Sprintf("  Categorize: %s", m.distractionPendingText)))
 			b.WriteString("\n")
-			b.WriteString(dim.Render("  [i]nternal  [e]xternal  [enter] skip category"))
+			b.WriteString(dim.Render("  " + distractionCategoryHelp(m.distractionCategories)))
 			b.WriteString("\n")
 		} else {
 			b.WriteString(dim.Render("  Distraction: ") + m.distractionInput.View())
@@ -713,8 +739,12 @@ func (m InlineModel) viewInlineActive(accent, dim, pausedStyle lipgloss.Style) s
 		pbar = progress.New(progress.WithGradient(m.theme.WorkGradientStart, m.theme.WorkGradientEnd))
 	}
 	pbar.Width = barWidth
-	b.WriteString("  " + pbar.ViewAs(prog))
-	b.WriteString(dim.Render(fmt.Sprintf("  %d%%", int(prog*100))))
+	if session.IsOpenEnded() {
+		b.WriteString(dim.Render("  counting up · [f]inish when your focus fades"))
+	} else {
+		b.WriteString("  " + pbar.ViewAs(prog))
+		b.WriteString(dim.Render(fmt.Sprintf("  %d%%", int(prog*100))))
+	}
 	b.WriteString("\n")
 
 	// Notification indicator
@@ -757,7 +787,7 @@ func (m InlineModel) viewInlineComplete(accent, dim lipgloss.Style) string {
 		if m.mode != nil && m.mode.HasShutdownRitual() {
 			return m.viewInlineDeepWorkComplete(accent, dim)
 		}
-		if m.mode != nil && m.mode.HasFocusScore() {
+		if m.mode != nil && m.mode.HasEnergizeReminder() {
 			return m.viewInlineMakeTimeComplete(accent, dim)
 		}
 		return m.viewInlineDefaultComplete(accent, dim)
@@ -767,9 +797,15 @@ func (m InlineModel) viewInlineComplete(accent, dim lipgloss.Style) string {
 	var b strings.Builder
 	b.WriteString(accent.Render(fmt.Sprintf("  %s Break over!", m.theme.IconApp)))
 	b.WriteString("\n")
-	b.WriteString(dim.Render("  Start your next session or call it a day."))
-	b.WriteString("\n")
-	b.WriteString(dim.Render("  [n]ew session  [m]ode  [q]uit"))
+	if next := upNext(m.completionInfo); next != nil {
+		b.WriteString(dim.Render("  Up next: " + next.String()))
+		b.WriteString("\n")
+		b.WriteString(dim.Render("  [n]ext rotation  [m]ode  [q]uit"))
+	} else {
+		b.WriteString(dim.Render("  Start your next session or call it a day."))
+		b.WriteString("\n")
+		b.WriteString(dim.Render("  [n]ew session  [m]ode  [q]uit"))
+	}
 	b.WriteString("\n")
 	return b.String()
 }
@@ -794,8 +830,29 @@ func (m InlineModel) viewInlineDefaultComplete(accent, dim lipgloss.Style) strin
 		b.WriteString("\n")
 	}
 
+	if line, saved := optionalFocusScoreLine(&m.completionState, m.mode); line != "" {
+		style := dim
+		if saved {
+			style = accent
+		}
+		b.WriteString(style.Render("  " + line))
+		b.WriteString("\n")
+	}
+
+	if vd.nextRotation != nil {
+		handover, breakLine := rotationLines(vd)
+		b.WriteString(accent.Render("  " + handover))
+		b.WriteString("\n")
+		if breakLine != "" {
+			b.WriteString(dim.Render("  " + breakLine))
+			b.WriteString("\n")
+		}
+	}
+
 	if m.autoBreakTicks > 0 {
 		b.WriteString(accent.Render(fmt.Sprintf("  Break starting in %ds... press any key to cancel", m.autoBreakTicks)))
+	} else if vd.nextRotation != nil {
+		b.WriteString(dim.Render("  [n]ext rotation  [b]reak  [m]ode  [q]uit"))
 	} else if vd.hasBreakInfo {
 		b.WriteString(dim.Render(fmt.Sprintf("  [n]ew session  [b]reak %s %s  [m]ode  [q]uit", vd.breakDur, vd.breakLabel)))
 	} else {
@@ -825,6 +882,15 @@ func (m InlineModel) viewInlineDeepWorkComplete(accent, dim lipgloss.Style) stri
 		formatMinutesCompact(vd.statsTotalWorkTime), vd.deepWorkPct, vd.deepWorkGoalHours)))
 	b.WriteString("\n")
 
+	if line, saved := optionalFocusScoreLine(&m.completionState, m.mode); line != "" {
+		style := dim
+		if saved {
+			style = accent
+		}
+		b.WriteString(style.Render("  " + line))
+		b.WriteString("\n")
+	}
+
 	if m.shutdownRitualMode {
 		b.WriteString(accent.Render(fmt.Sprintf("  Shutdown Ritual (step %d/3):", m.shutdownStep+1)))
 		b.WriteString("\n")
@@ -947,6 +1013,15 @@ func (m InlineModel) viewInlineMakeTimeComplete(accent, dim lipgloss.Style) stri
 	return b.String()
 }
 
+// presetLabel returns a preset's name and length, e.g. "Focus 25m", or
+// "Flow (counts up)" for an open-ended preset.
+func presetLabel(p config.SessionPreset) string {
+	if p.Duration == 0 {
+		return p.Name + " (counts up)"
+	}
+	return fmt.Sprintf("%s %s", p.Name, formatMinutesCompact(p.Duration))
+}
+
 func formatMinutesCompact(d time.Duration) string {
 	h := int(d.Hours())
 	m := int(d.Minutes()) % 60
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -51,15 +52,40 @@ var mainMenuOptions = []mainMenuOption{
 
 // modeOption describes a methodology choice in the mode picker.
 type modeOption struct {
-	methodology domain.Methodology
-	label       string
-	desc        string
+	mode  methodology.Mode
+	label string
+	desc  string
 }
 
-var modeOptions = []modeOption{
-	{domain.MethodologyPomodoro, "Pomodoro", "Classic 25/5 timer"},
-	{domain.MethodologyDeepWork, "Deep Work", "Longer sessions, distraction tracking"},
-	{domain.MethodologyMakeTime, "Make Time", "Daily Highlight, focus scoring"},
+// modeSummaries are the one-line pitches of the built-in modes.
+var modeSummaries = map[domain.Methodology]string{
+	domain.MethodologyPomodoro: "Classic 25/5 timer",
+	domain.MethodologyDeepWork: "Longer sessions, distraction tracking",
+	domain.MethodologyMakeTime: "Daily Highlight, focus scoring",
+	domain.MethodologyFlowtime: "Count up, break earned from time worked",
+	domain.MethodologyMob:      "Driver/navigator rotation for pairs and mobs",
+}
+
+// ModeSummary returns the one-line pitch shown for mode in mode pickers;
+// user-defined modes show their description.
+func ModeSummary(mode methodology.Mode) string {
+	if summary, ok := modeSummaries[mode.Name()]; ok {
+		return summary
+	}
+	return mode.Description()
+}
+
+// modeOptionsFor lists modes as mode picker choices, or the built-in
+// modes when there are none.
+func modeOptionsFor(modes []methodology.Mode) []modeOption {
+	if len(modes) == 0 {
+		modes = methodology.All(nil)
+	}
+	options := make([]modeOption, len(modes))
+	for i, mode := range modes {
+		options[i] = modeOption{mode: mode, label: mode.Name().Label(), desc: ModeSummary(mode)}
+	}
+	return options
 }
 
 func (m InlineModel) updateWelcome(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -87,7 +113,7 @@ func (m InlineModel) viewWelcome() string {
 	var b strings.Builder
 	b.WriteString(accent.Render("  Welcome to Flow!"))
 	b.WriteString("\n")
-	b.WriteString(dim.Render("  Three methodologies to choose from:"))
+	b.WriteString(dim.Render("  Four methodologies to choose from:"))
 	b.WriteString("\n")
 	b.WriteString(accent.Render("  Pomodoro   ") + dim.Render("25m sprints, short breaks — frictionless and fast"))
 	b.WriteString("\n")
@@ -95,6 +121,8 @@ func (m InlineModel) viewWelcome() string {
 	b.WriteString("\n")
 	b.WriteString(accent.Render("  Make Time  ") + dim.Render("daily Highlight, focus score, energize (Knapp)"))
 	b.WriteString("\n")
+	b.WriteString(accent.Render("  Flowtime   ") + dim.Render("count up until focus fades, break earned from time worked"))
+	b.WriteString("\n")
 	b.WriteString(dim.Render("  Change anytime with \"flow config\""))
 	b.WriteString("\n")
 	b.WriteString(dim.Render("  enter continue · c close"))
@@ -186,9 +214,7 @@ func (m InlineModel) updatePickMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "enter":
 				m.onboardingMode = false
-				m.phase = phasePickDuration
-				m.presetCursor = 0
-				return m, nil
+				return m.pickDuration(), nil
 			case "esc":
 				// Go back to mode picker
 				m.onboardingMode = false
@@ -208,20 +234,12 @@ func (m InlineModel) updatePickMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.modeCursor--
 			}
 		case "right", "l":
-			if m.modeCursor < len(modeOptions)-1 {
+			if m.modeCursor < len(m.modeOptions)-1 {
 				m.modeCursor++
 			}
-		case "1":
-			m.modeCursor = 0
-			return m.selectMode()
-		case "2":
-			if len(modeOptions) > 1 {
-				m.modeCursor = 1
-				return m.selectMode()
-			}
-		case "3":
-			if len(modeOptions) > 2 {
-				m.modeCursor = 2
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			if i := int(msg.String()[0] - '1'); i < len(m.modeOptions) {
+				m.modeCursor = i
 				return m.selectMode()
 			}
 		case "enter":
@@ -241,12 +259,12 @@ func (m InlineModel) updatePickMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m InlineModel) selectMode() (tea.Model, tea.Cmd) {
-	selected := modeOptions[m.modeCursor]
-	m.mode = methodology.ForMethodology(selected.methodology, nil)
+	selected := m.modeOptions[m.modeCursor]
+	m.mode = selected.mode
 	m.presets = m.mode.Presets()
 
 	if m.onModeSelected != nil {
-		m.onModeSelected(selected.methodology)
+		m.onModeSelected(selected.mode.Name())
 	}
 
 	// Show onboarding overlay with mode description
@@ -255,9 +273,7 @@ func (m InlineModel) selectMode() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	m.phase = phasePickDuration
-	m.presetCursor = 0
-	return m, nil
+	return m.pickDuration(), nil
 }
 
 func (m InlineModel) viewPickMode() string {
@@ -277,7 +293,7 @@ func (m InlineModel) viewPickMode() string {
 
 	b.WriteString(titleStyle.Render("  Mode:") + "  ")
 
-	for i, opt := range modeOptions {
+	for i, opt := range m.modeOptions {
 		label := opt.label
 		if i == m.modeCursor {
 			b.WriteString(activeStyle.Render(" ▸ " + label + " "))
@@ -288,7 +304,7 @@ func (m InlineModel) viewPickMode() string {
 	b.WriteString("\n")
 
 	// Show description of selected mode
-	desc := modeOptions[m.modeCursor].desc
+	desc := m.modeOptions[m.modeCursor].desc
 	b.WriteString(dimStyle.Render("  "+desc) + "\n")
 
 	b.WriteString(dimStyle.Render("  ←/→ select · enter confirm · esc back · c close") + "\n")
@@ -324,45 +340,131 @@ func (m InlineModel) advanceToTaskPhase() (tea.Model, tea.Cmd) {
 	m.taskInput.Focus()
 	return m, m.taskInput.Cursor.BlinkCmd()
 }
+
+// inlinePresetsVisible is how many presets the inline duration picker
+// shows at once; longer lists scroll.
+const inlinePresetsVisible = 5
+
+// pickDuration moves to the duration picker with the first preset selected.
+func (m InlineModel) pickDuration() InlineModel {
+	m.phase = phasePickDuration
+	m.presetCursor = 0
+	m.presetFilter = ""
+	m.presetFiltering = false
+	return m
+}
+
+// presetMatches returns the indexes of the presets that fuzzily match the
+// filter.
+func (m InlineModel) presetMatches() []int {
+	var idx []int
+	for i, p := range m.presets {
+		if fuzzyMatch(m.presetFilter, presetLabel(p)) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// setPresetFilter filters the presets and selects the first match.
+func (m InlineModel) setPresetFilter(filter string) InlineModel {
+	m.presetFilter = filter
+	if matches := m.presetMatches(); len(matches) > 0 {
+		m.presetCursor = matches[0]
+	}
+	return m
+}
+
 func (m InlineModel) updatePickDuration(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "left", "h":
-			if m.presetCursor > 0 {
-				m.presetCursor--
-			}
-		case "right", "l":
-			if m.presetCursor < len(m.presets)-1 {
-				m.presetCursor++
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	matches := m.presetMatches()
+	pos := slices.Index(matches, m.presetCursor)
+
+	if m.presetFiltering {
+		switch keyMsg.Type {
+		case tea.KeyRunes, tea.KeySpace:
+			return m.setPresetFilter(m.presetFilter + string(keyMsg.Runes)), nil
+		case tea.KeyBackspace:
+			if m.presetFilter == "" {
+				m.presetFiltering = false
+				return m, nil
 			}
-		case "1":
-			m.presetCursor = 0
+			runes := []rune(m.presetFilter)
+			return m.setPresetFilter(string(runes[:len(runes)-1])), nil
+		case tea.KeyEsc:
+			m.presetFiltering = false
+			return m.setPresetFilter(""), nil
+		}
+	}
+
+	switch keyMsg.String() {
+	case "left", "h":
+		if pos > 0 {
+			m.presetCursor = matches[pos-1]
+		}
+	case "right", "l":
+		if pos >= 0 && pos < len(matches)-1 {
+			m.presetCursor = matches[pos+1]
+		}
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if i := int(keyMsg.String()[0] - '1'); i < len(m.presets) {
+			m.presetCursor = i
 			return m.advanceToTaskPhase()
-		case "2":
-			if len(m.presets) > 1 {
-				m.presetCursor = 1
-				return m.advanceToTaskPhase()
-			}
-		case "3":
-			if len(m.presets) > 2 {
-				m.presetCursor = 2
-				return m.advanceToTaskPhase()
-			}
-		case "enter":
+		}
+	case "/":
+		m.presetFiltering = true
+	case "p":
+		if block := m.plannedBlock(); block != nil {
+			return m.followPlan(block)
+		}
+	case "enter":
+		if pos >= 0 {
 			return m.advanceToTaskPhase()
-		case "esc":
-			if !m.modeLocked {
-				m.phase = phasePickMode
-				return m, nil
-			}
-		case "c", "ctrl+c":
-			return m, tea.Quit
 		}
+	case "esc":
+		if !m.modeLocked {
+			m.phase = phasePickMode
+			return m, nil
+		}
+	case "c", "ctrl+c":
+		return m, tea.Quit
 	}
 	return m, nil
 }
 
+// plannedBlock returns the plan block under way when the session can
+// follow it, or nil.
+func (m InlineModel) plannedBlock() *domain.PlanBlock {
+	if m.onFollowPlan == nil || m.state == nil {
+		return nil
+	}
+	return m.state.CurrentBlock
+}
+
+// followPlan starts a session on block, in its methodology unless the
+// mode is locked, skipping the duration and task pickers.
+func (m InlineModel) followPlan(block *domain.PlanBlock) (tea.Model, tea.Cmd) {
+	if block.Methodology != "" && !m.modeLocked {
+		for i, opt := range m.modeOptions {
+			if opt.mode.Name() != block.Methodology {
+				continue
+			}
+			m.modeCursor = i
+			m.mode = opt.mode
+			m.presets = m.mode.Presets()
+			if m.onModeSelected != nil {
+				m.onModeSelected(block.Methodology)
+			}
+		}
+	}
+	_ = m.onFollowPlan(block)
+	m.phase = phaseTimer
+	return m, tickCmd()
+}
+
 func (m InlineModel) viewPickDuration() string {
 	var b strings.Builder
 
@@ -376,26 +478,51 @@ func (m InlineModel) viewPickDuration() string {
 	}
 
 	b.WriteString(titleStyle.Render("  Duration:") + "  ")
+	if m.presetFiltering || m.presetFilter != "" {
+		b.WriteString(activeStyle.Render("/"+m.presetFilter) + " ")
+	}
 
-	for i, p := range m.presets {
-		label := fmt.Sprintf("%s %s", p.Name, formatMinutesCompact(p.Duration))
+	matches := m.presetMatches()
+	if len(matches) == 0 {
+		b.WriteString(dimStyle.Render("   no matches"))
+	}
+	first, last := scrollWindow(max(slices.Index(matches, m.presetCursor), 0), len(matches), inlinePresetsVisible)
+	if first > 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf(" ‹%d", first)))
+	}
+	for _, i := range matches[first:last] {
+		label := presetLabel(m.presets[i])
 		if i == m.presetCursor {
 			b.WriteString(activeStyle.Render(" ▸ " + label + " "))
 		} else {
 			b.WriteString(dimStyle.Render("   " + label + " "))
 		}
 	}
+	if last < len(matches) {
+		b.WriteString(dimStyle.Render(fmt.Sprintf(" %d›", len(matches)-last)))
+	}
 	b.WriteString("\n")
 
 	if m.breakInfo != "" {
 		b.WriteString(dimStyle.Render("  "+m.breakInfo) + "\n")
 	}
+	if block := m.plannedBlock(); block != nil && !m.presetFiltering {
+		b.WriteString(activeStyle.Render("  Planned now: "+block.String()) + dimStyle.Render(" · [p] follow the plan") + "\n")
+	}
 
+	if m.presetFiltering {
+		b.WriteString(dimStyle.Render("  type to filter · ←/→ select · enter confirm · esc clear") + "\n")
+		return b.String()
+	}
 	escHint := "esc back · "
 	if m.modeLocked {
 		escHint = ""
 	}
-	b.WriteString(dimStyle.Render("  ←/→ select · enter confirm · "+escHint+"c close") + "\n")
+	filterHint := ""
+	if len(m.presets) > inlinePresetsVisible {
+		filterHint = "/ filter · "
+	}
+	b.WriteString(dimStyle.Render("  ←/→ select · "+filterHint+"enter confirm · "+escHint+"c close") + "\n")
 
 	return b.String()
 }
@@ -499,9 +626,10 @@ func (m InlineModel) selectRecentTask() (tea.Model, tea.Cmd) {
 // or start the session immediately. taskName may be empty.
 func (m InlineModel) advanceFromTask(taskName string) (tea.Model, tea.Cmd) {
 	if m.mode != nil && m.mode.OutcomePrompt() != "" {
-		// Deep Work: ask for intended outcome before starting
+		// Deep Work: ask for intended outcome before starting, pre-filled
+		// from the preset
 		m.taskInput.Blur()
-		m.outcomeInput.SetValue("")
+		m.outcomeInput.SetValue(m.presets[m.presetCursor].IntendedOutcome)
 		m.outcomeInput.Focus()
 		m.phase = phaseOutcome
 		return m, m.outcomeInput.Cursor.BlinkCmd()
@@ -529,7 +657,7 @@ func (m InlineModel) viewTaskSelect() string {
 	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.ColorHelp))
 
 	p := m.presets[m.presetCursor]
-	b.WriteString(activeStyle.Render(fmt.Sprintf("  ▸ %s %s", p.Name, formatMinutesCompact(p.Duration))))
+	b.WriteString(activeStyle.Render("  ▸ " + presetLabel(p)))
 	b.WriteString("\n")
 
 	b.WriteString(titleStyle.Render("  Task:") + "\n")
@@ -604,7 +732,7 @@ func (m InlineModel) viewTaskName() string {
 	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.ColorHelp))
 
 	p := m.presets[m.presetCursor]
-	b.WriteString(activeStyle.Render(fmt.Sprintf("  ▸ %s %s", p.Name, formatMinutesCompact(p.Duration))))
+	b.WriteString(activeStyle.Render("  ▸ " + presetLabel(p)))
 	b.WriteString("\n")
 
 	taskPrompt := "Task:"
@@ -656,7 +784,7 @@ func (m InlineModel) viewPickOutcome() string {
 	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.ColorHelp))
 
 	p := m.presets[m.presetCursor]
-	b.WriteString(activeStyle.Render(fmt.Sprintf("  ▸ %s %s", p.Name, formatMinutesCompact(p.Duration))))
+	b.WriteString(activeStyle.Render("  ▸ " + presetLabel(p)))
 	b.WriteString("\n")
 
 	if m.taskInput.Value() != "" {
@@ -757,7 +885,7 @@ func (m InlineModel) viewLaserChecklist() string {
 	crossStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")) // Red cross
 
 	p := m.presets[m.presetCursor]
-	b.WriteString(activeStyle.Render(fmt.Sprintf("  ▸ %s %s", p.Name, formatMinutesCompact(p.Duration))))
+	b.WriteString(activeStyle.Render("  ▸ " + presetLabel(p)))
 	b.WriteString("\n")
 
 	b.WriteString(titleStyle.Render("  Laser Checklist:"))
@@ -59,6 +59,7 @@ type Model struct {
 	commandCallback         func(ports.TimerCommand) error
 	onSessionComplete       func(domain.SessionType)
 	distractionCallback     func(string, string) error
+	distractionCategories   []string
 	accomplishmentCallback  func(string) error
 	focusScoreCallback      func(int) error
 	energizeCallback        func(string) error
@@ -224,7 +225,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.confirmBreak = false
 			m.confirmFinish = false
 		case "d":
-			// Deep Work: open distraction input during active work session
+			// Open distraction input during an active work session
 			if m.mode != nil && m.mode.HasDistractionLog() && !m.completed && m.state.ActiveSession != nil && m.state.ActiveSession.Type == domain.SessionTypeWork {
 				m.distractionMode = true
 				m.distractionInput.Reset()
@@ -253,7 +254,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.outcomeReviewMode = true
 			}
 		case "1", "2", "3", "4", "5":
-			// Make Time: record focus score on work completion
+			// Record focus score on work completion (always in Make Time,
+			// with focus_score on elsewhere)
 			if m.mode != nil && m.mode.HasFocusScore() && m.completed && m.completedSessionType == domain.SessionTypeWork && !m.focusScoreSaved {
 				score := int(msg.String()[0] - '0')
 				m.focusScore = &score
@@ -296,6 +298,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 			}
+			// Mob: the next driver takes over right away
+			if m.completed && m.mode != nil && m.mode.HasRotation() && m.commandCallback != nil {
+				_ = m.commandCallback(ports.CmdStart)
+				m.completed = false
+				m.notified = false
+				m.resetCompletionState()
+				return m, nil
+			}
 			// Session chaining: signal new session and quit TUI
 			if m.completed && m.completionPromptsComplete() {
 				m.WantsNewSession = true
@@ -393,7 +403,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Detect session completion: had a session before, now it's gone
 			if m.state.ActiveSession != nil && msg.state.ActiveSession == nil {
 				m.completedSessionType = m.state.ActiveSession.Type
-				m.completedElapsed = m.state.ActiveSession.Duration
+				m.completedElapsed = workedTime(m.state.ActiveSession)
 				m.completedIntendedOutcome = m.state.ActiveSession.IntendedOutcome
 				m.completed = true
 
@@ -419,6 +429,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			m.state = msg.state
+			m.syncDistractions(msg.state.ActiveSession)
 		}
 
 	case *domain.CurrentState:
@@ -447,8 +458,9 @@ func (m Model) completionPromptsComplete() bool {
 // updateDistractionInput handles input while in distraction logging mode.
 func (m Model) updateDistractionInput(msg tea.Msg) (tea.Model, tea.Cmd) {
 	cb := &completionCallbacks{
-		distractionCallback: m.distractionCallback,
-		mode:                m.mode,
+		distractionCallback:   m.distractionCallback,
+		distractionCategories: m.distractionCategories,
+		mode:                  m.mode,
 	}
 	return m, handleDistractionInput(&m.completionState, cb, msg, nil)
 }
@@ -527,6 +539,17 @@ func (m Model) View() string {
 		sections = append(sections, tagStyle.Render(tagStr))
 	}
 
+	// Mob rotation: who has the keyboard
+	if !m.completed && m.state.ActiveSession != nil && m.state.ActiveSession.Driver != "" {
+		rotation := domain.Rotation{Driver: m.state.ActiveSession.Driver, Navigator: m.state.ActiveSession.Navigator}
+		sections = append(sections, lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.ColorTask)).Render(rotation.String()))
+	}
+
+	// Today's plan: the block under way and the next one
+	if line := planLine(m.state); line != "" && !m.completed {
+		sections = append(sections, lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.ColorHelp)).Render(line))
+	}
+
 	if m.completed {
 		if m.completedSessionType == domain.SessionTypeWork {
 			sections = m.viewWorkComplete(sections)
@@ -580,7 +603,7 @@ func (m Model) viewWorkComplete(sections []string) []string {
 	if m.mode != nil && m.mode.HasShutdownRitual() {
 		return m.viewDeepWorkComplete(sections)
 	}
-	if m.mode != nil && m.mode.HasFocusScore() {
+	if m.mode != nil && m.mode.HasEnergizeReminder() {
 		return m.viewMakeTimeComplete(sections)
 	}
 	return m.viewDefaultWorkComplete(sections)
@@ -599,8 +622,14 @@ func (m Model) viewDefaultWorkComplete(sections []string) []string {
 	}
 	sections = append(sections, m.progress.ViewAs(1.0))
 
-	// Show break info
-	if vd.hasBreakInfo {
+	// Mob: announce the handover instead of the break
+	if vd.nextRotation != nil {
+		handover, breakLine := rotationLines(vd)
+		sections = append(sections, "", statusStyle.Render(handover))
+		if breakLine != "" {
+			sections = append(sections, helpStyle.Render(breakLine))
+		}
+	} else if vd.hasBreakInfo {
 		breakLine := fmt.Sprintf("[b] %s %s", vd.breakDur, vd.breakLabel)
 		if vd.isLongBreak {
 			breakLine += " - you earned it!"
@@ -608,7 +637,7 @@ func (m Model) viewDefaultWorkComplete(sections []string) []string {
 		sections = append(sections, "")
 		sections = append(sections, statusStyle.Render(breakLine))
 
-		if !vd.isLongBreak {
+		if !vd.isLongBreak && vd.sessionsBeforeLong > 0 {
 			countLine := fmt.Sprintf("%d of %d sessions until long break",
 				vd.sessionsBeforeLong-vd.sessionsUntilLong, vd.sessionsBeforeLong)
 			sections = append(sections, helpStyle.Render(countLine))
@@ -625,9 +654,17 @@ func (m Model) viewDefaultWorkComplete(sections []string) []string {
 		sections = append(sections, helpStyle.Render(fmt.Sprintf("\U0001F345 %d sessions today", vd.statsWorkSessions)))
 	}
 
+	if line, saved := optionalFocusScoreLine(&m.completionState, m.mode); saved {
+		sections = append(sections, "", statusStyle.Render(line))
+	} else if line != "" {
+		sections = append(sections, "", helpStyle.Render(line))
+	}
+
 	sections = append(sections, "")
 	if m.autoBreakTicks > 0 {
 		sections = append(sections, statusStyle.Render(fmt.Sprintf("Break starting in %ds... press any key to cancel", m.autoBreakTicks)))
+	} else if vd.nextRotation != nil {
+		sections = append(sections, helpStyle.Render("[n]ext rotation  [b]reak  [q]uit"))
 	} else {
 		sections = append(sections, helpStyle.Render("[n]ew session  [b]reak  [q]uit"))
 	}
@@ -657,6 +694,12 @@ func (m Model) viewDeepWorkComplete(sections []string) []string {
 	sections = append(sections, statusStyle.Render(fmt.Sprintf("Deep Work Score: %s today", formatDuration(vd.statsTotalWorkTime))))
 	sections = append(sections, helpStyle.Render(fmt.Sprintf("%.0f%% of %.0fh target", vd.deepWorkPct, vd.deepWorkGoalHours)))
 
+	if line, saved := optionalFocusScoreLine(&m.completionState, m.mode); saved {
+		sections = append(sections, "", statusStyle.Render(line))
+	} else if line != "" {
+		sections = append(sections, "", helpStyle.Render(line))
+	}
+
 	if vd.deepWorkStreak > 0 {
 		sections = append(sections, "")
 		sections = append(sections, statusStyle.Render(fmt.Sprintf("Deep Work streak: %d days", vd.deepWorkStreak)))
@@ -796,7 +839,12 @@ func (m Model) viewBreakComplete(sections []string) []string {
 	sections = append(sections, helpStyle.Render(statsText))
 
 	sections = append(sections, "")
-	sections = append(sections, helpStyle.Render("[n]ew session  [q]uit"))
+	if next := upNext(m.completionInfo); next != nil {
+		sections = append(sections, statusStyle.Render("Up next: "+next.String()))
+		sections = append(sections, helpStyle.Render("[n]ext rotation  [q]uit"))
+	} else {
+		sections = append(sections, helpStyle.Render("[n]ew session  [q]uit"))
+	}
 	return sections
 }
 
@@ -813,8 +861,7 @@ func (m Model) viewActiveSession(sections []string) []string {
 	sections = append(sections, statusStyle.Render(statusText))
 
 	// Big ASCII timer
-	remaining := session.RemainingTime()
-	timeStr := formatDuration(remaining)
+	timeStr := formatDuration(clockTime(session))
 	sections = append(sections, "")
 	sections = append(sections, renderBigTime(timeStr, timerColor, m.width))
 
@@ -843,16 +890,19 @@ func (m Model) viewActiveSession(sections []string) []string {
 		sections = append(sections, "")
 		if m.distractionCategoryMode {
 			sections = append(sections, helpStyle.Render(fmt.Sprintf("Categorize: %s", m.distractionPendingText)))
-			sections = append(sections, helpStyle.Render("[i]nternal  [e]xternal  [enter] skip category"))
+			sections = append(sections, helpStyle.Render(distractionCategoryHelp(m.distractionCategories)))
 		} else {
 			sections = append(sections, helpStyle.Render("Log distraction: ")+m.distractionInput.View())
 			sections = append(sections, helpStyle.Render("enter save · esc cancel"))
 		}
 	}
 
-	// Dynamic progress bar
+	// Dynamic progress bar; an open-ended session has nothing to fill
 	sections = append(sections, "")
 	prog := session.Progress()
+	if session.IsOpenEnded() {
+		sections = append(sections, helpStyle.Render("counting up · [f]inish when your focus fades"))
+	}
 	var pbar progress.Model
 	if session.Status == domain.SessionStatusPaused {
 		pbar = progress.New(progress.WithGradient(m.theme.PausedGradientStart, m.theme.PausedGradientEnd))
@@ -862,7 +912,9 @@ func (m Model) viewActiveSession(sections []string) []string {
 		pbar = progress.New(progress.WithGradient(m.theme.WorkGradientStart, m.theme.WorkGradientEnd))
 	}
 	pbar.Width = m.width - 4
-	sections = append(sections, pbar.ViewAs(prog))
+	if !session.IsOpenEnded() {
+		sections = append(sections, pbar.ViewAs(prog))
+	}
 
 	// Git context
 	if session.GitBranch != "" {
@@ -912,6 +964,25 @@ func tickCmd() tea.Cmd {
 	})
 }
 
+// clockTime returns what the timer shows for a session: the time left, or
+// the time elapsed for an open-ended session that counts up.
+func clockTime(session *domain.PomodoroSession) time.Duration {
+	if session.IsOpenEnded() {
+		return session.ElapsedTime()
+	}
+	return session.RemainingTime()
+}
+
+// workedTime returns how long a session that just ended ran. An open-ended
+// session only gets its Duration once stopped, so the cached copy counts
+// its elapsed time instead.
+func workedTime(session *domain.PomodoroSession) time.Duration {
+	if session.IsOpenEnded() {
+		return session.ElapsedTime()
+	}
+	return session.Duration
+}
+
 // formatDuration formats a duration as MM:SS.
 func formatDuration(d time.Duration) string {
 	minutes := int(d.Minutes())
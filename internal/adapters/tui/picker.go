@@ -22,41 +22,108 @@ type PickerResult struct {
 	Aborted bool
 }
 
+// pickerMaxVisible is how many items the picker shows at once; longer
+// lists scroll.
+const pickerMaxVisible = 7
+
+// pickerFilterMin is the item count from which the picker hints at "/" to
+// filter, as shorter lists are quicker to scan.
+const pickerFilterMin = 5
+
 type pickerModel struct {
-	title   string
-	items   []PickerItem
-	footer  string
-	cursor  int
-	chosen  bool
-	aborted bool
-	theme   config.ThemeConfig
+	title     string
+	items     []PickerItem
+	footer    string
+	cursor    int // index into matches()
+	filter    string
+	filtering bool
+	chosen    bool
+	aborted   bool
+	theme     config.ThemeConfig
 }
 
 func (m pickerModel) Init() tea.Cmd { return nil }
 
+// matches returns the indexes of the items that fuzzily match the filter.
+func (m pickerModel) matches() []int {
+	var idx []int
+	for i, item := range m.items {
+		if fuzzyMatch(m.filter, item.Label+" "+item.Desc) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
 func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "up", "k":
-			if m.cursor > 0 {
-				m.cursor--
-			}
-		case "down", "j":
-			if m.cursor < len(m.items)-1 {
-				m.cursor++
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	count := len(m.matches())
+
+	if m.filtering {
+		switch keyMsg.Type {
+		case tea.KeyRunes, tea.KeySpace:
+			m.filter += string(keyMsg.Runes)
+			m.cursor = 0
+			return m, nil
+		case tea.KeyBackspace:
+			if m.filter == "" {
+				m.filtering = false
+				return m, nil
 			}
-		case "enter":
+			m.filter = string([]rune(m.filter)[:len([]rune(m.filter))-1])
+			m.cursor = 0
+			return m, nil
+		case tea.KeyEsc:
+			m.filtering = false
+			m.filter = ""
+			m.cursor = 0
+			return m, nil
+		}
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < count-1 {
+			m.cursor++
+		}
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if i := int(keyMsg.String()[0] - '1'); i < len(m.items) {
+			m.filter = ""
+			m.cursor = i
 			m.chosen = true
 			return m, tea.Quit
-		case "ctrl+c", "esc":
-			m.aborted = true
-			return m, tea.Quit
 		}
+	case "/":
+		m.filtering = true
+	case "enter":
+		if count == 0 {
+			return m, nil
+		}
+		m.chosen = true
+		return m, tea.Quit
+	case "ctrl+c", "esc":
+		m.aborted = true
+		return m, tea.Quit
 	}
 	return m, nil
 }
 
+// selected returns the index in items of the item under the cursor.
+func (m pickerModel) selected() int {
+	matches := m.matches()
+	if m.cursor >= len(matches) {
+		return 0
+	}
+	return matches[m.cursor]
+}
+
 func (m pickerModel) View() string {
 	var b strings.Builder
 
@@ -66,11 +133,24 @@ func (m pickerModel) View() string {
 	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.ColorHelp))
 
 	b.WriteString("\n")
-	b.WriteString(titleStyle.Render("  "+m.title) + "\n\n")
+	b.WriteString(titleStyle.Render("  "+m.title) + "\n")
+	if m.filtering || m.filter != "" {
+		b.WriteString(activeStyle.Render("  / "+m.filter) + "\n")
+	}
+	b.WriteString("\n")
 
 	arrowStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.ColorWork)).Bold(true)
 
-	for i, item := range m.items {
+	matches := m.matches()
+	if len(matches) == 0 {
+		b.WriteString(dimStyle.Render("    No matches") + "\n")
+	}
+	first, last := scrollWindow(m.cursor, len(matches), pickerMaxVisible)
+	if first > 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("    ↑ %d more", first)) + "\n")
+	}
+	for i := first; i < last; i++ {
+		item := m.items[matches[i]]
 		if i == m.cursor {
 			arrow := arrowStyle.Render("▸")
 			line := activeStyle.Render(fmt.Sprintf(" %-10s %s", item.Label, item.Desc))
@@ -79,6 +159,9 @@ func (m pickerModel) View() string {
 			b.WriteString(dimStyle.Render(fmt.Sprintf("    %-10s %s", item.Label, item.Desc)) + "\n")
 		}
 	}
+	if last < len(matches) {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("    ↓ %d more", len(matches)-last)) + "\n")
+	}
 
 	if m.footer != "" {
 		b.WriteString("\n")
@@ -86,11 +169,54 @@ func (m pickerModel) View() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(dimStyle.Render("  ↑/↓ navigate · enter select · esc back") + "\n")
+	switch {
+	case m.filtering:
+		b.WriteString(dimStyle.Render("  type to filter · ↑/↓ navigate · enter select · esc clear") + "\n")
+	case len(m.items) >= pickerFilterMin:
+		b.WriteString(dimStyle.Render("  ↑/↓ navigate · / filter · enter select · esc back") + "\n")
+	default:
+		b.WriteString(dimStyle.Render("  ↑/↓ navigate · enter select · esc back") + "\n")
+	}
 
 	return b.String()
 }
 
+// scrollWindow returns the range [first, last) of a list of n items to
+// show so that at most size are visible and cursor is among them.
+func scrollWindow(cursor, n, size int) (first, last int) {
+	if n <= size {
+		return 0, n
+	}
+	first = cursor - size/2
+	if first < 0 {
+		first = 0
+	}
+	if first > n-size {
+		first = n - size
+	}
+	return first, first + size
+}
+
+// fuzzyMatch reports whether the characters of query appear in s in
+// order, ignoring case, so "dw" matches "Deep Work".
+func fuzzyMatch(query, s string) bool {
+	rest := []rune(strings.ToLower(s))
+	for _, q := range strings.ToLower(query) {
+		if q == ' ' {
+			continue
+		}
+		i := 0
+		for i < len(rest) && rest[i] != q {
+			i++
+		}
+		if i == len(rest) {
+			return false
+		}
+		rest = rest[i+1:]
+	}
+	return true
+}
+
 // --- Horizontal picker (for inline / narrow terminals) ---
 
 type hPickerModel struct {
@@ -117,19 +243,9 @@ func (m hPickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.cursor < len(m.items)-1 {
 				m.cursor++
 			}
-		case "1":
-			m.cursor = 0
-			m.chosen = true
-			return m, tea.Quit
-		case "2":
-			if len(m.items) > 1 {
-				m.cursor = 1
-				m.chosen = true
-				return m, tea.Quit
-			}
-		case "3":
-			if len(m.items) > 2 {
-				m.cursor = 2
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			if i := int(msg.String()[0] - '1'); i < len(m.items) {
+				m.cursor = i
 				m.chosen = true
 				return m, tea.Quit
 			}
@@ -215,7 +331,7 @@ func RunPicker(title string, items []PickerItem, footer string, theme *config.Th
 	if final.aborted {
 		return PickerResult{Aborted: true}
 	}
-	return PickerResult{Index: final.cursor}
+	return PickerResult{Index: final.selected()}
 }
 
 // --- Styled text prompt ---
@@ -0,0 +1,342 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/xvierd/flow-cli/internal/config"
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+// PlannerResult holds the outcome of the day planner.
+type PlannerResult struct {
+	Blocks []*domain.PlanBlock
+	Saved  bool // false when the planner was left without saving
+}
+
+// Fields of the block form, in tab order.
+const (
+	planFieldStart = iota
+	planFieldEnd
+	planFieldTask
+	planFieldMode
+	planFieldTags
+	planFieldCount
+)
+
+var planFieldLabels = [planFieldCount]string{"Start", "End", "Task", "Mode", "Tags"}
+
+// plannerModel lays out the time blocks of one day: a list of blocks and
+// a form to add or edit one.
+type plannerModel struct {
+	day    time.Time
+	now    time.Time
+	blocks []*domain.PlanBlock
+	cursor int
+
+	// Block form
+	editing   bool
+	editIndex int // index of the block being edited, or -1 for a new one
+	fields    [planFieldCount]textinput.Model
+	focus     int
+	formErr   string
+
+	dirty       bool
+	confirmQuit bool
+	saved       bool
+	theme       config.ThemeConfig
+}
+
+func newPlannerModel(day, now time.Time, blocks []*domain.PlanBlock, theme config.ThemeConfig) plannerModel {
+	m := plannerModel{day: day, now: now, blocks: blocks, theme: theme}
+	placeholders := [planFieldCount]string{"9:00", "10:30", "What is this block for?", "optional, e.g. deepwork", "optional, e.g. docs, writing"}
+	for i := range m.fields {
+		ti := textinput.New()
+		ti.Placeholder = placeholders[i]
+		ti.CharLimit = 120
+		ti.Width = 40
+		m.fields[i] = ti
+	}
+	domain.SortPlan(m.blocks)
+	return m
+}
+
+func (m plannerModel) Init() tea.Cmd { return nil }
+
+func (m plannerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.editing {
+		return m.updateForm(msg)
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	key := keyMsg.String()
+	if m.confirmQuit && key != "s" && key != "q" && key != "esc" && key != "ctrl+c" {
+		m.confirmQuit = false
+	}
+	switch key {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.blocks)-1 {
+			m.cursor++
+		}
+	case "a", "n":
+		return m.openForm(-1)
+	case "e", "enter":
+		if len(m.blocks) > 0 {
+			return m.openForm(m.cursor)
+		}
+	case "d", "x":
+		if len(m.blocks) > 0 {
+			m.blocks = append(m.blocks[:m.cursor:m.cursor], m.blocks[m.cursor+1:]...)
+			m.cursor = max(0, min(m.cursor, len(m.blocks)-1))
+			m.dirty = true
+		}
+	case "s":
+		m.saved = true
+		return m, tea.Quit
+	case "q", "esc":
+		if m.dirty && !m.confirmQuit {
+			m.confirmQuit = true
+			return m, nil
+		}
+		return m, tea.Quit
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// openForm shows the block form for the block at index, or for a new
+// block starting where the last one ends when index is -1.
+func (m plannerModel) openForm(index int) (tea.Model, tea.Cmd) {
+	var values [planFieldCount]string
+	if index >= 0 {
+		b := m.blocks[index]
+		values = [planFieldCount]string{
+			b.Start.Format("15:04"), b.EndClock(), b.Task, string(b.Methodology), strings.Join(b.Tags, ", "),
+		}
+	} else {
+		start := m.day.Add(9 * time.Hour)
+		if n := len(m.blocks); n > 0 {
+			start = m.blocks[n-1].End
+		}
+		values[planFieldStart] = start.Format("15:04")
+		values[planFieldEnd] = start.Add(time.Hour).Format("15:04")
+	}
+	for i := range m.fields {
+		m.fields[i].SetValue(values[i])
+		m.fields[i].Blur()
+	}
+	m.editing = true
+	m.editIndex = index
+	m.formErr = ""
+	m.focus = planFieldTask
+	if index >= 0 {
+		m.focus = planFieldStart
+	}
+	m.fields[m.focus].Focus()
+	return m, textinput.Blink
+}
+
+func (m plannerModel) updateForm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "tab", "down":
+			return m.focusField((m.focus + 1) % planFieldCount)
+		case "shift+tab", "up":
+			return m.focusField((m.focus + planFieldCount - 1) % planFieldCount)
+		case "enter":
+			if m.focus < planFieldCount-1 {
+				return m.focusField(m.focus + 1)
+			}
+			return m.submitForm(), nil
+		case "esc":
+			m.editing = false
+			return m, nil
+		case "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	m.fields[m.focus], cmd = m.fields[m.focus].Update(msg)
+	return m, cmd
+}
+
+func (m plannerModel) focusField(i int) (tea.Model, tea.Cmd) {
+	m.fields[m.focus].Blur()
+	m.focus = i
+	m.fields[m.focus].Focus()
+	return m, textinput.Blink
+}
+
+// submitForm checks the form and saves its block into the plan, or shows
+// what is wrong with it.
+func (m plannerModel) submitForm() plannerModel {
+	value := func(i int) string { return strings.TrimSpace(m.fields[i].Value()) }
+
+	start, err := domain.ParseClock(m.day, value(planFieldStart))
+	if err != nil {
+		m.formErr = err.Error()
+		return m
+	}
+	end, err := domain.ParseClock(m.day, value(planFieldEnd))
+	if err != nil {
+		m.formErr = err.Error()
+		return m
+	}
+	block, err := domain.NewPlanBlock(start, end, value(planFieldTask))
+	if err != nil {
+		m.formErr = err.Error()
+		return m
+	}
+	block.Tags = splitTagList(value(planFieldTags))
+	if mode := value(planFieldMode); mode != "" {
+		if block.Methodology, err = domain.ValidateMethodology(mode); err != nil {
+			m.formErr = err.Error()
+			return m
+		}
+	}
+
+	// Check the block against the rest of the plan before changing it
+	var blocks []*domain.PlanBlock
+	for i, b := range m.blocks {
+		if i == m.editIndex {
+			block.ID = b.ID
+			continue
+		}
+		blocks = append(blocks, b)
+	}
+	blocks = append(blocks, block)
+	domain.SortPlan(blocks)
+	if err := domain.ValidatePlan(blocks); err != nil {
+		m.formErr = err.Error()
+		return m
+	}
+
+	m.blocks = blocks
+	for i, b := range blocks {
+		if b == block {
+			m.cursor = i
+		}
+	}
+	m.editing = false
+	m.dirty = true
+	m.confirmQuit = false
+	return m
+}
+
+// splitTagList splits "docs, #writing" into tags.
+func splitTagList(input string) []string {
+	var tags []string
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if tag := strings.TrimPrefix(field, "#"); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func (m plannerModel) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.ColorTitle))
+	activeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.ColorWork)).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.ColorHelp))
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(titleStyle.Render("  Plan for "+m.day.Format("Mon Jan 2")) + "\n")
+
+	if m.editing {
+		heading := "New block"
+		if m.editIndex >= 0 {
+			heading = "Edit block"
+		}
+		b.WriteString(dimStyle.Render("  "+heading) + "\n")
+		for i, field := range m.fields {
+			label := fmt.Sprintf("  %-6s ", planFieldLabels[i])
+			if i == m.focus {
+				b.WriteString(activeStyle.Render(label))
+			} else {
+				b.WriteString(dimStyle.Render(label))
+			}
+			b.WriteString(field.View() + "\n")
+		}
+		if m.formErr != "" {
+			b.WriteString(errStyle.Render("  "+m.formErr) + "\n")
+		}
+		b.WriteString(dimStyle.Render("  tab/↑/↓ field · enter next/save · esc cancel") + "\n")
+		return b.String()
+	}
+
+	if len(m.blocks) == 0 {
+		b.WriteString(dimStyle.Render("  No blocks yet: [a] adds one") + "\n")
+	}
+	var planned time.Duration
+	for i, block := range m.blocks {
+		planned += block.Duration()
+		line := fmt.Sprintf("%s  %s", block.TimeRange(), block.Task)
+		if block.Methodology != "" {
+			line += "  · " + block.Methodology.Label()
+		}
+		for _, tag := range block.Tags {
+			line += " #" + tag
+		}
+		if block.Contains(m.now) {
+			line += "  ← now"
+		}
+		if i == m.cursor {
+			b.WriteString(activeStyle.Render("  ▸ "+line) + "\n")
+		} else {
+			b.WriteString(dimStyle.Render("    "+line) + "\n")
+		}
+	}
+	if planned > 0 {
+		b.WriteString(dimStyle.Render("  "+formatMinutesCompact(planned)+" planned") + "\n")
+	}
+
+	if m.confirmQuit {
+		b.WriteString(activeStyle.Render("  Unsaved changes: [s]ave or [q] again to discard") + "\n")
+		return b.String()
+	}
+	b.WriteString(dimStyle.Render("  [a]dd  [e]dit  [d]elete  [s]ave  [q]uit") + "\n")
+	return b.String()
+}
+
+// RunPlanner opens the time-block planner for day, starting from blocks.
+func RunPlanner(day time.Time, blocks []*domain.PlanBlock, theme *config.ThemeConfig) PlannerResult {
+	m := newPlannerModel(day, time.Now(), blocks, resolveTheme(theme))
+
+	p := tea.NewProgram(m)
+	result, err := p.Run()
+	if err != nil {
+		return PlannerResult{}
+	}
+
+	final := result.(plannerModel)
+	return PlannerResult{Blocks: final.blocks, Saved: final.saved}
+}
+
+// planLine describes the block under way and the next one in today's
+// plan, or "" when neither exists.
+func planLine(state *domain.CurrentState) string {
+	if state == nil {
+		return ""
+	}
+	var parts []string
+	if b := state.CurrentBlock; b != nil {
+		parts = append(parts, "Now: "+b.String())
+	}
+	if b := state.NextBlock; b != nil {
+		parts = append(parts, fmt.Sprintf("Next: %s %s", b.Start.Format("15:04"), b.Task))
+	}
+	return strings.Join(parts, " · ")
+}
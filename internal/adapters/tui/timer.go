@@ -19,6 +19,7 @@ type Timer struct {
 	commandCallback         func(ports.TimerCommand) error
 	onSessionComplete       func(domain.SessionType)
 	distractionCallback     func(string, string) error
+	distractionCategories   []string
 	accomplishmentCallback  func(string) error
 	shutdownRitualCallback  func(domain.ShutdownRitual) error
 	focusScoreCallback      func(int) error
@@ -30,7 +31,9 @@ type Timer struct {
 	presets                 []config.SessionPreset
 	breakInfo               string
 	onStartSession          func(presetIndex int, taskName string, intendedOutcome string) error
+	onFollowPlan            func(*domain.PlanBlock) error
 	mode                    methodology.Mode
+	modes                   []methodology.Mode
 	modeLocked              bool
 	onModeSelected          func(domain.Methodology)
 	fetchRecentTasks        func(limit int) []*domain.Task
@@ -67,6 +70,7 @@ type TimerConfig struct {
 	CommandCallback         func(ports.TimerCommand) error
 	OnSessionComplete       func(domain.SessionType)
 	DistractionCallback     func(string, string) error
+	DistractionCategories   []string
 	AccomplishmentCallback  func(string) error
 	ShutdownRitualCallback  func(domain.ShutdownRitual) error
 	FocusScoreCallback      func(int) error
@@ -79,7 +83,9 @@ type TimerConfig struct {
 	Presets                 []config.SessionPreset
 	BreakInfo               string
 	OnStartSession          func(presetIndex int, taskName string, intendedOutcome string) error
+	OnFollowPlan            func(*domain.PlanBlock) error // starts a session on the plan block under way
 	Mode                    methodology.Mode
+	Modes                   []methodology.Mode // offered in the mode picker; defaults to the built-in ones
 	ModeLocked              bool
 	OnModeSelected          func(domain.Methodology)
 	FetchRecentTasks        func(limit int) []*domain.Task
@@ -93,6 +99,7 @@ func (t *Timer) Configure(cfg TimerConfig) {
 	t.commandCallback = cfg.CommandCallback
 	t.onSessionComplete = cfg.OnSessionComplete
 	t.distractionCallback = cfg.DistractionCallback
+	t.distractionCategories = cfg.DistractionCategories
 	t.accomplishmentCallback = cfg.AccomplishmentCallback
 	t.shutdownRitualCallback = cfg.ShutdownRitualCallback
 	t.focusScoreCallback = cfg.FocusScoreCallback
@@ -105,7 +112,9 @@ func (t *Timer) Configure(cfg TimerConfig) {
 	t.presets = cfg.Presets
 	t.breakInfo = cfg.BreakInfo
 	t.onStartSession = cfg.OnStartSession
+	t.onFollowPlan = cfg.OnFollowPlan
 	t.mode = cfg.Mode
+	t.modes = cfg.Modes
 	t.modeLocked = cfg.ModeLocked
 	t.onModeSelected = cfg.OnModeSelected
 	t.fetchRecentTasks = cfg.FetchRecentTasks
@@ -139,6 +148,7 @@ func (t *Timer) Run(ctx context.Context, initialState *domain.CurrentState) erro
 	model.commandCallback = t.commandCallback
 	model.onSessionComplete = t.onSessionComplete
 	model.distractionCallback = t.distractionCallback
+	model.distractionCategories = t.distractionCategories
 	model.accomplishmentCallback = t.accomplishmentCallback
 	model.shutdownRitualCallback = t.shutdownRitualCallback
 	model.focusScoreCallback = t.focusScoreCallback
@@ -181,6 +191,7 @@ func (t *Timer) runInline(ctx context.Context, initialState *domain.CurrentState
 	model.commandCallback = t.commandCallback
 	model.onSessionComplete = t.onSessionComplete
 	model.distractionCallback = t.distractionCallback
+	model.distractionCategories = t.distractionCategories
 	model.accomplishmentCallback = t.accomplishmentCallback
 	model.shutdownRitualCallback = t.shutdownRitualCallback
 	model.focusScoreCallback = t.focusScoreCallback
@@ -189,7 +200,9 @@ func (t *Timer) runInline(ctx context.Context, initialState *domain.CurrentState
 	model.presets = t.presets
 	model.breakInfo = t.breakInfo
 	model.onStartSession = t.onStartSession
+	model.onFollowPlan = t.onFollowPlan
 	model.mode = t.mode
+	model.modeOptions = modeOptionsFor(t.modes)
 	model.modeLocked = t.modeLocked
 	model.onModeSelected = t.onModeSelected
 	model.fetchRecentTasks = t.fetchRecentTasks
@@ -203,8 +216,8 @@ func (t *Timer) runInline(ctx context.Context, initialState *domain.CurrentState
 		if !t.modeLocked {
 			model.phase = phaseMainMenu
 			// Pre-select current mode for when they reach mode picker
-			for i, m := range domain.ValidMethodologies {
-				if t.mode != nil && m == t.mode.Name() {
+			for i, opt := range model.modeOptions {
+				if t.mode != nil && opt.mode.Name() == t.mode.Name() {
 					model.modeCursor = i
 					break
 				}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/xvierd/flow-cli/internal/config"
 	"github.com/xvierd/flow-cli/internal/domain"
 	"github.com/xvierd/flow-cli/internal/methodology"
 	"github.com/xvierd/flow-cli/internal/ports"
@@ -420,6 +421,55 @@ func TestModel_DistractionCategory_ExternalKey(t *testing.T) {
 	}
 }
 
+func TestModel_DistractionCategory_ConfiguredCategories(t *testing.T) {
+	var gotCat string
+	m := NewModel(stateWithSession(), nil, nil)
+	m.mode = methodology.ForMethodology(domain.MethodologyDeepWork, nil)
+	m.distractionCategories = []string{"slack", "meeting", "music", "hunger"}
+	m.distractionCallback = func(_, cat string) error { gotCat = cat; return nil }
+
+	for _, tt := range []struct{ key, want string }{
+		{"s", "slack"},
+		{"h", "hunger"},
+		{"3", "music"},
+		{"m", ""}, // ambiguous: meeting and music
+		{"9", ""}, // out of range
+		{"e", ""}, // no longer a category
+	} {
+		gotCat = ""
+		m.distractionMode = true
+		m.distractionCategoryMode = true
+		m.distractionPendingText = "ping"
+		result, _ := m.updateDistractionInput(key(tt.key))
+		updated := result.(Model)
+		if gotCat != tt.want {
+			t.Errorf("key %q picked %q, want %q", tt.key, gotCat, tt.want)
+		}
+		if picked := tt.want != ""; updated.distractionCategoryMode == picked {
+			t.Errorf("key %q: category picker open = %v", tt.key, updated.distractionCategoryMode)
+		}
+	}
+
+	if help := distractionCategoryHelp(m.distractionCategories); help != "[s]lack  [2] meeting  [3] music  [h]unger  [enter] skip category" {
+		t.Errorf("help = %q", help)
+	}
+}
+
+func TestModel_SyncsDistractionsLoggedElsewhere(t *testing.T) {
+	m := NewModel(stateWithSession(), nil, nil)
+	m.mode = methodology.ForMethodology(domain.MethodologyDeepWork, nil)
+	m.distractions = []string{"email"}
+
+	state := stateWithSession()
+	state.ActiveSession.Distractions = []domain.Distraction{{Text: "email"}, {Text: "slack ping", Category: "external"}}
+	result, _ := m.Update(stateMsg{state: state})
+	updated := result.(Model)
+
+	if len(updated.distractions) != 2 || updated.distractions[1] != "slack ping" {
+		t.Errorf("distractions = %v, want the one logged with flow distract picked up", updated.distractions)
+	}
+}
+
 func TestModel_DistractionCategory_EnterSkipsCategory(t *testing.T) {
 	var gotCat string
 	m := NewModel(stateWithSession(), nil, nil)
@@ -610,7 +660,7 @@ func TestModel_FocusScore_KeySavesScore(t *testing.T) {
 	}
 }
 
-func TestModel_FocusScore_OnlyInMakeTimeMode(t *testing.T) {
+func TestModel_FocusScore_OffByDefaultInPomodoro(t *testing.T) {
 	m := NewModel(stateNoSession(), nil, nil)
 	m.mode = methodology.ForMethodology(domain.MethodologyPomodoro, nil)
 	m.completed = true
@@ -621,7 +671,35 @@ func TestModel_FocusScore_OnlyInMakeTimeMode(t *testing.T) {
 	updated := result.(Model)
 
 	if updated.focusScoreSaved {
-		t.Error("focus score should only be saved in Make Time mode")
+		t.Error("focus score should not be saved in Pomodoro without focus_score")
+	}
+}
+
+func TestModel_FocusScore_OptionalWithFocusScoreOn(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Pomodoro.FocusScore = true
+	m := NewModel(stateNoSession(), nil, nil)
+	m.mode = methodology.ForMethodology(domain.MethodologyPomodoro, cfg)
+	m.completed = true
+	m.completedSessionType = domain.SessionTypeWork
+	m.width = 80
+
+	if !m.completionPromptsComplete() {
+		t.Error("an unanswered focus score should not lock [n]ew session in Pomodoro")
+	}
+	if !strings.Contains(m.View(), "How focused were you?") {
+		t.Error("completion screen should offer the focus score with focus_score on")
+	}
+
+	var gotScore int
+	m.focusScoreCallback = func(score int) error { gotScore = score; return nil }
+	result, _ := m.Update(key("4"))
+	updated := result.(Model)
+	if !updated.focusScoreSaved || gotScore != 4 {
+		t.Errorf("key 4 should save score 4, saved=%v score=%d", updated.focusScoreSaved, gotScore)
+	}
+	if !strings.Contains(updated.View(), "Focus score: 4/5") {
+		t.Error("completion screen should show the saved focus score")
 	}
 }
 
@@ -776,6 +854,63 @@ func TestModel_NewSessionKey_BlockedUntilPromptsComplete(t *testing.T) {
 	}
 }
 
+func mobCompletionInfo() *domain.CompletionInfo {
+	return &domain.CompletionInfo{
+		NextBreakType:      domain.SessionTypeShortBreak,
+		NextBreakDuration:  10 * time.Minute,
+		SessionsBeforeLong: 6,
+		SessionsUntilLong:  4,
+		NextRotation:       &domain.Rotation{Driver: "Ben", Navigator: "Cy"},
+	}
+}
+
+func TestModel_NewSessionKey_StartsNextRotationInMob(t *testing.T) {
+	cb, cmds := commandTracker()
+	m := NewModel(stateNoSession(), mobCompletionInfo(), nil)
+	m.mode = methodology.ForMethodology(domain.MethodologyMob, nil)
+	m.commandCallback = cb
+	m.width, m.height = 80, 40
+	m.completed = true
+	m.completedSessionType = domain.SessionTypeWork
+
+	view := m.View()
+	if !strings.Contains(view, "Rotate! Ben drives, Cy navigates") || !strings.Contains(view, "2 of 6 rotations") {
+		t.Errorf("Mob completion should announce the next driver and navigator, got:\n%s", view)
+	}
+
+	result, _ := m.Update(key("n"))
+	updated := result.(Model)
+	if updated.WantsNewSession || updated.completed {
+		t.Error("[n] in Mob mode should start the next rotation in place")
+	}
+	if len(*cmds) != 1 || (*cmds)[0] != ports.CmdStart {
+		t.Errorf("[n] in Mob mode should send CmdStart, got %v", *cmds)
+	}
+}
+
+func TestInlineModel_MobCompletion_AnnouncesBreakWhenDue(t *testing.T) {
+	cb, cmds := commandTracker()
+	info := mobCompletionInfo()
+	info.NextBreakType = domain.SessionTypeLongBreak
+	info.SessionsUntilLong = 0
+	m := baseInlineModel()
+	m.completionInfo = info
+	m.mode = methodology.ForMethodology(domain.MethodologyMob, nil)
+	m.commandCallback = cb
+	m.completed = true
+	m.completedType = domain.SessionTypeWork
+
+	view := m.View()
+	if !strings.Contains(view, "Ben drives, Cy navigates") || !strings.Contains(view, "Break time: [b] 10:00 together") {
+		t.Errorf("Mob completion should offer the break once it is due, got:\n%s", view)
+	}
+
+	m = updateInline(m, key("n"))
+	if m.completed || len(*cmds) != 1 || (*cmds)[0] != ports.CmdStart {
+		t.Errorf("[n] in Mob mode should send CmdStart, got %v", *cmds)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Guard: modes block [f] key
 // ---------------------------------------------------------------------------
@@ -851,3 +986,229 @@ func TestEnergizeTicks_IsAtLeast30(t *testing.T) {
 		t.Errorf("energizeTicks should be >= 29 after trigger tick (30 - 1 decrement), got %d", updated.energizeTicks)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Preset picker
+// ---------------------------------------------------------------------------
+
+func manyPresets() []config.SessionPreset {
+	var presets []config.SessionPreset
+	for _, name := range []string{"Focus", "Short", "Deep", "Review", "Writing", "Admin", "Reading", "Planning", "Spec", "Email"} {
+		presets = append(presets, config.SessionPreset{Name: name, Duration: 30 * time.Minute})
+	}
+	return presets
+}
+
+// updateInline sends msg to m and returns the updated model.
+func updateInline(m InlineModel, msg tea.Msg) InlineModel {
+	result, _ := m.Update(msg)
+	return result.(InlineModel)
+}
+
+func presetPickerModel() InlineModel {
+	m := NewInlineModel(stateNoSession(), nil, nil)
+	m.mode = methodology.ForMethodology(domain.MethodologyPomodoro, nil)
+	m.presets = manyPresets()
+	return m.pickDuration()
+}
+
+func TestInlineModel_PresetPicker_ScrollsPastNine(t *testing.T) {
+	m := presetPickerModel()
+	for i := 0; i < 9; i++ {
+		m = updateInline(m, tea.KeyMsg{Type: tea.KeyRight})
+	}
+	if m.presetCursor != 9 {
+		t.Fatalf("presetCursor = %d after 9 × right, want 9", m.presetCursor)
+	}
+	if view := m.viewPickDuration(); !strings.Contains(view, "Email") || strings.Contains(view, "Focus") {
+		t.Errorf("view should scroll to show Email and hide Focus, got:\n%s", view)
+	}
+}
+
+func TestInlineModel_PresetPicker_FuzzyFilter(t *testing.T) {
+	m := presetPickerModel()
+	for _, k := range []string{"/", "r", "d", "n"} {
+		m = updateInline(m, key(k))
+	}
+	if got := m.presets[m.presetCursor].Name; got != "Reading" {
+		t.Fatalf("filter \"rdn\" selected %q, want Reading", got)
+	}
+	if matches := m.presetMatches(); len(matches) != 1 {
+		t.Errorf("presetMatches() = %v, want only Reading", matches)
+	}
+
+	m = updateInline(m, key("esc"))
+	if m.presetFiltering || len(m.presetMatches()) != len(m.presets) {
+		t.Error("esc should clear the filter")
+	}
+	if m.phase != phasePickDuration {
+		t.Errorf("esc while filtering should stay in the picker, phase = %v", m.phase)
+	}
+}
+
+func TestInlineModel_PresetPicker_OutcomePrefilled(t *testing.T) {
+	m := NewInlineModel(stateNoSession(), nil, nil)
+	m.mode = methodology.ForMethodology(domain.MethodologyDeepWork, nil)
+	m.presets = []config.SessionPreset{{Name: "Spec", Duration: time.Hour, IntendedOutcome: "First draft"}}
+	m = m.pickDuration()
+
+	m = updateInline(m, key("1"))
+	m = updateInline(m, key("enter"))
+	if m.phase != phaseOutcome {
+		t.Fatalf("phase = %v, want the outcome prompt", m.phase)
+	}
+	if got := m.outcomeInput.Value(); got != "First draft" {
+		t.Errorf("outcome = %q, want the preset's", got)
+	}
+}
+
+func TestPickerModel_FilterSelectsOriginalIndex(t *testing.T) {
+	var items []PickerItem
+	for _, p := range manyPresets() {
+		items = append(items, PickerItem{Label: p.Name, Desc: "30m"})
+	}
+	var model tea.Model = pickerModel{title: "Duration:", items: items}
+	for _, k := range []string{"/", "s", "p"} {
+		model, _ = model.Update(key(k))
+	}
+	m := model.(pickerModel)
+	if got := items[m.selected()].Label; got != "Spec" {
+		t.Errorf("filter \"sp\" selected %q, want Spec", got)
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query, s string
+		want     bool
+	}{
+		{"", "Focus 25m", true},
+		{"dw", "Deep Work", true},
+		{"DEEP", "deep 1h30m", true},
+		{"wd", "Deep Work", false},
+		{"90", "Deep 1h30m", false},
+	}
+	for _, tt := range tests {
+		if got := fuzzyMatch(tt.query, tt.s); got != tt.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tt.query, tt.s, got, tt.want)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Day planner
+// ---------------------------------------------------------------------------
+
+func updatePlanner(m plannerModel, msg tea.Msg) (plannerModel, tea.Cmd) {
+	result, cmd := m.Update(msg)
+	return result.(plannerModel), cmd
+}
+
+func TestPlannerModel_AddBlockAndSave(t *testing.T) {
+	day := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+	m := newPlannerModel(day, day.Add(8*time.Hour), nil, config.DefaultThemeConfig())
+
+	m, _ = updatePlanner(m, key("a"))
+	if !m.editing || m.focus != planFieldTask {
+		t.Fatalf("[a] should open the form on the task field, editing = %v focus = %d", m.editing, m.focus)
+	}
+	if got := m.fields[planFieldStart].Value(); got != "09:00" {
+		t.Errorf("a first block should default to 09:00, got %q", got)
+	}
+	m, _ = updatePlanner(m, key("Write docs"))
+	m, _ = updatePlanner(m, key("enter"))
+	m, _ = updatePlanner(m, key("deepwork"))
+	m, _ = updatePlanner(m, key("enter"))
+	m, _ = updatePlanner(m, key("docs"))
+	m, _ = updatePlanner(m, key("enter"))
+	if m.editing {
+		t.Fatalf("enter on the last field should submit the form, error = %q", m.formErr)
+	}
+	if len(m.blocks) != 1 {
+		t.Fatalf("blocks = %d, want 1", len(m.blocks))
+	}
+	b := m.blocks[0]
+	if b.Task != "Write docs" || b.Methodology != domain.MethodologyDeepWork || len(b.Tags) != 1 || b.TimeRange() != "09:00–10:00" {
+		t.Errorf("block = %+v, want the form's values", b)
+	}
+
+	// The next block starts where the last one ends
+	m, _ = updatePlanner(m, key("a"))
+	if got := m.fields[planFieldStart].Value(); got != "10:00" {
+		t.Errorf("the next block should start at 10:00, got %q", got)
+	}
+	m, _ = updatePlanner(m, key("esc"))
+
+	m, cmd := updatePlanner(m, key("s"))
+	if !m.saved || cmd == nil {
+		t.Error("[s] should save the plan and quit")
+	}
+}
+
+func TestPlannerModel_RejectsOverlappingBlock(t *testing.T) {
+	day := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+	existing, _ := domain.NewPlanBlock(day.Add(9*time.Hour), day.Add(10*time.Hour), "Email")
+	m := newPlannerModel(day, day, []*domain.PlanBlock{existing}, config.DefaultThemeConfig())
+
+	m, _ = updatePlanner(m, key("a"))
+	m.fields[planFieldStart].SetValue("9:30")
+	m.fields[planFieldEnd].SetValue("11:00")
+	m.fields[planFieldTask].SetValue("Review")
+	m = m.submitForm()
+	if !m.editing || m.formErr == "" {
+		t.Error("an overlapping block should keep the form open with an error")
+	}
+	if len(m.blocks) != 1 {
+		t.Errorf("blocks = %d, want the plan unchanged", len(m.blocks))
+	}
+}
+
+func TestPlannerModel_QuitWithUnsavedChangesConfirms(t *testing.T) {
+	day := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+	existing, _ := domain.NewPlanBlock(day.Add(9*time.Hour), day.Add(10*time.Hour), "Email")
+	m := newPlannerModel(day, day, []*domain.PlanBlock{existing}, config.DefaultThemeConfig())
+
+	m, _ = updatePlanner(m, key("d"))
+	m, cmd := updatePlanner(m, key("q"))
+	if !m.confirmQuit || cmd != nil {
+		t.Fatal("first [q] with unsaved changes should ask to confirm")
+	}
+	m, cmd = updatePlanner(m, key("q"))
+	if m.saved || cmd == nil {
+		t.Error("second [q] should quit without saving")
+	}
+}
+
+func TestInlineModel_FollowPlanKey(t *testing.T) {
+	now := time.Now()
+	block, _ := domain.NewPlanBlock(now.Add(-time.Hour), now.Add(time.Hour), "Write docs")
+	block.Methodology = domain.MethodologyDeepWork
+
+	m := NewInlineModel(&domain.CurrentState{CurrentBlock: block}, nil, nil)
+	m.mode = methodology.ForMethodology(domain.MethodologyPomodoro, nil)
+	m.presets = m.mode.Presets()
+	m = m.pickDuration()
+
+	if strings.Contains(m.View(), "follow the plan") {
+		t.Error("the plan hint needs an OnFollowPlan callback")
+	}
+	var followed *domain.PlanBlock
+	m.onFollowPlan = func(b *domain.PlanBlock) error {
+		followed = b
+		return nil
+	}
+	if !strings.Contains(m.View(), "Planned now: "+block.String()) {
+		t.Error("the duration picker should offer the block under way")
+	}
+
+	m = updateInline(m, key("p"))
+	if followed != block {
+		t.Fatal("[p] should start a session on the block")
+	}
+	if m.phase != phaseTimer {
+		t.Errorf("phase = %v, want the timer", m.phase)
+	}
+	if m.mode.Name() != domain.MethodologyDeepWork {
+		t.Errorf("mode = %v, want the block's", m.mode.Name())
+	}
+}
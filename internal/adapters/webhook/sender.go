@@ -0,0 +1,77 @@
+// Package webhook delivers session events to webhook URLs over HTTP,
+// signed with HMAC-SHA256 so receivers can check they came from Flow.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// Request headers sent with every delivery.
+const (
+	HeaderEvent     = "X-Flow-Event"
+	HeaderDelivery  = "X-Flow-Delivery"
+	HeaderSignature = "X-Flow-Signature-256"
+)
+
+// Timeout bounds each delivery attempt.
+const Timeout = 5 * time.Second
+
+// Sender implements ports.WebhookSender with net/http.
+type Sender struct {
+	client *http.Client
+}
+
+// Ensure Sender implements ports.WebhookSender.
+var _ ports.WebhookSender = (*Sender)(nil)
+
+// NewSender creates a webhook sender.
+func NewSender() *Sender {
+	return &Sender{client: &http.Client{Timeout: Timeout}}
+}
+
+// Send POSTs the delivery's payload. With a secret, the body's HMAC-SHA256
+// is sent hex-encoded as "sha256=<hex>" in the X-Flow-Signature-256 header.
+func (s *Sender) Send(ctx context.Context, delivery *domain.WebhookDelivery, secret string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "flow-cli")
+	req.Header.Set(HeaderEvent, string(delivery.Event))
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	if secret != "" {
+		req.Header.Set(HeaderSignature, Sign(secret, delivery.Payload))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the signature header value for body: "sha256=" followed by
+// the hex HMAC-SHA256 of body keyed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+func TestSender_SignsPayload(t *testing.T) {
+	var gotBody []byte
+	var gotHeader http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotHeader = r.Header
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	delivery := domain.NewWebhookDelivery(ts.URL, domain.HookComplete, []byte(`{"event":"on_complete"}`))
+	if err := NewSender().Send(context.Background(), delivery, "s3cret"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if string(gotBody) != `{"event":"on_complete"}` {
+		t.Errorf("body = %s", gotBody)
+	}
+	if gotHeader.Get(HeaderEvent) != "on_complete" || gotHeader.Get(HeaderDelivery) != delivery.ID {
+		t.Errorf("headers = %v, want the event and delivery id", gotHeader)
+	}
+	const want = "sha256=d63fdc38e1d711490cd5098a1b44eb0b6b5f6c919230c12c2237994174ac0b84"
+	if got := gotHeader.Get(HeaderSignature); !hmac.Equal([]byte(got), []byte(want)) {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+}
+
+func TestSender_FailsOnErrorStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(HeaderSignature) != "" {
+			t.Error("unsigned delivery sent a signature header")
+		}
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	delivery := domain.NewWebhookDelivery(ts.URL, domain.HookStart, []byte(`{}`))
+	if err := NewSender().Send(context.Background(), delivery, ""); err == nil {
+		t.Error("Send() to a failing endpoint = nil, want an error")
+	}
+}
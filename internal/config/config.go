@@ -6,23 +6,34 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
 	"github.com/xvierd/flow-cli/internal/domain"
 )
 
 // Config holds all configuration for the Flow application.
 type Config struct {
-	Methodology   string             `mapstructure:"methodology"`
-	FirstRun      bool               `mapstructure:"first_run"`
-	Pomodoro      PomodoroConfig     `mapstructure:"pomodoro"`
-	DeepWork      DeepWorkConfig     `mapstructure:"deepwork"`
-	MakeTime      MakeTimeConfig     `mapstructure:"maketime"`
-	Notifications NotificationConfig `mapstructure:"notifications"`
-	MCP           MCPConfig          `mapstructure:"mcp"`
-	Storage       StorageConfig      `mapstructure:"storage"`
-	Theme         ThemeConfig        `mapstructure:"theme"`
+	Methodology   string              `mapstructure:"methodology"`
+	FirstRun      bool                `mapstructure:"first_run"`
+	Pomodoro      PomodoroConfig      `mapstructure:"pomodoro"`
+	DeepWork      DeepWorkConfig      `mapstructure:"deepwork"`
+	MakeTime      MakeTimeConfig      `mapstructure:"maketime"`
+	Flowtime      FlowtimeConfig      `mapstructure:"flowtime"`
+	Mob           MobConfig           `mapstructure:"mob"`
+	Methodologies []MethodologyConfig `mapstructure:"methodologies"`
+	Notifications NotificationConfig  `mapstructure:"notifications"`
+	MCP           MCPConfig           `mapstructure:"mcp"`
+	Storage       StorageConfig       `mapstructure:"storage"`
+	Recovery      RecoveryConfig      `mapstructure:"recovery"`
+	Daemon        DaemonConfig        `mapstructure:"daemon"`
+	Hooks         HooksConfig         `mapstructure:"hooks"`
+	Webhooks      []WebhookConfig     `mapstructure:"webhooks"`
+	Distractions  DistractionsConfig  `mapstructure:"distractions"`
+	Theme         ThemeConfig         `mapstructure:"theme"`
 }
 
 // ThemeConfig holds theme customization settings (colors and icons).
@@ -71,75 +82,253 @@ func DefaultThemeConfig() ThemeConfig {
 
 // PomodoroConfig holds pomodoro timer settings.
 type PomodoroConfig struct {
-	WorkDuration       Duration `mapstructure:"work_duration"`
-	ShortBreak         Duration `mapstructure:"short_break"`
-	LongBreak          Duration `mapstructure:"long_break"`
-	SessionsBeforeLong int      `mapstructure:"sessions_before_long"`
-	AutoBreak          bool     `mapstructure:"auto_break"`
-	Preset1Name        string   `mapstructure:"preset1_name"`
-	Preset1Duration    Duration `mapstructure:"preset1_duration"`
-	Preset2Name        string   `mapstructure:"preset2_name"`
-	Preset2Duration    Duration `mapstructure:"preset2_duration"`
-	Preset3Name        string   `mapstructure:"preset3_name"`
-	Preset3Duration    Duration `mapstructure:"preset3_duration"`
+	WorkDuration       Duration       `mapstructure:"work_duration"`
+	ShortBreak         Duration       `mapstructure:"short_break"`
+	LongBreak          Duration       `mapstructure:"long_break"`
+	SessionsBeforeLong int            `mapstructure:"sessions_before_long"`
+	AutoBreak          bool           `mapstructure:"auto_break"`
+	DistractionLog     bool           `mapstructure:"distraction_log"` // offer [d]istraction in the timer
+	FocusScore         bool           `mapstructure:"focus_score"`     // ask for a 1-5 focus score on completion
+	Presets            []PresetConfig `mapstructure:"presets"`
 }
 
 // SessionPreset represents a named session duration preset.
 type SessionPreset struct {
-	Name     string
-	Duration time.Duration
+	Name            string
+	Duration        time.Duration
+	Tags            []string      // added to sessions started from the preset
+	IntendedOutcome string        // pre-fills the intended outcome
+	Break           time.Duration // the break after the session; 0 for the methodology's
 }
 
-// GetPresets returns the three session presets.
+// GetPresets returns the session presets.
 func (c *PomodoroConfig) GetPresets() []SessionPreset {
-	return []SessionPreset{
-		{Name: c.Preset1Name, Duration: time.Duration(c.Preset1Duration)},
-		{Name: c.Preset2Name, Duration: time.Duration(c.Preset2Duration)},
-		{Name: c.Preset3Name, Duration: time.Duration(c.Preset3Duration)},
-	}
+	return sessionPresets(c.Presets)
 }
 
 // DeepWorkConfig holds deep work timer settings.
 type DeepWorkConfig struct {
-	DeepWorkGoalHours float64  `mapstructure:"deep_work_goal_hours"`
-	BreakDuration     Duration `mapstructure:"break_duration"`
-	Philosophy        string   `mapstructure:"philosophy"`
-	Preset1Name       string   `mapstructure:"preset1_name"`
-	Preset1Duration   Duration `mapstructure:"preset1_duration"`
-	Preset2Name       string   `mapstructure:"preset2_name"`
-	Preset2Duration   Duration `mapstructure:"preset2_duration"`
-	Preset3Name       string   `mapstructure:"preset3_name"`
-	Preset3Duration   Duration `mapstructure:"preset3_duration"`
-}
-
-// GetPresets returns the three session presets for deep work.
+	DeepWorkGoalHours float64        `mapstructure:"deep_work_goal_hours"`
+	BreakDuration     Duration       `mapstructure:"break_duration"`
+	Philosophy        string         `mapstructure:"philosophy"`
+	FocusScore        bool           `mapstructure:"focus_score"` // ask for a 1-5 focus score on completion
+	Presets           []PresetConfig `mapstructure:"presets"`
+}
+
+// GetPresets returns the session presets for deep work.
 func (c *DeepWorkConfig) GetPresets() []SessionPreset {
-	return []SessionPreset{
-		{Name: c.Preset1Name, Duration: time.Duration(c.Preset1Duration)},
-		{Name: c.Preset2Name, Duration: time.Duration(c.Preset2Duration)},
-		{Name: c.Preset3Name, Duration: time.Duration(c.Preset3Duration)},
-	}
+	return sessionPresets(c.Presets)
 }
 
 // MakeTimeConfig holds make time timer settings.
 type MakeTimeConfig struct {
-	BreakDuration          Duration `mapstructure:"break_duration"`
-	HighlightTargetMinutes int      `mapstructure:"highlight_target_minutes"`
-	Preset1Name            string   `mapstructure:"preset1_name"`
-	Preset1Duration        Duration `mapstructure:"preset1_duration"`
-	Preset2Name            string   `mapstructure:"preset2_name"`
-	Preset2Duration        Duration `mapstructure:"preset2_duration"`
-	Preset3Name            string   `mapstructure:"preset3_name"`
-	Preset3Duration        Duration `mapstructure:"preset3_duration"`
+	BreakDuration          Duration       `mapstructure:"break_duration"`
+	HighlightTargetMinutes int            `mapstructure:"highlight_target_minutes"`
+	DistractionLog         bool           `mapstructure:"distraction_log"` // offer [d]istraction in the timer
+	Presets                []PresetConfig `mapstructure:"presets"`
 }
 
-// GetPresets returns the three session presets for make time.
+// GetPresets returns the session presets for make time.
 func (c *MakeTimeConfig) GetPresets() []SessionPreset {
-	return []SessionPreset{
-		{Name: c.Preset1Name, Duration: time.Duration(c.Preset1Duration)},
-		{Name: c.Preset2Name, Duration: time.Duration(c.Preset2Duration)},
-		{Name: c.Preset3Name, Duration: time.Duration(c.Preset3Duration)},
+	return sessionPresets(c.Presets)
+}
+
+// FlowtimeConfig holds Flowtime settings. Sessions count up until stopped
+// and earn a break from the time worked.
+type FlowtimeConfig struct {
+	// BreakRatio is the break earned per unit of work, as a fraction like
+	// "1/5" or a decimal like "0.2".
+	BreakRatio string `mapstructure:"break_ratio"`
+	// BreakRules replace the ratio with tiers when set: the first rule
+	// whose up_to covers the time worked gives the break.
+	BreakRules     []FlowtimeBreakRule `mapstructure:"break_rules"`
+	DistractionLog bool                `mapstructure:"distraction_log"` // offer [d]istraction in the timer
+	FocusScore     bool                `mapstructure:"focus_score"`     // ask for a 1-5 focus score on completion
+}
+
+// FlowtimeBreakRule is one [[flowtime.break_rules]] tier. A zero up_to
+// matches sessions of any length.
+type FlowtimeBreakRule struct {
+	UpTo  Duration `mapstructure:"up_to"`
+	Break Duration `mapstructure:"break"`
+}
+
+// BreakPolicy validates the ratio and rules into the domain policy.
+func (c *FlowtimeConfig) BreakPolicy() (domain.EarnedBreakPolicy, error) {
+	var policy domain.EarnedBreakPolicy
+	if c.BreakRatio != "" {
+		ratio, err := domain.ParseBreakRatio(c.BreakRatio)
+		if err != nil {
+			return policy, fmt.Errorf("flowtime.break_ratio: %w", err)
+		}
+		policy.Ratio = ratio
+	}
+	for i, r := range c.BreakRules {
+		if r.Break <= 0 {
+			return policy, fmt.Errorf("flowtime.break_rules entry %d has no break", i+1)
+		}
+		if i > 0 {
+			if prev := c.BreakRules[i-1].UpTo; prev == 0 || (r.UpTo != 0 && r.UpTo <= prev) {
+				return policy, fmt.Errorf("flowtime.break_rules entry %d: up_to must increase, with an open-ended rule last", i+1)
+			}
+		}
+		policy.Rules = append(policy.Rules, domain.EarnedBreakRule{
+			UpTo:  time.Duration(r.UpTo),
+			Break: time.Duration(r.Break),
+		})
+	}
+	return policy, nil
+}
+
+// MobConfig holds mob and pair programming settings. The roster takes
+// turns driving for one rotation each; the mob breaks together every
+// RotationsBeforeBreak rotations.
+type MobConfig struct {
+	Roster               []string `mapstructure:"roster"` // in rotation order
+	Rotation             Duration `mapstructure:"rotation"`
+	RotationsBeforeBreak int      `mapstructure:"rotations_before_break"`
+	BreakDuration        Duration `mapstructure:"break_duration"`
+	FocusScore           bool     `mapstructure:"focus_score"` // ask for a 1-5 focus score on completion
+}
+
+// RotationLength returns how long each driver has the keyboard.
+func (c *MobConfig) RotationLength() time.Duration {
+	if c.Rotation <= 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(c.Rotation)
+}
+
+// PresetConfig is a named session length, as listed in config.
+type PresetConfig struct {
+	Name            string   `mapstructure:"name"`
+	Duration        Duration `mapstructure:"duration"`
+	Tags            []string `mapstructure:"tags"`             // added to sessions started from the preset
+	IntendedOutcome string   `mapstructure:"intended_outcome"` // pre-fills the intended outcome
+	Break           Duration `mapstructure:"break"`            // replaces the methodology's break after it
+}
+
+// sessionPresets converts config presets to SessionPresets.
+func sessionPresets(list []PresetConfig) []SessionPreset {
+	presets := make([]SessionPreset, len(list))
+	for i, p := range list {
+		presets[i] = SessionPreset{
+			Name:            p.Name,
+			Duration:        time.Duration(p.Duration),
+			Tags:            p.Tags,
+			IntendedOutcome: p.IntendedOutcome,
+			Break:           time.Duration(p.Break),
+		}
+	}
+	return presets
+}
+
+// MethodologyConfig is one [[methodologies]] entry: a user-defined
+// methodology such as a 52/17 or ultradian 90/20 cycle.
+type MethodologyConfig struct {
+	// Name identifies the methodology in --mode, config and stored sessions.
+	Name        string         `mapstructure:"name"`
+	Label       string         `mapstructure:"label"` // shown in the TUI and stats; defaults to Name
+	Description string         `mapstructure:"description"`
+	Presets     []PresetConfig `mapstructure:"presets"`
+	ShortBreak  Duration       `mapstructure:"short_break"`
+	// LongBreak follows every SessionsBeforeLong work sessions; without
+	// both, every break is a ShortBreak.
+	LongBreak          Duration `mapstructure:"long_break"`
+	SessionsBeforeLong int      `mapstructure:"sessions_before_long"`
+	DistractionLog     bool     `mapstructure:"distraction_log"` // offer [d]istraction in the timer
+	FocusScore         bool     `mapstructure:"focus_score"`     // ask for a 1-5 focus score on completion
+	ShutdownRitual     bool     `mapstructure:"shutdown_ritual"` // Deep Work's end-of-session ritual
+	Highlight          bool     `mapstructure:"highlight"`       // Make Time's daily Highlight
+	LaserChecklist     bool     `mapstructure:"laser_checklist"` // Make Time's pre-session checklist
+}
+
+// GetPresets returns the methodology's session presets.
+func (c *MethodologyConfig) GetPresets() []SessionPreset {
+	return sessionPresets(c.Presets)
+}
+
+// validate checks an entry on its own; Validate checks the list.
+func (c *MethodologyConfig) validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("methodologies entry has no name")
+	}
+	if len(c.Presets) == 0 {
+		return fmt.Errorf("methodology %q has no presets", c.Name)
+	}
+	for _, p := range c.Presets {
+		if p.Name == "" || p.Duration <= 0 {
+			return fmt.Errorf("methodology %q: every preset needs a name and a duration", c.Name)
+		}
+	}
+	if c.ShortBreak <= 0 {
+		return fmt.Errorf("methodology %q has no short_break", c.Name)
+	}
+	if c.SessionsBeforeLong < 0 || (c.SessionsBeforeLong > 0 && c.LongBreak <= 0) {
+		return fmt.Errorf("methodology %q: sessions_before_long needs a long_break", c.Name)
+	}
+	return nil
+}
+
+// CustomMethodology returns the [[methodologies]] entry named m, or nil.
+func (c *Config) CustomMethodology(m domain.Methodology) *MethodologyConfig {
+	for i := range c.Methodologies {
+		if domain.Methodology(c.Methodologies[i].Name) == m {
+			return &c.Methodologies[i]
+		}
 	}
+	return nil
+}
+
+// PresetList returns the presets list of methodology m for editing, or nil
+// if m has none in config.
+func (c *Config) PresetList(m domain.Methodology) *[]PresetConfig {
+	switch m {
+	case domain.MethodologyPomodoro:
+		return &c.Pomodoro.Presets
+	case domain.MethodologyDeepWork:
+		return &c.DeepWork.Presets
+	case domain.MethodologyMakeTime:
+		return &c.MakeTime.Presets
+	}
+	if mc := c.CustomMethodology(m); mc != nil {
+		return &mc.Presets
+	}
+	return nil
+}
+
+// FindPreset returns the index in list of the preset named ref, matched
+// without regard to case, or else at the 1-based position ref.
+func FindPreset(list []PresetConfig, ref string) (int, error) {
+	for i, p := range list {
+		if strings.EqualFold(p.Name, ref) {
+			return i, nil
+		}
+	}
+	if n, err := strconv.Atoi(ref); err == nil && n >= 1 && n <= len(list) {
+		return n - 1, nil
+	}
+	return -1, fmt.Errorf("no preset %q", ref)
+}
+
+// ValidateMethodologies checks the [[methodologies]] entries, which must
+// each be complete and have a name of their own.
+func (c *Config) ValidateMethodologies() error {
+	seen := make(map[string]bool, len(c.Methodologies))
+	for i := range c.Methodologies {
+		mc := &c.Methodologies[i]
+		if err := mc.validate(); err != nil {
+			return err
+		}
+		if domain.Methodology(mc.Name).IsBuiltin() {
+			return fmt.Errorf("methodology %q is built in; pick another name", mc.Name)
+		}
+		if seen[mc.Name] {
+			return fmt.Errorf("methodology %q is declared twice", mc.Name)
+		}
+		seen[mc.Name] = true
+	}
+	return nil
 }
 
 // NotificationConfig holds notification settings.
@@ -157,6 +346,80 @@ type MCPConfig struct {
 // StorageConfig holds storage settings.
 type StorageConfig struct {
 	DataDir string `mapstructure:"data_dir"`
+	// BackupKeep is how many daily automatic snapshots to keep (0 disables them).
+	BackupKeep int `mapstructure:"backup_keep"`
+}
+
+// RecoveryConfig controls what happens to sessions left running after flow
+// exited, e.g. because the terminal was closed or the laptop slept.
+type RecoveryConfig struct {
+	// StalePolicy applies when no one can be asked (scripts, MCP): "ask"
+	// leaves the session for the next interactive launch, "keep" credits
+	// its full duration and "void" discards it.
+	StalePolicy string `mapstructure:"stale_policy"`
+}
+
+// DaemonConfig holds background daemon settings.
+type DaemonConfig struct {
+	// AutoStart spawns "flow daemon" whenever a session starts, so sessions
+	// end with a notification even after the TUI is closed.
+	AutoStart bool `mapstructure:"auto_start"`
+}
+
+// HooksConfig names shell commands to run on session lifecycle events.
+// Empty commands are skipped.
+type HooksConfig struct {
+	OnStart      string `mapstructure:"on_start"`
+	OnPause      string `mapstructure:"on_pause"`
+	OnResume     string `mapstructure:"on_resume"`
+	OnComplete   string `mapstructure:"on_complete"`
+	OnBreakStart string `mapstructure:"on_break_start"`
+	OnBreakEnd   string `mapstructure:"on_break_end"`
+	OnVoid       string `mapstructure:"on_void"`
+	// Timeout bounds each hook; commands still running are killed.
+	Timeout Duration `mapstructure:"timeout"`
+}
+
+// Commands returns the configured hook commands by event.
+func (c *HooksConfig) Commands() map[domain.HookEvent]string {
+	commands := make(map[domain.HookEvent]string)
+	for event, command := range map[domain.HookEvent]string{
+		domain.HookStart:      c.OnStart,
+		domain.HookPause:      c.OnPause,
+		domain.HookResume:     c.OnResume,
+		domain.HookComplete:   c.OnComplete,
+		domain.HookBreakStart: c.OnBreakStart,
+		domain.HookBreakEnd:   c.OnBreakEnd,
+		domain.HookVoid:       c.OnVoid,
+	} {
+		if command != "" {
+			commands[event] = command
+		}
+	}
+	return commands
+}
+
+// WebhookConfig is one [[webhooks]] entry: a URL that receives session
+// events as signed JSON POSTs.
+type WebhookConfig struct {
+	URL string `mapstructure:"url"`
+	// Events lists the hook events to send, e.g. "on_complete"; empty sends all.
+	Events []string `mapstructure:"events"`
+	// Secret keys the HMAC-SHA256 signature header; empty sends unsigned.
+	Secret string `mapstructure:"secret"`
+}
+
+// DistractionsConfig holds distraction logging settings.
+type DistractionsConfig struct {
+	// Categories are offered when categorizing a logged distraction, in
+	// order; the first nine can be picked by number.
+	Categories []string `mapstructure:"categories"`
+}
+
+// CategoryList returns the configured categories, normalized, or the
+// defaults when none are set.
+func (c *DistractionsConfig) CategoryList() []string {
+	return domain.NormalizeDistractionCategories(c.Categories)
 }
 
 // Duration is a wrapper around time.Duration for TOML parsing.
@@ -192,33 +455,38 @@ func DefaultConfig() *Config {
 			ShortBreak:         Duration(5 * time.Minute),
 			LongBreak:          Duration(15 * time.Minute),
 			SessionsBeforeLong: 4,
-			Preset1Name:        "Focus",
-			Preset1Duration:    Duration(25 * time.Minute),
-			Preset2Name:        "Short",
-			Preset2Duration:    Duration(15 * time.Minute),
-			Preset3Name:        "Deep",
-			Preset3Duration:    Duration(50 * time.Minute),
+			Presets: []PresetConfig{
+				{Name: "Focus", Duration: Duration(25 * time.Minute)},
+				{Name: "Short", Duration: Duration(15 * time.Minute)},
+				{Name: "Deep", Duration: Duration(50 * time.Minute)},
+			},
 		},
 		DeepWork: DeepWorkConfig{
 			DeepWorkGoalHours: 4.0,
 			BreakDuration:     Duration(20 * time.Minute),
 			Philosophy:        "", // empty triggers first-run philosophy picker
-			Preset1Name:       "Deep",
-			Preset1Duration:   Duration(90 * time.Minute),
-			Preset2Name:       "Focus",
-			Preset2Duration:   Duration(50 * time.Minute),
-			Preset3Name:       "Shallow",
-			Preset3Duration:   Duration(25 * time.Minute),
+			Presets: []PresetConfig{
+				{Name: "Deep", Duration: Duration(90 * time.Minute)},
+				{Name: "Focus", Duration: Duration(50 * time.Minute)},
+				{Name: "Shallow", Duration: Duration(25 * time.Minute)},
+			},
 		},
 		MakeTime: MakeTimeConfig{
 			BreakDuration:          Duration(15 * time.Minute),
 			HighlightTargetMinutes: 60,
-			Preset1Name:            "Highlight",
-			Preset1Duration:        Duration(60 * time.Minute),
-			Preset2Name:            "Sprint",
-			Preset2Duration:        Duration(25 * time.Minute),
-			Preset3Name:            "Quick",
-			Preset3Duration:        Duration(15 * time.Minute),
+			Presets: []PresetConfig{
+				{Name: "Highlight", Duration: Duration(60 * time.Minute)},
+				{Name: "Sprint", Duration: Duration(25 * time.Minute)},
+				{Name: "Quick", Duration: Duration(15 * time.Minute)},
+			},
+		},
+		Flowtime: FlowtimeConfig{
+			BreakRatio: "1/5",
+		},
+		Mob: MobConfig{
+			Rotation:             Duration(10 * time.Minute),
+			RotationsBeforeBreak: 6,
+			BreakDuration:        Duration(10 * time.Minute),
 		},
 		Notifications: NotificationConfig{
 			Enabled: true,
@@ -229,7 +497,20 @@ func DefaultConfig() *Config {
 			AutoStart: false,
 		},
 		Storage: StorageConfig{
-			DataDir: "~/.flow",
+			DataDir:    "~/.flow",
+			BackupKeep: 7,
+		},
+		Recovery: RecoveryConfig{
+			StalePolicy: "ask",
+		},
+		Daemon: DaemonConfig{
+			AutoStart: false,
+		},
+		Hooks: HooksConfig{
+			Timeout: Duration(10 * time.Second),
+		},
+		Distractions: DistractionsConfig{
+			Categories: append([]string(nil), domain.DefaultDistractionCategories...),
 		},
 		Theme: DefaultThemeConfig(),
 	}
@@ -267,23 +548,45 @@ func Load() (*Config, error) {
 	}
 
 	var cfg Config
-	if err := viper.Unmarshal(&cfg); err != nil {
+	// Durations are written as strings like "25m0s", so they decode
+	// through Duration.UnmarshalText.
+	decodeHook := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.TextUnmarshallerHookFunc(),
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	))
+	if err := viper.Unmarshal(&cfg, decodeHook); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	// Expand ~ in data directory
-	if cfg.Storage.DataDir == "~/.flow" || cfg.Storage.DataDir == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get home directory: %w", err)
-		}
-		cfg.Storage.DataDir = filepath.Join(homeDir, ".flow")
+	// Presets still listed the old way are read into the list here; the
+	// file keeps its old keys until the config is next saved.
+	migrateLegacyPresets(&cfg)
+
+	if err := cfg.ExpandDataDir(); err != nil {
+		return nil, err
 	}
 
 	return &cfg, nil
 }
 
-// Save saves the configuration to the config file.
+// ExpandDataDir replaces the default "~/.flow" data directory, or an unset
+// one, with the .flow directory under the user's home.
+func (c *Config) ExpandDataDir() error {
+	if c.Storage.DataDir != "~/.flow" && c.Storage.DataDir != "" {
+		return nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+	c.Storage.DataDir = filepath.Join(homeDir, ".flow")
+	return nil
+}
+
+// Save saves the configuration to the config file. The file is written
+// from cfg alone, so keys it doesn't know, such as the old preset1_name,
+// are dropped.
 func Save(cfg *Config) error {
 	configPath, err := GetConfigPath()
 	if err != nil {
@@ -296,14 +599,48 @@ func Save(cfg *Config) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	viper.SetConfigFile(configPath)
-	viper.SetConfigType("toml")
+	v := viper.New()
+	v.SetConfigType("toml")
+	for k, val := range flattenConfig(cfg, "") {
+		v.Set(k, val)
+	}
+
+	return v.WriteConfigAs(configPath)
+}
 
-	for k, v := range flattenConfig(cfg, "") {
-		viper.Set(k, v)
+// builtinPresets returns the presets list of each built-in methodology
+// with presets, keyed by its config section.
+func (c *Config) builtinPresets() map[string]*[]PresetConfig {
+	return map[string]*[]PresetConfig{
+		"pomodoro": &c.Pomodoro.Presets,
+		"deepwork": &c.DeepWork.Presets,
+		"maketime": &c.MakeTime.Presets,
 	}
+}
 
-	return viper.WriteConfig()
+// migrateLegacyPresets fills each built-in section's presets list: from
+// the old layout of exactly three presets, written as preset1_name …
+// preset3_duration with unset ones taking their defaults, or else from the
+// defaults when the list is empty.
+func migrateLegacyPresets(cfg *Config) {
+	defaults := DefaultConfig().builtinPresets()
+	for section, presets := range cfg.builtinPresets() {
+		if len(*presets) > 0 || viper.InConfig(section+".presets") {
+			continue
+		}
+		list := append([]PresetConfig(nil), *defaults[section]...)
+		for i := range list {
+			nameKey := fmt.Sprintf("%s.preset%d_name", section, i+1)
+			durationKey := fmt.Sprintf("%s.preset%d_duration", section, i+1)
+			if viper.InConfig(nameKey) {
+				list[i].Name = viper.GetString(nameKey)
+			}
+			if viper.InConfig(durationKey) {
+				list[i].Duration = Duration(viper.GetDuration(durationKey))
+			}
+		}
+		*presets = list
+	}
 }
 
 // flattenConfig walks a struct (or pointer to struct) using mapstructure tags and
@@ -339,6 +676,19 @@ func flattenConfig(v interface{}, prefix string) map[string]interface{} {
 			continue
 		}
 
+		// Slice of structs → array of tables, left out while empty
+		if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Struct {
+			if fv.Len() == 0 {
+				continue
+			}
+			tables := make([]map[string]interface{}, 0, fv.Len())
+			for j := 0; j < fv.Len(); j++ {
+				tables = append(tables, omitZero(fv.Index(j), flattenConfig(fv.Index(j).Interface(), "")))
+			}
+			result[key] = tables
+			continue
+		}
+
 		// Nested struct → recurse
 		if fv.Kind() == reflect.Struct {
 			for k, val := range flattenConfig(fv.Interface(), key) {
@@ -352,6 +702,17 @@ func flattenConfig(v interface{}, prefix string) map[string]interface{} {
 	return result
 }
 
+// omitZero drops the keys of table whose fields are unset in the struct v,
+// so array-of-tables entries list only what they set.
+func omitZero(v reflect.Value, table map[string]interface{}) map[string]interface{} {
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Field(i); f.IsZero() || (f.Kind() == reflect.Slice && f.Len() == 0) {
+			delete(table, v.Type().Field(i).Tag.Get("mapstructure"))
+		}
+	}
+	return table
+}
+
 // GetConfigPath returns the path to the config file.
 func GetConfigPath() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -361,6 +722,15 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(homeDir, ".flow", "config.toml"), nil
 }
 
+// GetTemplatesDir returns the directory holding named --format templates.
+func GetTemplatesDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".flow", "templates"), nil
+}
+
 // GetDBPath returns the path to the database file.
 func GetDBPath(cfg *Config) string {
 	return filepath.Join(cfg.Storage.DataDir, "flow.db")
@@ -375,35 +745,33 @@ func setDefaults() {
 	viper.SetDefault("pomodoro.long_break", "15m")
 	viper.SetDefault("pomodoro.sessions_before_long", 4)
 	viper.SetDefault("pomodoro.auto_break", false)
-	viper.SetDefault("pomodoro.preset1_name", "Focus")
-	viper.SetDefault("pomodoro.preset1_duration", "25m0s")
-	viper.SetDefault("pomodoro.preset2_name", "Short")
-	viper.SetDefault("pomodoro.preset2_duration", "15m0s")
-	viper.SetDefault("pomodoro.preset3_name", "Deep")
-	viper.SetDefault("pomodoro.preset3_duration", "50m0s")
+	viper.SetDefault("pomodoro.distraction_log", false)
+	viper.SetDefault("pomodoro.focus_score", false)
 	viper.SetDefault("deepwork.deep_work_goal_hours", 4.0)
 	viper.SetDefault("deepwork.break_duration", "20m0s")
 	// deepwork.philosophy intentionally has no default — empty string triggers
 	// the first-run philosophy picker in the wizard.
-	viper.SetDefault("deepwork.preset1_name", "Deep")
-	viper.SetDefault("deepwork.preset1_duration", "1h30m0s")
-	viper.SetDefault("deepwork.preset2_name", "Focus")
-	viper.SetDefault("deepwork.preset2_duration", "50m0s")
-	viper.SetDefault("deepwork.preset3_name", "Shallow")
-	viper.SetDefault("deepwork.preset3_duration", "25m0s")
+	viper.SetDefault("deepwork.focus_score", false)
 	viper.SetDefault("maketime.break_duration", "15m0s")
 	viper.SetDefault("maketime.highlight_target_minutes", 60)
-	viper.SetDefault("maketime.preset1_name", "Highlight")
-	viper.SetDefault("maketime.preset1_duration", "1h0m0s")
-	viper.SetDefault("maketime.preset2_name", "Sprint")
-	viper.SetDefault("maketime.preset2_duration", "25m0s")
-	viper.SetDefault("maketime.preset3_name", "Quick")
-	viper.SetDefault("maketime.preset3_duration", "15m0s")
+	viper.SetDefault("maketime.distraction_log", false)
+	viper.SetDefault("flowtime.break_ratio", "1/5")
+	viper.SetDefault("flowtime.distraction_log", false)
+	viper.SetDefault("flowtime.focus_score", false)
+	viper.SetDefault("mob.rotation", "10m0s")
+	viper.SetDefault("mob.rotations_before_break", 6)
+	viper.SetDefault("mob.break_duration", "10m0s")
+	viper.SetDefault("mob.focus_score", false)
 	viper.SetDefault("notifications.enabled", true)
 	viper.SetDefault("notifications.sound", true)
 	viper.SetDefault("mcp.enabled", true)
 	viper.SetDefault("mcp.auto_start", false)
 	viper.SetDefault("storage.data_dir", "~/.flow")
+	viper.SetDefault("storage.backup_keep", 7)
+	viper.SetDefault("recovery.stale_policy", "ask")
+	viper.SetDefault("daemon.auto_start", false)
+	viper.SetDefault("hooks.timeout", "10s")
+	viper.SetDefault("distractions.categories", domain.DefaultDistractionCategories)
 
 	// Theme defaults
 	defaults := DefaultThemeConfig()
@@ -436,6 +804,10 @@ func (c *Config) ToPomodoroDomainConfig() (work, shortBreak, longBreak time.Dura
 
 // GetBreakDurations returns the short and long break durations for the given methodology.
 // Deep Work and Make Time use a single break duration; Pomodoro uses short/long.
+// Flowtime breaks are earned (see FlowtimeConfig.BreakPolicy), so it falls
+// back to the Pomodoro breaks when there is no Flowtime session to earn from.
+// A mob takes the same break whenever it stops. User-defined methodologies
+// use their own breaks.
 func (c *Config) GetBreakDurations(m domain.Methodology) (short, long time.Duration) {
 	switch m {
 	case domain.MethodologyDeepWork:
@@ -450,7 +822,35 @@ func (c *Config) GetBreakDurations(m domain.Methodology) (short, long time.Durat
 			bd = 15 * time.Minute
 		}
 		return bd, bd
-	default:
-		return time.Duration(c.Pomodoro.ShortBreak), time.Duration(c.Pomodoro.LongBreak)
+	case domain.MethodologyMob:
+		bd := time.Duration(c.Mob.BreakDuration)
+		if bd == 0 {
+			bd = 10 * time.Minute
+		}
+		return bd, bd
+	}
+	if mc := c.CustomMethodology(m); mc != nil {
+		if mc.SessionsBeforeLong == 0 {
+			return time.Duration(mc.ShortBreak), time.Duration(mc.ShortBreak)
+		}
+		return time.Duration(mc.ShortBreak), time.Duration(mc.LongBreak)
+	}
+	return time.Duration(c.Pomodoro.ShortBreak), time.Duration(c.Pomodoro.LongBreak)
+}
+
+// GetSessionsBeforeLong returns how many work sessions come before a long
+// break in the given methodology: always 4 in Pomodoro (the technique's
+// rule), the rotations before a mob's break, a user-defined methodology's
+// own count, else the [pomodoro] setting.
+func (c *Config) GetSessionsBeforeLong(m domain.Methodology) int {
+	if m == domain.MethodologyPomodoro {
+		return 4
+	}
+	if m == domain.MethodologyMob && c.Mob.RotationsBeforeBreak > 0 {
+		return c.Mob.RotationsBeforeBreak
+	}
+	if mc := c.CustomMethodology(m); mc != nil && mc.SessionsBeforeLong > 0 {
+		return mc.SessionsBeforeLong
 	}
+	return c.Pomodoro.SessionsBeforeLong
 }
@@ -1,8 +1,13 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
 )
 
 func TestDefaultConfig_DeepWorkPresets(t *testing.T) {
@@ -39,3 +44,259 @@ func TestDeepWorkGoalHours_Default4(t *testing.T) {
 		t.Errorf("expected default DeepWorkGoalHours=4.0, got %f", cfg.DeepWork.DeepWorkGoalHours)
 	}
 }
+
+func TestLoad_RoundTripsSavedConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := DefaultConfig()
+	cfg.Pomodoro.WorkDuration = Duration(40 * time.Minute)
+	cfg.Hooks.OnStart = "echo started"
+	cfg.Hooks.Timeout = Duration(3 * time.Second)
+	cfg.Webhooks = []WebhookConfig{
+		{URL: "https://example.com/flow", Events: []string{"on_start", "on_complete"}, Secret: "s3cret"},
+		{URL: "https://example.com/all"},
+	}
+	cfg.Distractions.Categories = []string{"Slack", "meeting", "hunger"}
+	cfg.Flowtime.BreakRules = []FlowtimeBreakRule{
+		{UpTo: Duration(25 * time.Minute), Break: Duration(5 * time.Minute)},
+		{Break: Duration(10 * time.Minute)},
+	}
+	cfg.Methodologies = []MethodologyConfig{{
+		Name:       "ultradian",
+		Label:      "Ultradian",
+		Presets:    []PresetConfig{{Name: "Cycle", Duration: Duration(90 * time.Minute)}},
+		ShortBreak: Duration(20 * time.Minute),
+		FocusScore: true,
+	}}
+	cfg.DeepWork.Presets = append(cfg.DeepWork.Presets, PresetConfig{
+		Name:            "Spec",
+		Duration:        Duration(2 * time.Hour),
+		Tags:            []string{"writing", "spec"},
+		IntendedOutcome: "First draft",
+		Break:           Duration(30 * time.Minute),
+	})
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got.Pomodoro.WorkDuration != Duration(40*time.Minute) {
+		t.Errorf("WorkDuration = %v, want 40m", got.Pomodoro.WorkDuration)
+	}
+	if got.Hooks.OnStart != "echo started" || got.Hooks.Timeout != Duration(3*time.Second) {
+		t.Errorf("Hooks = %+v, want the saved hook and timeout", got.Hooks)
+	}
+	if len(got.Webhooks) != 2 || got.Webhooks[0].URL != "https://example.com/flow" ||
+		len(got.Webhooks[0].Events) != 2 || got.Webhooks[0].Secret != "s3cret" || got.Webhooks[1].URL != "https://example.com/all" {
+		t.Errorf("Webhooks = %+v, want the two saved webhooks", got.Webhooks)
+	}
+	if categories := got.Distractions.CategoryList(); len(categories) != 3 || categories[0] != "slack" || categories[2] != "hunger" {
+		t.Errorf("CategoryList() = %v, want slack, meeting, hunger", categories)
+	}
+	if commands := got.Hooks.Commands(); len(commands) != 1 || commands[domain.HookStart] != "echo started" {
+		t.Errorf("Commands() = %v, want only on_start", commands)
+	}
+	if mc := got.CustomMethodology("ultradian"); mc == nil || mc.Label != "Ultradian" || !mc.FocusScore ||
+		len(mc.GetPresets()) != 1 || mc.GetPresets()[0].Duration != 90*time.Minute {
+		t.Errorf("Methodologies = %+v, want the saved ultradian entry", got.Methodologies)
+	}
+	if policy, err := got.Flowtime.BreakPolicy(); err != nil || policy.BreakFor(time.Hour) != 10*time.Minute {
+		t.Errorf("Flowtime.BreakPolicy() = %v, %v, want the saved break rules", policy, err)
+	}
+	if presets := got.DeepWork.GetPresets(); len(presets) != 4 || presets[3].Name != "Spec" ||
+		len(presets[3].Tags) != 2 || presets[3].IntendedOutcome != "First draft" || presets[3].Break != 30*time.Minute {
+		t.Errorf("DeepWork presets = %+v, want the three defaults and Spec", presets)
+	}
+}
+
+func TestLoad_MigratesLegacyPresets(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".flow", "config.toml")
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	legacy := `methodology = "pomodoro"
+
+[pomodoro]
+work_duration = "25m0s"
+preset1_name = "Focus"
+preset1_duration = "25m0s"
+preset2_name = "Long"
+preset2_duration = "45m0s"
+
+[maketime]
+preset3_name = "Tiny"
+`
+	if err := os.WriteFile(path, []byte(legacy), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	pomodoro := cfg.Pomodoro.GetPresets()
+	if len(pomodoro) != 3 || pomodoro[1].Name != "Long" || pomodoro[1].Duration != 45*time.Minute ||
+		pomodoro[2].Name != "Deep" || pomodoro[2].Duration != 50*time.Minute {
+		t.Errorf("Pomodoro presets = %+v, want Focus, Long 45m and the default Deep", pomodoro)
+	}
+	maketime := cfg.MakeTime.GetPresets()
+	if len(maketime) != 3 || maketime[2].Name != "Tiny" || maketime[2].Duration != 15*time.Minute {
+		t.Errorf("MakeTime presets = %+v, want Tiny in place of Quick", maketime)
+	}
+	if deepwork := cfg.DeepWork.GetPresets(); len(deepwork) != 3 || deepwork[0].Name != "Deep" {
+		t.Errorf("DeepWork presets = %+v, want the defaults", deepwork)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != legacy {
+		t.Errorf("Load() rewrote the config file:\n%s", data)
+	}
+
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if data, err = os.ReadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "preset2_name") || !strings.Contains(string(data), "[[pomodoro.presets]]") {
+		t.Errorf("config file not saved as a presets list:\n%s", data)
+	}
+
+	again, err := Load()
+	if err != nil {
+		t.Fatalf("Load() after migration error = %v", err)
+	}
+	if presets := again.Pomodoro.GetPresets(); len(presets) != 3 || presets[1].Name != "Long" {
+		t.Errorf("Pomodoro presets after migration = %+v, want them kept", presets)
+	}
+}
+
+func TestConfig_ExpandDataDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg := DefaultConfig()
+	if err := cfg.ExpandDataDir(); err != nil {
+		t.Fatalf("ExpandDataDir() error = %v", err)
+	}
+	if want := filepath.Join(home, ".flow"); cfg.Storage.DataDir != want {
+		t.Errorf("DataDir = %q, want %q", cfg.Storage.DataDir, want)
+	}
+
+	cfg.Storage.DataDir = "/srv/flow"
+	if err := cfg.ExpandDataDir(); err != nil || cfg.Storage.DataDir != "/srv/flow" {
+		t.Errorf("ExpandDataDir() = %v, DataDir %q, want a set data dir kept", err, cfg.Storage.DataDir)
+	}
+}
+
+func TestConfig_PresetList(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Methodologies = []MethodologyConfig{{
+		Name:    "ultradian",
+		Presets: []PresetConfig{{Name: "Cycle", Duration: Duration(90 * time.Minute)}},
+	}}
+
+	if list := cfg.PresetList(domain.MethodologyDeepWork); list == nil || len(*list) != 3 {
+		t.Errorf("PresetList(deepwork) = %v, want the three Deep Work presets", list)
+	}
+	if list := cfg.PresetList("ultradian"); list == nil || (*list)[0].Name != "Cycle" {
+		t.Errorf("PresetList(ultradian) = %v, want the custom presets", list)
+	}
+	if list := cfg.PresetList(domain.MethodologyFlowtime); list != nil {
+		t.Errorf("PresetList(flowtime) = %v, want nil", *list)
+	}
+
+	list := *cfg.PresetList(domain.MethodologyPomodoro)
+	for ref, want := range map[string]int{"deep": 2, "2": 1, "Focus": 0} {
+		if got, err := FindPreset(list, ref); err != nil || got != want {
+			t.Errorf("FindPreset(%q) = %d, %v, want %d", ref, got, err, want)
+		}
+	}
+	for _, ref := range []string{"4", "0", "Sprint"} {
+		if _, err := FindPreset(list, ref); err == nil {
+			t.Errorf("FindPreset(%q) should fail", ref)
+		}
+	}
+}
+
+func TestFlowtimeConfig_BreakPolicy(t *testing.T) {
+	policy, err := DefaultConfig().Flowtime.BreakPolicy()
+	if err != nil || policy.Ratio != 0.2 {
+		t.Errorf("default BreakPolicy() = %+v, %v, want a 1/5 ratio", policy, err)
+	}
+
+	invalid := []FlowtimeConfig{
+		{BreakRatio: "3/2"},
+		{BreakRules: []FlowtimeBreakRule{{UpTo: Duration(time.Hour)}}},
+		{BreakRules: []FlowtimeBreakRule{
+			{UpTo: Duration(time.Hour), Break: Duration(10 * time.Minute)},
+			{UpTo: Duration(30 * time.Minute), Break: Duration(5 * time.Minute)},
+		}},
+		{BreakRules: []FlowtimeBreakRule{
+			{Break: Duration(10 * time.Minute)},
+			{UpTo: Duration(time.Hour), Break: Duration(15 * time.Minute)},
+		}},
+	}
+	for _, cfg := range invalid {
+		if _, err := cfg.BreakPolicy(); err == nil {
+			t.Errorf("BreakPolicy() for %+v = nil error, want one", cfg)
+		}
+	}
+}
+
+func TestConfig_CustomMethodologies(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Methodologies = []MethodologyConfig{
+		{
+			Name:       "52-17",
+			Presets:    []PresetConfig{{Name: "Work", Duration: Duration(52 * time.Minute)}},
+			ShortBreak: Duration(17 * time.Minute),
+		},
+		{
+			Name:               "ultradian",
+			Presets:            []PresetConfig{{Name: "Cycle", Duration: Duration(90 * time.Minute)}},
+			ShortBreak:         Duration(20 * time.Minute),
+			LongBreak:          Duration(45 * time.Minute),
+			SessionsBeforeLong: 3,
+		},
+	}
+	if err := cfg.ValidateMethodologies(); err != nil {
+		t.Fatalf("ValidateMethodologies() error = %v", err)
+	}
+
+	if short, long := cfg.GetBreakDurations("52-17"); short != 17*time.Minute || long != 17*time.Minute {
+		t.Errorf("GetBreakDurations(52-17) = %v, %v, want 17m for both", short, long)
+	}
+	if short, long := cfg.GetBreakDurations("ultradian"); short != 20*time.Minute || long != 45*time.Minute {
+		t.Errorf("GetBreakDurations(ultradian) = %v, %v, want 20m and 45m", short, long)
+	}
+	if n := cfg.GetSessionsBeforeLong("ultradian"); n != 3 {
+		t.Errorf("GetSessionsBeforeLong(ultradian) = %d, want 3", n)
+	}
+	if n := cfg.GetSessionsBeforeLong(domain.MethodologyPomodoro); n != 4 {
+		t.Errorf("GetSessionsBeforeLong(pomodoro) = %d, want 4", n)
+	}
+
+	for name, mutate := range map[string]func(*Config){
+		"built-in name": func(c *Config) { c.Methodologies[0].Name = "deepwork" },
+		"duplicate":     func(c *Config) { c.Methodologies[1].Name = "52-17" },
+		"no presets":    func(c *Config) { c.Methodologies[0].Presets = nil },
+		"no break":      func(c *Config) { c.Methodologies[0].ShortBreak = 0 },
+		"no long break": func(c *Config) { c.Methodologies[1].LongBreak = 0 },
+	} {
+		bad := *cfg
+		bad.Methodologies = append([]MethodologyConfig(nil), cfg.Methodologies...)
+		mutate(&bad)
+		if err := bad.ValidateMethodologies(); err == nil {
+			t.Errorf("ValidateMethodologies() with %s = nil error, want one", name)
+		}
+	}
+}
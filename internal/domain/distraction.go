@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultDistractionCategories are offered when no categories are configured.
+var DefaultDistractionCategories = []string{"internal", "external"}
+
+// Uncategorized labels distractions logged without a category.
+const Uncategorized = "uncategorized"
+
+// NormalizeDistractionCategories trims, lowercases and dedupes configured
+// categories, keeping their order. An empty list yields the defaults.
+func NormalizeDistractionCategories(categories []string) []string {
+	seen := make(map[string]bool, len(categories))
+	result := make([]string, 0, len(categories))
+	for _, c := range categories {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" || seen[c] {
+			continue
+		}
+		seen[c] = true
+		result = append(result, c)
+	}
+	if len(result) == 0 {
+		return append([]string(nil), DefaultDistractionCategories...)
+	}
+	return result
+}
+
+// DistractionTheme is a group of similar distractions, named after its most
+// frequent phrasing.
+type DistractionTheme struct {
+	Phrase   string
+	Count    int
+	Variants []string // the other phrasings grouped under Phrase, most frequent first
+}
+
+// themeSimilarity is the share of words two phrasings must have in common
+// to be grouped into one theme.
+const themeSimilarity = 0.5
+
+// distractionStopwords are left out when comparing phrasings.
+var distractionStopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "to": true, "of": true, "on": true,
+	"in": true, "at": true, "for": true, "from": true, "with": true, "and": true,
+	"or": true, "my": true, "i": true, "me": true, "about": true, "some": true,
+	"is": true, "was": true, "it": true, "again": true,
+}
+
+// NormalizeDistraction lowercases a distraction and reduces its punctuation
+// and spacing to single spaces, so "Slack ping!" and "slack  ping" match.
+func NormalizeDistraction(text string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'' {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			if r != '\'' {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		space = true
+	}
+	return b.String()
+}
+
+// distractionTokens returns the words of a normalized phrasing that carry
+// meaning, with plurals folded into their singular.
+func distractionTokens(phrase string) map[string]bool {
+	tokens := map[string]bool{}
+	for _, word := range strings.Fields(phrase) {
+		if distractionStopwords[word] {
+			continue
+		}
+		if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			word = strings.TrimSuffix(word, "s")
+		}
+		tokens[word] = true
+	}
+	if len(tokens) == 0 && phrase != "" {
+		tokens[phrase] = true
+	}
+	return tokens
+}
+
+// jaccard is the share of tokens two sets have in common.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range a {
+		if b[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// ClusterDistractions groups distraction texts into themes. Texts are
+// normalized, then each phrasing joins the first theme, most frequent first,
+// whose leading phrasing shares enough of its words. Themes are sorted by
+// count, then phrase.
+func ClusterDistractions(texts []string) []DistractionTheme {
+	counts := map[string]int{}
+	for _, text := range texts {
+		if phrase := NormalizeDistraction(text); phrase != "" {
+			counts[phrase]++
+		}
+	}
+	phrases := make([]string, 0, len(counts))
+	for phrase := range counts {
+		phrases = append(phrases, phrase)
+	}
+	sort.Slice(phrases, func(i, j int) bool {
+		if counts[phrases[i]] != counts[phrases[j]] {
+			return counts[phrases[i]] > counts[phrases[j]]
+		}
+		return phrases[i] < phrases[j]
+	})
+
+	var themes []DistractionTheme
+	var leaders []map[string]bool
+	for _, phrase := range phrases {
+		tokens := distractionTokens(phrase)
+		joined := false
+		for i, leader := range leaders {
+			if jaccard(tokens, leader) >= themeSimilarity {
+				themes[i].Count += counts[phrase]
+				themes[i].Variants = append(themes[i].Variants, phrase)
+				joined = true
+				break
+			}
+		}
+		if !joined {
+			themes = append(themes, DistractionTheme{Phrase: phrase, Count: counts[phrase]})
+			leaders = append(leaders, tokens)
+		}
+	}
+	sort.SliceStable(themes, func(i, j int) bool {
+		if themes[i].Count != themes[j].Count {
+			return themes[i].Count > themes[j].Count
+		}
+		return themes[i].Phrase < themes[j].Phrase
+	})
+	return themes
+}
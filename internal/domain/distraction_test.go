@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeDistraction(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Slack ping!", "slack ping"},
+		{"  slack   ping ", "slack ping"},
+		{"E-mail: the boss", "e mail the boss"},
+		{"Don't check Twitter", "dont check twitter"},
+		{"!!!", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeDistraction(tt.input); got != tt.want {
+			t.Errorf("NormalizeDistraction(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeDistractionCategories(t *testing.T) {
+	got := NormalizeDistractionCategories([]string{" Slack", "meeting", "slack", ""})
+	if want := []string{"slack", "meeting"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeDistractionCategories() = %v, want %v", got, want)
+	}
+	if got := NormalizeDistractionCategories(nil); !reflect.DeepEqual(got, DefaultDistractionCategories) {
+		t.Errorf("NormalizeDistractionCategories(nil) = %v, want the defaults", got)
+	}
+}
+
+func TestClusterDistractions(t *testing.T) {
+	themes := ClusterDistractions([]string{
+		"Slack ping", "slack ping", "slack pings", "ping on Slack",
+		"email", "Email!", "checked the news",
+		"hungry", "",
+	})
+	want := []DistractionTheme{
+		{Phrase: "slack ping", Count: 4, Variants: []string{"ping on slack", "slack pings"}},
+		{Phrase: "email", Count: 2},
+		{Phrase: "checked the news", Count: 1},
+		{Phrase: "hungry", Count: 1},
+	}
+	if !reflect.DeepEqual(themes, want) {
+		t.Errorf("ClusterDistractions() = %+v, want %+v", themes, want)
+	}
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxOpenEnded is how long an open-ended (Flowtime) session may count up
+// before it ends as if its time ran out, so one left running is never
+// credited with more than this.
+const MaxOpenEnded = 4 * time.Hour
+
+// DefaultBreakRatio is the Flowtime break earned per unit of work: five
+// minutes off for every 25 worked.
+const DefaultBreakRatio = 0.2
+
+// MinEarnedBreak is the shortest break a Flowtime session earns.
+const MinEarnedBreak = time.Minute
+
+// ErrInvalidBreakRatio is returned for a break ratio that isn't a
+// fraction between 0 and 1.
+var ErrInvalidBreakRatio = errors.New("invalid break ratio")
+
+// ParseBreakRatio parses a break ratio written as a fraction ("1/5") or a
+// decimal ("0.2").
+func ParseBreakRatio(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	var ratio float64
+	if num, den, ok := strings.Cut(s, "/"); ok {
+		n, err1 := strconv.ParseFloat(strings.TrimSpace(num), 64)
+		d, err2 := strconv.ParseFloat(strings.TrimSpace(den), 64)
+		if err1 != nil || err2 != nil || d == 0 {
+			return 0, fmt.Errorf("%w %q: use a fraction like 1/5 or a decimal like 0.2", ErrInvalidBreakRatio, s)
+		}
+		ratio = n / d
+	} else {
+		r, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w %q: use a fraction like 1/5 or a decimal like 0.2", ErrInvalidBreakRatio, s)
+		}
+		ratio = r
+	}
+	if ratio <= 0 || ratio > 1 {
+		return 0, fmt.Errorf("%w %q: must be more than 0 and at most 1", ErrInvalidBreakRatio, s)
+	}
+	return ratio, nil
+}
+
+// EarnedBreakRule is one tier of a Flowtime break schedule: sessions of up
+// to UpTo earn Break. A zero UpTo matches sessions of any length.
+type EarnedBreakRule struct {
+	UpTo  time.Duration
+	Break time.Duration
+}
+
+// EarnedBreakPolicy computes the break a Flowtime session earns from the
+// time worked: from tiered rules when there are any, else as a ratio.
+type EarnedBreakPolicy struct {
+	Ratio float64           // break per unit of work; 0 means DefaultBreakRatio
+	Rules []EarnedBreakRule // in ascending UpTo order
+}
+
+// BreakFor returns the break earned by worked time. Sessions longer than
+// every tier earn the last tier's break.
+func (p EarnedBreakPolicy) BreakFor(worked time.Duration) time.Duration {
+	var earned time.Duration
+	if len(p.Rules) > 0 {
+		earned = p.Rules[len(p.Rules)-1].Break
+		for _, r := range p.Rules {
+			if r.UpTo == 0 || worked <= r.UpTo {
+				earned = r.Break
+				break
+			}
+		}
+	} else {
+		ratio := p.Ratio
+		if ratio <= 0 {
+			ratio = DefaultBreakRatio
+		}
+		earned = time.Duration(float64(worked) * ratio).Round(time.Second)
+	}
+	if earned < MinEarnedBreak {
+		return MinEarnedBreak
+	}
+	return earned
+}
+
+// String describes the policy, e.g. "1/5 of time worked" or "5m up to
+// 25m, 10m up to 1h, then 15m".
+func (p EarnedBreakPolicy) String() string {
+	if len(p.Rules) == 0 {
+		ratio := p.Ratio
+		if ratio <= 0 {
+			ratio = DefaultBreakRatio
+		}
+		if inv := 1 / ratio; inv == float64(int(inv)) {
+			return fmt.Sprintf("1/%d of time worked", int(inv))
+		}
+		return fmt.Sprintf("%.0f%% of time worked", ratio*100)
+	}
+	parts := make([]string, 0, len(p.Rules))
+	for i, r := range p.Rules {
+		switch {
+		case r.UpTo != 0:
+			parts = append(parts, fmt.Sprintf("%s up to %s", shortDuration(r.Break), shortDuration(r.UpTo)))
+		case i > 0:
+			parts = append(parts, "then "+shortDuration(r.Break))
+		default:
+			parts = append(parts, shortDuration(r.Break))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// shortDuration formats d without zero units, e.g. "1h30m" or "5m".
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// NewEarnedBreakSession creates the break a Flowtime session earned by
+// working for worked.
+func NewEarnedBreakSession(policy EarnedBreakPolicy, worked time.Duration) *PomodoroSession {
+	now := time.Now()
+	return &PomodoroSession{
+		ID:        generateID(),
+		Type:      SessionTypeShortBreak,
+		Status:    SessionStatusRunning,
+		Duration:  policy.BreakFor(worked),
+		StartedAt: now,
+		Events:    []SessionEvent{{Type: SessionEventStart, At: now}},
+	}
+}
+
+// IsOpenEnded reports whether the session counts up with no preset end
+// (Flowtime) rather than down from its Duration. Once it ends its Duration
+// holds the time worked, which may be 0, and it is no longer open-ended.
+func (s *PomodoroSession) IsOpenEnded() bool {
+	return s.OpenEnded
+}
+
+// TimeUp reports whether the session has run its planned duration; an
+// open-ended session only once it reaches MaxOpenEnded.
+func (s *PomodoroSession) TimeUp() bool {
+	return s.ElapsedTime() >= s.PlannedDuration()
+}
+
+// PlannedDuration returns Duration, or MaxOpenEnded for an open-ended session.
+func (s *PomodoroSession) PlannedDuration() time.Duration {
+	if s.IsOpenEnded() {
+		return MaxOpenEnded
+	}
+	return s.Duration
+}
+
+// OpenEndedBucket is how finely reports group the lengths of open-ended
+// sessions, which vary too much to compare minute by minute.
+const OpenEndedBucket = 15 * time.Minute
+
+// DurationBucket returns the length a session of methodology m lasting d
+// is grouped under in reports: d itself for timed methodologies, or d
+// rounded to OpenEndedBucket for those that count up.
+func DurationBucket(m Methodology, d time.Duration) time.Duration {
+	if !m.CountsUp() {
+		return d
+	}
+	if rounded := d.Round(OpenEndedBucket); rounded > 0 {
+		return rounded
+	}
+	return OpenEndedBucket
+}
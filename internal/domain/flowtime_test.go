@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseBreakRatio(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"1/5", 0.2, false},
+		{" 1 / 4 ", 0.25, false},
+		{"0.2", 0.2, false},
+		{"1", 1, false},
+		{"0", 0, true},
+		{"2/1", 0, true},
+		{"1/0", 0, true},
+		{"fifth", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseBreakRatio(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidBreakRatio) {
+				t.Errorf("ParseBreakRatio(%q) error = %v, want ErrInvalidBreakRatio", tt.in, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseBreakRatio(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestEarnedBreakPolicy_BreakFor(t *testing.T) {
+	ratio := EarnedBreakPolicy{}
+	if got := ratio.BreakFor(50 * time.Minute); got != 10*time.Minute {
+		t.Errorf("default ratio BreakFor(50m) = %v, want 10m", got)
+	}
+	if got := ratio.BreakFor(2 * time.Minute); got != MinEarnedBreak {
+		t.Errorf("BreakFor(2m) = %v, want the %v minimum", got, MinEarnedBreak)
+	}
+
+	tiered := EarnedBreakPolicy{Rules: []EarnedBreakRule{
+		{UpTo: 25 * time.Minute, Break: 5 * time.Minute},
+		{UpTo: 50 * time.Minute, Break: 8 * time.Minute},
+		{UpTo: 90 * time.Minute, Break: 10 * time.Minute},
+	}}
+	for worked, want := range map[time.Duration]time.Duration{
+		10 * time.Minute:  5 * time.Minute,
+		25 * time.Minute:  5 * time.Minute,
+		40 * time.Minute:  8 * time.Minute,
+		90 * time.Minute:  10 * time.Minute,
+		120 * time.Minute: 10 * time.Minute, // past every tier: the last one
+	} {
+		if got := tiered.BreakFor(worked); got != want {
+			t.Errorf("tiered BreakFor(%v) = %v, want %v", worked, got, want)
+		}
+	}
+
+	tiered.Rules = append(tiered.Rules, EarnedBreakRule{Break: 15 * time.Minute})
+	if got := tiered.BreakFor(120 * time.Minute); got != 15*time.Minute {
+		t.Errorf("BreakFor(2h) with an open-ended rule = %v, want 15m", got)
+	}
+	if want := "5m up to 25m, 8m up to 50m, 10m up to 1h30m, then 15m"; tiered.String() != want {
+		t.Errorf("String() = %q, want %q", tiered.String(), want)
+	}
+	if got := (EarnedBreakPolicy{Ratio: 0.25}).String(); got != "1/4 of time worked" {
+		t.Errorf("String() = %q, want 1/4 of time worked", got)
+	}
+}
+
+func TestPomodoroSession_OpenEnded(t *testing.T) {
+	s := NewPomodoroSession(PomodoroConfig{}, nil)
+	s.OpenEnded = true
+	s.StartedAt = time.Now().Add(-40 * time.Minute).Round(0)
+	s.Events = []SessionEvent{{Type: SessionEventStart, At: s.StartedAt}}
+
+	if !s.IsOpenEnded() {
+		t.Fatal("IsOpenEnded() = false for a session counting up")
+	}
+	if s.RemainingTime() != 0 || s.Progress() != 0 {
+		t.Errorf("RemainingTime() = %v, Progress() = %v, want 0 for an open-ended session", s.RemainingTime(), s.Progress())
+	}
+	if s.TimeUp() {
+		t.Error("TimeUp() = true 40 minutes into an open-ended session")
+	}
+	if want := s.StartedAt.Add(MaxOpenEnded); !s.PlannedEnd().Equal(want) {
+		t.Errorf("PlannedEnd() = %v, want %v", s.PlannedEnd(), want)
+	}
+
+	s.StartedAt = time.Now().Add(-MaxOpenEnded - time.Minute).Round(0)
+	s.Events = []SessionEvent{{Type: SessionEventStart, At: s.StartedAt}}
+	if !s.TimeUp() {
+		t.Error("TimeUp() = false past MaxOpenEnded")
+	}
+	s.Expire()
+	if s.Duration != MaxOpenEnded || s.IsOpenEnded() {
+		t.Errorf("Expire() credited %v, open-ended %v, want MaxOpenEnded and no longer open-ended", s.Duration, s.IsOpenEnded())
+	}
+}
+
+func TestDurationBucket(t *testing.T) {
+	if got := DurationBucket(MethodologyPomodoro, 25*time.Minute+10*time.Second); got != 25*time.Minute+10*time.Second {
+		t.Errorf("DurationBucket(pomodoro) = %v, want the duration unchanged", got)
+	}
+	for d, want := range map[time.Duration]time.Duration{
+		3 * time.Minute:  OpenEndedBucket,
+		37 * time.Minute: 30 * time.Minute,
+		38 * time.Minute: 45 * time.Minute,
+	} {
+		if got := DurationBucket(MethodologyFlowtime, d); got != want {
+			t.Errorf("DurationBucket(flowtime, %v) = %v, want %v", d, got, want)
+		}
+	}
+}
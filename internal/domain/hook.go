@@ -0,0 +1,48 @@
+package domain
+
+import "fmt"
+
+// HookEvent names a session lifecycle event that can run a user hook. The
+// values match the keys of the [hooks] config section.
+type HookEvent string
+
+const (
+	HookStart      HookEvent = "on_start"
+	HookPause      HookEvent = "on_pause"
+	HookResume     HookEvent = "on_resume"
+	HookComplete   HookEvent = "on_complete"
+	HookBreakStart HookEvent = "on_break_start"
+	HookBreakEnd   HookEvent = "on_break_end"
+	HookVoid       HookEvent = "on_void"
+)
+
+// StartHook returns the hook event for the session starting.
+func (s *PomodoroSession) StartHook() HookEvent {
+	if s.IsBreakSession() {
+		return HookBreakStart
+	}
+	return HookStart
+}
+
+// EndHook returns the hook event for the session ending: breaks end however
+// they end, work sessions complete or are voided.
+func (s *PomodoroSession) EndHook() HookEvent {
+	switch {
+	case s.IsBreakSession():
+		return HookBreakEnd
+	case s.Status == SessionStatusCompleted:
+		return HookComplete
+	default:
+		return HookVoid
+	}
+}
+
+// ValidateHookEvent parses a hook event name such as "on_start".
+func ValidateHookEvent(name string) (HookEvent, error) {
+	switch e := HookEvent(name); e {
+	case HookStart, HookPause, HookResume, HookComplete, HookBreakStart, HookBreakEnd, HookVoid:
+		return e, nil
+	default:
+		return "", fmt.Errorf("unknown event %q: use on_start, on_pause, on_resume, on_complete, on_break_start, on_break_end or on_void", name)
+	}
+}
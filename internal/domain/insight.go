@@ -0,0 +1,360 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ScoredSession is a completed work session with a focus score, reduced to
+// the conditions it ran under.
+type ScoredSession struct {
+	Score        int
+	StartedAt    time.Time // in the time zone the report is read in
+	Duration     time.Duration
+	Methodology  Methodology
+	Tags         []string // its own and its task's tags, with their parents
+	Branch       string
+	Distractions int
+}
+
+// ConditionFactor is one of the conditions a session ran under.
+type ConditionFactor string
+
+const (
+	FactorDuration     ConditionFactor = "duration"
+	FactorMethodology  ConditionFactor = "methodology"
+	FactorHour         ConditionFactor = "hour"
+	FactorWeekday      ConditionFactor = "weekday"
+	FactorTag          ConditionFactor = "tag"
+	FactorBranch       ConditionFactor = "branch"
+	FactorDistractions ConditionFactor = "distractions"
+)
+
+// factorOrder is the order filters appear in a condition's summary.
+var factorOrder = map[ConditionFactor]int{
+	FactorDuration: 0, FactorMethodology: 1, FactorHour: 2, FactorWeekday: 3,
+	FactorTag: 4, FactorBranch: 5, FactorDistractions: 6,
+}
+
+// ConditionFilter restricts sessions on one factor, e.g. the hour factor
+// with the value "before 11:00".
+type ConditionFilter struct {
+	Factor ConditionFactor
+	Value  string
+	phrase string
+	match  func(ScoredSession) bool
+}
+
+// Condition is a set of filters on different factors together with how
+// the sessions matching all of them scored.
+type Condition struct {
+	Filters  []ConditionFilter
+	Sessions int
+	AvgScore float64
+	matched  []bool
+}
+
+// Summary describes the condition in a sentence such as "your 50m sessions
+// before 11:00 average 4.4".
+func (c Condition) Summary() string {
+	var before, after []string
+	for _, f := range c.Filters {
+		if f.Factor == FactorDuration || f.Factor == FactorMethodology {
+			before = append(before, f.phrase)
+		} else {
+			after = append(after, f.phrase)
+		}
+	}
+	words := append(append(append([]string{"your"}, before...), "sessions"), after...)
+	return fmt.Sprintf("%s average %.1f", strings.Join(words, " "), c.AvgScore)
+}
+
+// signature identifies the factors a condition combines.
+func (c Condition) signature() string {
+	factors := make([]string, len(c.Filters))
+	for i, f := range c.Filters {
+		factors[i] = string(f.Factor)
+	}
+	return strings.Join(factors, "+")
+}
+
+// BestConditions finds the conditions under which sessions score highest:
+// single filters and pairs of filters on different factors, matched by at
+// least minSessions sessions and averaging above all sessions. A pair only
+// counts when it beats both of its filters on their own. Each condition
+// returned combines different factors than the ones before it, so the list
+// isn't ten variations on the same hour; top limits its length, 0 for all.
+func BestConditions(sessions []ScoredSession, minSessions, top int) []Condition {
+	if len(sessions) == 0 {
+		return nil
+	}
+	minSessions = max(minSessions, 1)
+	overall := 0.0
+	for _, s := range sessions {
+		overall += float64(s.Score)
+	}
+	overall /= float64(len(sessions))
+
+	var singles []Condition
+	for _, f := range conditionFilters(sessions) {
+		c := evaluateCondition(sessions, []ConditionFilter{f}, nil)
+		if c.Sessions >= minSessions {
+			singles = append(singles, c)
+		}
+	}
+	candidates := append([]Condition(nil), singles...)
+	for i := range singles {
+		for j := i + 1; j < len(singles); j++ {
+			a, b := singles[i], singles[j]
+			if a.Filters[0].Factor == b.Filters[0].Factor {
+				continue
+			}
+			filters := []ConditionFilter{a.Filters[0], b.Filters[0]}
+			sort.SliceStable(filters, func(x, y int) bool {
+				return factorOrder[filters[x].Factor] < factorOrder[filters[y].Factor]
+			})
+			c := evaluateCondition(sessions, filters, func(k int) bool { return a.matched[k] && b.matched[k] })
+			if c.Sessions >= minSessions && c.AvgScore > max(a.AvgScore, b.AvgScore) {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.AvgScore != b.AvgScore {
+			return a.AvgScore > b.AvgScore
+		}
+		if a.Sessions != b.Sessions {
+			return a.Sessions > b.Sessions
+		}
+		if len(a.Filters) != len(b.Filters) {
+			return len(a.Filters) < len(b.Filters)
+		}
+		return a.Summary() < b.Summary()
+	})
+
+	var best []Condition
+	used := map[string]bool{}
+	for _, c := range candidates {
+		if top > 0 && len(best) == top {
+			break
+		}
+		if c.AvgScore <= overall || used[c.signature()] || sameSessions(best, c) {
+			continue
+		}
+		used[c.signature()] = true
+		best = append(best, c)
+	}
+	return best
+}
+
+// evaluateCondition scores the sessions matching every filter; matches,
+// when given, decides instead of the filters.
+func evaluateCondition(sessions []ScoredSession, filters []ConditionFilter, matches func(int) bool) Condition {
+	c := Condition{Filters: filters, matched: make([]bool, len(sessions))}
+	total := 0
+	for k, s := range sessions {
+		ok := true
+		if matches != nil {
+			ok = matches(k)
+		} else {
+			for _, f := range filters {
+				ok = ok && f.match(s)
+			}
+		}
+		if ok {
+			c.matched[k] = true
+			c.Sessions++
+			total += s.Score
+		}
+	}
+	if c.Sessions > 0 {
+		c.AvgScore = float64(total) / float64(c.Sessions)
+	}
+	return c
+}
+
+// sameSessions reports whether c matches exactly the sessions of a
+// condition already chosen.
+func sameSessions(chosen []Condition, c Condition) bool {
+	for _, other := range chosen {
+		if other.Sessions == c.Sessions && equalMatches(other.matched, c.matched) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalMatches(a, b []bool) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// conditionFilters lists every filter worth trying on the sessions.
+func conditionFilters(sessions []ScoredSession) []ConditionFilter {
+	durations := map[int]bool{}
+	methodologies := map[Methodology]bool{}
+	hours := map[int]bool{}
+	weekdays := map[time.Weekday]bool{}
+	tags := map[string]string{} // lowercased -> first spelling seen
+	branches := map[string]bool{}
+	for _, s := range sessions {
+		durations[int(DurationBucket(s.Methodology, s.Duration)/time.Minute)] = true
+		methodologies[s.Methodology] = true
+		hours[s.StartedAt.Hour()] = true
+		weekdays[s.StartedAt.Weekday()] = true
+		for _, t := range s.Tags {
+			if _, ok := tags[strings.ToLower(t)]; !ok {
+				tags[strings.ToLower(t)] = t
+			}
+		}
+		if s.Branch != "" {
+			branches[s.Branch] = true
+		}
+	}
+
+	var filters []ConditionFilter
+	for _, minutes := range sortedKeys(durations) {
+		filters = append(filters, ConditionFilter{
+			Factor: FactorDuration,
+			Value:  fmt.Sprintf("%dm", minutes),
+			phrase: fmt.Sprintf("%dm", minutes),
+			match: func(s ScoredSession) bool {
+				return int(DurationBucket(s.Methodology, s.Duration)/time.Minute) == minutes
+			},
+		})
+	}
+	for _, m := range ValidMethodologies {
+		if !methodologies[m] {
+			continue
+		}
+		filters = append(filters, ConditionFilter{
+			Factor: FactorMethodology,
+			Value:  string(m),
+			phrase: m.Label(),
+			match:  func(s ScoredSession) bool { return s.Methodology == m },
+		})
+	}
+	// Split the day at every hour a session started in, both ways.
+	for _, h := range sortedKeys(hours) {
+		if h > 0 {
+			filters = append(filters, ConditionFilter{
+				Factor: FactorHour,
+				Value:  fmt.Sprintf("from %02d:00", h),
+				phrase: fmt.Sprintf("from %02d:00", h),
+				match:  func(s ScoredSession) bool { return s.StartedAt.Hour() >= h },
+			})
+		}
+		if h < 23 {
+			filters = append(filters, ConditionFilter{
+				Factor: FactorHour,
+				Value:  fmt.Sprintf("before %02d:00", h+1),
+				phrase: fmt.Sprintf("before %02d:00", h+1),
+				match:  func(s ScoredSession) bool { return s.StartedAt.Hour() <= h },
+			})
+		}
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if !weekdays[d] {
+			continue
+		}
+		filters = append(filters, ConditionFilter{
+			Factor: FactorWeekday,
+			Value:  d.String(),
+			phrase: "on " + d.String() + "s",
+			match:  func(s ScoredSession) bool { return s.StartedAt.Weekday() == d },
+		})
+	}
+	tagKeys := make([]string, 0, len(tags))
+	for key := range tags {
+		tagKeys = append(tagKeys, key)
+	}
+	sort.Strings(tagKeys)
+	for _, key := range tagKeys {
+		filters = append(filters, ConditionFilter{
+			Factor: FactorTag,
+			Value:  tags[key],
+			phrase: "tagged #" + tags[key],
+			match: func(s ScoredSession) bool {
+				for _, t := range s.Tags {
+					if strings.EqualFold(t, key) {
+						return true
+					}
+				}
+				return false
+			},
+		})
+	}
+	branchNames := make([]string, 0, len(branches))
+	for b := range branches {
+		branchNames = append(branchNames, b)
+	}
+	sort.Strings(branchNames)
+	for _, b := range branchNames {
+		filters = append(filters, ConditionFilter{
+			Factor: FactorBranch,
+			Value:  b,
+			phrase: "on branch " + b,
+			match:  func(s ScoredSession) bool { return s.Branch == b },
+		})
+	}
+	filters = append(filters,
+		ConditionFilter{
+			Factor: FactorDistractions,
+			Value:  "0",
+			phrase: "without distractions",
+			match:  func(s ScoredSession) bool { return s.Distractions == 0 },
+		},
+		ConditionFilter{
+			Factor: FactorDistractions,
+			Value:  "<=1",
+			phrase: "with at most one distraction",
+			match:  func(s ScoredSession) bool { return s.Distractions <= 1 },
+		},
+	)
+	return filters
+}
+
+func sortedKeys(m map[int]bool) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// Correlation returns Pearson's correlation coefficient of xs and ys, or
+// false when there are fewer than three pairs or either side never varies.
+func Correlation(xs, ys []float64) (float64, bool) {
+	n := len(xs)
+	if n < 3 || n != len(ys) {
+		return 0, false
+	}
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= float64(n)
+	meanY /= float64(n)
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, false
+	}
+	return cov / math.Sqrt(varX*varY), true
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBestConditions(t *testing.T) {
+	monday := time.Date(2026, 3, 16, 0, 0, 0, 0, time.Local)
+	var sessions []ScoredSession
+	add := func(hour int, length time.Duration, scores ...int) {
+		for day, score := range scores {
+			sessions = append(sessions, ScoredSession{
+				Score:       score,
+				StartedAt:   monday.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour),
+				Duration:    length,
+				Methodology: MethodologyPomodoro,
+			})
+		}
+	}
+	add(9, 50*time.Minute, 5, 4, 5, 4, 4)
+	add(15, 50*time.Minute, 3, 3, 2, 3, 3)
+	add(9, 25*time.Minute, 3, 3, 3, 3, 3)
+
+	best := BestConditions(sessions, 5, 3)
+	if len(best) == 0 {
+		t.Fatal("BestConditions() found nothing")
+	}
+	if got, want := best[0].Summary(), "your 50m sessions before 10:00 average 4.4"; got != want {
+		t.Errorf("best condition = %q, want %q", got, want)
+	}
+	if best[0].Sessions != 5 || len(best[0].Filters) != 2 {
+		t.Errorf("best condition matches %d sessions with %d filters, want 5 with 2", best[0].Sessions, len(best[0].Filters))
+	}
+	seen := map[string]bool{}
+	for _, c := range best {
+		if c.AvgScore <= 3.4 {
+			t.Errorf("%q is not above the average of 3.4", c.Summary())
+		}
+		if seen[c.signature()] {
+			t.Errorf("%q repeats the factors of an earlier condition", c.Summary())
+		}
+		seen[c.signature()] = true
+	}
+
+	if got := BestConditions(sessions, 6, 0); len(got) != 2 {
+		t.Errorf("with 6 sessions required, got %d conditions, want the two singles", len(got))
+	}
+	if got := BestConditions(nil, 5, 3); got != nil {
+		t.Errorf("BestConditions(nil) = %v, want nil", got)
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	if r, ok := Correlation([]float64{1, 2, 3, 4}, []float64{2, 4, 6, 8}); !ok || math.Abs(r-1) > 1e-9 {
+		t.Errorf("Correlation(rising) = %v, %v; want 1", r, ok)
+	}
+	if r, ok := Correlation([]float64{1, 2, 3}, []float64{3, 2, 1}); !ok || math.Abs(r+1) > 1e-9 {
+		t.Errorf("Correlation(falling) = %v, %v; want -1", r, ok)
+	}
+	if _, ok := Correlation([]float64{1, 1, 1}, []float64{1, 2, 3}); ok {
+		t.Error("Correlation() should fail when one side never varies")
+	}
+	if _, ok := Correlation([]float64{1, 2}, []float64{1, 2}); ok {
+		t.Error("Correlation() should fail with fewer than three pairs")
+	}
+}
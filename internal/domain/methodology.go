@@ -1,6 +1,10 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
 
 // Methodology represents the productivity methodology for a session.
 type Methodology string
@@ -9,24 +13,68 @@ const (
 	MethodologyPomodoro Methodology = "pomodoro"
 	MethodologyDeepWork Methodology = "deepwork"
 	MethodologyMakeTime Methodology = "maketime"
+	MethodologyFlowtime Methodology = "flowtime"
+	MethodologyMob      Methodology = "mob"
 )
 
-// ValidMethodologies lists all supported methodology values.
+// ValidMethodologies lists all supported methodology values: the built-in
+// ones, then any registered from config.
 var ValidMethodologies = []Methodology{
 	MethodologyPomodoro,
 	MethodologyDeepWork,
 	MethodologyMakeTime,
+	MethodologyFlowtime,
+	MethodologyMob,
 }
 
-// ValidateMethodology checks if a string is a valid methodology.
+// ValidateMethodology checks if a string is a valid methodology: a
+// built-in one or one registered with RegisterMethodology.
 func ValidateMethodology(s string) (Methodology, error) {
 	m := Methodology(s)
-	for _, valid := range ValidMethodologies {
+	names := make([]string, len(ValidMethodologies))
+	for i, valid := range ValidMethodologies {
 		if m == valid {
 			return m, nil
 		}
+		names[i] = string(valid)
+	}
+	return "", fmt.Errorf("invalid methodology %q: must be one of %s", s, strings.Join(names, ", "))
+}
+
+// customLabels holds the labels of the methodologies added with
+// RegisterMethodology.
+var customLabels = map[Methodology]string{}
+
+// methodologyName is the form of a user-defined methodology name.
+var methodologyName = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// RegisterMethodology adds a user-defined methodology to
+// ValidMethodologies, shown as label. Registering a name again updates its
+// label; the built-in names can't be registered.
+func RegisterMethodology(m Methodology, label string) error {
+	if !methodologyName.MatchString(string(m)) {
+		return fmt.Errorf("invalid methodology name %q: use lowercase letters, digits, - and _", m)
+	}
+	if m.IsBuiltin() {
+		return fmt.Errorf("methodology %q is built in", m)
+	}
+	if label == "" {
+		label = string(m)
 	}
-	return "", fmt.Errorf("invalid methodology %q: must be one of pomodoro, deepwork, maketime", s)
+	if _, ok := customLabels[m]; !ok {
+		ValidMethodologies = append(ValidMethodologies, m)
+	}
+	customLabels[m] = label
+	return nil
+}
+
+// IsBuiltin reports whether m is one of the methodologies Flow ships with.
+func (m Methodology) IsBuiltin() bool {
+	switch m {
+	case MethodologyPomodoro, MethodologyDeepWork, MethodologyMakeTime, MethodologyFlowtime, MethodologyMob:
+		return true
+	}
+	return false
 }
 
 // Label returns a human-readable label for the methodology.
@@ -38,7 +86,20 @@ func (m Methodology) Label() string {
 		return "Deep Work"
 	case MethodologyMakeTime:
 		return "Make Time"
-	default:
-		return "Unknown"
+	case MethodologyFlowtime:
+		return "Flowtime"
+	case MethodologyMob:
+		return "Mob"
+	}
+	if label, ok := customLabels[m]; ok {
+		return label
 	}
+	return "Unknown"
+}
+
+// CountsUp reports whether sessions of this methodology are open-ended:
+// the timer counts up until stopped and the break is earned from the time
+// worked (Flowtime).
+func (m Methodology) CountsUp() bool {
+	return m == MethodologyFlowtime
 }
@@ -0,0 +1,41 @@
+package domain
+
+import "testing"
+
+func TestRegisterMethodology(t *testing.T) {
+	if _, err := ValidateMethodology("ultradian"); err == nil {
+		t.Fatal("ValidateMethodology(ultradian) = nil error before it is registered")
+	}
+
+	if err := RegisterMethodology("ultradian", "Ultradian 90/20"); err != nil {
+		t.Fatalf("RegisterMethodology() error = %v", err)
+	}
+	if err := RegisterMethodology("ultradian", "Ultradian"); err != nil {
+		t.Fatalf("RegisterMethodology() again error = %v", err)
+	}
+	m, err := ValidateMethodology("ultradian")
+	if err != nil {
+		t.Fatalf("ValidateMethodology(ultradian) error = %v", err)
+	}
+	if m.Label() != "Ultradian" {
+		t.Errorf("Label() = %q, want the label registered last", m.Label())
+	}
+	if m.IsBuiltin() || m.CountsUp() {
+		t.Error("a registered methodology reports itself built in or counting up")
+	}
+	count := 0
+	for _, valid := range ValidMethodologies {
+		if valid == m {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("ValidMethodologies lists ultradian %d times, want once", count)
+	}
+
+	for _, bad := range []Methodology{"pomodoro", "", "Deep Work", "52/17"} {
+		if err := RegisterMethodology(bad, ""); err == nil {
+			t.Errorf("RegisterMethodology(%q) = nil error, want one", bad)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "strings"
+
+// Rotation is who drives and who navigates one mob rotation. Navigator is
+// empty when the mob is a single person.
+type Rotation struct {
+	Driver    string
+	Navigator string
+}
+
+// String describes the rotation, e.g. "Ana drives, Ben navigates".
+func (r Rotation) String() string {
+	if r.Navigator == "" {
+		return r.Driver + " drives"
+	}
+	return r.Driver + " drives, " + r.Navigator + " navigates"
+}
+
+// NextRotation returns the rotation after the one lastDriver drove: the
+// next participant in roster drives and the one after them navigates, so
+// each navigator drives next. Without a lastDriver in roster the first
+// participant drives. An empty roster has no rotation.
+func NextRotation(roster []string, lastDriver string) Rotation {
+	if len(roster) == 0 {
+		return Rotation{}
+	}
+	next := 0
+	if lastDriver != "" {
+		for i, name := range roster {
+			if strings.EqualFold(name, lastDriver) {
+				next = (i + 1) % len(roster)
+				break
+			}
+		}
+	}
+	r := Rotation{Driver: roster[next]}
+	if len(roster) > 1 {
+		r.Navigator = roster[(next+1)%len(roster)]
+	}
+	return r
+}
+
+// SetRotation records who drives and navigates the session, and the mob
+// it belongs to.
+func (s *PomodoroSession) SetRotation(r Rotation, roster []string) {
+	s.Driver = r.Driver
+	s.Navigator = r.Navigator
+	s.Participants = append([]string(nil), roster...)
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "testing"
+
+func TestNextRotation(t *testing.T) {
+	roster := []string{"Ana", "Ben", "Cy"}
+
+	tests := []struct {
+		name       string
+		roster     []string
+		lastDriver string
+		want       Rotation
+	}{
+		{"first rotation", roster, "", Rotation{Driver: "Ana", Navigator: "Ben"}},
+		{"navigator drives next", roster, "Ana", Rotation{Driver: "Ben", Navigator: "Cy"}},
+		{"wraps around", roster, "Cy", Rotation{Driver: "Ana", Navigator: "Ben"}},
+		{"matches without case", roster, "ben", Rotation{Driver: "Cy", Navigator: "Ana"}},
+		{"driver left the mob", roster, "Dee", Rotation{Driver: "Ana", Navigator: "Ben"}},
+		{"pair", []string{"Ana", "Ben"}, "Ana", Rotation{Driver: "Ben", Navigator: "Ana"}},
+		{"solo", []string{"Ana"}, "Ana", Rotation{Driver: "Ana"}},
+		{"no roster", nil, "Ana", Rotation{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextRotation(tt.roster, tt.lastDriver); got != tt.want {
+				t.Errorf("NextRotation(%v, %q) = %+v, want %+v", tt.roster, tt.lastDriver, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotation_String(t *testing.T) {
+	if got := (Rotation{Driver: "Ana", Navigator: "Ben"}).String(); got != "Ana drives, Ben navigates" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (Rotation{Driver: "Ana"}).String(); got != "Ana drives" {
+		t.Errorf("String() solo = %q", got)
+	}
+}
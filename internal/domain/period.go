@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidPeriod is returned for an unknown stats period.
+var ErrInvalidPeriod = errors.New("invalid period")
+
+// Period is a calendar span that stats are reported over.
+type Period string
+
+const (
+	PeriodWeek    Period = "week"
+	PeriodMonth   Period = "month"
+	PeriodQuarter Period = "quarter"
+	PeriodYear    Period = "year"
+)
+
+// ValidatePeriod parses a period name.
+func ValidatePeriod(s string) (Period, error) {
+	switch p := Period(s); p {
+	case PeriodWeek, PeriodMonth, PeriodQuarter, PeriodYear:
+		return p, nil
+	}
+	return "", fmt.Errorf("%w %q: use week, month, quarter or year", ErrInvalidPeriod, s)
+}
+
+// Bounds returns the [start, end) range of the period containing now, with
+// weeks starting on Monday, and its dashboard label.
+func (p Period) Bounds(now time.Time) (start, end time.Time, label string) {
+	switch p {
+	case PeriodMonth:
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(0, 1, 0), now.Format("January 2006")
+	case PeriodQuarter:
+		quarter := (int(now.Month()) - 1) / 3
+		start = time.Date(now.Year(), time.Month(quarter*3+1), 1, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(0, 3, 0), fmt.Sprintf("Q%d %d", quarter+1, now.Year())
+	case PeriodYear:
+		start = time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(1, 0, 0), now.Format("2006")
+	default:
+		weekday := int(now.Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+		start = time.Date(now.Year(), now.Month(), now.Day()-(weekday-1), 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(0, 0, 7), fmt.Sprintf("Week of %s", start.Format("Jan 2"))
+	}
+}
+
+// Range returns the period offset periods away from the one containing
+// now: -1 is the previous week, month, quarter or year.
+func (p Period) Range(now time.Time, offset int) DateRange {
+	// Step from the period's first day so a month offset from the 31st
+	// doesn't skip a shorter month.
+	start, _, _ := p.Bounds(now)
+	switch p {
+	case PeriodMonth:
+		start = start.AddDate(0, offset, 0)
+	case PeriodQuarter:
+		start = start.AddDate(0, 3*offset, 0)
+	case PeriodYear:
+		start = start.AddDate(offset, 0, 0)
+	default:
+		start = start.AddDate(0, 0, 7*offset)
+	}
+	start, end, label := p.Bounds(start)
+	return DateRange{Start: start, End: end, Label: label}
+}
+
+// DateRange is a span of whole days that stats are reported over.
+type DateRange struct {
+	Start time.Time // first day, inclusive
+	End   time.Time // day after the last, exclusive
+	Label string
+}
+
+// NewDateRange returns the range from the day of from through the day of
+// to, both inclusive, labelled with its dates.
+func NewDateRange(from, to time.Time) DateRange {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	end := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, to.Location())
+	return DateRange{Start: start, End: end, Label: rangeLabel(start, end.AddDate(0, 0, -1))}
+}
+
+// Days returns how many days the range covers.
+func (r DateRange) Days() int {
+	days := 0
+	for d := r.Start; d.Before(r.End); d = d.AddDate(0, 0, 1) {
+		days++
+	}
+	return days
+}
+
+// Previous returns the range of the same number of days ending where r
+// starts.
+func (r DateRange) Previous() DateRange {
+	return NewDateRange(r.Start.AddDate(0, 0, -r.Days()), r.Start.AddDate(0, 0, -1))
+}
+
+// rangeLabel labels the days first through last, leaving out the year
+// when both fall in the same one: "Sep 17 – Oct 16, 2026".
+func rangeLabel(first, last time.Time) string {
+	if first.Equal(last) {
+		return first.Format("Jan 2, 2006")
+	}
+	if first.Year() == last.Year() {
+		return fmt.Sprintf("%s – %s", first.Format("Jan 2"), last.Format("Jan 2, 2006"))
+	}
+	return fmt.Sprintf("%s – %s", first.Format("Jan 2, 2006"), last.Format("Jan 2, 2006"))
+}
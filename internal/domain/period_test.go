@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPeriodRange(t *testing.T) {
+	now := time.Date(2026, 3, 31, 15, 0, 0, 0, time.UTC) // a Tuesday
+
+	tests := []struct {
+		period     Period
+		offset     int
+		start, end string
+		label      string
+	}{
+		{PeriodWeek, 0, "2026-03-30", "2026-04-06", "Week of Mar 30"},
+		{PeriodWeek, -1, "2026-03-23", "2026-03-30", "Week of Mar 23"},
+		{PeriodMonth, 0, "2026-03-01", "2026-04-01", "March 2026"},
+		{PeriodMonth, -1, "2026-02-01", "2026-03-01", "February 2026"},
+		{PeriodQuarter, 0, "2026-01-01", "2026-04-01", "Q1 2026"},
+		{PeriodQuarter, -1, "2025-10-01", "2026-01-01", "Q4 2025"},
+		{PeriodYear, -2, "2024-01-01", "2025-01-01", "2024"},
+	}
+	for _, tt := range tests {
+		r := tt.period.Range(now, tt.offset)
+		if got := r.Start.Format("2006-01-02"); got != tt.start {
+			t.Errorf("%s %+d start = %s, want %s", tt.period, tt.offset, got, tt.start)
+		}
+		if got := r.End.Format("2006-01-02"); got != tt.end {
+			t.Errorf("%s %+d end = %s, want %s", tt.period, tt.offset, got, tt.end)
+		}
+		if r.Label != tt.label {
+			t.Errorf("%s %+d label = %q, want %q", tt.period, tt.offset, r.Label, tt.label)
+		}
+	}
+}
+
+func TestValidatePeriod(t *testing.T) {
+	if p, err := ValidatePeriod("quarter"); err != nil || p != PeriodQuarter {
+		t.Errorf("ValidatePeriod(quarter) = %q, %v", p, err)
+	}
+	if _, err := ValidatePeriod("fortnight"); !errors.Is(err, ErrInvalidPeriod) {
+		t.Errorf("ValidatePeriod(fortnight) error = %v, want ErrInvalidPeriod", err)
+	}
+}
+
+func TestDateRangePrevious(t *testing.T) {
+	r := NewDateRange(
+		time.Date(2026, 9, 17, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC),
+	)
+	if r.Days() != 30 {
+		t.Errorf("Days() = %d, want 30", r.Days())
+	}
+	if r.Label != "Sep 17 – Oct 16, 2026" {
+		t.Errorf("Label = %q", r.Label)
+	}
+
+	prev := r.Previous()
+	if got := prev.Start.Format("2006-01-02"); got != "2026-08-18" {
+		t.Errorf("Previous().Start = %s, want 2026-08-18", got)
+	}
+	if !prev.End.Equal(r.Start) {
+		t.Errorf("Previous().End = %s, want %s", prev.End, r.Start)
+	}
+
+	year := NewDateRange(time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC))
+	if year.Label != "Dec 20, 2025 – Jan 5, 2026" {
+		t.Errorf("Label across years = %q", year.Label)
+	}
+}
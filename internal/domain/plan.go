@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidPlanBlock is returned for a time block without a task or that
+// does not end after it starts.
+var ErrInvalidPlanBlock = errors.New("invalid plan block")
+
+// ErrPlanOverlap is returned for a day plan whose blocks overlap.
+var ErrPlanOverlap = errors.New("plan blocks overlap")
+
+// PlanBlock is a stretch of a day set aside for one task in a time-block
+// plan (Cal Newport's time-block planning).
+type PlanBlock struct {
+	ID          string
+	Start       time.Time
+	End         time.Time
+	Task        string
+	Methodology Methodology // "" leaves the mode to the session
+	Tags        []string
+}
+
+// NewPlanBlock creates a block for task from start to end.
+func NewPlanBlock(start, end time.Time, task string) (*PlanBlock, error) {
+	b := &PlanBlock{
+		ID:    generateID(),
+		Start: start,
+		End:   end,
+		Task:  strings.TrimSpace(task),
+	}
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Validate checks that the block has a task and ends after it starts.
+func (b *PlanBlock) Validate() error {
+	if b.Task == "" {
+		return fmt.Errorf("%w: a block needs a task", ErrInvalidPlanBlock)
+	}
+	if !b.End.After(b.Start) {
+		return fmt.Errorf("%w: %s must end after it starts", ErrInvalidPlanBlock, b.TimeRange())
+	}
+	return nil
+}
+
+// Duration returns how long the block lasts.
+func (b *PlanBlock) Duration() time.Duration {
+	return b.End.Sub(b.Start)
+}
+
+// Contains reports whether t falls within the block.
+func (b *PlanBlock) Contains(t time.Time) bool {
+	return !t.Before(b.Start) && t.Before(b.End)
+}
+
+// Overlaps reports whether the block and other share any time.
+func (b *PlanBlock) Overlaps(other *PlanBlock) bool {
+	return b.Start.Before(other.End) && other.Start.Before(b.End)
+}
+
+// Remaining returns how much of the block is left at now.
+func (b *PlanBlock) Remaining(now time.Time) time.Duration {
+	switch {
+	case now.Before(b.Start):
+		return b.Duration()
+	case now.Before(b.End):
+		return b.End.Sub(now)
+	default:
+		return 0
+	}
+}
+
+// EndClock formats the block's end time, e.g. "10:30", or "24:00" for a
+// block running to the end of its day.
+func (b *PlanBlock) EndClock() string {
+	if b.End.After(b.Start) && b.End.Day() != b.Start.Day() && b.End.Hour() == 0 && b.End.Minute() == 0 {
+		return "24:00"
+	}
+	return b.End.Format("15:04")
+}
+
+// TimeRange formats the block's times, e.g. "09:00–10:30".
+func (b *PlanBlock) TimeRange() string {
+	return b.Start.Format("15:04") + "–" + b.EndClock()
+}
+
+// String describes the block, e.g. "09:00–10:30 Write API docs".
+func (b *PlanBlock) String() string {
+	return b.TimeRange() + " " + b.Task
+}
+
+// TimeWorked returns the active time of the work sessions that fell within
+// the block. Cancelled and voided sessions don't count, and one still
+// running counts up to now.
+func (b *PlanBlock) TimeWorked(sessions []*PomodoroSession, now time.Time) time.Duration {
+	var worked time.Duration
+	for _, s := range sessions {
+		if !s.IsWorkSession() || s.Status == SessionStatusCancelled || s.Status == SessionStatusInterrupted {
+			continue
+		}
+		from, to := s.StartedAt, s.WallClockEnd(now)
+		if b.Start.After(from) {
+			from = b.Start
+		}
+		if b.End.Before(to) {
+			to = b.End
+		}
+		if to.After(from) {
+			worked += s.ActiveTimeAt(to) - s.ActiveTimeAt(from)
+		}
+	}
+	return worked
+}
+
+// SortPlan orders blocks by start time.
+func SortPlan(blocks []*PlanBlock) {
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return blocks[i].Start.Before(blocks[j].Start)
+	})
+}
+
+// ValidatePlan checks every block and that no two of them overlap. blocks
+// must be in start order.
+func ValidatePlan(blocks []*PlanBlock) error {
+	for i, b := range blocks {
+		if err := b.Validate(); err != nil {
+			return err
+		}
+		if i > 0 && blocks[i-1].End.After(b.Start) {
+			return fmt.Errorf("%w: %s and %s", ErrPlanOverlap, blocks[i-1], b)
+		}
+	}
+	return nil
+}
+
+// CurrentAndNextBlock returns the block under way at now, if any, and the
+// first one starting after now. blocks must be in start order.
+func CurrentAndNextBlock(blocks []*PlanBlock, now time.Time) (current, next *PlanBlock) {
+	for _, b := range blocks {
+		switch {
+		case b.Contains(now):
+			current = b
+		case b.Start.After(now):
+			return current, b
+		}
+	}
+	return current, nil
+}
+
+// ParseClock parses a time of day such as "9", "9:30" or "14:05" as that
+// time on day. "24:00" is the midnight that ends day.
+func ParseClock(day time.Time, s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	hourStr, minStr, hasMin := strings.Cut(s, ":")
+	hour, err := strconv.Atoi(hourStr)
+	minute := 0
+	if err == nil && hasMin {
+		if len(minStr) != 2 {
+			err = errors.New("minutes need two digits")
+		} else {
+			minute, err = strconv.Atoi(minStr)
+		}
+	}
+	if err != nil || hour < 0 || minute < 0 || minute > 59 || hour > 24 || (hour == 24 && minute > 0) {
+		return time.Time{}, fmt.Errorf("invalid time %q: use HH:MM, e.g. 9:30 or 14:00", s)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location()), nil
+}
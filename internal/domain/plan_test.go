@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var planDay = time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+
+func clock(hour, minute int) time.Time {
+	return planDay.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{"9", clock(9, 0), false},
+		{"9:30", clock(9, 30), false},
+		{" 14:05 ", clock(14, 5), false},
+		{"24:00", planDay.AddDate(0, 0, 1), false},
+		{"24:30", time.Time{}, true},
+		{"9:5", time.Time{}, true},
+		{"9:60", time.Time{}, true},
+		{"noon", time.Time{}, true},
+		{"", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseClock(planDay, tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseClock(%q) = %v, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseClock(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestNewPlanBlock_Validates(t *testing.T) {
+	if _, err := NewPlanBlock(clock(10, 0), clock(9, 0), "Write"); !errors.Is(err, ErrInvalidPlanBlock) {
+		t.Errorf("block ending before it starts: error = %v, want ErrInvalidPlanBlock", err)
+	}
+	if _, err := NewPlanBlock(clock(9, 0), clock(10, 0), "  "); !errors.Is(err, ErrInvalidPlanBlock) {
+		t.Errorf("block without a task: error = %v, want ErrInvalidPlanBlock", err)
+	}
+	b, err := NewPlanBlock(clock(9, 0), clock(10, 30), " Write API docs ")
+	if err != nil {
+		t.Fatalf("NewPlanBlock() error = %v", err)
+	}
+	if b.String() != "09:00–10:30 Write API docs" || b.Duration() != 90*time.Minute {
+		t.Errorf("block = %q lasting %v", b, b.Duration())
+	}
+}
+
+func TestValidatePlan_RejectsOverlaps(t *testing.T) {
+	first, _ := NewPlanBlock(clock(9, 0), clock(10, 0), "Write")
+	second, _ := NewPlanBlock(clock(10, 0), clock(11, 0), "Email")
+	if err := ValidatePlan([]*PlanBlock{first, second}); err != nil {
+		t.Errorf("back-to-back blocks: error = %v", err)
+	}
+	overlapping, _ := NewPlanBlock(clock(9, 30), clock(10, 30), "Calls")
+	blocks := []*PlanBlock{second, overlapping, first}
+	SortPlan(blocks)
+	if err := ValidatePlan(blocks); !errors.Is(err, ErrPlanOverlap) {
+		t.Errorf("overlapping blocks: error = %v, want ErrPlanOverlap", err)
+	}
+}
+
+func TestCurrentAndNextBlock(t *testing.T) {
+	first, _ := NewPlanBlock(clock(9, 0), clock(10, 0), "Write")
+	second, _ := NewPlanBlock(clock(11, 0), clock(12, 0), "Email")
+	blocks := []*PlanBlock{first, second}
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		current, next *PlanBlock
+	}{
+		{"before the day's plan", clock(8, 0), nil, first},
+		{"inside a block", clock(9, 30), first, second},
+		{"between blocks", clock(10, 30), nil, second},
+		{"last block", clock(11, 0), second, nil},
+		{"after the plan", clock(13, 0), nil, nil},
+	}
+	for _, tt := range tests {
+		current, next := CurrentAndNextBlock(blocks, tt.now)
+		if current != tt.current || next != tt.next {
+			t.Errorf("%s: CurrentAndNextBlock() = %v, %v, want %v, %v", tt.name, current, next, tt.current, tt.next)
+		}
+	}
+	if got := first.Remaining(clock(9, 45)); got != 15*time.Minute {
+		t.Errorf("Remaining() = %v, want 15m", got)
+	}
+}
+
+func TestPlanBlock_TimeWorked(t *testing.T) {
+	block, _ := NewPlanBlock(clock(9, 0), clock(10, 0), "Write")
+	ended := clock(9, 20)
+	early := &PomodoroSession{
+		Type: SessionTypeWork, Status: SessionStatusCompleted,
+		StartedAt: clock(8, 50), CompletedAt: &ended,
+	}
+	paused := &PomodoroSession{
+		Type: SessionTypeWork, Status: SessionStatusRunning, StartedAt: clock(9, 30),
+		Events: []SessionEvent{
+			{Type: SessionEventStart, At: clock(9, 30)},
+			{Type: SessionEventPause, At: clock(9, 40)},
+			{Type: SessionEventResume, At: clock(9, 50)},
+		},
+	}
+	voided := &PomodoroSession{
+		Type: SessionTypeWork, Status: SessionStatusInterrupted,
+		StartedAt: clock(9, 20), CompletedAt: &ended,
+	}
+	brk := &PomodoroSession{
+		Type: SessionTypeShortBreak, Status: SessionStatusCompleted,
+		StartedAt: clock(9, 20), CompletedAt: &ended,
+	}
+
+	// 20m of the early session fall in the block; the running one has
+	// worked 10m before its pause and 5m since, up to now.
+	got := block.TimeWorked([]*PomodoroSession{early, paused, voided, brk}, clock(9, 55))
+	if got != 35*time.Minute {
+		t.Errorf("TimeWorked() = %v, want 35m", got)
+	}
+}
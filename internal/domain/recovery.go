@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// StaleGrace is how long a running session may go past its planned end
+// before it is treated as abandoned rather than just finishing.
+const StaleGrace = 2 * time.Minute
+
+// StaleAction says what to do with a session that was left running,
+// e.g. because the terminal was closed or the laptop went to sleep.
+type StaleAction string
+
+const (
+	// StaleActionKeep credits the session's full planned duration.
+	StaleActionKeep StaleAction = "keep"
+	// StaleActionCredit credits only a given amount of work time.
+	StaleActionCredit StaleAction = "credit"
+	// StaleActionVoid discards the session from stats.
+	StaleActionVoid StaleAction = "void"
+	// StaleActionAsk leaves the choice to the user on the next launch.
+	StaleActionAsk StaleAction = "ask"
+)
+
+// ErrInvalidStaleAction is returned for an unknown stale-session action.
+var ErrInvalidStaleAction = errors.New("invalid stale session action")
+
+// ValidateStaleAction parses a stale-session action from config or flags.
+func ValidateStaleAction(s string) (StaleAction, error) {
+	switch a := StaleAction(s); a {
+	case StaleActionKeep, StaleActionCredit, StaleActionVoid, StaleActionAsk:
+		return a, nil
+	}
+	return "", ErrInvalidStaleAction
+}
+
+// PlannedEnd returns when the session would have finished had it run
+// without further pauses: its start, plus its duration (MaxOpenEnded for
+// an open-ended session), plus time paused.
+func (s *PomodoroSession) PlannedEnd() time.Time {
+	_, paused := s.Pauses(s.lastEventAt())
+	return s.StartedAt.Add(s.PlannedDuration() + paused)
+}
+
+// IsStale reports whether the session is still marked running well after
+// its planned end, meaning nothing was around to complete it.
+func (s *PomodoroSession) IsStale(now time.Time) bool {
+	return s.Status == SessionStatusRunning && now.Sub(s.PlannedEnd()) > StaleGrace
+}
+
+// Recover closes a stale session. StaleActionKeep completes it with its
+// full duration, StaleActionCredit completes it with credit as the work
+// time (capped at the planned duration) and StaleActionVoid interrupts it.
+// The session ends at its planned (or credited) end, not at the time it
+// was found, so the wall-clock span stays accurate.
+func (s *PomodoroSession) Recover(action StaleAction, credit time.Duration) error {
+	switch action {
+	case StaleActionKeep:
+		s.Expire()
+	case StaleActionCredit:
+		if credit < 0 {
+			return ErrInvalidDuration
+		}
+		if credit < s.PlannedDuration() {
+			s.Duration = credit
+			s.OpenEnded = false
+		}
+		s.Expire()
+	case StaleActionVoid:
+		s.endAt(SessionStatusInterrupted, SessionEventVoid, s.PlannedEnd())
+	default:
+		return ErrInvalidStaleAction
+	}
+	return nil
+}
+
+// Expire completes a session that ran its full duration, ending it at its
+// planned end rather than whenever the expiry was noticed. An open-ended
+// session is credited MaxOpenEnded.
+func (s *PomodoroSession) Expire() {
+	end := s.PlannedEnd()
+	s.Duration = s.PlannedDuration()
+	s.OpenEnded = false
+	s.endAt(SessionStatusCompleted, SessionEventStop, end)
+}
+
+// endAt finishes the session at the given time, never before its last
+// recorded event so the history stays in order.
+func (s *PomodoroSession) endAt(status SessionStatus, event SessionEventType, at time.Time) {
+	if last := s.lastEventAt(); at.Before(last) {
+		at = last
+	}
+	s.CompletedAt = &at
+	s.PausedAt = nil
+	s.Status = status
+	s.record(event, at)
+}
+
+// lastEventAt returns the time of the latest recorded event, or StartedAt.
+func (s *PomodoroSession) lastEventAt() time.Time {
+	if n := len(s.Events); n > 0 && s.Events[n-1].At.After(s.StartedAt) {
+		return s.Events[n-1].At
+	}
+	return s.StartedAt
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// staleSession returns a 90-minute work session started two hours ago with
+// one 10-minute pause, never completed.
+func staleSession() *PomodoroSession {
+	start := time.Now().Add(-2 * time.Hour).Round(0)
+	s := NewPomodoroSession(PomodoroConfig{WorkDuration: 90 * time.Minute}, nil)
+	s.StartedAt = start
+	s.Events = []SessionEvent{
+		{Type: SessionEventStart, At: start},
+		{Type: SessionEventPause, At: start.Add(5 * time.Minute)},
+		{Type: SessionEventResume, At: start.Add(15 * time.Minute)},
+	}
+	return s
+}
+
+func TestPomodoroSession_IsStale(t *testing.T) {
+	s := staleSession()
+	if want := s.StartedAt.Add(100 * time.Minute); !s.PlannedEnd().Equal(want) {
+		t.Errorf("PlannedEnd() = %v, want %v", s.PlannedEnd(), want)
+	}
+	if !s.IsStale(time.Now()) {
+		t.Error("IsStale() = false for a session 20 minutes past its end")
+	}
+	if s.IsStale(s.PlannedEnd().Add(StaleGrace / 2)) {
+		t.Error("IsStale() = true within the grace period")
+	}
+
+	s.Pause()
+	if s.IsStale(time.Now()) {
+		t.Error("IsStale() = true for a paused session")
+	}
+}
+
+func TestPomodoroSession_Recover(t *testing.T) {
+	tests := []struct {
+		name         string
+		action       StaleAction
+		credit       time.Duration
+		wantStatus   SessionStatus
+		wantDuration time.Duration
+		wantEndAfter time.Duration // CompletedAt relative to StartedAt
+	}{
+		{"keep", StaleActionKeep, 0, SessionStatusCompleted, 90 * time.Minute, 100 * time.Minute},
+		{"credit", StaleActionCredit, 30 * time.Minute, SessionStatusCompleted, 30 * time.Minute, 40 * time.Minute},
+		{"credit capped", StaleActionCredit, 3 * time.Hour, SessionStatusCompleted, 90 * time.Minute, 100 * time.Minute},
+		{"credit before last event", StaleActionCredit, time.Minute, SessionStatusCompleted, time.Minute, 15 * time.Minute},
+		{"credit nothing", StaleActionCredit, 0, SessionStatusCompleted, 0, 15 * time.Minute},
+		{"void", StaleActionVoid, 0, SessionStatusInterrupted, 90 * time.Minute, 100 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := staleSession()
+			if err := s.Recover(tt.action, tt.credit); err != nil {
+				t.Fatalf("Recover() error = %v", err)
+			}
+			if s.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", s.Status, tt.wantStatus)
+			}
+			if s.Duration != tt.wantDuration {
+				t.Errorf("Duration = %v, want %v", s.Duration, tt.wantDuration)
+			}
+			if s.CompletedAt == nil || s.CompletedAt.Sub(s.StartedAt) != tt.wantEndAfter {
+				t.Errorf("CompletedAt = %v after start, want %v", s.CompletedAt.Sub(s.StartedAt), tt.wantEndAfter)
+			}
+			if last := s.Events[len(s.Events)-1]; !last.IsTerminal() {
+				t.Errorf("last event = %v, want a terminal event", last.Type)
+			}
+		})
+	}
+
+	open := staleSession()
+	open.Duration, open.OpenEnded = 0, true
+	if err := open.Recover(StaleActionCredit, 0); err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+	if open.Duration != 0 || open.IsOpenEnded() {
+		t.Errorf("open-ended session credited 0: Duration = %v, open-ended %v, want 0 and no longer open-ended", open.Duration, open.IsOpenEnded())
+	}
+
+	if err := staleSession().Recover("later", 0); !errors.Is(err, ErrInvalidStaleAction) {
+		t.Errorf("Recover(unknown) error = %v, want ErrInvalidStaleAction", err)
+	}
+}
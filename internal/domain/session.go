@@ -41,7 +41,9 @@ type ShutdownRitual struct {
 // Distraction represents a logged distraction during a session.
 type Distraction struct {
 	Text     string
-	Category string // "internal", "external", or ""
+	Category string        // one of the configured categories, or ""
+	At       time.Time     `json:",omitzero"` // when it was logged; zero for older entries
+	Offset   time.Duration `json:",omitzero"` // active time into the session when logged
 }
 
 // PomodoroSession represents a single work or break interval.
@@ -51,6 +53,7 @@ type PomodoroSession struct {
 	Type             SessionType
 	Status           SessionStatus
 	Duration         time.Duration
+	OpenEnded        bool // counts up with no preset end until it ends; see IsOpenEnded
 	StartedAt        time.Time
 	PausedAt         *time.Time
 	CompletedAt      *time.Time
@@ -66,7 +69,12 @@ type PomodoroSession struct {
 	IntendedOutcome  string
 	Tags             []string
 	EnergizeActivity string
-	OutcomeAchieved  string // y/p/n for Deep Work outcome review
+	OutcomeAchieved  string        // y/p/n for Deep Work outcome review
+	BreakAfter       time.Duration // break that follows in place of the methodology's; 0 for the usual
+	Driver           string        // who typed during a mob rotation
+	Navigator        string        // who guided the driver during a mob rotation
+	Participants     []string      // the whole mob of a rotation, in rotation order
+	Events           []SessionEvent
 }
 
 // PomodoroConfig holds configuration for pomodoro sessions.
@@ -75,6 +83,8 @@ type PomodoroConfig struct {
 	ShortBreakDuration time.Duration
 	LongBreakDuration  time.Duration
 	SessionsBeforeLong int
+	EarnedBreaks       EarnedBreakPolicy // breaks after Flowtime sessions
+	Roster             []string          // the mob taking turns in Mob mode, in rotation order
 }
 
 // DefaultPomodoroConfig returns the standard pomodoro configuration.
@@ -89,13 +99,15 @@ func DefaultPomodoroConfig() PomodoroConfig {
 
 // NewPomodoroSession creates a new work session.
 func NewPomodoroSession(config PomodoroConfig, taskID *string) *PomodoroSession {
+	now := time.Now()
 	return &PomodoroSession{
 		ID:        generateID(),
 		TaskID:    taskID,
 		Type:      SessionTypeWork,
 		Status:    SessionStatusRunning,
 		Duration:  config.WorkDuration,
-		StartedAt: time.Now(),
+		StartedAt: now,
+		Events:    []SessionEvent{{Type: SessionEventStart, At: now}},
 	}
 }
 
@@ -109,12 +121,14 @@ func NewBreakSession(config PomodoroConfig, sessionCount int) *PomodoroSession {
 		sessionType = SessionTypeLongBreak
 	}
 
+	now := time.Now()
 	return &PomodoroSession{
 		ID:        generateID(),
 		Type:      sessionType,
 		Status:    SessionStatusRunning,
 		Duration:  duration,
-		StartedAt: time.Now(),
+		StartedAt: now,
+		Events:    []SessionEvent{{Type: SessionEventStart, At: now}},
 	}
 }
 
@@ -126,18 +140,19 @@ func (s *PomodoroSession) Pause() {
 	now := time.Now()
 	s.PausedAt = &now
 	s.Status = SessionStatusPaused
+	s.record(SessionEventPause, now)
 }
 
-// Resume continues a paused session.
+// Resume continues a paused session. StartedAt keeps the real start time;
+// the pause is recorded in Events and left out of ElapsedTime.
 func (s *PomodoroSession) Resume() {
 	if s.Status != SessionStatusPaused || s.PausedAt == nil {
 		return
 	}
 
-	pausedDuration := time.Since(*s.PausedAt)
-	s.StartedAt = s.StartedAt.Add(pausedDuration)
 	s.PausedAt = nil
 	s.Status = SessionStatusRunning
+	s.record(SessionEventResume, time.Now())
 }
 
 // Complete marks the session as finished.
@@ -145,65 +160,63 @@ func (s *PomodoroSession) Complete() {
 	now := time.Now()
 	s.CompletedAt = &now
 	s.Status = SessionStatusCompleted
+	s.OpenEnded = false
+	s.record(SessionEventStop, now)
 }
 
 // Cancel aborts the session.
 func (s *PomodoroSession) Cancel() {
 	s.Status = SessionStatusCancelled
+	s.record(SessionEventCancel, time.Now())
 }
 
 // Interrupt marks the session as interrupted (voided), recording actual elapsed time.
 func (s *PomodoroSession) Interrupt() {
 	// Record actual elapsed time before voiding
-	elapsed := time.Since(s.StartedAt)
-	if s.Status == SessionStatusPaused && s.PausedAt != nil {
-		elapsed = s.PausedAt.Sub(s.StartedAt)
-	}
-	if elapsed >= time.Second && elapsed < s.Duration {
+	elapsed := s.ElapsedTime()
+	if elapsed >= time.Second && elapsed < s.PlannedDuration() {
 		s.Duration = elapsed
 	}
+	s.OpenEnded = false
 	now := time.Now()
 	s.CompletedAt = &now
 	s.Status = SessionStatusInterrupted
+	s.record(SessionEventVoid, now)
 }
 
-// RemainingTime returns how much time is left in the session.
+// RemainingTime returns how much time is left in the session. Open-ended
+// sessions have no preset end, so nothing is left; use TimeUp to tell
+// whether a session should end.
 func (s *PomodoroSession) RemainingTime() time.Duration {
-	if s.Status == SessionStatusPaused {
-		if s.PausedAt == nil {
-			return s.Duration
-		}
-		elapsed := s.PausedAt.Sub(s.StartedAt)
-		remaining := s.Duration - elapsed
-		if remaining < 0 {
-			return 0
-		}
-		return remaining
-	}
-
-	if s.Status != SessionStatusRunning {
+	if s.IsOpenEnded() || (s.Status != SessionStatusRunning && s.Status != SessionStatusPaused) {
 		return 0
 	}
 
-	elapsed := time.Since(s.StartedAt)
-	remaining := s.Duration - elapsed
+	remaining := s.Duration - s.ElapsedTime()
 	if remaining < 0 {
 		return 0
 	}
 	return remaining
 }
 
-// ElapsedTime returns how much time has passed since session started.
+// ElapsedTime returns how long the session has been running: the time
+// since it started, or until it ended, minus the time spent paused.
 func (s *PomodoroSession) ElapsedTime() time.Duration {
-	if s.Status == SessionStatusPaused && s.PausedAt != nil {
-		return s.PausedAt.Sub(s.StartedAt)
+	// Wall-clock only: StartedAt and events loaded from storage carry no
+	// monotonic reading, and mixing the two would make a paused session drift.
+	now := time.Now().Round(0)
+	_, paused := s.Pauses(now)
+	elapsed := s.WallClockEnd(now).Sub(s.StartedAt) - paused
+	if elapsed < 0 {
+		return 0
 	}
-	return time.Since(s.StartedAt)
+	return elapsed
 }
 
-// Progress returns the completion percentage (0.0 to 1.0).
+// Progress returns the completion percentage (0.0 to 1.0), always 0 for an
+// open-ended session.
 func (s *PomodoroSession) Progress() float64 {
-	if s.Duration == 0 {
+	if s.IsOpenEnded() || s.Duration == 0 {
 		return 0
 	}
 
@@ -0,0 +1,127 @@
+package domain
+
+import "time"
+
+// SessionEventType is a state change in a session's lifetime.
+type SessionEventType string
+
+const (
+	SessionEventStart  SessionEventType = "start"
+	SessionEventPause  SessionEventType = "pause"
+	SessionEventResume SessionEventType = "resume"
+	SessionEventStop   SessionEventType = "stop"
+	SessionEventVoid   SessionEventType = "void"
+	SessionEventCancel SessionEventType = "cancel"
+)
+
+// SessionEvent records when a session started, paused, resumed or ended.
+type SessionEvent struct {
+	Type SessionEventType
+	At   time.Time
+}
+
+// IsTerminal reports whether the event ends the session.
+func (e SessionEvent) IsTerminal() bool {
+	return e.Type == SessionEventStop || e.Type == SessionEventVoid || e.Type == SessionEventCancel
+}
+
+// SummarizePauses counts the pauses in events and how long they lasted.
+// A pause still open at the end of the events runs until end.
+func SummarizePauses(events []SessionEvent, end time.Time) (count int, paused time.Duration) {
+	var pausedAt *time.Time
+	for _, e := range events {
+		switch {
+		case e.Type == SessionEventPause && pausedAt == nil:
+			at := e.At
+			pausedAt = &at
+			count++
+		case (e.Type == SessionEventResume || e.IsTerminal()) && pausedAt != nil:
+			paused += e.At.Sub(*pausedAt)
+			pausedAt = nil
+		}
+	}
+	if pausedAt != nil && end.After(*pausedAt) {
+		paused += end.Sub(*pausedAt)
+	}
+	return count, paused
+}
+
+// record appends an event to the session's history.
+func (s *PomodoroSession) record(t SessionEventType, at time.Time) {
+	s.Events = append(s.Events, SessionEvent{Type: t, At: at})
+}
+
+// EndedAt returns when the session stopped, was voided or was cancelled,
+// or nil while it is still active.
+func (s *PomodoroSession) EndedAt() *time.Time {
+	for i := len(s.Events) - 1; i >= 0; i-- {
+		if s.Events[i].IsTerminal() {
+			at := s.Events[i].At
+			return &at
+		}
+	}
+	return s.CompletedAt
+}
+
+// WallClockEnd returns when the session ended, or now if it is still active.
+func (s *PomodoroSession) WallClockEnd(now time.Time) time.Time {
+	if end := s.EndedAt(); end != nil {
+		return *end
+	}
+	return now
+}
+
+// Pauses returns how many times the session was paused and the total time
+// spent paused, counting an ongoing pause up to now.
+func (s *PomodoroSession) Pauses(now time.Time) (int, time.Duration) {
+	end := s.WallClockEnd(now)
+	if len(s.Events) == 0 {
+		// Sessions recorded before event history only know about the
+		// current pause.
+		if s.Status == SessionStatusPaused && s.PausedAt != nil {
+			return 1, end.Sub(*s.PausedAt)
+		}
+		return 0, 0
+	}
+	return SummarizePauses(s.Events, end)
+}
+
+// ActiveTimeAt returns how long the session had been running at t, leaving
+// out time spent paused before then.
+func (s *PomodoroSession) ActiveTimeAt(t time.Time) time.Duration {
+	var before []SessionEvent
+	for _, e := range s.Events {
+		if !e.At.After(t) {
+			before = append(before, e)
+		}
+	}
+	_, paused := SummarizePauses(before, t)
+	if active := t.Sub(s.StartedAt) - paused; active > 0 {
+		return active
+	}
+	return 0
+}
+
+// LogDistraction records a distraction logged at at, with how far into the
+// session's active time that was.
+func (s *PomodoroSession) LogDistraction(text, category string, at time.Time) {
+	s.Distractions = append(s.Distractions, Distraction{
+		Text:     text,
+		Category: category,
+		At:       at,
+		Offset:   s.ActiveTimeAt(at),
+	})
+}
+
+// DistractionOffset returns how far into the session's active time d was
+// logged, or false for entries logged before times were recorded.
+func (s *PomodoroSession) DistractionOffset(d Distraction) (time.Duration, bool) {
+	switch {
+	case d.At.IsZero():
+		return 0, false
+	case d.Offset > 0:
+		return d.Offset, true
+	default:
+		return s.ActiveTimeAt(d.At), true
+	}
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizePauses(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	at := func(min int) time.Time { return start.Add(time.Duration(min) * time.Minute) }
+
+	tests := []struct {
+		name       string
+		events     []SessionEvent
+		end        time.Time
+		wantCount  int
+		wantPaused time.Duration
+	}{
+		{"no pauses", []SessionEvent{{SessionEventStart, at(0)}, {SessionEventStop, at(25)}}, at(25), 0, 0},
+		{"two pauses", []SessionEvent{
+			{SessionEventStart, at(0)},
+			{SessionEventPause, at(5)}, {SessionEventResume, at(8)},
+			{SessionEventPause, at(10)}, {SessionEventResume, at(20)},
+			{SessionEventStop, at(35)},
+		}, at(35), 2, 13 * time.Minute},
+		{"stopped while paused", []SessionEvent{
+			{SessionEventStart, at(0)}, {SessionEventPause, at(5)}, {SessionEventVoid, at(9)},
+		}, at(30), 1, 4 * time.Minute},
+		{"still paused", []SessionEvent{
+			{SessionEventStart, at(0)}, {SessionEventPause, at(5)},
+		}, at(12), 1, 7 * time.Minute},
+		{"repeated pause ignored", []SessionEvent{
+			{SessionEventStart, at(0)}, {SessionEventPause, at(5)}, {SessionEventPause, at(6)}, {SessionEventResume, at(7)},
+		}, at(10), 1, 2 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, paused := SummarizePauses(tt.events, tt.end)
+			if count != tt.wantCount || paused != tt.wantPaused {
+				t.Errorf("SummarizePauses() = %d, %v; want %d, %v", count, paused, tt.wantCount, tt.wantPaused)
+			}
+		})
+	}
+}
+
+func TestPomodoroSession_WallClockSpan(t *testing.T) {
+	session := NewPomodoroSession(DefaultPomodoroConfig(), nil)
+	session.StartedAt = time.Now().Add(-40 * time.Minute)
+	session.Events = []SessionEvent{
+		{SessionEventStart, session.StartedAt},
+		{SessionEventPause, session.StartedAt.Add(10 * time.Minute)},
+		{SessionEventResume, session.StartedAt.Add(25 * time.Minute)},
+	}
+
+	// 40 minutes on the clock, 15 of them paused.
+	if elapsed := session.ElapsedTime().Round(time.Minute); elapsed != 25*time.Minute {
+		t.Errorf("ElapsedTime() = %v, want 25m", elapsed)
+	}
+	if remaining := session.RemainingTime().Round(time.Minute); remaining != 0 {
+		t.Errorf("RemainingTime() = %v, want 0 (25m session fully elapsed)", remaining)
+	}
+
+	session.Complete()
+	if end := session.EndedAt(); end == nil || !end.Equal(*session.CompletedAt) {
+		t.Errorf("EndedAt() = %v, want CompletedAt", end)
+	}
+	if span := session.WallClockEnd(time.Now()).Sub(session.StartedAt).Round(time.Minute); span != 40*time.Minute {
+		t.Errorf("wall-clock span = %v, want 40m", span)
+	}
+}
+
+func TestPomodoroSession_ActiveTimeAt(t *testing.T) {
+	start := time.Date(2026, 3, 18, 9, 0, 0, 0, time.Local)
+	session := &PomodoroSession{
+		StartedAt: start,
+		Events: []SessionEvent{
+			{SessionEventStart, start},
+			{SessionEventPause, start.Add(10 * time.Minute)},
+			{SessionEventResume, start.Add(25 * time.Minute)},
+		},
+	}
+
+	tests := []struct {
+		at   time.Duration
+		want time.Duration
+	}{
+		{5 * time.Minute, 5 * time.Minute},
+		{15 * time.Minute, 10 * time.Minute}, // during the pause
+		{30 * time.Minute, 15 * time.Minute},
+		{-time.Minute, 0},
+	}
+	for _, tt := range tests {
+		if got := session.ActiveTimeAt(start.Add(tt.at)); got != tt.want {
+			t.Errorf("ActiveTimeAt(+%v) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestPomodoroSession_LogDistraction(t *testing.T) {
+	start := time.Date(2026, 3, 18, 9, 0, 0, 0, time.Local)
+	session := &PomodoroSession{
+		StartedAt: start,
+		Events: []SessionEvent{
+			{SessionEventStart, start},
+			{SessionEventPause, start.Add(5 * time.Minute)},
+			{SessionEventResume, start.Add(15 * time.Minute)},
+		},
+		Distractions: []Distraction{{Text: "before times were recorded"}},
+	}
+	session.LogDistraction("slack ping", "external", start.Add(20*time.Minute))
+
+	if _, ok := session.DistractionOffset(session.Distractions[0]); ok {
+		t.Error("DistractionOffset() of an untimed entry reported an offset")
+	}
+	d := session.Distractions[1]
+	if offset, ok := session.DistractionOffset(d); !ok || offset != 10*time.Minute || d.Offset != offset {
+		t.Errorf("DistractionOffset() = %v, %v; want the stored 10m", offset, ok)
+	}
+}
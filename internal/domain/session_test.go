@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"reflect"
 	"testing"
 	"time"
 )
@@ -119,8 +120,25 @@ func TestPomodoroSession_Resume(t *testing.T) {
 		t.Error("PausedAt should be nil after resume")
 	}
 
-	if !session.StartedAt.After(originalStart) {
-		t.Error("StartedAt should be adjusted after resume")
+	if !session.StartedAt.Equal(originalStart) {
+		t.Error("StartedAt should keep the real start time after resume")
+	}
+
+	count, paused := session.Pauses(time.Now())
+	if count != 1 || paused < 50*time.Millisecond {
+		t.Errorf("Pauses() = %d, %v; want 1 pause of at least 50ms", count, paused)
+	}
+	if elapsed := session.ElapsedTime(); elapsed >= 100*time.Millisecond {
+		t.Errorf("ElapsedTime() = %v, should not include the pause", elapsed)
+	}
+
+	var types []SessionEventType
+	for _, e := range session.Events {
+		types = append(types, e.Type)
+	}
+	want := []SessionEventType{SessionEventStart, SessionEventPause, SessionEventResume}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("Events = %v, want %v", types, want)
 	}
 }
 
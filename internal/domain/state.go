@@ -9,6 +9,8 @@ type CurrentState struct {
 	ActiveTask    *Task
 	ActiveSession *PomodoroSession
 	TodayStats    DailyStats
+	CurrentBlock  *PlanBlock // the time block under way in today's plan, if any
+	NextBlock     *PlanBlock // the next block starting later today, if any
 }
 
 // DailyStats aggregates pomodoro statistics for a day.
@@ -20,6 +22,14 @@ type DailyStats struct {
 	TasksCompleted int
 }
 
+// DayTotal holds the completed work of one day.
+type DayTotal struct {
+	Date         time.Time // midnight, local time
+	WorkSessions int
+	WorkTime     time.Duration
+	DeepWorkTime time.Duration // the part of WorkTime done in Deep Work mode
+}
+
 // CompletionInfo holds pre-computed context about what break comes next
 // after a work session completes, or signals that a break just ended.
 type CompletionInfo struct {
@@ -28,6 +38,12 @@ type CompletionInfo struct {
 	SessionsUntilLong  int
 	SessionsBeforeLong int
 	DeepWorkStreak     int
+	// EarnedBreaks computes the break after an open-ended (Flowtime)
+	// session, which depends on the time worked rather than NextBreakDuration.
+	EarnedBreaks EarnedBreakPolicy
+	// NextRotation is who takes over when a mob rotation ends; nil outside
+	// Mob mode. SessionsUntilLong then counts rotations until the mob's break.
+	NextRotation *Rotation
 }
 
 // MethodologyBreakdown holds session counts and total time per methodology.
@@ -48,6 +64,16 @@ type PeriodStats struct {
 	AvgFocusScore    float64
 	FocusScoreCount  int
 	DistractionCount int
+	PauseCount       int           // pauses across all sessions in the period
+	PausedTime       time.Duration // wall-clock time lost to those pauses
+}
+
+// PausesPerSession returns the average number of pauses per session.
+func (p *PeriodStats) PausesPerSession() float64 {
+	if p.TotalSessions == 0 {
+		return 0
+	}
+	return float64(p.PauseCount) / float64(p.TotalSessions)
 }
 
 // EnergizeStat holds aggregated focus score data for a specific energize activity.
@@ -57,6 +83,13 @@ type EnergizeStat struct {
 	AvgFocusScore float64
 }
 
+// DriverStat holds the time one participant spent driving mob rotations.
+type DriverStat struct {
+	Driver    string
+	Rotations int
+	TotalTime time.Duration
+}
+
 // StateSnapshot captures the complete system state at a point in time.
 type StateSnapshot struct {
 	Timestamp      time.Time
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// TagSeparator separates the levels of a hierarchical tag such as "client/acme".
+const TagSeparator = "/"
+
+// ErrTagNotFound is returned when a tag does not exist.
+var ErrTagNotFound = errors.New("tag not found")
+
+// TagUsage counts how many tasks and sessions carry a tag.
+type TagUsage struct {
+	Name     string
+	Tasks    int
+	Sessions int
+}
+
+// TagStat holds completed work for a tag over a period. Time logged under
+// nested tags (client/acme) also counts toward their parents (client).
+type TagStat struct {
+	Tag          string
+	SessionCount int
+	TotalTime    time.Duration
+}
+
+// NormalizeTag returns the canonical form of a tag: no leading '#', no
+// surrounding whitespace and no empty path levels ("#client//acme/" becomes
+// "client/acme"). It returns "" for input that holds no tag.
+func NormalizeTag(tag string) string {
+	tag = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+	parts := strings.Split(tag, TagSeparator)
+	kept := parts[:0]
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, TagSeparator)
+}
+
+// NormalizeTags normalizes every tag, dropping empty and duplicate ones
+// (case-insensitively) while keeping the original order.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	var result []string
+	for _, t := range tags {
+		t = NormalizeTag(t)
+		key := strings.ToLower(t)
+		if t == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, t)
+	}
+	return result
+}
+
+// TagMatches reports whether tag is filter or nested beneath it, so the
+// filter "client" matches "client" and "client/acme" but not "clients".
+func TagMatches(tag, filter string) bool {
+	tag, filter = strings.ToLower(NormalizeTag(tag)), strings.ToLower(NormalizeTag(filter))
+	if filter == "" {
+		return false
+	}
+	return tag == filter || strings.HasPrefix(tag, filter+TagSeparator)
+}
+
+// HasTag reports whether any of tags matches filter (see TagMatches).
+func HasTag(tags []string, filter string) bool {
+	for _, t := range tags {
+		if TagMatches(t, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// TagAncestors returns the tag and every parent above it, outermost first:
+// "client/acme/web" gives ["client", "client/acme", "client/acme/web"].
+func TagAncestors(tag string) []string {
+	tag = NormalizeTag(tag)
+	if tag == "" {
+		return nil
+	}
+	parts := strings.Split(tag, TagSeparator)
+	result := make([]string, len(parts))
+	for i := range parts {
+		result[i] = strings.Join(parts[:i+1], TagSeparator)
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"work", "work"},
+		{"#work", "work"},
+		{"  #client/acme ", "client/acme"},
+		{"client//acme/", "client/acme"},
+		{"/client / acme", "client/acme"},
+		{"#", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeTag(tt.input); got != tt.want {
+			t.Errorf("NormalizeTag(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got := NormalizeTags([]string{"#work", "Work", "", "client/acme", "client//acme"})
+	want := []string{"work", "client/acme"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeTags() = %v, want %v", got, want)
+	}
+}
+
+func TestTagMatches(t *testing.T) {
+	tests := []struct {
+		tag, filter string
+		want        bool
+	}{
+		{"client", "client", true},
+		{"client/acme", "client", true},
+		{"Client/Acme/web", "client/acme", true},
+		{"clients", "client", false},
+		{"client", "client/acme", false},
+		{"client", "", false},
+	}
+	for _, tt := range tests {
+		if got := TagMatches(tt.tag, tt.filter); got != tt.want {
+			t.Errorf("TagMatches(%q, %q) = %v, want %v", tt.tag, tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestTagAncestors(t *testing.T) {
+	got := TagAncestors("#client/acme/web")
+	want := []string{"client", "client/acme", "client/acme/web"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TagAncestors() = %v, want %v", got, want)
+	}
+	if got := TagAncestors(""); got != nil {
+		t.Errorf("TagAncestors(\"\") = %v, want nil", got)
+	}
+}
@@ -5,6 +5,7 @@ package domain
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
@@ -16,6 +17,7 @@ var (
 	ErrInvalidDuration      = errors.New("invalid duration")
 	ErrSessionAlreadyActive = errors.New("session already active")
 	ErrNoActiveSession      = errors.New("no active session")
+	ErrNotWorkSession       = errors.New("the active session is a break")
 )
 
 // TaskStatus represents the current state of a task.
@@ -109,10 +111,15 @@ func (t *Task) Cancel() {
 	t.UpdatedAt = time.Now()
 }
 
-// AddTag adds a tag to the task.
+// AddTag adds a tag to the task. Tags are normalized (see NormalizeTag) and
+// compared case-insensitively.
 func (t *Task) AddTag(tag string) {
+	tag = NormalizeTag(tag)
+	if tag == "" {
+		return
+	}
 	for _, existing := range t.Tags {
-		if existing == tag {
+		if strings.EqualFold(existing, tag) {
 			return
 		}
 	}
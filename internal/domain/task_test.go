@@ -139,6 +139,18 @@ func TestTask_AddTag(t *testing.T) {
 			newTag:   "urgent",
 			expected: 2,
 		},
+		{
+			name:     "duplicate differing in case and hash",
+			tags:     []string{"work"},
+			newTag:   "#Work",
+			expected: 1,
+		},
+		{
+			name:     "empty tag ignored",
+			tags:     []string{"work"},
+			newTag:   " # ",
+			expected: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"time"
+)
+
+// WebhookDeliveryStatus is where a webhook delivery stands.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookPending   WebhookDeliveryStatus = "pending"
+	WebhookDelivered WebhookDeliveryStatus = "delivered"
+	WebhookFailed    WebhookDeliveryStatus = "failed"
+)
+
+const (
+	// WebhookMaxAttempts is how many times a delivery is tried before it
+	// is given up as failed.
+	WebhookMaxAttempts = 8
+
+	webhookFirstRetry = 30 * time.Second
+	webhookMaxRetry   = time.Hour
+)
+
+// WebhookDelivery is one POST of an event payload to a webhook URL,
+// kept until delivered or given up so failures can be retried.
+type WebhookDelivery struct {
+	ID            string
+	URL           string
+	Event         HookEvent
+	Payload       []byte
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	LastError     string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	DeliveredAt   *time.Time
+}
+
+// NewWebhookDelivery creates a pending delivery, due now.
+func NewWebhookDelivery(url string, event HookEvent, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:            generateID(),
+		URL:           url,
+		Event:         event,
+		Payload:       payload,
+		Status:        WebhookPending,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+}
+
+// RecordSuccess marks the delivery as delivered.
+func (d *WebhookDelivery) RecordSuccess(now time.Time) {
+	d.Attempts++
+	d.Status = WebhookDelivered
+	d.LastError = ""
+	d.DeliveredAt = &now
+}
+
+// RecordFailure counts a failed attempt and schedules the next one, backing
+// off exponentially from 30s up to an hour, or gives up after
+// WebhookMaxAttempts.
+func (d *WebhookDelivery) RecordFailure(err error, now time.Time) {
+	d.Attempts++
+	d.LastError = err.Error()
+	if d.Attempts >= WebhookMaxAttempts {
+		d.Status = WebhookFailed
+		return
+	}
+	delay := webhookFirstRetry << (d.Attempts - 1)
+	if delay > webhookMaxRetry {
+		delay = webhookMaxRetry
+	}
+	d.NextAttemptAt = now.Add(delay)
+}
+
+// GiveUp marks the delivery as failed without trying again.
+func (d *WebhookDelivery) GiveUp(reason string) {
+	d.Status = WebhookFailed
+	d.LastError = reason
+}
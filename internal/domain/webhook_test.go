@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWebhookDelivery_Backoff(t *testing.T) {
+	now := time.Now()
+	d := NewWebhookDelivery("http://example.com/hook", HookComplete, []byte(`{}`))
+
+	wantDelays := []time.Duration{
+		30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute,
+		8 * time.Minute, 16 * time.Minute, 32 * time.Minute,
+	}
+	for i, want := range wantDelays {
+		d.RecordFailure(errors.New("503 Service Unavailable"), now)
+		if d.Status != WebhookPending {
+			t.Fatalf("attempt %d: status = %v, want pending", i+1, d.Status)
+		}
+		if got := d.NextAttemptAt.Sub(now); got != want {
+			t.Errorf("attempt %d: next retry in %v, want %v", i+1, got, want)
+		}
+	}
+
+	d.RecordFailure(errors.New("503 Service Unavailable"), now)
+	if d.Status != WebhookFailed || d.Attempts != WebhookMaxAttempts {
+		t.Errorf("after %d attempts: status = %v, want failed", d.Attempts, d.Status)
+	}
+	if d.LastError != "503 Service Unavailable" {
+		t.Errorf("LastError = %q", d.LastError)
+	}
+}
+
+func TestWebhookDelivery_RecordSuccess(t *testing.T) {
+	now := time.Now()
+	d := NewWebhookDelivery("http://example.com/hook", HookStart, nil)
+	d.RecordFailure(errors.New("timeout"), now)
+	d.RecordSuccess(now)
+	if d.Status != WebhookDelivered || d.Attempts != 2 || d.LastError != "" || d.DeliveredAt == nil {
+		t.Errorf("delivery = %+v, want delivered on the second attempt", d)
+	}
+}
+
+func TestValidateHookEvent(t *testing.T) {
+	if e, err := ValidateHookEvent("on_complete"); err != nil || e != HookComplete {
+		t.Errorf("ValidateHookEvent(on_complete) = %v, %v", e, err)
+	}
+	if _, err := ValidateHookEvent("complete"); err == nil {
+		t.Error("ValidateHookEvent(complete) = nil error, want an error")
+	}
+}
@@ -24,13 +24,15 @@ type Mode interface {
 	// OutcomePrompt returns the prompt for intended outcome (Deep Work only; empty for others).
 	OutcomePrompt() string
 
-	// HasDistractionLog returns true if this mode tracks distractions during a session.
+	// HasDistractionLog returns true if this mode tracks distractions during a
+	// session: always in Deep Work, opt-in with distraction_log elsewhere.
 	HasDistractionLog() bool
 
 	// HasEnergizeReminder returns true if this mode shows energize reminders mid-session.
 	HasEnergizeReminder() bool
 
-	// HasFocusScore returns true if this mode asks for a focus score on completion.
+	// HasFocusScore returns true if this mode asks for a focus score on
+	// completion: always in Make Time, opt-in with focus_score elsewhere.
 	HasFocusScore() bool
 
 	// HasShutdownRitual returns true if this mode asks for accomplishment on completion.
@@ -57,6 +59,10 @@ type Mode interface {
 
 	// TUITitle returns the title shown in the fullscreen TUI.
 	TUITitle() string
+
+	// HasRotation returns true if sessions are mob rotations: the roster
+	// takes turns driving and [n] starts the next rotation right away.
+	HasRotation() bool
 }
 
 // ForMethodology returns the Mode implementation for the given methodology.
@@ -72,14 +78,59 @@ func ForMethodology(m domain.Methodology, cfg *config.Config) Mode {
 			return &makeTimeMode{cfg: &cfg.MakeTime}
 		}
 		return &makeTimeMode{}
+	case domain.MethodologyFlowtime:
+		if cfg != nil {
+			return &flowtimeMode{cfg: &cfg.Flowtime}
+		}
+		return &flowtimeMode{}
+	case domain.MethodologyMob:
+		if cfg != nil {
+			return &mobMode{cfg: &cfg.Mob}
+		}
+		return &mobMode{}
 	default:
 		if cfg != nil {
+			if mc := cfg.CustomMethodology(m); mc != nil {
+				return &customMode{cfg: mc}
+			}
 			return &pomodoroMode{cfg: &cfg.Pomodoro}
 		}
 		return &pomodoroMode{}
 	}
 }
 
+// All returns every available mode in picker order: the built-in ones,
+// then the [[methodologies]] declared in cfg.
+func All(cfg *config.Config) []Mode {
+	modes := []Mode{
+		ForMethodology(domain.MethodologyPomodoro, cfg),
+		ForMethodology(domain.MethodologyDeepWork, cfg),
+		ForMethodology(domain.MethodologyMakeTime, cfg),
+		ForMethodology(domain.MethodologyFlowtime, cfg),
+		ForMethodology(domain.MethodologyMob, cfg),
+	}
+	if cfg != nil {
+		for i := range cfg.Methodologies {
+			modes = append(modes, &customMode{cfg: &cfg.Methodologies[i]})
+		}
+	}
+	return modes
+}
+
+// Register validates the [[methodologies]] declared in cfg and registers
+// them with the domain, so they are accepted by --mode and labelled in stats.
+func Register(cfg *config.Config) error {
+	if err := cfg.ValidateMethodologies(); err != nil {
+		return err
+	}
+	for _, mc := range cfg.Methodologies {
+		if err := domain.RegisterMethodology(domain.Methodology(mc.Name), mc.Label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // --- Pomodoro Mode ---
 
 type pomodoroMode struct {
@@ -89,9 +140,9 @@ type pomodoroMode struct {
 func (p *pomodoroMode) Name() domain.Methodology   { return domain.MethodologyPomodoro }
 func (p *pomodoroMode) TaskPrompt() string         { return "What are you working on? (Enter to skip):" }
 func (p *pomodoroMode) OutcomePrompt() string      { return "" }
-func (p *pomodoroMode) HasDistractionLog() bool    { return false }
+func (p *pomodoroMode) HasDistractionLog() bool    { return p.cfg != nil && p.cfg.DistractionLog }
 func (p *pomodoroMode) HasEnergizeReminder() bool  { return false }
-func (p *pomodoroMode) HasFocusScore() bool        { return false }
+func (p *pomodoroMode) HasFocusScore() bool        { return p.cfg != nil && p.cfg.FocusScore }
 func (p *pomodoroMode) HasShutdownRitual() bool    { return false }
 func (p *pomodoroMode) HasHighlight() bool         { return false }
 func (p *pomodoroMode) HasLaserChecklist() bool    { return false }
@@ -101,10 +152,11 @@ func (p *pomodoroMode) DeepWorkPhilosophy() string { return "" }
 func (p *pomodoroMode) Description() string {
 	return "The Pomodoro Technique: 25-minute focused sprints with short breaks to maintain sustainable productivity."
 }
-func (p *pomodoroMode) TUITitle() string { return "Flow - Pomodoro Timer" }
+func (p *pomodoroMode) TUITitle() string  { return "Flow - Pomodoro Timer" }
+func (p *pomodoroMode) HasRotation() bool { return false }
 
 func (p *pomodoroMode) Presets() []config.SessionPreset {
-	if p.cfg != nil {
+	if p.cfg != nil && len(p.cfg.Presets) > 0 {
 		return p.cfg.GetPresets()
 	}
 	return []config.SessionPreset{
@@ -125,7 +177,7 @@ func (d *deepWorkMode) TaskPrompt() string        { return "What will you focus
 func (d *deepWorkMode) OutcomePrompt() string     { return "Intended outcome for this session:" }
 func (d *deepWorkMode) HasDistractionLog() bool   { return true }
 func (d *deepWorkMode) HasEnergizeReminder() bool { return false }
-func (d *deepWorkMode) HasFocusScore() bool       { return false }
+func (d *deepWorkMode) HasFocusScore() bool       { return d.cfg != nil && d.cfg.FocusScore }
 func (d *deepWorkMode) HasShutdownRitual() bool   { return true }
 func (d *deepWorkMode) HasHighlight() bool        { return false }
 func (d *deepWorkMode) HasLaserChecklist() bool   { return false }
@@ -145,10 +197,11 @@ func (d *deepWorkMode) DeepWorkPhilosophy() string {
 func (d *deepWorkMode) Description() string {
 	return "Cal Newport's Deep Work: distraction-free blocks of cognitively demanding work that push your abilities to their limit."
 }
-func (d *deepWorkMode) TUITitle() string { return "Deep Work" }
+func (d *deepWorkMode) TUITitle() string  { return "Deep Work" }
+func (d *deepWorkMode) HasRotation() bool { return false }
 
 func (d *deepWorkMode) Presets() []config.SessionPreset {
-	if d.cfg != nil {
+	if d.cfg != nil && len(d.cfg.Presets) > 0 {
 		return d.cfg.GetPresets()
 	}
 	return []config.SessionPreset{
@@ -167,7 +220,7 @@ type makeTimeMode struct {
 func (mt *makeTimeMode) Name() domain.Methodology   { return domain.MethodologyMakeTime }
 func (mt *makeTimeMode) TaskPrompt() string         { return "What's your Highlight for today?" }
 func (mt *makeTimeMode) OutcomePrompt() string      { return "" }
-func (mt *makeTimeMode) HasDistractionLog() bool    { return false }
+func (mt *makeTimeMode) HasDistractionLog() bool    { return mt.cfg != nil && mt.cfg.DistractionLog }
 func (mt *makeTimeMode) HasEnergizeReminder() bool  { return true }
 func (mt *makeTimeMode) HasFocusScore() bool        { return true }
 func (mt *makeTimeMode) HasShutdownRitual() bool    { return false }
@@ -179,10 +232,11 @@ func (mt *makeTimeMode) DeepWorkPhilosophy() string { return "" }
 func (mt *makeTimeMode) Description() string {
 	return "Jake Knapp's Make Time: choose a daily Highlight and laser focus on it. Energize your body to fuel your mind."
 }
-func (mt *makeTimeMode) TUITitle() string { return "Make Time" }
+func (mt *makeTimeMode) TUITitle() string  { return "Make Time" }
+func (mt *makeTimeMode) HasRotation() bool { return false }
 
 func (mt *makeTimeMode) Presets() []config.SessionPreset {
-	if mt.cfg != nil {
+	if mt.cfg != nil && len(mt.cfg.Presets) > 0 {
 		return mt.cfg.GetPresets()
 	}
 	return []config.SessionPreset{
@@ -191,3 +245,108 @@ func (mt *makeTimeMode) Presets() []config.SessionPreset {
 		{Name: "Quick", Duration: 15 * time.Minute},
 	}
 }
+
+// --- Flowtime Mode ---
+
+type flowtimeMode struct {
+	cfg *config.FlowtimeConfig
+}
+
+func (f *flowtimeMode) Name() domain.Methodology   { return domain.MethodologyFlowtime }
+func (f *flowtimeMode) TaskPrompt() string         { return "What are you working on? (Enter to skip):" }
+func (f *flowtimeMode) OutcomePrompt() string      { return "" }
+func (f *flowtimeMode) HasDistractionLog() bool    { return f.cfg != nil && f.cfg.DistractionLog }
+func (f *flowtimeMode) HasEnergizeReminder() bool  { return false }
+func (f *flowtimeMode) HasFocusScore() bool        { return f.cfg != nil && f.cfg.FocusScore }
+func (f *flowtimeMode) HasShutdownRitual() bool    { return false }
+func (f *flowtimeMode) HasHighlight() bool         { return false }
+func (f *flowtimeMode) HasLaserChecklist() bool    { return false }
+func (f *flowtimeMode) CompletionTitle() string    { return "Flow session complete." }
+func (f *flowtimeMode) DeepWorkGoalHours() float64 { return 0 }
+func (f *flowtimeMode) DeepWorkPhilosophy() string { return "" }
+func (f *flowtimeMode) Description() string {
+	return "Flowtime: the timer counts up while you're in flow. Stop when your focus fades and take a break earned from the time you worked."
+}
+func (f *flowtimeMode) TUITitle() string  { return "Flowtime" }
+func (f *flowtimeMode) HasRotation() bool { return false }
+
+// Presets returns a single open-ended preset: Flowtime sessions have no
+// preset end.
+func (f *flowtimeMode) Presets() []config.SessionPreset {
+	return []config.SessionPreset{{Name: "Flow", Duration: 0}}
+}
+
+// --- Mob Mode ---
+
+type mobMode struct {
+	cfg *config.MobConfig
+}
+
+func (mm *mobMode) Name() domain.Methodology   { return domain.MethodologyMob }
+func (mm *mobMode) TaskPrompt() string         { return "What is the mob working on? (Enter to skip):" }
+func (mm *mobMode) OutcomePrompt() string      { return "" }
+func (mm *mobMode) HasDistractionLog() bool    { return false }
+func (mm *mobMode) HasEnergizeReminder() bool  { return false }
+func (mm *mobMode) HasFocusScore() bool        { return mm.cfg != nil && mm.cfg.FocusScore }
+func (mm *mobMode) HasShutdownRitual() bool    { return false }
+func (mm *mobMode) HasHighlight() bool         { return false }
+func (mm *mobMode) HasLaserChecklist() bool    { return false }
+func (mm *mobMode) CompletionTitle() string    { return "Rotation complete. Switch!" }
+func (mm *mobMode) DeepWorkGoalHours() float64 { return 0 }
+func (mm *mobMode) DeepWorkPhilosophy() string { return "" }
+func (mm *mobMode) Description() string {
+	return "Mob and pair programming: one driver at the keyboard, one navigator guiding. Rotate every few minutes and break together."
+}
+func (mm *mobMode) TUITitle() string  { return "Mob" }
+func (mm *mobMode) HasRotation() bool { return true }
+
+// Presets returns a single preset, one rotation: every driver gets the
+// same time at the keyboard.
+func (mm *mobMode) Presets() []config.SessionPreset {
+	var cfg config.MobConfig
+	if mm.cfg != nil {
+		cfg = *mm.cfg
+	}
+	return []config.SessionPreset{{Name: "Rotation", Duration: cfg.RotationLength()}}
+}
+
+// --- User-defined Mode ---
+
+// customMode is a methodology declared in a [[methodologies]] config entry.
+type customMode struct {
+	cfg *config.MethodologyConfig
+}
+
+func (c *customMode) Name() domain.Methodology { return domain.Methodology(c.cfg.Name) }
+func (c *customMode) TaskPrompt() string {
+	if c.cfg.Highlight {
+		return "What's your Highlight for today?"
+	}
+	return "What are you working on? (Enter to skip):"
+}
+func (c *customMode) OutcomePrompt() string {
+	if c.cfg.ShutdownRitual {
+		return "Intended outcome for this session:"
+	}
+	return ""
+}
+func (c *customMode) HasDistractionLog() bool    { return c.cfg.DistractionLog }
+func (c *customMode) HasEnergizeReminder() bool  { return false }
+func (c *customMode) HasFocusScore() bool        { return c.cfg.FocusScore }
+func (c *customMode) HasShutdownRitual() bool    { return c.cfg.ShutdownRitual }
+func (c *customMode) HasHighlight() bool         { return c.cfg.Highlight }
+func (c *customMode) HasLaserChecklist() bool    { return c.cfg.LaserChecklist }
+func (c *customMode) CompletionTitle() string    { return "Session complete! Great work." }
+func (c *customMode) DeepWorkGoalHours() float64 { return 0 }
+func (c *customMode) DeepWorkPhilosophy() string { return "" }
+func (c *customMode) Description() string        { return c.cfg.Description }
+func (c *customMode) HasRotation() bool          { return false }
+func (c *customMode) TUITitle() string {
+	if c.cfg.Label != "" {
+		return c.cfg.Label
+	}
+	return c.cfg.Name
+}
+func (c *customMode) Presets() []config.SessionPreset {
+	return c.cfg.GetPresets()
+}
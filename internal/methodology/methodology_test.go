@@ -2,6 +2,7 @@ package methodology
 
 import (
 	"testing"
+	"time"
 
 	"github.com/xvierd/flow-cli/internal/config"
 	"github.com/xvierd/flow-cli/internal/domain"
@@ -37,6 +38,54 @@ func TestDeepWorkGoalHours(t *testing.T) {
 	}
 }
 
+func TestHasDistractionLog_OptIn(t *testing.T) {
+	cfg := config.DefaultConfig()
+	for m, want := range map[domain.Methodology]bool{
+		domain.MethodologyDeepWork: true,
+		domain.MethodologyPomodoro: false,
+		domain.MethodologyMakeTime: false,
+		domain.MethodologyFlowtime: false,
+	} {
+		if got := ForMethodology(m, cfg).HasDistractionLog(); got != want {
+			t.Errorf("%s HasDistractionLog() = %v by default, want %v", m, got, want)
+		}
+	}
+
+	cfg.Pomodoro.DistractionLog = true
+	cfg.MakeTime.DistractionLog = true
+	cfg.Flowtime.DistractionLog = true
+	for _, m := range []domain.Methodology{domain.MethodologyPomodoro, domain.MethodologyMakeTime, domain.MethodologyFlowtime} {
+		if !ForMethodology(m, cfg).HasDistractionLog() {
+			t.Errorf("%s HasDistractionLog() = false with distraction_log on", m)
+		}
+	}
+}
+
+func TestHasFocusScore_OptIn(t *testing.T) {
+	cfg := config.DefaultConfig()
+	for m, want := range map[domain.Methodology]bool{
+		domain.MethodologyMakeTime: true,
+		domain.MethodologyPomodoro: false,
+		domain.MethodologyDeepWork: false,
+		domain.MethodologyFlowtime: false,
+		domain.MethodologyMob:      false,
+	} {
+		if got := ForMethodology(m, cfg).HasFocusScore(); got != want {
+			t.Errorf("%s HasFocusScore() = %v by default, want %v", m, got, want)
+		}
+	}
+
+	cfg.Pomodoro.FocusScore = true
+	cfg.DeepWork.FocusScore = true
+	cfg.Flowtime.FocusScore = true
+	cfg.Mob.FocusScore = true
+	for _, m := range []domain.Methodology{domain.MethodologyPomodoro, domain.MethodologyDeepWork, domain.MethodologyFlowtime, domain.MethodologyMob} {
+		if !ForMethodology(m, cfg).HasFocusScore() {
+			t.Errorf("%s HasFocusScore() = false with focus_score on", m)
+		}
+	}
+}
+
 func TestTUITitle(t *testing.T) {
 	cfg := config.DefaultConfig()
 	tests := []struct {
@@ -46,6 +95,8 @@ func TestTUITitle(t *testing.T) {
 		{domain.MethodologyPomodoro, "Flow - Pomodoro Timer"},
 		{domain.MethodologyDeepWork, "Deep Work"},
 		{domain.MethodologyMakeTime, "Make Time"},
+		{domain.MethodologyFlowtime, "Flowtime"},
+		{domain.MethodologyMob, "Mob"},
 	}
 	for _, tt := range tests {
 		mode := ForMethodology(tt.methodology, cfg)
@@ -61,6 +112,8 @@ func TestDescription(t *testing.T) {
 		domain.MethodologyPomodoro,
 		domain.MethodologyDeepWork,
 		domain.MethodologyMakeTime,
+		domain.MethodologyFlowtime,
+		domain.MethodologyMob,
 	}
 	for _, m := range tests {
 		mode := ForMethodology(m, cfg)
@@ -69,3 +122,58 @@ func TestDescription(t *testing.T) {
 		}
 	}
 }
+
+func TestFlowtimePresetIsOpenEnded(t *testing.T) {
+	presets := ForMethodology(domain.MethodologyFlowtime, config.DefaultConfig()).Presets()
+	if len(presets) != 1 || presets[0].Duration != 0 {
+		t.Errorf("Flowtime presets = %v, want one open-ended preset", presets)
+	}
+}
+
+func TestMobPresetIsOneRotation(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Mob.Rotation = config.Duration(7 * time.Minute)
+	mode := ForMethodology(domain.MethodologyMob, cfg)
+	presets := mode.Presets()
+	if len(presets) != 1 || presets[0].Duration != 7*time.Minute {
+		t.Errorf("Mob presets = %v, want one 7m rotation", presets)
+	}
+	if !mode.HasRotation() || ForMethodology(domain.MethodologyPomodoro, cfg).HasRotation() {
+		t.Error("HasRotation() should be true in Mob mode only")
+	}
+}
+
+func TestCustomMethodology(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Methodologies = []config.MethodologyConfig{{
+		Name:           "ultradian",
+		Label:          "Ultradian",
+		Description:    "90-minute cycles with a 20-minute rest",
+		Presets:        []config.PresetConfig{{Name: "Cycle", Duration: config.Duration(90 * time.Minute)}},
+		ShortBreak:     config.Duration(20 * time.Minute),
+		ShutdownRitual: true,
+		FocusScore:     true,
+	}}
+	if err := Register(cfg); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if _, err := domain.ValidateMethodology("ultradian"); err != nil {
+		t.Errorf("ValidateMethodology(ultradian) after Register() error = %v", err)
+	}
+
+	mode := ForMethodology("ultradian", cfg)
+	if mode.Name() != "ultradian" || mode.TUITitle() != "Ultradian" || mode.Description() == "" {
+		t.Errorf("ForMethodology(ultradian) = %s %q, want the configured mode", mode.Name(), mode.TUITitle())
+	}
+	if !mode.HasShutdownRitual() || !mode.HasFocusScore() || mode.HasHighlight() || mode.HasDistractionLog() {
+		t.Error("custom mode feature flags don't follow its config")
+	}
+	if presets := mode.Presets(); len(presets) != 1 || presets[0].Duration != 90*time.Minute {
+		t.Errorf("Presets() = %v, want the configured Cycle preset", presets)
+	}
+
+	modes := All(cfg)
+	if len(modes) != 6 || modes[5].Name() != "ultradian" {
+		t.Errorf("All() lists %d modes, want the 5 built-in ones then ultradian", len(modes))
+	}
+}
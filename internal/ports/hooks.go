@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+// HookRunner runs the user's hook commands on session lifecycle events.
+// This is a driven port (implemented by adapters).
+type HookRunner interface {
+	// Run runs the command configured for event, if any, and waits for it.
+	// task is the session's task, or nil.
+	Run(ctx context.Context, event domain.HookEvent, session *domain.PomodoroSession, task *domain.Task) error
+}
+
+// WebhookSender POSTs webhook deliveries to their URL.
+// This is a driven port (implemented by adapters).
+type WebhookSender interface {
+	// Send POSTs the delivery's payload, signed with secret when it is set.
+	// It fails unless the endpoint answers with a 2xx status.
+	Send(ctx context.Context, delivery *domain.WebhookDelivery, secret string) error
+}
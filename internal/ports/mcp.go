@@ -66,4 +66,10 @@ type MCPStateProvider interface {
 
 	// SetHighlight marks a task as today's highlight (Make Time mode).
 	SetHighlight(ctx context.Context, taskID string) (*domain.Task, error)
+
+	// Search runs a full-text search over tasks and sessions.
+	Search(ctx context.Context, query SearchQuery) ([]SearchResult, error)
+
+	// GetStats returns the stats report for a period.
+	GetStats(ctx context.Context, query StatsQuery) (*StatsReport, error)
 }
@@ -0,0 +1,11 @@
+package ports
+
+// Notifier announces the end of sessions, e.g. with a desktop notification.
+// This is a driven port (implemented by adapters).
+type Notifier interface {
+	// NotifyPomodoroComplete announces a finished work session of the given length.
+	NotifyPomodoroComplete(duration string) error
+
+	// NotifyBreakComplete announces the end of a "Short" or "Long" break.
+	NotifyBreakComplete(breakType string) error
+}
@@ -0,0 +1,356 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+// The report types below are the stable JSON layouts of "flow stats
+// --json", "flow reflect --json", "flow distractions --json" and the MCP
+// get_stats tool. Durations are
+// whole seconds and dates are YYYY-MM-DD. Fields may be added, but never
+// renamed or removed; "flow schema" documents them.
+
+// StatsQuery selects what a StatsReport covers.
+type StatsQuery struct {
+	Start      time.Time // inclusive
+	End        time.Time // exclusive
+	Label      string
+	Tag        string            // only sessions with this tag or one nested beneath it
+	ByTag      bool              // include the time-per-tag breakdown
+	Philosophy string            // Deep Work philosophy to report on; empty for none
+	Compare    *domain.DateRange // range to compare with, usually the previous one; nil for none
+}
+
+// StatsReport is the stats dashboard.
+type StatsReport struct {
+	Label           string            `json:"label"`
+	Start           string            `json:"start"`
+	End             string            `json:"end"` // last day, inclusive
+	Tag             *string           `json:"tag"`
+	TotalSessions   int               `json:"total_sessions"`
+	WorkSeconds     int               `json:"work_seconds"`
+	ByMethodology   []MethodologyStat `json:"by_methodology"`
+	AvgFocusScore   *float64          `json:"avg_focus_score"`
+	FocusScoreCount int               `json:"focus_score_count"`
+	Distractions    int               `json:"distractions"`
+	Pauses          int               `json:"pauses"`
+	PausedSeconds   int               `json:"paused_seconds"`
+	Hourly          []HourStat        `json:"hourly"` // last 30 days; empty when filtered by tag
+	Tags            []TagTime         `json:"tags"`   // empty unless requested
+	Energize        []EnergizeStat    `json:"energize"`
+	Drivers         []DriverTime      `json:"drivers"` // empty without mob rotations
+	DeepWork        *DeepWorkStats    `json:"deep_work"`
+	Previous        *PeriodSummary    `json:"previous"` // the range compared with; null for none
+}
+
+// PeriodSummary is the headline numbers of a range, for comparison.
+type PeriodSummary struct {
+	Label           string   `json:"label"`
+	Start           string   `json:"start"`
+	End             string   `json:"end"` // last day, inclusive
+	TotalSessions   int      `json:"total_sessions"`
+	WorkSeconds     int      `json:"work_seconds"`
+	AvgFocusScore   *float64 `json:"avg_focus_score"`
+	FocusScoreCount int      `json:"focus_score_count"`
+	Distractions    int      `json:"distractions"`
+}
+
+// MethodologyStat is the work done in one methodology.
+type MethodologyStat struct {
+	Methodology string `json:"methodology"`
+	Sessions    int    `json:"sessions"`
+	WorkSeconds int    `json:"work_seconds"`
+}
+
+// HourStat is the work done in one hour of the day.
+type HourStat struct {
+	Hour        int `json:"hour"`
+	WorkSeconds int `json:"work_seconds"`
+}
+
+// TagTime is the work done under one tag, including nested tags.
+type TagTime struct {
+	Tag         string `json:"tag"`
+	Sessions    int    `json:"sessions"`
+	WorkSeconds int    `json:"work_seconds"`
+}
+
+// EnergizeStat is the average focus after one energize activity.
+type EnergizeStat struct {
+	Activity      string  `json:"activity"`
+	Sessions      int     `json:"sessions"`
+	AvgFocusScore float64 `json:"avg_focus_score"`
+}
+
+// DriverTime is the time one participant drove mob rotations.
+type DriverTime struct {
+	Driver      string `json:"driver"`
+	Rotations   int    `json:"rotations"`
+	WorkSeconds int    `json:"work_seconds"`
+}
+
+// DeepWorkStats is the context for a Deep Work philosophy.
+type DeepWorkStats struct {
+	Philosophy          string `json:"philosophy"`
+	StreakDays          int    `json:"streak_days"`
+	PreviousWeekSeconds int    `json:"previous_week_seconds"`
+	MonthSeconds        int    `json:"month_seconds"`
+}
+
+// WeeklyReflection is the week from Monday to today.
+type WeeklyReflection struct {
+	WeekStart       string           `json:"week_start"`
+	Days            []ReflectionDay  `json:"days"`
+	TotalSessions   int              `json:"total_sessions"`
+	WorkSeconds     int              `json:"work_seconds"`
+	AvgFocusScore   *float64         `json:"avg_focus_score"`
+	FocusScoreCount int              `json:"focus_score_count"`
+	Distractions    int              `json:"distractions"`
+	Highlights      []HighlightEntry `json:"highlights"`
+	Energize        []EnergizeStat   `json:"energize"`
+}
+
+// ReflectionDay is one day of a weekly reflection.
+type ReflectionDay struct {
+	Date         string `json:"date"`
+	Day          string `json:"day"` // Mon, Tue, ...
+	WorkSessions int    `json:"work_sessions"`
+	WorkSeconds  int    `json:"work_seconds"`
+}
+
+// HighlightEntry is a day's highlight task.
+type HighlightEntry struct {
+	Date   string `json:"date"`
+	TaskID string `json:"task_id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+// DailyReflection is today's reflection.
+type DailyReflection struct {
+	Date            string          `json:"date"`
+	Methodology     string          `json:"methodology"`
+	WorkSessions    int             `json:"work_sessions"`
+	WorkSeconds     int             `json:"work_seconds"`
+	Completed       int             `json:"completed"`
+	Interrupted     int             `json:"interrupted"`
+	Distractions    int             `json:"distractions"`
+	AvgFocusScore   *float64        `json:"avg_focus_score"`
+	FocusScoreCount int             `json:"focus_score_count"`
+	Highlight       *HighlightEntry `json:"highlight"`
+	Plan            *DayPlan        `json:"plan"` // nil when the day had no plan
+}
+
+// DayPlan is a day's time-block plan next to the work done in each block.
+type DayPlan struct {
+	Date             string      `json:"date"`
+	Blocks           []PlanEntry `json:"blocks"`
+	PlannedSeconds   int         `json:"planned_seconds"`
+	ActualSeconds    int         `json:"actual_seconds"`
+	UnplannedSeconds int         `json:"unplanned_seconds"` // work done outside every block
+}
+
+// PlanEntry is one time block of a day plan.
+type PlanEntry struct {
+	ID             string   `json:"id"`
+	Start          string   `json:"start"` // HH:MM
+	End            string   `json:"end"`
+	Task           string   `json:"task"`
+	Methodology    string   `json:"methodology"`
+	Tags           []string `json:"tags"`
+	PlannedSeconds int      `json:"planned_seconds"`
+	ActualSeconds  int      `json:"actual_seconds"`
+}
+
+// Heatmap metrics: what a heatmap day counts.
+const (
+	HeatmapMetricTotal    = "total"    // all completed work
+	HeatmapMetricDeepWork = "deepwork" // work done in Deep Work mode
+)
+
+// Heatmap is a year of daily focus time, a week per column.
+type Heatmap struct {
+	Metric        string       `json:"metric"`
+	Start         string       `json:"start"`      // the Monday 52 weeks before this week's
+	End           string       `json:"end"`        // today
+	Days          []HeatmapDay `json:"days"`       // every day from start to end
+	Thresholds    []int        `json:"thresholds"` // upper bounds in seconds of levels 1 to 3; empty without any work
+	TotalSeconds  int          `json:"total_seconds"`
+	ActiveDays    int          `json:"active_days"`
+	CurrentStreak int          `json:"current_streak"` // days up to today, or yesterday when today has none yet
+	LongestStreak int          `json:"longest_streak"`
+	Months        []MonthTotal `json:"months"`
+}
+
+// HeatmapDay is one cell of a heatmap.
+type HeatmapDay struct {
+	Date     string `json:"date"`
+	Sessions int    `json:"sessions"`
+	Seconds  int    `json:"seconds"` // of the heatmap's metric
+	Level    int    `json:"level"`   // 0 for none, 1 to 4 by quartile of active days
+}
+
+// MonthTotal is the work of one calendar month.
+type MonthTotal struct {
+	Month   string `json:"month"` // YYYY-MM
+	Seconds int    `json:"seconds"`
+}
+
+// DistractionQuery selects what a DistractionReport covers.
+type DistractionQuery struct {
+	Start      time.Time // inclusive
+	End        time.Time // exclusive
+	Label      string
+	Categories []string // configured categories, listed first and in order
+	Top        int      // themes to include, most frequent first; 0 for all
+}
+
+// DistractionReport breaks down the distractions logged during completed
+// work sessions.
+type DistractionReport struct {
+	Label              string                   `json:"label"`
+	Start              string                   `json:"start"`
+	End                string                   `json:"end"` // last day, inclusive
+	Total              int                      `json:"total"`
+	Sessions           int                      `json:"sessions"`
+	DistractedSessions int                      `json:"distracted_sessions"`
+	ByCategory         []CategoryCount          `json:"by_category"`
+	ByMethodology      []MethodologyDistraction `json:"by_methodology"`
+	Themes             []DistractionTheme       `json:"themes"`
+	Timed              int                      `json:"timed"`     // distractions logged with a time, which by_offset counts
+	ByOffset           []OffsetCount            `json:"by_offset"` // every bucket, in order
+	ByHour             []HourCount              `json:"by_hour"`   // hours with any, in order; untimed distractions count at their session's start
+}
+
+// CategoryCount is the number of distractions in one category.
+type CategoryCount struct {
+	Category string `json:"category"` // "uncategorized" for none
+	Count    int    `json:"count"`
+}
+
+// MethodologyDistraction is the distractions of one methodology's sessions.
+type MethodologyDistraction struct {
+	Methodology  string  `json:"methodology"`
+	Sessions     int     `json:"sessions"`
+	Distractions int     `json:"distractions"`
+	PerSession   float64 `json:"per_session"`
+}
+
+// DistractionTheme is a group of similarly worded distractions.
+type DistractionTheme struct {
+	Phrase   string   `json:"phrase"` // the most frequent wording, normalized
+	Count    int      `json:"count"`
+	Variants []string `json:"variants"` // other wordings grouped with it
+}
+
+// OffsetCount is the number of distractions logged within a span of active
+// time into their session.
+type OffsetCount struct {
+	FromMinutes int  `json:"from_minutes"`
+	ToMinutes   *int `json:"to_minutes"` // exclusive; null for the open-ended last bucket
+	Count       int  `json:"count"`
+}
+
+// HourCount is the number of distractions in one hour of the day.
+type HourCount struct {
+	Hour  int `json:"hour"`
+	Count int `json:"count"`
+}
+
+// InsightsQuery selects what an InsightsReport covers.
+type InsightsQuery struct {
+	Start       time.Time // inclusive
+	End         time.Time // exclusive
+	Label       string
+	MinSessions int // scored sessions a condition, tag or branch needs to be listed
+	Top         int // best conditions to include; 0 for all
+}
+
+// InsightsReport relates the focus scores of completed work sessions to the
+// conditions they ran under.
+type InsightsReport struct {
+	Label          string             `json:"label"`
+	Start          string             `json:"start"`
+	End            string             `json:"end"` // last day, inclusive
+	Sessions       int                `json:"sessions"`
+	ScoredSessions int                `json:"scored_sessions"`
+	AvgFocusScore  *float64           `json:"avg_focus_score"` // null without scored sessions
+	MinSessions    int                `json:"min_sessions"`
+	BestConditions []ScoreCondition   `json:"best_conditions"` // highest average first
+	Correlations   []ScoreCorrelation `json:"correlations"`
+	ByHour         []ScoreBucket      `json:"by_hour"`         // start hour, in order
+	ByDuration     []ScoreBucket      `json:"by_duration"`     // planned length, shortest first
+	ByWeekday      []ScoreBucket      `json:"by_weekday"`      // Monday first
+	ByTag          []ScoreBucket      `json:"by_tag"`          // highest average first
+	ByBranch       []ScoreBucket      `json:"by_branch"`       // highest average first
+	ByDistractions []ScoreBucket      `json:"by_distractions"` // 0, 1, 2, 3+
+	ByMethodology  []ScoreBucket      `json:"by_methodology"`  // highest average first
+}
+
+// ScoreBucket is the average focus score of the scored sessions sharing one
+// value of a condition.
+type ScoreBucket struct {
+	Key           string  `json:"key"`
+	Sessions      int     `json:"sessions"`
+	AvgFocusScore float64 `json:"avg_focus_score"`
+}
+
+// ScoreCorrelation is how strongly a numeric condition moves with the focus
+// score.
+type ScoreCorrelation struct {
+	Factor      string   `json:"factor"`      // hour, duration_minutes or distractions
+	Coefficient *float64 `json:"coefficient"` // Pearson's r; null when it can't be computed
+}
+
+// ScoreCondition is a combination of conditions whose sessions score above
+// the average.
+type ScoreCondition struct {
+	Summary       string            `json:"summary"`
+	Filters       []ConditionFilter `json:"filters"`
+	Sessions      int               `json:"sessions"`
+	AvgFocusScore float64           `json:"avg_focus_score"`
+	Lift          float64           `json:"lift"` // above the average of every scored session
+}
+
+// ConditionFilter is one condition of a ScoreCondition.
+type ConditionFilter struct {
+	Factor string `json:"factor"` // duration, methodology, hour, weekday, tag, branch or distractions
+	Value  string `json:"value"`
+}
+
+// SearchReport is the layout of search results on every surface: "flow
+// search --json", the MCP search tool and the HTTP API's /search.
+type SearchReport struct {
+	Query      string      `json:"query"`
+	Results    []SearchHit `json:"results"` // never null, [] without matches
+	TotalCount int         `json:"total_count"`
+}
+
+// SearchHit is one match in a SearchReport.
+type SearchHit struct {
+	Kind    string   `json:"kind"`
+	ID      string   `json:"id"`
+	Title   string   `json:"title"`
+	Date    string   `json:"date"` // local time, YYYY-MM-DDTHH:MM:SS
+	Tags    []string `json:"tags"`
+	Snippet string   `json:"snippet"`
+}
+
+// NewSearchReport lays out the results of a search for query.
+func NewSearchReport(query string, results []SearchResult) *SearchReport {
+	report := &SearchReport{Query: query, Results: make([]SearchHit, 0, len(results))}
+	for _, r := range results {
+		report.Results = append(report.Results, SearchHit{
+			Kind:    r.Kind,
+			ID:      r.ID,
+			Title:   r.Title,
+			Date:    r.Date.Format("2006-01-02T15:04:05"),
+			Tags:    r.Tags,
+			Snippet: r.Snippet,
+		})
+	}
+	report.TotalCount = len(report.Results)
+	return report
+}
@@ -47,6 +47,10 @@ type TaskRepository interface {
 
 	// FindYesterdayHighlight returns yesterday's highlight task if it wasn't completed.
 	FindYesterdayHighlight(ctx context.Context, today time.Time) (*domain.Task, error)
+
+	// FindHighlights returns the tasks highlighted for days in [start, end),
+	// by highlight date and, within a day, most recently updated first.
+	FindHighlights(ctx context.Context, start, end time.Time) ([]*domain.Task, error)
 }
 
 // SessionRepository defines the interface for pomodoro session persistence.
@@ -70,25 +74,131 @@ type SessionRepository interface {
 	// Update modifies an existing session.
 	Update(ctx context.Context, session *domain.PomodoroSession) error
 
+	// Finish saves a session that has just ended, but only if it is still
+	// running or paused in storage, and reports whether it was. A session
+	// ended twice at once, say by the daemon and the TUI, is finished once.
+	Finish(ctx context.Context, session *domain.PomodoroSession) (bool, error)
+
 	// GetDailyStats returns aggregated statistics for a specific date.
 	GetDailyStats(ctx context.Context, date time.Time) (*domain.DailyStats, error)
 
 	// GetPeriodStats returns aggregated statistics for a time range.
 	GetPeriodStats(ctx context.Context, start, end time.Time) (*domain.PeriodStats, error)
 
+	// GetTaggedPeriodStats returns aggregated statistics for a time range,
+	// counting only sessions carrying the tag (or a tag nested beneath it)
+	// directly or through their task.
+	GetTaggedPeriodStats(ctx context.Context, start, end time.Time, tag string) (*domain.PeriodStats, error)
+
 	// GetDeepWorkStreak returns consecutive days (ending today) with >= threshold deep work hours.
 	GetDeepWorkStreak(ctx context.Context, threshold time.Duration) (int, error)
 
+	// GetDailyTotals returns the completed work of each day in [start, end)
+	// that has any, in date order. Days follow start's time zone.
+	GetDailyTotals(ctx context.Context, start, end time.Time) ([]domain.DayTotal, error)
+
 	// GetHourlyProductivity returns total work minutes per hour-of-day for the last N days.
 	GetHourlyProductivity(ctx context.Context, days int) (map[int]time.Duration, error)
 
 	// GetEnergizeStats returns avg focus score per energize activity for a time range.
 	GetEnergizeStats(ctx context.Context, start, end time.Time) ([]domain.EnergizeStat, error)
 
+	// GetDriverStats returns the rotations and time per mob driver for a time
+	// range, restricted to a tag (and tags nested beneath it) unless tag is "".
+	GetDriverStats(ctx context.Context, start, end time.Time, tag string) ([]domain.DriverStat, error)
+
 	// GetDeepWorkHours returns total deep work hours for a date range.
 	GetDeepWorkHours(ctx context.Context, start, end time.Time) (time.Duration, error)
 }
 
+// TagRepository manages the tags shared by tasks and sessions. Renames,
+// merges and deletes rewrite every task and session that uses the tag.
+type TagRepository interface {
+	// List returns every tag with how many tasks and sessions use it.
+	List(ctx context.Context) ([]domain.TagUsage, error)
+
+	// Rename renames a tag and the tags nested beneath it, merging into any
+	// tag that already has the new name. It returns how many tags changed.
+	Rename(ctx context.Context, from, to string) (int, error)
+
+	// Merge folds each source tag (and its nested tags) into target.
+	Merge(ctx context.Context, sources []string, target string) (int, error)
+
+	// Delete removes a tag and the tags nested beneath it everywhere.
+	Delete(ctx context.Context, tag string) (int, error)
+
+	// Stats returns completed work per tag for a time range, rolled up
+	// so parent tags include the time of their nested tags.
+	Stats(ctx context.Context, start, end time.Time) ([]domain.TagStat, error)
+}
+
+// WebhookRepository stores webhook deliveries: the retry queue of pending
+// ones and the log of delivered and failed ones.
+type WebhookRepository interface {
+	// Save inserts or updates a delivery.
+	Save(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// FindDue returns pending deliveries due at or before now, oldest first.
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error)
+
+	// FindRecent returns the latest deliveries, newest first.
+	FindRecent(ctx context.Context, limit int) ([]*domain.WebhookDelivery, error)
+}
+
+// PlanRepository stores the time blocks of each day's plan.
+type PlanRepository interface {
+	// FindByDay returns the blocks planned for the local date of day, in
+	// start order.
+	FindByDay(ctx context.Context, day time.Time) ([]*domain.PlanBlock, error)
+
+	// FindAll returns the blocks of every day's plan, in start order.
+	FindAll(ctx context.Context) ([]*domain.PlanBlock, error)
+
+	// FindByID returns the block with id, or nil if there is none.
+	FindByID(ctx context.Context, id string) (*domain.PlanBlock, error)
+
+	// Save adds a block to the plan of the local date it starts on.
+	Save(ctx context.Context, block *domain.PlanBlock) error
+
+	// ReplaceDay replaces the plan for the local date of day with blocks.
+	ReplaceDay(ctx context.Context, day time.Time, blocks []*domain.PlanBlock) error
+}
+
+// MigrationStatus describes a schema migration and whether it has been applied.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// SearchMatchMarker wraps each matched term in SearchResult.Snippet, Markdown-bold style.
+const SearchMatchMarker = "**"
+
+// SearchQuery describes a full-text search over tasks and sessions.
+type SearchQuery struct {
+	Text  string
+	Since time.Time // zero means no lower bound
+	Tag   string    // empty matches any tag
+	Limit int       // 0 uses the repository default
+}
+
+// SearchResult is a task or session matching a full-text search.
+type SearchResult struct {
+	Kind    string // "task" or "session"
+	ID      string
+	Title   string
+	Date    time.Time
+	Tags    []string
+	Snippet string
+}
+
+// SearchRepository queries the full-text index over tasks and sessions.
+// The index itself is kept in sync by the task and session repositories.
+type SearchRepository interface {
+	// Search returns the best matches for the query, most relevant first.
+	Search(ctx context.Context, query SearchQuery) ([]SearchResult, error)
+}
+
 // Storage is the combined repository interface.
 // This is a driven port (implemented by adapters).
 type Storage interface {
@@ -98,9 +208,36 @@ type Storage interface {
 	// Sessions provides access to session operations.
 	Sessions() SessionRepository
 
+	// Search provides access to full-text search.
+	Search() SearchRepository
+
+	// Tags provides access to tag management.
+	Tags() TagRepository
+
+	// Webhooks provides access to the webhook delivery queue.
+	Webhooks() WebhookRepository
+
+	// Plans provides access to the day plans.
+	Plans() PlanRepository
+
+	// WithTx runs fn with a storage whose repositories all write in a
+	// single transaction, committed when fn returns nil and rolled back
+	// when it returns an error.
+	WithTx(ctx context.Context, fn func(Storage) error) error
+
 	// Close closes the storage connection.
 	Close() error
 
 	// Migrate runs database migrations.
 	Migrate() error
+
+	// MigrationStatus reports every known migration and when it was applied.
+	MigrationStatus(ctx context.Context) ([]MigrationStatus, error)
+
+	// Backup writes a consistent snapshot of the database to destPath.
+	Backup(ctx context.Context, destPath string) error
+
+	// RebuildRollups recomputes the daily stats rollups from every session
+	// and returns how many days hold work.
+	RebuildRollups(ctx context.Context) (int, error)
 }
@@ -83,6 +83,10 @@ func (m *mockTaskRepository) FindYesterdayHighlight(ctx context.Context, today t
 	return nil, nil
 }
 
+func (m *mockTaskRepository) FindHighlights(ctx context.Context, start, end time.Time) ([]*domain.Task, error) {
+	return nil, nil
+}
+
 func TestMockTaskRepository(t *testing.T) {
 	repo := &mockTaskRepository{tasks: make(map[string]*domain.Task)}
 	ctx := context.Background()
@@ -0,0 +1,527 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// ArchiveVersion is the format version written by Export. Import rejects
+// archives with a newer version.
+const ArchiveVersion = 1
+
+// Archive is a lossless dump of every task, session and plan block, used to
+// move data between machines or merge two databases.
+type Archive struct {
+	Version    int                `json:"version"`
+	ExportedAt time.Time          `json:"exported_at"`
+	Tasks      []ArchiveTask      `json:"tasks"`
+	Sessions   []ArchiveSession   `json:"sessions"`
+	PlanBlocks []ArchivePlanBlock `json:"plan_blocks"`
+}
+
+// ArchiveTask mirrors domain.Task with a stable JSON layout.
+type ArchiveTask struct {
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Status        string     `json:"status"`
+	Tags          []string   `json:"tags"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	CompletedAt   *time.Time `json:"completed_at"`
+	HighlightDate *time.Time `json:"highlight_date"`
+}
+
+// ArchiveSession mirrors domain.PomodoroSession with a stable JSON layout.
+type ArchiveSession struct {
+	ID               string                 `json:"id"`
+	TaskID           *string                `json:"task_id"`
+	Type             string                 `json:"type"`
+	Status           string                 `json:"status"`
+	DurationMs       int64                  `json:"duration_ms"`
+	OpenEnded        bool                   `json:"open_ended,omitempty"`
+	StartedAt        time.Time              `json:"started_at"`
+	PausedAt         *time.Time             `json:"paused_at"`
+	CompletedAt      *time.Time             `json:"completed_at"`
+	GitBranch        string                 `json:"git_branch"`
+	GitCommit        string                 `json:"git_commit"`
+	GitModified      []string               `json:"git_modified"`
+	Notes            string                 `json:"notes"`
+	Methodology      string                 `json:"methodology"`
+	FocusScore       *int                   `json:"focus_score"`
+	Distractions     []ArchiveDistraction   `json:"distractions"`
+	ShutdownRitual   *ArchiveShutdownRitual `json:"shutdown_ritual"`
+	Accomplishment   string                 `json:"accomplishment"`
+	IntendedOutcome  string                 `json:"intended_outcome"`
+	Tags             []string               `json:"tags"`
+	EnergizeActivity string                 `json:"energize_activity"`
+	OutcomeAchieved  string                 `json:"outcome_achieved"`
+	BreakAfterMs     int64                  `json:"break_after_ms,omitempty"`
+	Driver           string                 `json:"driver,omitempty"`
+	Navigator        string                 `json:"navigator,omitempty"`
+	Participants     []string               `json:"participants,omitempty"`
+	Events           []ArchiveSessionEvent  `json:"events,omitempty"`
+}
+
+// ArchiveSessionEvent mirrors domain.SessionEvent.
+type ArchiveSessionEvent struct {
+	Type string    `json:"type"`
+	At   time.Time `json:"at"`
+}
+
+// ArchiveDistraction mirrors domain.Distraction.
+type ArchiveDistraction struct {
+	Text     string     `json:"text"`
+	Category string     `json:"category"`
+	At       *time.Time `json:"at,omitempty"`
+	OffsetMs int64      `json:"offset_ms,omitempty"`
+}
+
+// ArchiveShutdownRitual mirrors domain.ShutdownRitual.
+type ArchiveShutdownRitual struct {
+	PendingTasksReview string `json:"pending_tasks_review"`
+	CalendarReview     string `json:"calendar_review"`
+	TomorrowPlan       string `json:"tomorrow_plan"`
+	ClosingPhrase      string `json:"closing_phrase"`
+}
+
+// ArchivePlanBlock mirrors domain.PlanBlock; it belongs to the plan of the
+// local date it starts on.
+type ArchivePlanBlock struct {
+	ID          string    `json:"id"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Task        string    `json:"task"`
+	Methodology string    `json:"methodology"`
+	Tags        []string  `json:"tags"`
+}
+
+// ImportConflict describes a record that was not imported.
+type ImportConflict struct {
+	Kind   string // "task", "session" or "block"
+	ID     string
+	Reason string
+}
+
+// ImportResult summarizes a merge-import.
+type ImportResult struct {
+	TasksAdded        int
+	TasksUnchanged    int
+	SessionsAdded     int
+	SessionsUnchanged int
+	BlocksAdded       int
+	BlocksUnchanged   int
+	Conflicts         []ImportConflict
+}
+
+// ArchiveService handles whole-database export and merge-import.
+type ArchiveService struct {
+	storage ports.Storage
+}
+
+// NewArchiveService creates a new archive service.
+func NewArchiveService(storage ports.Storage) *ArchiveService {
+	return &ArchiveService{storage: storage}
+}
+
+// Export dumps every task, session and plan block, including breaks and
+// unfinished sessions.
+func (s *ArchiveService) Export(ctx context.Context) (*Archive, error) {
+	tasks, err := s.storage.Tasks().FindAll(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	sessions, err := s.storage.Sessions().FindRecent(ctx, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sessions: %w", err)
+	}
+	blocks, err := s.storage.Plans().FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch plan blocks: %w", err)
+	}
+
+	archive := &Archive{
+		Version:    ArchiveVersion,
+		ExportedAt: time.Now(),
+		Tasks:      make([]ArchiveTask, 0, len(tasks)),
+		Sessions:   make([]ArchiveSession, 0, len(sessions)),
+		PlanBlocks: make([]ArchivePlanBlock, 0, len(blocks)),
+	}
+	for _, t := range tasks {
+		archive.Tasks = append(archive.Tasks, archiveTaskFrom(t))
+	}
+	for _, sess := range sessions {
+		archive.Sessions = append(archive.Sessions, archiveSessionFrom(sess))
+	}
+	for _, b := range blocks {
+		archive.PlanBlocks = append(archive.PlanBlocks, archivePlanBlockFrom(b))
+	}
+	return archive, nil
+}
+
+// Import merges an archive into storage. Records are matched by ID: new ones
+// are added, identical ones are left alone, and ones that differ from the
+// local copy are kept as-is locally and reported as conflicts. With dryRun
+// set, nothing is written but the result reports what would happen. The
+// import runs in one transaction, so a failure leaves storage untouched.
+func (s *ArchiveService) Import(ctx context.Context, archive *Archive, dryRun bool) (*ImportResult, error) {
+	if archive.Version > ArchiveVersion {
+		return nil, fmt.Errorf("archive version %d is newer than this version of flow supports (%d)", archive.Version, ArchiveVersion)
+	}
+
+	var result *ImportResult
+	err := s.storage.WithTx(ctx, func(store ports.Storage) error {
+		var err error
+		result, err = mergeArchive(ctx, store, archive, dryRun)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// mergeArchive matches the archive against store and, unless dryRun is set,
+// writes the new records to it.
+func mergeArchive(ctx context.Context, store ports.Storage, archive *Archive, dryRun bool) (*ImportResult, error) {
+	result := &ImportResult{}
+	knownTasks := make(map[string]bool)
+
+	var newTasks []*domain.Task
+	for _, at := range archive.Tasks {
+		if at.ID == "" {
+			return nil, errors.New("archive contains a task without an id")
+		}
+		existing, err := store.Tasks().FindByID(ctx, at.ID)
+		if err != nil && !errors.Is(err, domain.ErrTaskNotFound) {
+			return nil, fmt.Errorf("failed to look up task %s: %w", at.ID, err)
+		}
+		knownTasks[at.ID] = true
+		if existing == nil {
+			newTasks = append(newTasks, at.toDomain())
+			continue
+		}
+		if sameJSON(archiveTaskFrom(existing), at) {
+			result.TasksUnchanged++
+			continue
+		}
+		result.Conflicts = append(result.Conflicts, ImportConflict{
+			Kind: "task", ID: at.ID, Reason: "differs from the local task; kept local version",
+		})
+	}
+
+	active, err := store.Sessions().FindActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check active session: %w", err)
+	}
+
+	var newSessions []*domain.PomodoroSession
+	for _, as := range archive.Sessions {
+		if as.ID == "" {
+			return nil, errors.New("archive contains a session without an id")
+		}
+		existing, err := store.Sessions().FindByID(ctx, as.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up session %s: %w", as.ID, err)
+		}
+		if existing != nil {
+			local := archiveSessionFrom(existing)
+			if len(as.Events) == 0 {
+				// Archives written before event history was recorded
+				// carry no events; don't count that as a difference.
+				local.Events = nil
+			}
+			if sameJSON(local, as) {
+				result.SessionsUnchanged++
+				continue
+			}
+			result.Conflicts = append(result.Conflicts, ImportConflict{
+				Kind: "session", ID: as.ID, Reason: "differs from the local session; kept local version",
+			})
+			continue
+		}
+
+		if as.TaskID != nil && !knownTasks[*as.TaskID] {
+			if _, err := store.Tasks().FindByID(ctx, *as.TaskID); err != nil {
+				result.Conflicts = append(result.Conflicts, ImportConflict{
+					Kind: "session", ID: as.ID, Reason: fmt.Sprintf("references missing task %s", *as.TaskID),
+				})
+				continue
+			}
+			knownTasks[*as.TaskID] = true
+		}
+
+		session := as.toDomain()
+		if session.Status == domain.SessionStatusRunning || session.Status == domain.SessionStatusPaused {
+			if active != nil {
+				result.Conflicts = append(result.Conflicts, ImportConflict{
+					Kind: "session", ID: as.ID, Reason: "another session is already active locally",
+				})
+				continue
+			}
+			active = session
+		}
+		newSessions = append(newSessions, session)
+	}
+
+	// Blocks are kept per local day, where they may not overlap.
+	planned := make(map[string][]*domain.PlanBlock)
+	var newBlocks []*domain.PlanBlock
+	for _, ab := range archive.PlanBlocks {
+		if ab.ID == "" {
+			return nil, errors.New("archive contains a plan block without an id")
+		}
+		existing, err := store.Plans().FindByID(ctx, ab.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up plan block %s: %w", ab.ID, err)
+		}
+		if existing != nil {
+			if sameJSON(archivePlanBlockFrom(existing), ab) {
+				result.BlocksUnchanged++
+				continue
+			}
+			result.Conflicts = append(result.Conflicts, ImportConflict{
+				Kind: "block", ID: ab.ID, Reason: "differs from the local plan block; kept local version",
+			})
+			continue
+		}
+
+		block := ab.toDomain()
+		if err := block.Validate(); err != nil {
+			result.Conflicts = append(result.Conflicts, ImportConflict{
+				Kind: "block", ID: ab.ID, Reason: err.Error(),
+			})
+			continue
+		}
+		day := block.Start.Format("2006-01-02")
+		dayBlocks, ok := planned[day]
+		if !ok {
+			if dayBlocks, err = store.Plans().FindByDay(ctx, block.Start); err != nil {
+				return nil, fmt.Errorf("failed to load plan for %s: %w", day, err)
+			}
+			planned[day] = dayBlocks
+		}
+		if overlapsAny(block, dayBlocks) {
+			result.Conflicts = append(result.Conflicts, ImportConflict{
+				Kind: "block", ID: ab.ID, Reason: "overlaps a block already planned locally",
+			})
+			continue
+		}
+		planned[day] = append(dayBlocks, block)
+		newBlocks = append(newBlocks, block)
+	}
+
+	result.TasksAdded = len(newTasks)
+	result.SessionsAdded = len(newSessions)
+	result.BlocksAdded = len(newBlocks)
+	if dryRun {
+		return result, nil
+	}
+
+	// Tasks first so sessions can reference them.
+	for _, t := range newTasks {
+		if err := store.Tasks().Save(ctx, t); err != nil {
+			return nil, fmt.Errorf("failed to import task %s: %w", t.ID, err)
+		}
+	}
+	for _, sess := range newSessions {
+		if err := store.Sessions().Save(ctx, sess); err != nil {
+			return nil, fmt.Errorf("failed to import session %s: %w", sess.ID, err)
+		}
+	}
+	for _, b := range newBlocks {
+		if err := store.Plans().Save(ctx, b); err != nil {
+			return nil, fmt.Errorf("failed to import plan block %s: %w", b.ID, err)
+		}
+	}
+
+	return result, nil
+}
+
+// sameJSON reports whether two records serialize identically.
+func sameJSON(a, b interface{}) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+// utcPtr normalizes an optional time to UTC so records compare equal
+// regardless of the zone they were read in.
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+func archiveTaskFrom(t *domain.Task) ArchiveTask {
+	return ArchiveTask{
+		ID:            t.ID,
+		Title:         t.Title,
+		Description:   t.Description,
+		Status:        string(t.Status),
+		Tags:          nonNil(t.Tags),
+		CreatedAt:     t.CreatedAt.UTC(),
+		UpdatedAt:     t.UpdatedAt.UTC(),
+		CompletedAt:   utcPtr(t.CompletedAt),
+		HighlightDate: utcPtr(t.HighlightDate),
+	}
+}
+
+func (at ArchiveTask) toDomain() *domain.Task {
+	return &domain.Task{
+		ID:            at.ID,
+		Title:         at.Title,
+		Description:   at.Description,
+		Status:        domain.TaskStatus(at.Status),
+		Tags:          at.Tags,
+		CreatedAt:     at.CreatedAt,
+		UpdatedAt:     at.UpdatedAt,
+		CompletedAt:   at.CompletedAt,
+		HighlightDate: at.HighlightDate,
+	}
+}
+
+func archiveSessionFrom(s *domain.PomodoroSession) ArchiveSession {
+	as := ArchiveSession{
+		ID:               s.ID,
+		TaskID:           s.TaskID,
+		Type:             string(s.Type),
+		Status:           string(s.Status),
+		DurationMs:       s.Duration.Milliseconds(),
+		OpenEnded:        s.OpenEnded,
+		StartedAt:        s.StartedAt.UTC(),
+		PausedAt:         utcPtr(s.PausedAt),
+		CompletedAt:      utcPtr(s.CompletedAt),
+		GitBranch:        s.GitBranch,
+		GitCommit:        s.GitCommit,
+		GitModified:      nonNil(s.GitModified),
+		Notes:            s.Notes,
+		Methodology:      string(s.Methodology),
+		FocusScore:       s.FocusScore,
+		Distractions:     make([]ArchiveDistraction, 0, len(s.Distractions)),
+		Accomplishment:   s.Accomplishment,
+		IntendedOutcome:  s.IntendedOutcome,
+		Tags:             nonNil(s.Tags),
+		EnergizeActivity: s.EnergizeActivity,
+		OutcomeAchieved:  s.OutcomeAchieved,
+		BreakAfterMs:     s.BreakAfter.Milliseconds(),
+		Driver:           s.Driver,
+		Navigator:        s.Navigator,
+		Participants:     s.Participants,
+	}
+	for _, d := range s.Distractions {
+		ad := ArchiveDistraction{Text: d.Text, Category: d.Category, OffsetMs: d.Offset.Milliseconds()}
+		if !d.At.IsZero() {
+			ad.At = utcPtr(&d.At)
+		}
+		as.Distractions = append(as.Distractions, ad)
+	}
+	for _, e := range s.Events {
+		as.Events = append(as.Events, ArchiveSessionEvent{Type: string(e.Type), At: e.At.UTC()})
+	}
+	if s.ShutdownRitual != nil {
+		as.ShutdownRitual = &ArchiveShutdownRitual{
+			PendingTasksReview: s.ShutdownRitual.PendingTasksReview,
+			CalendarReview:     s.ShutdownRitual.CalendarReview,
+			TomorrowPlan:       s.ShutdownRitual.TomorrowPlan,
+			ClosingPhrase:      s.ShutdownRitual.ClosingPhrase,
+		}
+	}
+	return as
+}
+
+func (as ArchiveSession) toDomain() *domain.PomodoroSession {
+	s := &domain.PomodoroSession{
+		ID:               as.ID,
+		TaskID:           as.TaskID,
+		Type:             domain.SessionType(as.Type),
+		Status:           domain.SessionStatus(as.Status),
+		Duration:         time.Duration(as.DurationMs) * time.Millisecond,
+		OpenEnded:        as.OpenEnded,
+		StartedAt:        as.StartedAt,
+		PausedAt:         as.PausedAt,
+		CompletedAt:      as.CompletedAt,
+		GitBranch:        as.GitBranch,
+		GitCommit:        as.GitCommit,
+		GitModified:      as.GitModified,
+		Notes:            as.Notes,
+		Methodology:      domain.Methodology(as.Methodology),
+		FocusScore:       as.FocusScore,
+		Accomplishment:   as.Accomplishment,
+		IntendedOutcome:  as.IntendedOutcome,
+		Tags:             as.Tags,
+		EnergizeActivity: as.EnergizeActivity,
+		OutcomeAchieved:  as.OutcomeAchieved,
+		BreakAfter:       time.Duration(as.BreakAfterMs) * time.Millisecond,
+		Driver:           as.Driver,
+		Navigator:        as.Navigator,
+		Participants:     as.Participants,
+	}
+	for _, d := range as.Distractions {
+		distraction := domain.Distraction{Text: d.Text, Category: d.Category, Offset: time.Duration(d.OffsetMs) * time.Millisecond}
+		if d.At != nil {
+			distraction.At = *d.At
+		}
+		s.Distractions = append(s.Distractions, distraction)
+	}
+	for _, e := range as.Events {
+		s.Events = append(s.Events, domain.SessionEvent{Type: domain.SessionEventType(e.Type), At: e.At})
+	}
+	if as.ShutdownRitual != nil {
+		s.ShutdownRitual = &domain.ShutdownRitual{
+			PendingTasksReview: as.ShutdownRitual.PendingTasksReview,
+			CalendarReview:     as.ShutdownRitual.CalendarReview,
+			TomorrowPlan:       as.ShutdownRitual.TomorrowPlan,
+			ClosingPhrase:      as.ShutdownRitual.ClosingPhrase,
+		}
+	}
+	return s
+}
+
+func archivePlanBlockFrom(b *domain.PlanBlock) ArchivePlanBlock {
+	return ArchivePlanBlock{
+		ID:          b.ID,
+		Start:       b.Start.UTC(),
+		End:         b.End.UTC(),
+		Task:        b.Task,
+		Methodology: string(b.Methodology),
+		Tags:        nonNil(b.Tags),
+	}
+}
+
+func (ab ArchivePlanBlock) toDomain() *domain.PlanBlock {
+	return &domain.PlanBlock{
+		ID:          ab.ID,
+		Start:       ab.Start.In(time.Local),
+		End:         ab.End.In(time.Local),
+		Task:        ab.Task,
+		Methodology: domain.Methodology(ab.Methodology),
+		Tags:        domain.NormalizeTags(ab.Tags),
+	}
+}
+
+// overlapsAny reports whether block shares time with any of blocks.
+func overlapsAny(block *domain.PlanBlock, blocks []*domain.PlanBlock) bool {
+	for _, b := range blocks {
+		if block.Overlaps(b) {
+			return true
+		}
+	}
+	return false
+}
+
+// nonNil returns an empty slice for nil so exports always emit [] rather than null.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+func TestArchiveService_ExportImportRoundTrip(t *testing.T) {
+	src, cleanupSrc := setupTestStorage(t)
+	defer cleanupSrc()
+	ctx := context.Background()
+
+	task, _ := domain.NewTask("Write report")
+	task.Description = "Quarterly numbers"
+	task.AddTag("work")
+	task.SetAsHighlight()
+	if err := src.Tasks().Save(ctx, task); err != nil {
+		t.Fatalf("Save(task) error = %v", err)
+	}
+
+	score := 4
+	work := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), &task.ID)
+	work.SetGitContext("main", "abc123", []string{"a.go", "b.go"})
+	work.Methodology = domain.MethodologyDeepWork
+	work.FocusScore = &score
+	work.Distractions = []domain.Distraction{{Text: "slack", Category: "external"}}
+	work.ShutdownRitual = &domain.ShutdownRitual{TomorrowPlan: "ship it"}
+	work.Tags = []string{"work"}
+	work.Complete()
+	if err := src.Sessions().Save(ctx, work); err != nil {
+		t.Fatalf("Save(work) error = %v", err)
+	}
+	brk := domain.NewBreakSession(domain.DefaultPomodoroConfig(), 1)
+	brk.Complete()
+	if err := src.Sessions().Save(ctx, brk); err != nil {
+		t.Fatalf("Save(break) error = %v", err)
+	}
+
+	day := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+	block, _ := domain.NewPlanBlock(day.Add(9*time.Hour), day.Add(10*time.Hour+30*time.Minute), "Write report")
+	block.Methodology = domain.MethodologyDeepWork
+	block.Tags = []string{"work"}
+	if err := src.Plans().ReplaceDay(ctx, day, []*domain.PlanBlock{block}); err != nil {
+		t.Fatalf("ReplaceDay() error = %v", err)
+	}
+
+	archive, err := NewArchiveService(src).Export(ctx)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(archive.Tasks) != 1 || len(archive.Sessions) != 2 || len(archive.PlanBlocks) != 1 {
+		t.Fatalf("Export() got %d tasks, %d sessions, %d blocks; want 1, 2, 1",
+			len(archive.Tasks), len(archive.Sessions), len(archive.PlanBlocks))
+	}
+
+	// Round-trip through JSON like the CLI does.
+	data, err := json.Marshal(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Archive
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	dst, cleanupDst := setupTestStorage(t)
+	defer cleanupDst()
+	service := NewArchiveService(dst)
+
+	result, err := service.Import(ctx, &decoded, false)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.TasksAdded != 1 || result.SessionsAdded != 2 || result.BlocksAdded != 1 || len(result.Conflicts) != 0 {
+		t.Errorf("Import() = %+v, want 1 task, 2 sessions, 1 block, no conflicts", result)
+	}
+
+	got, err := dst.Sessions().FindByID(ctx, work.ID)
+	if err != nil || got == nil {
+		t.Fatalf("imported session missing: %v", err)
+	}
+	if got.GitBranch != "main" || len(got.GitModified) != 2 || got.ShutdownRitual == nil ||
+		got.ShutdownRitual.TomorrowPlan != "ship it" || got.FocusScore == nil || *got.FocusScore != 4 {
+		t.Errorf("imported session lost fields: %+v", got)
+	}
+	gotTask, err := dst.Tasks().FindByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("imported task missing: %v", err)
+	}
+	if gotTask.HighlightDate == nil || gotTask.Description != "Quarterly numbers" {
+		t.Errorf("imported task lost fields: %+v", gotTask)
+	}
+	blocks, err := dst.Plans().FindByDay(ctx, day)
+	if err != nil || len(blocks) != 1 {
+		t.Fatalf("imported plan = %v, %v; want the one block", blocks, err)
+	}
+	if got := blocks[0]; got.ID != block.ID || !got.Start.Equal(block.Start) || !got.End.Equal(block.End) ||
+		got.Methodology != domain.MethodologyDeepWork || len(got.Tags) != 1 || got.Tags[0] != "work" {
+		t.Errorf("imported block lost fields: %+v", got)
+	}
+
+	// Importing the same dump again is a no-op.
+	again, err := service.Import(ctx, &decoded, false)
+	if err != nil {
+		t.Fatalf("second Import() error = %v", err)
+	}
+	if again.TasksAdded != 0 || again.SessionsAdded != 0 || again.BlocksAdded != 0 ||
+		again.TasksUnchanged != 1 || again.SessionsUnchanged != 2 || again.BlocksUnchanged != 1 {
+		t.Errorf("second Import() = %+v, want everything unchanged", again)
+	}
+}
+
+func TestArchiveService_ImportReportsConflicts(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+	service := NewArchiveService(store)
+
+	task, _ := domain.NewTask("Local title")
+	if err := store.Tasks().Save(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+	block, _ := domain.NewPlanBlock(day.Add(9*time.Hour), day.Add(10*time.Hour), "Local block")
+	if err := store.Plans().ReplaceDay(ctx, day, []*domain.PlanBlock{block}); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := service.Export(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive.Tasks[0].Title = "Remote title"
+	archive.PlanBlocks[0].Task = "Remote block"
+	archive.PlanBlocks = append(archive.PlanBlocks, ArchivePlanBlock{
+		ID: "overlapping", Start: day.Add(9*time.Hour + 30*time.Minute), End: day.Add(11 * time.Hour), Task: "Elsewhere",
+	})
+	missing := "no-such-task"
+	archive.Sessions = append(archive.Sessions, ArchiveSession{
+		ID: "orphan", TaskID: &missing, Type: "work", Status: "completed",
+		DurationMs: 60000, StartedAt: time.Now(),
+	})
+
+	result, err := service.Import(ctx, archive, false)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if len(result.Conflicts) != 4 {
+		t.Fatalf("got %d conflicts, want 4: %+v", len(result.Conflicts), result.Conflicts)
+	}
+
+	kept, _ := store.Tasks().FindByID(ctx, task.ID)
+	if kept.Title != "Local title" {
+		t.Errorf("conflicting import overwrote local task: %q", kept.Title)
+	}
+	if s, _ := store.Sessions().FindByID(ctx, "orphan"); s != nil {
+		t.Error("session with a missing task was imported")
+	}
+	if blocks, _ := store.Plans().FindByDay(ctx, day); len(blocks) != 1 || blocks[0].Task != "Local block" {
+		t.Errorf("conflicting import changed the local plan: %v", blocks)
+	}
+}
+
+func TestArchiveService_ImportDryRunWritesNothing(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	archive := &Archive{
+		Version: ArchiveVersion,
+		Tasks: []ArchiveTask{{
+			ID: "t1", Title: "From elsewhere", Status: "pending",
+			CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}},
+	}
+
+	result, err := NewArchiveService(store).Import(ctx, archive, true)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.TasksAdded != 1 {
+		t.Errorf("TasksAdded = %d, want 1", result.TasksAdded)
+	}
+	if _, err := store.Tasks().FindByID(ctx, "t1"); err == nil {
+		t.Error("dry run wrote a task")
+	}
+}
+
+func TestArchiveService_ImportRollsBackOnError(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	now := time.Now()
+	session := ArchiveSession{
+		ID: "s1", Type: "work", Status: "completed", DurationMs: 60000,
+		StartedAt: now.Add(-time.Minute), CompletedAt: &now,
+	}
+	// The second copy of s1 fails to save after t1 and the first s1 were
+	// written.
+	archive := &Archive{
+		Version: ArchiveVersion,
+		Tasks: []ArchiveTask{{
+			ID: "t1", Title: "From elsewhere", Status: "pending",
+			CreatedAt: now, UpdatedAt: now,
+		}},
+		Sessions: []ArchiveSession{session, session},
+	}
+
+	if _, err := NewArchiveService(store).Import(ctx, archive, false); err == nil {
+		t.Fatal("Import() error = nil, want the duplicate session to fail")
+	}
+	if _, err := store.Tasks().FindByID(ctx, "t1"); err == nil {
+		t.Error("failed import left task t1 behind")
+	}
+	if s, err := store.Sessions().FindByID(ctx, "s1"); err != nil || s != nil {
+		t.Errorf("failed import left session s1 behind (err = %v)", err)
+	}
+}
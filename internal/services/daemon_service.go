@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// DaemonService watches the active session from a background process, so a
+// session started by a script or over MCP still ends on time, with a
+// notification and an automatic break, when no TUI is open.
+type DaemonService struct {
+	storage   ports.Storage
+	pomodoro  *PomodoroService
+	notifier  ports.Notifier
+	autoBreak bool
+	attached  func() bool
+	logf      func(format string, args ...interface{})
+
+	mu       sync.Mutex
+	watching *domain.PomodoroSession
+}
+
+// NewDaemonService creates a daemon service. notifier may be nil.
+func NewDaemonService(storage ports.Storage, pomodoro *PomodoroService, notifier ports.Notifier) *DaemonService {
+	return &DaemonService{
+		storage:  storage,
+		pomodoro: pomodoro,
+		notifier: notifier,
+		attached: func() bool { return false },
+		logf:     func(string, ...interface{}) {},
+	}
+}
+
+// SetAutoBreak starts a break whenever the daemon completes a work session.
+func (d *DaemonService) SetAutoBreak(autoBreak bool) {
+	d.autoBreak = autoBreak
+}
+
+// SetAttached tells the daemon whether a TUI is watching the session. The
+// TUI notifies and starts breaks itself, so the daemon then only records
+// the completion.
+func (d *DaemonService) SetAttached(attached func() bool) {
+	d.attached = attached
+}
+
+// SetLogger sets where Run reports errors.
+func (d *DaemonService) SetLogger(logf func(format string, args ...interface{})) {
+	d.logf = logf
+}
+
+// Watching returns a copy of the session the daemon is currently watching,
+// or nil. The copy is safe to read while Tick completes the session.
+func (d *DaemonService) Watching() *domain.PomodoroSession {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.watching == nil {
+		return nil
+	}
+	watched := *d.watching
+	return &watched
+}
+
+// Run checks the active session every interval until ctx is cancelled.
+func (d *DaemonService) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.Tick(ctx, time.Now()); err != nil {
+			d.logf("%v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick checks the active session once and completes it if its time is up,
+// returning the session it completed. Sessions already long overdue (the
+// daemon was not running) are left for stale-session recovery.
+func (d *DaemonService) Tick(ctx context.Context, now time.Time) (*domain.PomodoroSession, error) {
+	session, err := d.storage.Sessions().FindActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active session: %w", err)
+	}
+	// Watch a copy: the session itself is changed below, outside the lock
+	d.mu.Lock()
+	d.watching = nil
+	if session != nil {
+		watched := *session
+		d.watching = &watched
+	}
+	d.mu.Unlock()
+
+	if session == nil || session.Status != domain.SessionStatusRunning || !session.TimeUp() || session.IsStale(now) {
+		return nil, nil
+	}
+
+	err = d.pomodoro.ExpireSession(ctx, session)
+	if err != nil && !errors.Is(err, domain.ErrNoActiveSession) {
+		return nil, err
+	}
+	d.mu.Lock()
+	d.watching = nil
+	d.mu.Unlock()
+	// Someone else completed it first, and notified for it
+	if err != nil {
+		return nil, nil
+	}
+
+	if d.attached() {
+		return session, nil
+	}
+	if err := d.notify(session); err != nil {
+		d.logf("notification failed: %v", err)
+	}
+	if d.autoBreak && session.IsWorkSession() {
+		if _, err := d.pomodoro.StartBreak(ctx, ""); err != nil {
+			return session, fmt.Errorf("failed to start break: %w", err)
+		}
+	}
+	return session, nil
+}
+
+// notify announces a completed session.
+func (d *DaemonService) notify(session *domain.PomodoroSession) error {
+	if d.notifier == nil {
+		return nil
+	}
+	switch session.Type {
+	case domain.SessionTypeShortBreak:
+		return d.notifier.NotifyBreakComplete("Short")
+	case domain.SessionTypeLongBreak:
+		return d.notifier.NotifyBreakComplete("Long")
+	default:
+		return d.notifier.NotifyPomodoroComplete(fmt.Sprintf("%dm", int(session.Duration.Minutes())))
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+type recordingNotifier struct {
+	work   []string
+	breaks []string
+}
+
+func (n *recordingNotifier) NotifyPomodoroComplete(duration string) error {
+	n.work = append(n.work, duration)
+	return nil
+}
+
+func (n *recordingNotifier) NotifyBreakComplete(breakType string) error {
+	n.breaks = append(n.breaks, breakType)
+	return nil
+}
+
+// saveStarted stores a running work session of length d started ago.
+func saveStarted(t *testing.T, store ports.Storage, d, ago time.Duration) *domain.PomodoroSession {
+	t.Helper()
+	session := domain.NewPomodoroSession(domain.PomodoroConfig{WorkDuration: d}, nil)
+	session.StartedAt = time.Now().Add(-ago).Round(0)
+	session.Events[0].At = session.StartedAt
+	if err := store.Sessions().Save(context.Background(), session); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	return session
+}
+
+func TestDaemonService_Tick(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	pomodoro := NewPomodoroService(store, nil)
+	notifier := &recordingNotifier{}
+	daemon := NewDaemonService(store, pomodoro, notifier)
+	daemon.SetAutoBreak(true)
+
+	running := saveStarted(t, store, 25*time.Minute, 10*time.Minute)
+	if done, err := daemon.Tick(ctx, time.Now()); err != nil || done != nil {
+		t.Fatalf("Tick() on a running session = %v, %v; want nothing completed", done, err)
+	}
+	if w := daemon.Watching(); w == nil || w.ID != running.ID {
+		t.Errorf("Watching() = %v, want the running session", w)
+	}
+	clearSessions(t, store, ctx)
+
+	expired := saveStarted(t, store, 25*time.Minute, 26*time.Minute)
+	done, err := daemon.Tick(ctx, time.Now())
+	if err != nil || done == nil || done.ID != expired.ID {
+		t.Fatalf("Tick() = %v, %v; want the expired session completed", done, err)
+	}
+	got, _ := store.Sessions().FindByID(ctx, expired.ID)
+	if got.Status != domain.SessionStatusCompleted {
+		t.Errorf("status = %v, want completed", got.Status)
+	}
+	if want := expired.StartedAt.Add(25 * time.Minute); !got.CompletedAt.Equal(want) {
+		t.Errorf("CompletedAt = %v, want the planned end %v", got.CompletedAt, want)
+	}
+	if len(notifier.work) != 1 || notifier.work[0] != "25m" {
+		t.Errorf("work notifications = %v, want [25m]", notifier.work)
+	}
+	active, _ := store.Sessions().FindActive(ctx)
+	if active == nil || !active.IsBreakSession() {
+		t.Errorf("active session after auto-break = %v, want a break", active)
+	}
+	clearSessions(t, store, ctx)
+
+	// Sessions left overdue while no daemon ran are left for recovery.
+	stale := saveStarted(t, store, 25*time.Minute, 2*time.Hour)
+	if done, _ := daemon.Tick(ctx, time.Now()); done != nil {
+		t.Error("Tick() completed a stale session")
+	}
+	if got, _ := store.Sessions().FindByID(ctx, stale.ID); got.Status != domain.SessionStatusRunning {
+		t.Errorf("stale session status = %v, want running", got.Status)
+	}
+	clearSessions(t, store, ctx)
+
+	// With a TUI attached the daemon records the completion only.
+	daemon.SetAttached(func() bool { return true })
+	saveStarted(t, store, 25*time.Minute, 26*time.Minute)
+	if done, err := daemon.Tick(ctx, time.Now()); err != nil || done == nil {
+		t.Fatalf("Tick() attached = %v, %v; want the session completed", done, err)
+	}
+	if len(notifier.work) != 1 {
+		t.Errorf("work notifications while attached = %v, want none added", notifier.work)
+	}
+	if active, _ := store.Sessions().FindActive(ctx); active != nil {
+		t.Errorf("active session while attached = %v, want no auto-break", active)
+	}
+}
+
+func TestDaemonService_ExpiresOnce(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	hooks := &recordingHooks{}
+	pomodoro := NewPomodoroService(store, nil)
+	pomodoro.AddHooks(hooks)
+	notifier := &recordingNotifier{}
+	daemon := NewDaemonService(store, pomodoro, notifier)
+	daemon.SetAutoBreak(true)
+
+	// The TUI read the session before the daemon completed it
+	expired := saveStarted(t, store, 25*time.Minute, 26*time.Minute)
+	seenByTUI, _ := store.Sessions().FindByID(ctx, expired.ID)
+
+	if done, err := daemon.Tick(ctx, time.Now()); err != nil || done == nil {
+		t.Fatalf("Tick() = %v, %v; want the session completed", done, err)
+	}
+	if err := pomodoro.ExpireSession(ctx, seenByTUI); !errors.Is(err, domain.ErrNoActiveSession) {
+		t.Errorf("second ExpireSession() error = %v, want ErrNoActiveSession", err)
+	}
+
+	if len(hooks.events) != 2 || hooks.events[0] != domain.HookComplete || hooks.events[1] != domain.HookBreakStart {
+		t.Errorf("hook events = %v, want one completion and the auto-break", hooks.events)
+	}
+	if len(notifier.work) != 1 {
+		t.Errorf("work notifications = %v, want one", notifier.work)
+	}
+	got, _ := store.Sessions().FindByID(ctx, expired.ID)
+	stops := 0
+	for _, e := range got.Events {
+		if e.Type == domain.SessionEventStop {
+			stops++
+		}
+	}
+	if stops != 1 {
+		t.Errorf("stop events = %d, want 1", stops)
+	}
+}
+
+// TestDaemonService_WatchingWhileTicking reads the watched session the way
+// the daemon's status socket does while Tick completes it; run with -race.
+func TestDaemonService_WatchingWhileTicking(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	daemon := NewDaemonService(store, NewPomodoroService(store, nil), nil)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if session := daemon.Watching(); session != nil {
+				_ = session.RemainingTime()
+				_ = session.Status
+			}
+		}
+	}()
+
+	for i := 0; i < 20; i++ {
+		saveStarted(t, store, 25*time.Minute, 26*time.Minute)
+		if _, err := daemon.Tick(ctx, time.Now()); err != nil {
+			t.Fatalf("Tick() error = %v", err)
+		}
+	}
+	close(done)
+	<-stopped
+}
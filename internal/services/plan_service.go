@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// PlanService handles the time-block plan of each day.
+type PlanService struct {
+	storage ports.Storage
+}
+
+// NewPlanService creates a new plan service.
+func NewPlanService(storage ports.Storage) *PlanService {
+	return &PlanService{storage: storage}
+}
+
+// Day returns the blocks planned for day, in start order.
+func (s *PlanService) Day(ctx context.Context, day time.Time) ([]*domain.PlanBlock, error) {
+	blocks, err := s.storage.Plans().FindByDay(ctx, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plan: %w", err)
+	}
+	return blocks, nil
+}
+
+// SaveDay replaces the plan for day with blocks, which must not overlap.
+func (s *PlanService) SaveDay(ctx context.Context, day time.Time, blocks []*domain.PlanBlock) error {
+	domain.SortPlan(blocks)
+	if err := domain.ValidatePlan(blocks); err != nil {
+		return err
+	}
+	for _, b := range blocks {
+		b.Tags = domain.NormalizeTags(b.Tags)
+	}
+	if err := s.storage.Plans().ReplaceDay(ctx, day, blocks); err != nil {
+		return fmt.Errorf("failed to save plan: %w", err)
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 type PomodoroService struct {
 	storage     ports.Storage
 	gitDetector ports.GitDetector
+	hooks       []ports.HookRunner
 	config      domain.PomodoroConfig
 }
 
@@ -30,6 +31,27 @@ func (s *PomodoroService) SetConfig(config domain.PomodoroConfig) {
 	s.config = config
 }
 
+// AddHooks adds a runner told about every session lifecycle event, such as
+// the user's [hooks] commands or webhooks.
+func (s *PomodoroService) AddHooks(hooks ports.HookRunner) {
+	s.hooks = append(s.hooks, hooks)
+}
+
+// runHook tells every hook runner about event. Hooks never fail the session
+// change that triggered them; runners log or queue their own failures.
+func (s *PomodoroService) runHook(ctx context.Context, event domain.HookEvent, session *domain.PomodoroSession) {
+	if len(s.hooks) == 0 {
+		return
+	}
+	var task *domain.Task
+	if session.TaskID != nil {
+		task, _ = s.storage.Tasks().FindByID(ctx, *session.TaskID)
+	}
+	for _, hooks := range s.hooks {
+		_ = hooks.Run(ctx, event, session, task)
+	}
+}
+
 // StartPomodoroRequest contains data to start a work session.
 type StartPomodoroRequest struct {
 	TaskID          *string
@@ -38,6 +60,7 @@ type StartPomodoroRequest struct {
 	Methodology     domain.Methodology
 	IntendedOutcome string
 	Tags            []string
+	BreakAfter      time.Duration // the break that follows, in place of the methodology's
 }
 
 // StartPomodoro begins a new pomodoro work session.
@@ -66,10 +89,14 @@ func (s *PomodoroService) StartPomodoro(ctx context.Context, req StartPomodoroRe
 	// Create and save the session
 	session := domain.NewPomodoroSession(s.config, req.TaskID)
 
-	// Apply custom duration if provided
+	// Apply custom duration if provided; Flowtime sessions count up instead
 	if req.Duration > 0 {
 		session.Duration = req.Duration
 	}
+	if req.Methodology.CountsUp() {
+		session.Duration = 0
+		session.OpenEnded = true
+	}
 
 	// Set methodology fields
 	if req.Methodology != "" {
@@ -77,6 +104,12 @@ func (s *PomodoroService) StartPomodoro(ctx context.Context, req StartPomodoroRe
 	}
 	session.IntendedOutcome = req.IntendedOutcome
 	session.Tags = req.Tags
+	session.BreakAfter = req.BreakAfter
+
+	// Each mob rotation passes the keyboard on, carrying on the mob's task
+	if session.Methodology == domain.MethodologyMob {
+		s.assignRotation(ctx, session)
+	}
 
 	// Detect git context if available
 	if s.gitDetector != nil && s.gitDetector.IsAvailable() {
@@ -90,6 +123,7 @@ func (s *PomodoroService) StartPomodoro(ctx context.Context, req StartPomodoroRe
 		return nil, fmt.Errorf("failed to save session: %w", err)
 	}
 
+	s.runHook(ctx, domain.HookStart, session)
 	return session, nil
 }
 
@@ -110,15 +144,112 @@ func (s *PomodoroService) StartBreak(ctx context.Context, workingDir string) (*d
 		stats = &domain.DailyStats{}
 	}
 
-	session := domain.NewBreakSession(s.config, stats.WorkSessions)
+	count := stats.WorkSessions
+	if last, rotations := s.lastRotation(ctx); last != nil && rotations > 0 {
+		// A mob takes its long break every SessionsBeforeLong rotations
+		count = rotations
+	}
+	session := domain.NewBreakSession(s.config, count)
+
+	// After a Flowtime session the break is earned from the time worked;
+	// a session started from a preset with its own break takes that one
+	if last := s.lastFinishedSession(ctx); last != nil && last.IsWorkSession() {
+		if last.Methodology.CountsUp() {
+			session = domain.NewEarnedBreakSession(s.config.EarnedBreaks, last.Duration)
+		}
+		if last.BreakAfter > 0 {
+			session.Duration = last.BreakAfter
+		}
+	}
 
 	if err := s.storage.Sessions().Save(ctx, session); err != nil {
 		return nil, fmt.Errorf("failed to save break session: %w", err)
 	}
 
+	s.runHook(ctx, domain.HookBreakStart, session)
 	return session, nil
 }
 
+// NextRotation returns who drives and navigates the next mob rotation,
+// and how many rotations the mob has worked since its last break.
+func (s *PomodoroService) NextRotation(ctx context.Context) (domain.Rotation, int) {
+	last, rotations := s.lastRotation(ctx)
+	var lastDriver string
+	if last != nil {
+		lastDriver = last.Driver
+	}
+	return domain.NextRotation(s.config.Roster, lastDriver), rotations
+}
+
+// assignRotation gives session to the next driver of the roster. Without a
+// task of its own it carries on the task and tags of the last rotation,
+// unless that task is finished.
+func (s *PomodoroService) assignRotation(ctx context.Context, session *domain.PomodoroSession) {
+	last, _ := s.lastRotation(ctx)
+	var lastDriver string
+	if last != nil {
+		lastDriver = last.Driver
+		if session.TaskID == nil && last.TaskID != nil {
+			if task, err := s.storage.Tasks().FindByID(ctx, *last.TaskID); err == nil && task.Status != domain.StatusCompleted && task.Status != domain.StatusCancelled {
+				session.TaskID = last.TaskID
+				if len(session.Tags) == 0 {
+					session.Tags = last.Tags
+				}
+			}
+		}
+	}
+	session.SetRotation(domain.NextRotation(s.config.Roster, lastDriver), s.config.Roster)
+}
+
+// lastRotation returns the latest mob rotation of the last day, running or
+// completed, and how many rotations ran since the last break or session
+// in another methodology. Cancelled and voided rotations don't count, so
+// their driver goes again.
+func (s *PomodoroService) lastRotation(ctx context.Context) (*domain.PomodoroSession, int) {
+	recent, err := s.storage.Sessions().FindRecent(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		return nil, 0
+	}
+	var last *domain.PomodoroSession
+	rotations := 0
+	counting := true
+	for _, session := range recent {
+		if session.Status == domain.SessionStatusCancelled || session.Status == domain.SessionStatusInterrupted {
+			continue
+		}
+		isRotation := session.IsWorkSession() && session.Methodology == domain.MethodologyMob
+		if !isRotation {
+			if last != nil {
+				break
+			}
+			counting = false
+			continue
+		}
+		if last == nil {
+			last = session
+		}
+		if counting {
+			rotations++
+		}
+	}
+	return last, rotations
+}
+
+// lastFinishedSession returns the most recent completed session of the
+// last day, or nil if there is none.
+func (s *PomodoroService) lastFinishedSession(ctx context.Context) *domain.PomodoroSession {
+	recent, err := s.storage.Sessions().FindRecent(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		return nil
+	}
+	for _, session := range recent {
+		if session.Status == domain.SessionStatusCompleted {
+			return session
+		}
+	}
+	return nil
+}
+
 // PauseSession pauses the active session.
 func (s *PomodoroService) PauseSession(ctx context.Context) (*domain.PomodoroSession, error) {
 	session, err := s.storage.Sessions().FindActive(ctx)
@@ -134,6 +265,7 @@ func (s *PomodoroService) PauseSession(ctx context.Context) (*domain.PomodoroSes
 		return nil, fmt.Errorf("failed to update session: %w", err)
 	}
 
+	s.runHook(ctx, domain.HookPause, session)
 	return session, nil
 }
 
@@ -152,6 +284,7 @@ func (s *PomodoroService) ResumeSession(ctx context.Context) (*domain.PomodoroSe
 		return nil, fmt.Errorf("failed to update session: %w", err)
 	}
 
+	s.runHook(ctx, domain.HookResume, session)
 	return session, nil
 }
 
@@ -165,16 +298,17 @@ func (s *PomodoroService) StopSession(ctx context.Context) (*domain.PomodoroSess
 		return nil, domain.ErrNoActiveSession
 	}
 
-	// Record actual elapsed time if session was stopped early (minimum 1s to filter test/immediate stops)
-	elapsed := time.Since(session.StartedAt)
-	if elapsed >= time.Second && elapsed < session.Duration {
+	// Record actual elapsed time if session was stopped early (minimum 1s to filter test/immediate stops).
+	// Paused time is not work time, so it is left out. Open-ended sessions
+	// always take the time they counted up to.
+	elapsed := session.ElapsedTime()
+	if session.IsOpenEnded() || (elapsed >= time.Second && elapsed < session.Duration) {
 		session.Duration = elapsed
 	}
 	session.Complete()
-	if err := s.storage.Sessions().Update(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to update session: %w", err)
+	if err := s.finish(ctx, session); err != nil {
+		return nil, err
 	}
-
 	return session, nil
 }
 
@@ -189,7 +323,7 @@ func (s *PomodoroService) CancelSession(ctx context.Context) error {
 	}
 
 	session.Cancel()
-	return s.storage.Sessions().Update(ctx, session)
+	return s.finish(ctx, session)
 }
 
 // VoidSession marks the active session as interrupted (voided).
@@ -204,13 +338,58 @@ func (s *PomodoroService) VoidSession(ctx context.Context) (*domain.PomodoroSess
 	}
 
 	session.Interrupt()
-	if err := s.storage.Sessions().Update(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to void session: %w", err)
+	if err := s.finish(ctx, session); err != nil {
+		return nil, err
 	}
+	return session, nil
+}
 
+// ExpireSession completes a session whose time ran out, at its planned end.
+// It returns domain.ErrNoActiveSession when the session was already ended
+// elsewhere, e.g. by the daemon.
+func (s *PomodoroService) ExpireSession(ctx context.Context, session *domain.PomodoroSession) error {
+	session.Expire()
+	return s.finish(ctx, session)
+}
+
+// finish saves a session that has just ended and runs its end hook. Only
+// the first caller to end a session finishes it; the rest get
+// domain.ErrNoActiveSession and run no hooks.
+func (s *PomodoroService) finish(ctx context.Context, session *domain.PomodoroSession) error {
+	finished, err := s.storage.Sessions().Finish(ctx, session)
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	if !finished {
+		return domain.ErrNoActiveSession
+	}
+
+	s.runHook(ctx, session.EndHook(), session)
+	return nil
+}
+
+// FindStaleSession returns the active session if it is still marked running
+// well past its planned end, or nil if there is none.
+func (s *PomodoroService) FindStaleSession(ctx context.Context) (*domain.PomodoroSession, error) {
+	session, err := s.storage.Sessions().FindActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active session: %w", err)
+	}
+	if session == nil || !session.IsStale(time.Now()) {
+		return nil, nil
+	}
 	return session, nil
 }
 
+// RecoverSession closes a stale session: keeping its full duration,
+// crediting only the given work time, or voiding it.
+func (s *PomodoroService) RecoverSession(ctx context.Context, session *domain.PomodoroSession, action domain.StaleAction, credit time.Duration) error {
+	if err := session.Recover(action, credit); err != nil {
+		return err
+	}
+	return s.finish(ctx, session)
+}
+
 // LogDistraction appends a distraction entry to the active session.
 func (s *PomodoroService) LogDistraction(ctx context.Context, sessionID string, text string, category string) error {
 	session, err := s.storage.Sessions().FindByID(ctx, sessionID)
@@ -220,10 +399,30 @@ func (s *PomodoroService) LogDistraction(ctx context.Context, sessionID string,
 	if session == nil {
 		return domain.ErrNoActiveSession
 	}
-	session.Distractions = append(session.Distractions, domain.Distraction{Text: text, Category: category})
+	session.LogDistraction(text, category, time.Now())
 	return s.storage.Sessions().Update(ctx, session)
 }
 
+// LogActiveDistraction appends a distraction entry to the active work
+// session, for logging from outside the timer.
+func (s *PomodoroService) LogActiveDistraction(ctx context.Context, text string, category string) (*domain.PomodoroSession, error) {
+	session, err := s.storage.Sessions().FindActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active session: %w", err)
+	}
+	if session == nil {
+		return nil, domain.ErrNoActiveSession
+	}
+	if session.Type != domain.SessionTypeWork {
+		return nil, domain.ErrNotWorkSession
+	}
+	session.LogDistraction(text, category, time.Now())
+	if err := s.storage.Sessions().Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+	return session, nil
+}
+
 // SetAccomplishment records the accomplishment text on a session (Deep Work shutdown ritual).
 func (s *PomodoroService) SetAccomplishment(ctx context.Context, sessionID string, text string) error {
 	session, err := s.storage.Sessions().FindByID(ctx, sessionID)
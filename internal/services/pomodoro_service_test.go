@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -160,6 +162,157 @@ func TestPomodoroService_StopSession(t *testing.T) {
 	})
 }
 
+func TestPomodoroService_Flowtime(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	service := NewPomodoroService(store, nil)
+	service.SetConfig(domain.PomodoroConfig{
+		WorkDuration:       25 * time.Minute,
+		ShortBreakDuration: 5 * time.Minute,
+		LongBreakDuration:  15 * time.Minute,
+		SessionsBeforeLong: 4,
+		EarnedBreaks:       domain.EarnedBreakPolicy{Ratio: 0.2},
+	})
+	ctx := context.Background()
+	clearSessions(t, store, ctx)
+
+	session, err := service.StartPomodoro(ctx, StartPomodoroRequest{
+		Duration:    25 * time.Minute,
+		Methodology: domain.MethodologyFlowtime,
+	})
+	if err != nil {
+		t.Fatalf("StartPomodoro() error = %v", err)
+	}
+	if !session.IsOpenEnded() {
+		t.Fatalf("Flowtime session Duration = %v, want open-ended", session.Duration)
+	}
+
+	// Pretend the session has been counting up for 50 minutes.
+	session.StartedAt = time.Now().Add(-50 * time.Minute).Round(0)
+	session.Events = []domain.SessionEvent{{Type: domain.SessionEventStart, At: session.StartedAt}}
+	if err := store.Sessions().Update(ctx, session); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	stopped, err := service.StopSession(ctx)
+	if err != nil {
+		t.Fatalf("StopSession() error = %v", err)
+	}
+	if stopped.Duration.Round(time.Minute) != 50*time.Minute {
+		t.Errorf("StopSession() Duration = %v, want the 50m worked", stopped.Duration)
+	}
+
+	brk, err := service.StartBreak(ctx, ".")
+	if err != nil {
+		t.Fatalf("StartBreak() error = %v", err)
+	}
+	if brk.Duration != 10*time.Minute {
+		t.Errorf("StartBreak() after 50m of Flowtime = %v, want a 10m earned break", brk.Duration)
+	}
+}
+
+func TestPomodoroService_BreakAfter(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	service := NewPomodoroService(store, nil)
+	ctx := context.Background()
+	clearSessions(t, store, ctx)
+
+	if _, err := service.StartPomodoro(ctx, StartPomodoroRequest{
+		Duration:   90 * time.Minute,
+		BreakAfter: 20 * time.Minute,
+	}); err != nil {
+		t.Fatalf("StartPomodoro() error = %v", err)
+	}
+	if _, err := service.StopSession(ctx); err != nil {
+		t.Fatalf("StopSession() error = %v", err)
+	}
+
+	brk, err := service.StartBreak(ctx, ".")
+	if err != nil {
+		t.Fatalf("StartBreak() error = %v", err)
+	}
+	if brk.Duration != 20*time.Minute {
+		t.Errorf("StartBreak() after a preset with its own break = %v, want 20m", brk.Duration)
+	}
+}
+
+func TestPomodoroService_MobRotation(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	service := NewPomodoroService(store, nil)
+	config := domain.DefaultPomodoroConfig()
+	config.WorkDuration = 10 * time.Minute
+	config.SessionsBeforeLong = 2
+	config.Roster = []string{"Ana", "Ben", "Cy"}
+	service.SetConfig(config)
+	ctx := context.Background()
+	clearSessions(t, store, ctx)
+
+	task, err := NewTaskService(store).AddTask(ctx, AddTaskRequest{Title: "Kata"})
+	if err != nil {
+		t.Fatalf("AddTask() error = %v", err)
+	}
+
+	rotate := func(req StartPomodoroRequest) *domain.PomodoroSession {
+		t.Helper()
+		req.Methodology = domain.MethodologyMob
+		session, err := service.StartPomodoro(ctx, req)
+		if err != nil {
+			t.Fatalf("StartPomodoro() error = %v", err)
+		}
+		if _, err := service.StopSession(ctx); err != nil {
+			t.Fatalf("StopSession() error = %v", err)
+		}
+		return session
+	}
+
+	first := rotate(StartPomodoroRequest{TaskID: &task.ID, Tags: []string{"kata"}})
+	if first.Driver != "Ana" || first.Navigator != "Ben" || len(first.Participants) != 3 {
+		t.Errorf("first rotation = %s/%s %v, want Ana driving, Ben navigating, the whole mob", first.Driver, first.Navigator, first.Participants)
+	}
+	second := rotate(StartPomodoroRequest{})
+	if second.Driver != "Ben" || second.Navigator != "Cy" {
+		t.Errorf("second rotation = %s/%s, want Ben driving, Cy navigating", second.Driver, second.Navigator)
+	}
+	if second.TaskID == nil || *second.TaskID != task.ID || len(second.Tags) != 1 {
+		t.Errorf("second rotation task = %v tags = %v, want the mob's task and tags carried on", second.TaskID, second.Tags)
+	}
+
+	next, rotations := service.NextRotation(ctx)
+	if next.Driver != "Cy" || next.Navigator != "Ana" || rotations != 2 {
+		t.Errorf("NextRotation() = %+v, %d; want Cy driving, Ana navigating after 2 rotations", next, rotations)
+	}
+
+	brk, err := service.StartBreak(ctx, ".")
+	if err != nil {
+		t.Fatalf("StartBreak() error = %v", err)
+	}
+	if brk.Type != domain.SessionTypeLongBreak {
+		t.Errorf("break after 2 rotations = %s, want the long break", brk.Type)
+	}
+	if _, err := service.StopSession(ctx); err != nil {
+		t.Fatalf("StopSession() error = %v", err)
+	}
+	if next, rotations := service.NextRotation(ctx); next.Driver != "Cy" || rotations != 0 {
+		t.Errorf("NextRotation() after the break = %+v, %d; want Cy driving with no rotations yet", next, rotations)
+	}
+
+	// A voided rotation leaves its driver up next
+	if _, err := service.StartPomodoro(ctx, StartPomodoroRequest{Methodology: domain.MethodologyMob}); err != nil {
+		t.Fatalf("StartPomodoro() error = %v", err)
+	}
+	if _, err := service.VoidSession(ctx); err != nil {
+		t.Fatalf("VoidSession() error = %v", err)
+	}
+	if next, _ := service.NextRotation(ctx); next.Driver != "Cy" {
+		t.Errorf("NextRotation() after a voided rotation = %+v, want Cy again", next)
+	}
+}
+
 func TestPomodoroService_CancelSession(t *testing.T) {
 	store, cleanup := setupTestStorage(t)
 	defer cleanup()
@@ -308,6 +461,50 @@ func TestPomodoroService_LogDistraction_WithCategory(t *testing.T) {
 	}
 }
 
+func TestPomodoroService_LogActiveDistraction(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	service := NewPomodoroService(store, nil)
+	ctx := context.Background()
+
+	clearSessions(t, store, ctx)
+	if _, err := service.LogActiveDistraction(ctx, "slack ping", ""); !errors.Is(err, domain.ErrNoActiveSession) {
+		t.Fatalf("LogActiveDistraction() without a session error = %v, want ErrNoActiveSession", err)
+	}
+
+	started, err := service.StartPomodoro(ctx, StartPomodoroRequest{})
+	if err != nil {
+		t.Fatalf("StartPomodoro() error = %v", err)
+	}
+	// Ten minutes in, five of them paused
+	started.StartedAt = started.StartedAt.Add(-10 * time.Minute)
+	started.Events = []domain.SessionEvent{
+		{Type: domain.SessionEventStart, At: started.StartedAt},
+		{Type: domain.SessionEventPause, At: started.StartedAt.Add(2 * time.Minute)},
+		{Type: domain.SessionEventResume, At: started.StartedAt.Add(7 * time.Minute)},
+	}
+	if err := store.Sessions().Update(ctx, started); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	session, err := service.LogActiveDistraction(ctx, "slack ping", "external")
+	if err != nil {
+		t.Fatalf("LogActiveDistraction() error = %v", err)
+	}
+	found, _ := store.Sessions().FindByID(ctx, session.ID)
+	if len(found.Distractions) != 1 {
+		t.Fatalf("got %d distractions, want 1", len(found.Distractions))
+	}
+	d := found.Distractions[0]
+	if d.Text != "slack ping" || d.Category != "external" || d.At.IsZero() {
+		t.Errorf("distraction = %+v, want a timed external slack ping", d)
+	}
+	if offset := d.Offset.Round(time.Minute); offset != 5*time.Minute {
+		t.Errorf("Offset = %v, want 5m of active time", offset)
+	}
+}
+
 func TestPomodoroService_SetShutdownRitual(t *testing.T) {
 	store, cleanup := setupTestStorage(t)
 	defer cleanup()
@@ -391,3 +588,161 @@ func TestPomodoroService_GetDeepWorkStreak_CustomThreshold(t *testing.T) {
 		t.Errorf("streak = %d, want 0 with default 4h threshold", streak)
 	}
 }
+
+func TestStateService_ExpiryOwner(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	clearSessions(t, store, ctx)
+	hooks := &recordingHooks{}
+	pomodoro := NewPomodoroService(store, nil)
+	pomodoro.AddHooks(hooks)
+	state := NewStateService(store)
+	state.SetPomodoroService(pomodoro)
+
+	// With a daemon running, reading the state leaves expiry to it.
+	daemonUp := true
+	state.SetDaemonCheck(func() bool { return daemonUp })
+	expired := saveStarted(t, store, 25*time.Minute, 26*time.Minute)
+	for i := 0; i < 3; i++ {
+		if _, err := state.GetCurrentState(ctx); err != nil {
+			t.Fatalf("GetCurrentState() error = %v", err)
+		}
+	}
+	if got, _ := store.Sessions().FindByID(ctx, expired.ID); got.Status != domain.SessionStatusRunning || len(hooks.events) != 0 {
+		t.Fatalf("with a daemon: status %v, hooks %v; want the session left running", got.Status, hooks.events)
+	}
+
+	// Without one, the first reader completes it and later reads do nothing.
+	daemonUp = false
+	for i := 0; i < 3; i++ {
+		current, err := state.GetCurrentState(ctx)
+		if err != nil || current.ActiveSession != nil {
+			t.Fatalf("GetCurrentState() = %v, %v; want the session completed", current.ActiveSession, err)
+		}
+	}
+	if len(hooks.events) != 1 || hooks.events[0] != domain.HookComplete {
+		t.Errorf("hook events = %v, want one completion", hooks.events)
+	}
+}
+
+func TestStateService_StalePolicy(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	pomodoro := NewPomodoroService(store, nil)
+	state := NewStateService(store)
+
+	// saveStale stores a 25-minute session started two hours ago.
+	saveStale := func() *domain.PomodoroSession {
+		clearSessions(t, store, ctx)
+		session := domain.NewPomodoroSession(domain.PomodoroConfig{WorkDuration: 25 * time.Minute}, nil)
+		session.StartedAt = time.Now().Add(-2 * time.Hour).Round(0)
+		session.Events[0].At = session.StartedAt
+		if err := store.Sessions().Save(ctx, session); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		return session
+	}
+
+	saveStale()
+	current, err := state.GetCurrentState(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentState() error = %v", err)
+	}
+	if current.ActiveSession == nil {
+		t.Fatal("GetCurrentState() with the ask policy resolved the stale session")
+	}
+	stale, err := pomodoro.FindStaleSession(ctx)
+	if err != nil || stale == nil {
+		t.Fatalf("FindStaleSession() = %v, %v; want the stale session", stale, err)
+	}
+
+	if err := pomodoro.RecoverSession(ctx, stale, domain.StaleActionCredit, 10*time.Minute); err != nil {
+		t.Fatalf("RecoverSession() error = %v", err)
+	}
+	got, _ := store.Sessions().FindByID(ctx, stale.ID)
+	if got.Status != domain.SessionStatusCompleted || got.Duration != 10*time.Minute {
+		t.Errorf("recovered session = %v, %v; want completed, 10m", got.Status, got.Duration)
+	}
+
+	state.SetStalePolicy(domain.StaleActionVoid)
+	voided := saveStale()
+	current, err = state.GetCurrentState(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentState() error = %v", err)
+	}
+	if current.ActiveSession != nil {
+		t.Error("GetCurrentState() with the void policy kept the stale session active")
+	}
+	got, _ = store.Sessions().FindByID(ctx, voided.ID)
+	if got.Status != domain.SessionStatusInterrupted {
+		t.Errorf("stale session status = %v, want interrupted", got.Status)
+	}
+}
+
+type recordingHooks struct {
+	events []domain.HookEvent
+	tasks  []string
+}
+
+func (h *recordingHooks) Run(ctx context.Context, event domain.HookEvent, session *domain.PomodoroSession, task *domain.Task) error {
+	h.events = append(h.events, event)
+	if task != nil {
+		h.tasks = append(h.tasks, task.Title)
+	}
+	return nil
+}
+
+func TestPomodoroService_Hooks(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	clearSessions(t, store, ctx)
+	hooks := &recordingHooks{}
+	service := NewPomodoroService(store, nil)
+	service.AddHooks(hooks)
+
+	task, _ := domain.NewTask("Write docs")
+	if err := store.Tasks().Save(ctx, task); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	steps := []func() error{
+		func() error { _, err := service.StartPomodoro(ctx, StartPomodoroRequest{TaskID: &task.ID}); return err },
+		func() error { _, err := service.PauseSession(ctx); return err },
+		func() error { _, err := service.ResumeSession(ctx); return err },
+		func() error { _, err := service.StopSession(ctx); return err },
+		func() error { _, err := service.StartBreak(ctx, ""); return err },
+		func() error { return service.CancelSession(ctx) },
+		func() error { _, err := service.StartPomodoro(ctx, StartPomodoroRequest{}); return err },
+		func() error { _, err := service.VoidSession(ctx); return err },
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("step %d error = %v", i, err)
+		}
+	}
+
+	// A session whose time ran out completes through ExpireSession.
+	expired := saveStarted(t, store, 25*time.Minute, 26*time.Minute)
+	if err := service.ExpireSession(ctx, expired); err != nil {
+		t.Fatalf("ExpireSession() error = %v", err)
+	}
+
+	want := []domain.HookEvent{
+		domain.HookStart, domain.HookPause, domain.HookResume, domain.HookComplete,
+		domain.HookBreakStart, domain.HookBreakEnd,
+		domain.HookStart, domain.HookVoid,
+		domain.HookComplete,
+	}
+	if fmt.Sprint(hooks.events) != fmt.Sprint(want) {
+		t.Errorf("hook events = %v, want %v", hooks.events, want)
+	}
+	if len(hooks.tasks) != 4 || hooks.tasks[0] != "Write docs" {
+		t.Errorf("hook tasks = %v, want the task for the first session's 4 events", hooks.tasks)
+	}
+}
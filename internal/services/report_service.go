@@ -0,0 +1,772 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// hourlyProductivityDays is how far back the stats report looks for the
+// most productive hours of the day.
+const hourlyProductivityDays = 30
+
+// ReportService computes the stats and reflection reports, so the CLI
+// dashboards, their --json output and the MCP server share one set of
+// aggregates.
+type ReportService struct {
+	storage ports.Storage
+}
+
+// NewReportService creates a report service.
+func NewReportService(storage ports.Storage) *ReportService {
+	return &ReportService{storage: storage}
+}
+
+// Stats builds the stats report for a period.
+func (s *ReportService) Stats(ctx context.Context, q ports.StatsQuery) (*ports.StatsReport, error) {
+	sessions := s.storage.Sessions()
+
+	stats, err := s.periodStats(ctx, q.Start, q.End, q.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ports.StatsReport{
+		Label:           q.Label,
+		Start:           q.Start.Format("2006-01-02"),
+		End:             q.End.AddDate(0, 0, -1).Format("2006-01-02"),
+		TotalSessions:   stats.TotalSessions,
+		WorkSeconds:     seconds(stats.TotalWorkTime),
+		ByMethodology:   make([]ports.MethodologyStat, 0, len(stats.ByMethodology)),
+		FocusScoreCount: stats.FocusScoreCount,
+		Distractions:    stats.DistractionCount,
+		Pauses:          stats.PauseCount,
+		PausedSeconds:   seconds(stats.PausedTime),
+		Hourly:          []ports.HourStat{},
+		Tags:            []ports.TagTime{},
+		Energize:        []ports.EnergizeStat{},
+		Drivers:         []ports.DriverTime{},
+	}
+	if q.Tag != "" {
+		tag := domain.NormalizeTag(q.Tag)
+		report.Tag = &tag
+	}
+	if stats.FocusScoreCount > 0 {
+		report.AvgFocusScore = &stats.AvgFocusScore
+	}
+	for _, m := range stats.ByMethodology {
+		report.ByMethodology = append(report.ByMethodology, ports.MethodologyStat{
+			Methodology: string(m.Methodology),
+			Sessions:    m.SessionCount,
+			WorkSeconds: seconds(m.TotalTime),
+		})
+	}
+
+	// Hourly productivity is not tracked per tag, so it is left out of
+	// tag-filtered reports. It is a nice-to-have, so errors are ignored.
+	if q.Tag == "" {
+		hourly, _ := sessions.GetHourlyProductivity(ctx, hourlyProductivityDays)
+		for h := 0; h < 24; h++ {
+			if d, ok := hourly[h]; ok {
+				report.Hourly = append(report.Hourly, ports.HourStat{Hour: h, WorkSeconds: seconds(d)})
+			}
+		}
+	}
+
+	if q.ByTag {
+		tagStats, err := s.storage.Tags().Stats(ctx, q.Start, q.End)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tag stats: %w", err)
+		}
+		for _, t := range tagStats {
+			if q.Tag != "" && !domain.TagMatches(t.Tag, q.Tag) {
+				continue
+			}
+			report.Tags = append(report.Tags, ports.TagTime{
+				Tag:         t.Tag,
+				Sessions:    t.SessionCount,
+				WorkSeconds: seconds(t.TotalTime),
+			})
+		}
+	}
+
+	if energize, err := sessions.GetEnergizeStats(ctx, q.Start, q.End); err == nil {
+		report.Energize = energizeStats(energize)
+	}
+
+	if drivers, err := sessions.GetDriverStats(ctx, q.Start, q.End, q.Tag); err == nil {
+		for _, d := range drivers {
+			report.Drivers = append(report.Drivers, ports.DriverTime{
+				Driver:      d.Driver,
+				Rotations:   d.Rotations,
+				WorkSeconds: seconds(d.TotalTime),
+			})
+		}
+	}
+
+	if q.Philosophy != "" {
+		report.DeepWork = s.deepWorkStats(ctx, q)
+	}
+
+	if q.Compare != nil {
+		prev, err := s.periodStats(ctx, q.Compare.Start, q.Compare.End, q.Tag)
+		if err != nil {
+			return nil, err
+		}
+		report.Previous = &ports.PeriodSummary{
+			Label:           q.Compare.Label,
+			Start:           q.Compare.Start.Format("2006-01-02"),
+			End:             q.Compare.End.AddDate(0, 0, -1).Format("2006-01-02"),
+			TotalSessions:   prev.TotalSessions,
+			WorkSeconds:     seconds(prev.TotalWorkTime),
+			FocusScoreCount: prev.FocusScoreCount,
+			Distractions:    prev.DistractionCount,
+		}
+		if prev.FocusScoreCount > 0 {
+			report.Previous.AvgFocusScore = &prev.AvgFocusScore
+		}
+	}
+	return report, nil
+}
+
+// periodStats loads the stats of [start, end), only counting sessions
+// tagged with tag when it is set.
+func (s *ReportService) periodStats(ctx context.Context, start, end time.Time, tag string) (*domain.PeriodStats, error) {
+	var stats *domain.PeriodStats
+	var err error
+	if tag != "" {
+		stats, err = s.storage.Sessions().GetTaggedPeriodStats(ctx, start, end, tag)
+	} else {
+		stats, err = s.storage.Sessions().GetPeriodStats(ctx, start, end)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+	return stats, nil
+}
+
+// deepWorkStats gathers the numbers a Deep Work philosophy is judged by.
+// They are context for the dashboard, so lookup errors leave them zero.
+func (s *ReportService) deepWorkStats(ctx context.Context, q ports.StatsQuery) *ports.DeepWorkStats {
+	sessions := s.storage.Sessions()
+	dw := &ports.DeepWorkStats{Philosophy: q.Philosophy}
+	switch q.Philosophy {
+	case "rhythmic":
+		// Consecutive days with any deep work
+		dw.StreakDays, _ = sessions.GetDeepWorkStreak(ctx, 1*time.Minute)
+	case "bimodal", "journalistic":
+		prevWeek, _ := sessions.GetDeepWorkHours(ctx, q.Start.AddDate(0, 0, -7), q.Start)
+		dw.PreviousWeekSeconds = seconds(prevWeek)
+	case "monastic":
+		monthStart, monthEnd, _ := domain.PeriodMonth.Bounds(time.Now())
+		month, _ := sessions.GetDeepWorkHours(ctx, monthStart, monthEnd)
+		dw.MonthSeconds = seconds(month)
+	}
+	return dw
+}
+
+// WeeklyReflection builds the reflection for the week containing now, from
+// Monday to today. When the daily totals fail to load, days are left out.
+func (s *ReportService) WeeklyReflection(ctx context.Context, now time.Time) *ports.WeeklyReflection {
+	weekStart, weekEnd, _ := domain.PeriodWeek.Bounds(now)
+	report := &ports.WeeklyReflection{
+		WeekStart:  weekStart.Format("2006-01-02"),
+		Days:       []ports.ReflectionDay{},
+		Highlights: []ports.HighlightEntry{},
+		Energize:   []ports.EnergizeStat{},
+	}
+
+	totals, totalsErr := s.storage.Sessions().GetDailyTotals(ctx, weekStart, weekEnd)
+	byDay := make(map[string]domain.DayTotal, len(totals))
+	for _, t := range totals {
+		byDay[t.Date.Format("2006-01-02")] = t
+	}
+	// Highlights come newest first within a day; the first one is kept.
+	highlights, _ := s.storage.Tasks().FindHighlights(ctx, weekStart, weekEnd)
+	highlightByDay := make(map[string]*domain.Task, len(highlights))
+	for _, h := range highlights {
+		date := h.HighlightDate.In(weekStart.Location()).Format("2006-01-02")
+		if _, ok := highlightByDay[date]; !ok {
+			highlightByDay[date] = h
+		}
+	}
+
+	for i := 0; i < 7; i++ {
+		day := weekStart.AddDate(0, 0, i)
+		if day.After(now) {
+			break
+		}
+		date := day.Format("2006-01-02")
+		if totalsErr == nil {
+			total := byDay[date]
+			report.Days = append(report.Days, ports.ReflectionDay{
+				Date:         date,
+				Day:          day.Format("Mon"),
+				WorkSessions: total.WorkSessions,
+				WorkSeconds:  seconds(total.WorkTime),
+			})
+			report.TotalSessions += total.WorkSessions
+			report.WorkSeconds += seconds(total.WorkTime)
+		}
+		if highlight, ok := highlightByDay[date]; ok {
+			report.Highlights = append(report.Highlights, highlightEntry(day, highlight))
+		}
+	}
+
+	if periodStats, err := s.storage.Sessions().GetPeriodStats(ctx, weekStart, weekEnd); err == nil {
+		if periodStats.FocusScoreCount > 0 {
+			report.AvgFocusScore = &periodStats.AvgFocusScore
+		}
+		report.FocusScoreCount = periodStats.FocusScoreCount
+		report.Distractions = periodStats.DistractionCount
+	}
+	if energize, err := s.storage.Sessions().GetEnergizeStats(ctx, weekStart, weekEnd); err == nil {
+		report.Energize = energizeStats(energize)
+	}
+	return report
+}
+
+// DailyReflection builds today's reflection. sessions are today's sessions.
+func (s *ReportService) DailyReflection(ctx context.Context, now time.Time, methodology domain.Methodology, sessions []*domain.PomodoroSession) (*ports.DailyReflection, error) {
+	stats, err := s.storage.Sessions().GetDailyStats(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get today's stats: %w", err)
+	}
+
+	report := &ports.DailyReflection{
+		Date:         now.Format("2006-01-02"),
+		Methodology:  string(methodology),
+		WorkSessions: stats.WorkSessions,
+		WorkSeconds:  seconds(stats.TotalWorkTime),
+	}
+	focusSum := 0
+	for _, session := range sessions {
+		if !session.IsWorkSession() {
+			continue
+		}
+		switch session.Status {
+		case domain.SessionStatusCompleted:
+			report.Completed++
+		case domain.SessionStatusInterrupted:
+			report.Interrupted++
+		}
+		report.Distractions += len(session.Distractions)
+		if session.FocusScore != nil {
+			focusSum += *session.FocusScore
+			report.FocusScoreCount++
+		}
+	}
+	if report.FocusScoreCount > 0 {
+		avg := float64(focusSum) / float64(report.FocusScoreCount)
+		report.AvgFocusScore = &avg
+	}
+	if highlight, _ := s.storage.Tasks().FindTodayHighlight(ctx, now); highlight != nil {
+		entry := highlightEntry(now, highlight)
+		report.Highlight = &entry
+	}
+	blocks, err := s.storage.Plans().FindByDay(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plan: %w", err)
+	}
+	if len(blocks) > 0 {
+		report.Plan = dayPlan(now, blocks, sessions, now)
+	}
+	return report, nil
+}
+
+// DayPlan builds the plan of day, with the work done so far in each block.
+func (s *ReportService) DayPlan(ctx context.Context, day, now time.Time) (*ports.DayPlan, error) {
+	blocks, err := s.storage.Plans().FindByDay(ctx, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plan: %w", err)
+	}
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	sessions, err := s.storage.Sessions().FindRecent(ctx, dayStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	return dayPlan(day, blocks, sessions, now), nil
+}
+
+// dayPlan compares the blocks planned for day with the work sessions.
+// Work outside every block counts as unplanned.
+func dayPlan(day time.Time, blocks []*domain.PlanBlock, sessions []*domain.PomodoroSession, now time.Time) *ports.DayPlan {
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	wholeDay := &domain.PlanBlock{Start: dayStart, End: dayStart.AddDate(0, 0, 1)}
+
+	plan := &ports.DayPlan{
+		Date:   dayStart.Format("2006-01-02"),
+		Blocks: make([]ports.PlanEntry, 0, len(blocks)),
+	}
+	var planned, actual time.Duration
+	for _, b := range blocks {
+		worked := b.TimeWorked(sessions, now)
+		planned += b.Duration()
+		actual += worked
+		plan.Blocks = append(plan.Blocks, ports.PlanEntry{
+			ID:             b.ID,
+			Start:          b.Start.Format("15:04"),
+			End:            b.EndClock(),
+			Task:           b.Task,
+			Methodology:    string(b.Methodology),
+			Tags:           append([]string{}, b.Tags...),
+			PlannedSeconds: seconds(b.Duration()),
+			ActualSeconds:  seconds(worked),
+		})
+	}
+	plan.PlannedSeconds = seconds(planned)
+	plan.ActualSeconds = seconds(actual)
+	plan.UnplannedSeconds = seconds(wholeDay.TimeWorked(sessions, now) - actual)
+	return plan
+}
+
+// heatmapWeeks is how many weeks a heatmap covers, the current one included.
+const heatmapWeeks = 53
+
+// Heatmap builds the year of daily focus time up to now. metric is one of
+// the ports.HeatmapMetric values.
+func (s *ReportService) Heatmap(ctx context.Context, now time.Time, metric string) (*ports.Heatmap, error) {
+	weekStart, _, _ := domain.PeriodWeek.Bounds(now)
+	start := weekStart.AddDate(0, 0, -7*(heatmapWeeks-1))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	totals, err := s.storage.Sessions().GetDailyTotals(ctx, start, today.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily totals: %w", err)
+	}
+	byDay := make(map[string]domain.DayTotal, len(totals))
+	for _, t := range totals {
+		byDay[t.Date.Format("2006-01-02")] = t
+	}
+
+	heatmap := &ports.Heatmap{
+		Metric:     metric,
+		Start:      start.Format("2006-01-02"),
+		End:        today.Format("2006-01-02"),
+		Thresholds: []int{},
+		Months:     []ports.MonthTotal{},
+	}
+	var active []int
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		total := byDay[date]
+		work := total.WorkTime
+		if metric == ports.HeatmapMetricDeepWork {
+			work = total.DeepWorkTime
+		}
+		entry := ports.HeatmapDay{Date: date, Sessions: total.WorkSessions, Seconds: seconds(work)}
+		heatmap.Days = append(heatmap.Days, entry)
+
+		month := day.Format("2006-01")
+		if n := len(heatmap.Months); n == 0 || heatmap.Months[n-1].Month != month {
+			heatmap.Months = append(heatmap.Months, ports.MonthTotal{Month: month})
+		}
+		heatmap.Months[len(heatmap.Months)-1].Seconds += entry.Seconds
+		heatmap.TotalSeconds += entry.Seconds
+		if entry.Seconds > 0 {
+			active = append(active, entry.Seconds)
+		}
+	}
+	heatmap.ActiveDays = len(active)
+
+	// Levels split the active days into quartiles, like GitHub's graph.
+	if len(active) > 0 {
+		sort.Ints(active)
+		for _, q := range []float64{0.25, 0.5, 0.75} {
+			heatmap.Thresholds = append(heatmap.Thresholds, active[int(q*float64(len(active)-1))])
+		}
+	}
+	run := 0
+	for i := range heatmap.Days {
+		day := &heatmap.Days[i]
+		if day.Seconds == 0 {
+			run = 0
+			continue
+		}
+		day.Level = 4
+		for level, threshold := range heatmap.Thresholds {
+			if day.Seconds <= threshold {
+				day.Level = level + 1
+				break
+			}
+		}
+		run++
+		if run > heatmap.LongestStreak {
+			heatmap.LongestStreak = run
+		}
+	}
+
+	// The current streak survives a today without any work yet.
+	days := heatmap.Days
+	if n := len(days); n > 0 && days[n-1].Seconds == 0 {
+		days = days[:n-1]
+	}
+	for i := len(days) - 1; i >= 0 && days[i].Seconds > 0; i-- {
+		heatmap.CurrentStreak++
+	}
+	return heatmap, nil
+}
+
+// distractionOffsetBucket is the width of the by_offset buckets, and
+// distractionOffsetBuckets how many there are before the open-ended one.
+const (
+	distractionOffsetBucket  = 10 * time.Minute
+	distractionOffsetBuckets = 6
+)
+
+// Distractions builds the distraction report for a range.
+func (s *ReportService) Distractions(ctx context.Context, q ports.DistractionQuery) (*ports.DistractionReport, error) {
+	sessions, err := s.storage.Sessions().FindRecent(ctx, q.Start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	report := &ports.DistractionReport{
+		Label:         q.Label,
+		Start:         q.Start.Format("2006-01-02"),
+		End:           q.End.AddDate(0, 0, -1).Format("2006-01-02"),
+		ByCategory:    []ports.CategoryCount{},
+		ByMethodology: []ports.MethodologyDistraction{},
+		Themes:        []ports.DistractionTheme{},
+		ByHour:        []ports.HourCount{},
+	}
+	for i := 0; i <= distractionOffsetBuckets; i++ {
+		bucket := ports.OffsetCount{FromMinutes: i * int(distractionOffsetBucket/time.Minute)}
+		if i < distractionOffsetBuckets {
+			to := bucket.FromMinutes + int(distractionOffsetBucket/time.Minute)
+			bucket.ToMinutes = &to
+		}
+		report.ByOffset = append(report.ByOffset, bucket)
+	}
+
+	categories := map[string]int{}
+	methodologies := map[string]*ports.MethodologyDistraction{}
+	var hours [24]int
+	var texts []string
+	for _, session := range sessions {
+		if session.Type != domain.SessionTypeWork || session.Status != domain.SessionStatusCompleted ||
+			!session.StartedAt.Before(q.End) {
+			continue
+		}
+		report.Sessions++
+		name := string(session.Methodology)
+		if name == "" {
+			name = string(domain.MethodologyPomodoro)
+		}
+		m, ok := methodologies[name]
+		if !ok {
+			m = &ports.MethodologyDistraction{Methodology: name}
+			methodologies[name] = m
+		}
+		m.Sessions++
+		m.Distractions += len(session.Distractions)
+		if len(session.Distractions) > 0 {
+			report.DistractedSessions++
+		}
+
+		for _, d := range session.Distractions {
+			report.Total++
+			texts = append(texts, d.Text)
+			category := strings.ToLower(strings.TrimSpace(d.Category))
+			if category == "" {
+				category = domain.Uncategorized
+			}
+			categories[category]++
+
+			if d.At.IsZero() {
+				hours[session.StartedAt.In(q.Start.Location()).Hour()]++
+				continue
+			}
+			hours[d.At.In(q.Start.Location()).Hour()]++
+			report.Timed++
+			offset, _ := session.DistractionOffset(d)
+			bucket := int(offset / distractionOffsetBucket)
+			report.ByOffset[min(bucket, distractionOffsetBuckets)].Count++
+		}
+	}
+
+	// Configured categories come first, in order, then any others logged
+	// before the configuration changed, then the uncategorized ones.
+	for _, c := range q.Categories {
+		report.ByCategory = append(report.ByCategory, ports.CategoryCount{Category: c, Count: categories[c]})
+		delete(categories, c)
+	}
+	uncategorized := categories[domain.Uncategorized]
+	delete(categories, domain.Uncategorized)
+	var others []ports.CategoryCount
+	for c, n := range categories {
+		others = append(others, ports.CategoryCount{Category: c, Count: n})
+	}
+	sort.Slice(others, func(i, j int) bool {
+		if others[i].Count != others[j].Count {
+			return others[i].Count > others[j].Count
+		}
+		return others[i].Category < others[j].Category
+	})
+	report.ByCategory = append(report.ByCategory, others...)
+	if uncategorized > 0 {
+		report.ByCategory = append(report.ByCategory, ports.CategoryCount{Category: domain.Uncategorized, Count: uncategorized})
+	}
+
+	for _, m := range methodologies {
+		m.PerSession = float64(m.Distractions) / float64(m.Sessions)
+		report.ByMethodology = append(report.ByMethodology, *m)
+	}
+	sort.Slice(report.ByMethodology, func(i, j int) bool {
+		a, b := report.ByMethodology[i], report.ByMethodology[j]
+		if a.Distractions != b.Distractions {
+			return a.Distractions > b.Distractions
+		}
+		return a.Methodology < b.Methodology
+	})
+
+	for _, theme := range domain.ClusterDistractions(texts) {
+		if q.Top > 0 && len(report.Themes) == q.Top {
+			break
+		}
+		variants := theme.Variants
+		if variants == nil {
+			variants = []string{}
+		}
+		report.Themes = append(report.Themes, ports.DistractionTheme{Phrase: theme.Phrase, Count: theme.Count, Variants: variants})
+	}
+
+	for h, n := range hours {
+		if n > 0 {
+			report.ByHour = append(report.ByHour, ports.HourCount{Hour: h, Count: n})
+		}
+	}
+	return report, nil
+}
+
+// Insights builds the focus score insights report for a range.
+func (s *ReportService) Insights(ctx context.Context, q ports.InsightsQuery) (*ports.InsightsReport, error) {
+	sessions, err := s.storage.Sessions().FindRecent(ctx, q.Start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	tasks, err := s.storage.Tasks().FindAll(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+	taskTags := make(map[string][]string, len(tasks))
+	for _, t := range tasks {
+		taskTags[t.ID] = t.Tags
+	}
+
+	report := &ports.InsightsReport{
+		Label:          q.Label,
+		Start:          q.Start.Format("2006-01-02"),
+		End:            q.End.AddDate(0, 0, -1).Format("2006-01-02"),
+		MinSessions:    q.MinSessions,
+		BestConditions: []ports.ScoreCondition{},
+	}
+
+	var scored []domain.ScoredSession
+	for _, session := range sessions {
+		if session.Type != domain.SessionTypeWork || session.Status != domain.SessionStatusCompleted ||
+			!session.StartedAt.Before(q.End) {
+			continue
+		}
+		report.Sessions++
+		if session.FocusScore == nil {
+			continue
+		}
+		methodology := session.Methodology
+		if methodology == "" {
+			methodology = domain.MethodologyPomodoro
+		}
+		var tags []string
+		names := append([]string{}, session.Tags...)
+		if session.TaskID != nil {
+			names = append(names, taskTags[*session.TaskID]...)
+		}
+		for _, name := range names {
+			tags = append(tags, domain.TagAncestors(name)...)
+		}
+		scored = append(scored, domain.ScoredSession{
+			Score:        *session.FocusScore,
+			StartedAt:    session.StartedAt.In(q.Start.Location()),
+			Duration:     session.Duration,
+			Methodology:  methodology,
+			Tags:         domain.NormalizeTags(tags),
+			Branch:       session.GitBranch,
+			Distractions: len(session.Distractions),
+		})
+	}
+	report.ScoredSessions = len(scored)
+
+	report.Correlations = scoreCorrelations(scored)
+	report.ByHour = scoreBuckets(scored, 0, func(s domain.ScoredSession) []string {
+		return []string{fmt.Sprintf("%02d:00", s.StartedAt.Hour())}
+	}, nil)
+	report.ByDuration = scoreBuckets(scored, 0, func(s domain.ScoredSession) []string {
+		return []string{fmt.Sprintf("%dm", int(domain.DurationBucket(s.Methodology, s.Duration)/time.Minute))}
+	}, func(a, b ports.ScoreBucket) bool { return bucketMinutes(a.Key) < bucketMinutes(b.Key) })
+	report.ByWeekday = scoreBuckets(scored, 0, func(s domain.ScoredSession) []string {
+		return []string{s.StartedAt.Weekday().String()}
+	}, func(a, b ports.ScoreBucket) bool { return weekdayOrder(a.Key) < weekdayOrder(b.Key) })
+	report.ByTag = scoreBuckets(scored, q.MinSessions, func(s domain.ScoredSession) []string {
+		return s.Tags
+	}, byAverageScore)
+	report.ByBranch = scoreBuckets(scored, q.MinSessions, func(s domain.ScoredSession) []string {
+		if s.Branch == "" {
+			return nil
+		}
+		return []string{s.Branch}
+	}, byAverageScore)
+	report.ByDistractions = scoreBuckets(scored, 0, func(s domain.ScoredSession) []string {
+		if s.Distractions >= 3 {
+			return []string{"3+"}
+		}
+		return []string{strconv.Itoa(s.Distractions)}
+	}, nil)
+	report.ByMethodology = scoreBuckets(scored, 0, func(s domain.ScoredSession) []string {
+		return []string{string(s.Methodology)}
+	}, byAverageScore)
+
+	if len(scored) == 0 {
+		return report, nil
+	}
+	total := 0
+	for _, s := range scored {
+		total += s.Score
+	}
+	avg := float64(total) / float64(len(scored))
+	report.AvgFocusScore = &avg
+
+	for _, c := range domain.BestConditions(scored, q.MinSessions, q.Top) {
+		condition := ports.ScoreCondition{
+			Summary:       c.Summary(),
+			Sessions:      c.Sessions,
+			AvgFocusScore: c.AvgScore,
+			Lift:          c.AvgScore - avg,
+		}
+		for _, f := range c.Filters {
+			condition.Filters = append(condition.Filters, ports.ConditionFilter{Factor: string(f.Factor), Value: f.Value})
+		}
+		report.BestConditions = append(report.BestConditions, condition)
+	}
+	return report, nil
+}
+
+// scoreCorrelations relates the focus score to the start hour, the planned
+// length and the number of distractions.
+func scoreCorrelations(sessions []domain.ScoredSession) []ports.ScoreCorrelation {
+	factors := []struct {
+		name  string
+		value func(domain.ScoredSession) float64
+	}{
+		{"hour", func(s domain.ScoredSession) float64 {
+			return float64(s.StartedAt.Hour()) + float64(s.StartedAt.Minute())/60
+		}},
+		{"duration_minutes", func(s domain.ScoredSession) float64 { return s.Duration.Minutes() }},
+		{"distractions", func(s domain.ScoredSession) float64 { return float64(s.Distractions) }},
+	}
+	scores := make([]float64, len(sessions))
+	for i, s := range sessions {
+		scores[i] = float64(s.Score)
+	}
+	result := make([]ports.ScoreCorrelation, 0, len(factors))
+	for _, f := range factors {
+		values := make([]float64, len(sessions))
+		for i, s := range sessions {
+			values[i] = f.value(s)
+		}
+		c := ports.ScoreCorrelation{Factor: f.name}
+		if r, ok := domain.Correlation(values, scores); ok {
+			c.Coefficient = &r
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// scoreBuckets averages the focus scores per key, keeping keys with at
+// least minSessions sessions, sorted with less or by key when it's nil.
+func scoreBuckets(sessions []domain.ScoredSession, minSessions int, keys func(domain.ScoredSession) []string, less func(a, b ports.ScoreBucket) bool) []ports.ScoreBucket {
+	buckets := map[string]*ports.ScoreBucket{}
+	totals := map[string]int{}
+	for _, s := range sessions {
+		for _, key := range keys(s) {
+			b, ok := buckets[key]
+			if !ok {
+				b = &ports.ScoreBucket{Key: key}
+				buckets[key] = b
+			}
+			b.Sessions++
+			totals[key] += s.Score
+		}
+	}
+	result := make([]ports.ScoreBucket, 0, len(buckets))
+	for key, b := range buckets {
+		if b.Sessions < max(minSessions, 1) {
+			continue
+		}
+		b.AvgFocusScore = float64(totals[key]) / float64(b.Sessions)
+		result = append(result, *b)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if less != nil && less(result[i], result[j]) != less(result[j], result[i]) {
+			return less(result[i], result[j])
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// byAverageScore orders score buckets by their average, then their size.
+func byAverageScore(a, b ports.ScoreBucket) bool {
+	if a.AvgFocusScore != b.AvgFocusScore {
+		return a.AvgFocusScore > b.AvgFocusScore
+	}
+	return a.Sessions > b.Sessions
+}
+
+// bucketMinutes reads back a "50m" duration bucket key.
+func bucketMinutes(key string) int {
+	minutes, _ := strconv.Atoi(strings.TrimSuffix(key, "m"))
+	return minutes
+}
+
+// weekdayOrder places a weekday bucket key in a Monday-first week.
+func weekdayOrder(key string) int {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if d.String() == key {
+			return (int(d) + 6) % 7
+		}
+	}
+	return 7
+}
+
+func highlightEntry(day time.Time, task *domain.Task) ports.HighlightEntry {
+	return ports.HighlightEntry{
+		Date:   day.Format("2006-01-02"),
+		TaskID: task.ID,
+		Title:  task.Title,
+		Status: string(task.Status),
+	}
+}
+
+func energizeStats(stats []domain.EnergizeStat) []ports.EnergizeStat {
+	out := make([]ports.EnergizeStat, 0, len(stats))
+	for _, e := range stats {
+		out = append(out, ports.EnergizeStat{
+			Activity:      e.Activity,
+			Sessions:      e.SessionCount,
+			AvgFocusScore: e.AvgFocusScore,
+		})
+	}
+	return out
+}
+
+// seconds converts a duration to whole seconds for a report.
+func seconds(d time.Duration) int {
+	return int(d / time.Second)
+}
@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+func TestReportService_StatsComparesWithPreviousRange(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.Local)
+	save := func(startedAt time.Time, score int, distractions int) {
+		t.Helper()
+		s := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), nil)
+		s.StartedAt = startedAt
+		s.FocusScore = &score
+		for i := 0; i < distractions; i++ {
+			s.Distractions = append(s.Distractions, domain.Distraction{Text: "chat"})
+		}
+		s.Complete()
+		completed := startedAt.Add(s.Duration)
+		s.CompletedAt = &completed
+		if err := store.Sessions().Save(ctx, s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	save(now.Add(-time.Hour), 4, 1)
+	save(now.Add(-2*time.Hour), 2, 0)
+	save(now.AddDate(0, 0, -7), 5, 3)
+
+	current := domain.PeriodWeek.Range(now, 0)
+	previous := domain.PeriodWeek.Range(now, -1)
+	report, err := NewReportService(store).Stats(ctx, ports.StatsQuery{
+		Start:   current.Start,
+		End:     current.End,
+		Label:   current.Label,
+		Compare: &previous,
+	})
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+
+	if report.TotalSessions != 2 || report.Distractions != 1 {
+		t.Errorf("current = %d sessions, %d distractions; want 2, 1", report.TotalSessions, report.Distractions)
+	}
+	prev := report.Previous
+	if prev == nil {
+		t.Fatal("Previous = nil, want last week's summary")
+	}
+	if prev.Start != "2026-03-09" || prev.End != "2026-03-15" || prev.Label != "Week of Mar 9" {
+		t.Errorf("Previous range = %s..%s %q", prev.Start, prev.End, prev.Label)
+	}
+	if prev.TotalSessions != 1 || prev.Distractions != 3 || prev.WorkSeconds != 25*60 {
+		t.Errorf("Previous = %+v, want 1 session of 25m with 3 distractions", prev)
+	}
+	if prev.AvgFocusScore == nil || *prev.AvgFocusScore != 5 {
+		t.Errorf("Previous.AvgFocusScore = %v, want 5", prev.AvgFocusScore)
+	}
+
+	report, err = NewReportService(store).Stats(ctx, ports.StatsQuery{Start: current.Start, End: current.End})
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if report.Previous != nil {
+		t.Errorf("Previous = %+v without a comparison range, want nil", report.Previous)
+	}
+}
+
+func TestReportService_Heatmap(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.Local) // a Wednesday
+	save := func(daysAgo int, minutes int, methodology domain.Methodology) {
+		t.Helper()
+		s := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), nil)
+		s.StartedAt = now.AddDate(0, 0, -daysAgo).Add(-3 * time.Hour)
+		s.Duration = time.Duration(minutes) * time.Minute
+		s.Methodology = methodology
+		s.Complete()
+		if err := store.Sessions().Save(ctx, s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	// Yesterday and the two days before, a gap, then a five-day run in February.
+	for _, d := range []int{1, 2, 3} {
+		save(d, 30, domain.MethodologyPomodoro)
+	}
+	for d := 30; d < 35; d++ {
+		save(d, 60*(d-29), domain.MethodologyDeepWork)
+	}
+
+	heatmap, err := NewReportService(store).Heatmap(ctx, now, ports.HeatmapMetricTotal)
+	if err != nil {
+		t.Fatalf("Heatmap() error = %v", err)
+	}
+	if heatmap.Start != "2025-03-17" || heatmap.End != "2026-03-18" {
+		t.Errorf("range = %s..%s, want 53 weeks from Monday 2025-03-17 to today", heatmap.Start, heatmap.End)
+	}
+	if len(heatmap.Days) != 52*7+3 {
+		t.Errorf("len(Days) = %d, want %d", len(heatmap.Days), 52*7+3)
+	}
+	if heatmap.ActiveDays != 8 || heatmap.CurrentStreak != 3 || heatmap.LongestStreak != 5 {
+		t.Errorf("active %d, current streak %d, longest %d; want 8, 3, 5",
+			heatmap.ActiveDays, heatmap.CurrentStreak, heatmap.LongestStreak)
+	}
+	last := heatmap.Days[len(heatmap.Days)-2] // yesterday
+	if last.Seconds != 30*60 || last.Level != 1 {
+		t.Errorf("yesterday = %+v, want 30m at level 1", last)
+	}
+	if top := heatmap.Days[len(heatmap.Days)-1-34]; top.Seconds != 5*3600 || top.Level != 4 {
+		t.Errorf("the 5h day = %+v, want level 4", top)
+	}
+	months := heatmap.Months
+	if n := len(months); n != 13 || months[n-1].Month != "2026-03" || months[n-1].Seconds != 90*60 {
+		t.Errorf("months = %+v, want 13 ending with 1h30m in 2026-03", months)
+	}
+
+	deep, err := NewReportService(store).Heatmap(ctx, now, ports.HeatmapMetricDeepWork)
+	if err != nil {
+		t.Fatalf("Heatmap(deepwork) error = %v", err)
+	}
+	if deep.ActiveDays != 5 || deep.CurrentStreak != 0 || deep.TotalSeconds != 15*3600 {
+		t.Errorf("deep work heatmap = %d days, streak %d, %ds; want 5, 0, 15h",
+			deep.ActiveDays, deep.CurrentStreak, deep.TotalSeconds)
+	}
+}
+
+func TestReportService_Distractions(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.Local)
+	save := func(startedAt time.Time, methodology domain.Methodology, distractions ...domain.Distraction) {
+		t.Helper()
+		s := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), nil)
+		s.StartedAt = startedAt
+		s.Events = []domain.SessionEvent{
+			{Type: domain.SessionEventStart, At: startedAt},
+			{Type: domain.SessionEventPause, At: startedAt.Add(5 * time.Minute)},
+			{Type: domain.SessionEventResume, At: startedAt.Add(20 * time.Minute)},
+		}
+		s.Methodology = methodology
+		s.Distractions = distractions
+		s.Complete()
+		if err := store.Sessions().Save(ctx, s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	nine := time.Date(2026, 3, 17, 9, 0, 0, 0, time.Local)
+	save(nine, domain.MethodologyPomodoro,
+		domain.Distraction{Text: "Slack ping", Category: "external", At: nine.Add(3 * time.Minute)},
+		domain.Distraction{Text: "slack pings", Category: "external", At: nine.Add(30 * time.Minute)}, // 15m active
+		domain.Distraction{Text: "hungry", Category: "Body"},
+	)
+	save(nine.Add(5*time.Hour), domain.MethodologyDeepWork,
+		domain.Distraction{Text: "email"},
+	)
+	save(nine.Add(6*time.Hour), domain.MethodologyDeepWork)
+	save(now.AddDate(0, 0, -7), domain.MethodologyPomodoro, domain.Distraction{Text: "last week"})
+
+	week := domain.PeriodWeek.Range(now, 0)
+	report, err := NewReportService(store).Distractions(ctx, ports.DistractionQuery{
+		Start:      week.Start,
+		End:        week.End,
+		Label:      week.Label,
+		Categories: []string{"internal", "external"},
+		Top:        2,
+	})
+	if err != nil {
+		t.Fatalf("Distractions() error = %v", err)
+	}
+
+	if report.Total != 4 || report.Sessions != 3 || report.DistractedSessions != 2 || report.Timed != 2 {
+		t.Errorf("totals = %d in %d/%d sessions, %d timed; want 4 in 2/3, 2 timed",
+			report.Total, report.DistractedSessions, report.Sessions, report.Timed)
+	}
+	wantCategories := []ports.CategoryCount{
+		{Category: "internal", Count: 0},
+		{Category: "external", Count: 2},
+		{Category: "body", Count: 1},
+		{Category: domain.Uncategorized, Count: 1},
+	}
+	if !reflect.DeepEqual(report.ByCategory, wantCategories) {
+		t.Errorf("ByCategory = %+v, want %+v", report.ByCategory, wantCategories)
+	}
+	if m := report.ByMethodology; len(m) != 2 || m[0].Methodology != "pomodoro" || m[0].Distractions != 3 ||
+		m[1].Sessions != 2 || m[1].PerSession != 0.5 {
+		t.Errorf("ByMethodology = %+v, want pomodoro 3 in 1, deepwork 1 in 2", m)
+	}
+	if len(report.Themes) != 2 || report.Themes[0].Phrase != "slack ping" || report.Themes[0].Count != 2 {
+		t.Errorf("Themes = %+v, want slack ping ×2 first and two themes", report.Themes)
+	}
+	if len(report.ByOffset) != 7 || report.ByOffset[0].Count != 1 || report.ByOffset[1].Count != 1 ||
+		report.ByOffset[6].ToMinutes != nil {
+		t.Errorf("ByOffset = %+v, want one at 0-10m and one at 10-20m", report.ByOffset)
+	}
+	wantHours := []ports.HourCount{{Hour: 9, Count: 3}, {Hour: 14, Count: 1}}
+	if !reflect.DeepEqual(report.ByHour, wantHours) {
+		t.Errorf("ByHour = %+v, want %+v", report.ByHour, wantHours)
+	}
+}
+
+func TestReportService_Insights(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	task, _ := domain.NewTask("API")
+	task.Tags = []string{"client/acme"}
+	if err := store.Tasks().Save(ctx, task); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	monday := time.Date(2026, 3, 16, 0, 0, 0, 0, time.Local)
+	save := func(startedAt time.Time, length time.Duration, score *int, branch string, taskID *string) {
+		t.Helper()
+		s := domain.NewPomodoroSession(domain.PomodoroConfig{WorkDuration: length}, taskID)
+		s.StartedAt = startedAt
+		s.GitBranch = branch
+		s.FocusScore = score
+		s.Complete()
+		if err := store.Sessions().Save(ctx, s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	score := func(n int) *int { return &n }
+	for day, n := range []int{5, 4, 5, 4, 4} {
+		save(monday.AddDate(0, 0, day).Add(9*time.Hour), 50*time.Minute, score(n), "main", &task.ID)
+	}
+	for day, n := range []int{3, 3, 2, 3, 3} {
+		save(monday.AddDate(0, 0, day).Add(15*time.Hour), 25*time.Minute, score(n), "", nil)
+	}
+	save(monday.Add(17*time.Hour), 25*time.Minute, nil, "", nil)
+
+	week := domain.PeriodWeek.Range(monday, 0)
+	report, err := NewReportService(store).Insights(ctx, ports.InsightsQuery{
+		Start:       week.Start,
+		End:         week.End,
+		Label:       week.Label,
+		MinSessions: 5,
+		Top:         3,
+	})
+	if err != nil {
+		t.Fatalf("Insights() error = %v", err)
+	}
+
+	if report.Sessions != 11 || report.ScoredSessions != 10 || report.AvgFocusScore == nil || *report.AvgFocusScore != 3.6 {
+		t.Errorf("sessions = %d (%d scored, avg %v), want 11 (10 scored, avg 3.6)",
+			report.Sessions, report.ScoredSessions, report.AvgFocusScore)
+	}
+	if len(report.BestConditions) == 0 || report.BestConditions[0].Summary != "your 50m sessions average 4.4" ||
+		math.Abs(report.BestConditions[0].Lift-0.8) > 1e-9 {
+		t.Errorf("BestConditions = %+v, want 50m sessions averaging 4.4 first", report.BestConditions)
+	}
+	wantTags := []ports.ScoreBucket{
+		{Key: "client", Sessions: 5, AvgFocusScore: 4.4},
+		{Key: "client/acme", Sessions: 5, AvgFocusScore: 4.4},
+	}
+	if !reflect.DeepEqual(report.ByTag, wantTags) {
+		t.Errorf("ByTag = %+v, want %+v", report.ByTag, wantTags)
+	}
+	if b := report.ByBranch; len(b) != 1 || b[0].Key != "main" {
+		t.Errorf("ByBranch = %+v, want main", b)
+	}
+	if d := report.ByDuration; len(d) != 2 || d[0].Key != "25m" || d[1].Key != "50m" {
+		t.Errorf("ByDuration = %+v, want 25m then 50m", d)
+	}
+	if w := report.ByWeekday; len(w) != 5 || w[0].Key != "Monday" || w[0].Sessions != 2 {
+		t.Errorf("ByWeekday = %+v, want Monday to Friday", w)
+	}
+	if c := report.Correlations; len(c) != 3 || c[0].Coefficient == nil || *c[0].Coefficient >= 0 || c[2].Coefficient != nil {
+		t.Errorf("Correlations = %+v, want a negative hour correlation and none for distractions", c)
+	}
+}
+
+func TestReportService_DayPlan(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	day := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	write, _ := domain.NewPlanBlock(at(9, 0), at(10, 30), "Write API docs")
+	write.Methodology = domain.MethodologyDeepWork
+	email, _ := domain.NewPlanBlock(at(11, 0), at(11, 30), "Email")
+	if err := NewPlanService(store).SaveDay(ctx, day, []*domain.PlanBlock{email, write}); err != nil {
+		t.Fatalf("SaveDay() error = %v", err)
+	}
+
+	save := func(id string, start, end time.Time) {
+		t.Helper()
+		s := &domain.PomodoroSession{
+			ID: id, Type: domain.SessionTypeWork, Status: domain.SessionStatusCompleted,
+			Duration: end.Sub(start), StartedAt: start, CompletedAt: &end,
+			Events: []domain.SessionEvent{{Type: domain.SessionEventStart, At: start}, {Type: domain.SessionEventStop, At: end}},
+		}
+		if err := store.Sessions().Save(ctx, s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	save("early", at(8, 45), at(9, 45)) // 45m in the writing block, 15m before it
+	save("late", at(14, 0), at(14, 25)) // unplanned
+
+	plan, err := NewReportService(store).DayPlan(ctx, day, at(18, 0))
+	if err != nil {
+		t.Fatalf("DayPlan() error = %v", err)
+	}
+	if plan.Date != "2026-03-09" || len(plan.Blocks) != 2 {
+		t.Fatalf("DayPlan() = %+v, want the day's two blocks", plan)
+	}
+	first := plan.Blocks[0]
+	if first.Start != "09:00" || first.End != "10:30" || first.Methodology != "deepwork" ||
+		first.PlannedSeconds != 90*60 || first.ActualSeconds != 45*60 {
+		t.Errorf("Blocks[0] = %+v, want 45m of the 1h30m writing block done", first)
+	}
+	if plan.Blocks[1].ActualSeconds != 0 {
+		t.Errorf("Blocks[1] = %+v, want nothing done", plan.Blocks[1])
+	}
+	if plan.PlannedSeconds != 120*60 || plan.ActualSeconds != 45*60 || plan.UnplannedSeconds != 40*60 {
+		t.Errorf("totals = %d planned, %d actual, %d unplanned; want 2h, 45m, 40m",
+			plan.PlannedSeconds, plan.ActualSeconds, plan.UnplannedSeconds)
+	}
+
+	if err := NewPlanService(store).SaveDay(ctx, day, []*domain.PlanBlock{write, {Start: at(10, 0), End: at(11, 0), Task: "Calls"}}); !errors.Is(err, domain.ErrPlanOverlap) {
+		t.Errorf("SaveDay() with overlapping blocks error = %v, want ErrPlanOverlap", err)
+	}
+}
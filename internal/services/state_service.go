@@ -13,11 +13,30 @@ type StateService struct {
 	storage     ports.Storage
 	taskService *TaskService
 	pomodoroSvc *PomodoroService
+	stalePolicy domain.StaleAction
+	daemonUp    func() bool
 }
 
 // NewStateService creates a new state service.
 func NewStateService(storage ports.Storage) *StateService {
-	return &StateService{storage: storage}
+	return &StateService{
+		storage:     storage,
+		stalePolicy: domain.StaleActionAsk,
+		daemonUp:    func() bool { return false },
+	}
+}
+
+// SetStalePolicy sets what GetCurrentState does with a session left running
+// past its planned end. StaleActionAsk leaves it for the user to resolve.
+func (s *StateService) SetStalePolicy(policy domain.StaleAction) {
+	s.stalePolicy = policy
+}
+
+// SetDaemonCheck tells GetCurrentState how to find out whether a daemon is
+// running. While one is, it alone completes sessions whose time is up, and
+// GetCurrentState leaves them to it.
+func (s *StateService) SetDaemonCheck(daemonUp func() bool) {
+	s.daemonUp = daemonUp
 }
 
 // SetTaskService sets the task service for write operations.
@@ -35,11 +54,19 @@ func (s *StateService) GetCurrentState(ctx context.Context) (*domain.CurrentStat
 	activeTask, _ := s.storage.Tasks().FindActive(ctx)
 	activeSession, _ := s.storage.Sessions().FindActive(ctx)
 
-	// Auto-complete expired sessions that are still marked as running
-	if activeSession != nil && activeSession.Status == domain.SessionStatusRunning && activeSession.RemainingTime() == 0 {
-		activeSession.Complete()
-		_ = s.storage.Sessions().Update(ctx, activeSession)
-		activeSession = nil
+	// Auto-complete expired sessions that are still marked as running,
+	// unless a daemon is there to do it. One that expired long ago was
+	// abandoned, so the stale policy decides whether it counts; left alone,
+	// it is offered for recovery on launch.
+	if activeSession != nil && activeSession.Status == domain.SessionStatusRunning && activeSession.TimeUp() {
+		if !activeSession.IsStale(time.Now()) {
+			if !s.daemonUp() {
+				_ = s.expireSession(ctx, activeSession)
+				activeSession = nil
+			}
+		} else if s.stalePolicy != domain.StaleActionAsk && s.recoverSession(ctx, activeSession) == nil {
+			activeSession = nil
+		}
 	}
 
 	todayStats, err := s.storage.Sessions().GetDailyStats(ctx, time.Now())
@@ -47,13 +74,50 @@ func (s *StateService) GetCurrentState(ctx context.Context) (*domain.CurrentStat
 		todayStats = &domain.DailyStats{}
 	}
 
+	now := time.Now()
+	blocks, _ := s.storage.Plans().FindByDay(ctx, now)
+	currentBlock, nextBlock := domain.CurrentAndNextBlock(blocks, now)
+
 	return &domain.CurrentState{
 		ActiveTask:    activeTask,
 		ActiveSession: activeSession,
 		TodayStats:    *todayStats,
+		CurrentBlock:  currentBlock,
+		NextBlock:     nextBlock,
 	}, nil
 }
 
+// expireSession completes a session whose time ran out, through the
+// pomodoro service when wired so its hooks run.
+func (s *StateService) expireSession(ctx context.Context, session *domain.PomodoroSession) error {
+	if s.pomodoroSvc != nil {
+		return s.pomodoroSvc.ExpireSession(ctx, session)
+	}
+	session.Expire()
+	return s.finishSession(ctx, session)
+}
+
+// recoverSession closes a stale session following the stale policy.
+func (s *StateService) recoverSession(ctx context.Context, session *domain.PomodoroSession) error {
+	if s.pomodoroSvc != nil {
+		return s.pomodoroSvc.RecoverSession(ctx, session, s.stalePolicy, 0)
+	}
+	if err := session.Recover(s.stalePolicy, 0); err != nil {
+		return err
+	}
+	return s.finishSession(ctx, session)
+}
+
+// finishSession saves a session that has just ended, unless it was already
+// ended elsewhere.
+func (s *StateService) finishSession(ctx context.Context, session *domain.PomodoroSession) error {
+	finished, err := s.storage.Sessions().Finish(ctx, session)
+	if err == nil && !finished {
+		err = domain.ErrNoActiveSession
+	}
+	return err
+}
+
 // ListTasks implements ports.MCPStateProvider.
 func (s *StateService) ListTasks(ctx context.Context, status *domain.TaskStatus) ([]*domain.Task, error) {
 	return s.storage.Tasks().FindAll(ctx, status)
@@ -186,5 +250,15 @@ func (s *StateService) SetHighlight(ctx context.Context, taskID string) (*domain
 	return task, nil
 }
 
+// Search implements ports.MCPStateProvider.
+func (s *StateService) Search(ctx context.Context, query ports.SearchQuery) ([]ports.SearchResult, error) {
+	return s.storage.Search().Search(ctx, query)
+}
+
+// GetStats implements ports.MCPStateProvider.
+func (s *StateService) GetStats(ctx context.Context, query ports.StatsQuery) (*ports.StatsReport, error) {
+	return NewReportService(s.storage).Stats(ctx, query)
+}
+
 // Ensure StateService implements MCPStateProvider.
 var _ ports.MCPStateProvider = (*StateService)(nil)
@@ -5,6 +5,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/xvierd/flow-cli/internal/domain"
 	"github.com/xvierd/flow-cli/internal/ports"
@@ -46,6 +47,21 @@ func (s *TaskService) AddTask(ctx context.Context, req AddTaskRequest) (*domain.
 	return task, nil
 }
 
+// FindOrAddTask returns the pending task titled title, ignoring case, or
+// creates one when there is none.
+func (s *TaskService) FindOrAddTask(ctx context.Context, title string) (*domain.Task, error) {
+	pending, err := s.storage.Tasks().FindPending(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending tasks: %w", err)
+	}
+	for _, task := range pending {
+		if strings.EqualFold(task.Title, strings.TrimSpace(title)) {
+			return task, nil
+		}
+	}
+	return s.AddTask(ctx, AddTaskRequest{Title: title})
+}
+
 // ListTasksRequest contains filters for listing tasks.
 type ListTasksRequest struct {
 	Status      *domain.TaskStatus
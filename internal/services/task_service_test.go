@@ -184,3 +184,28 @@ func TestTaskService_StartTask(t *testing.T) {
 		t.Errorf("StartTask() status = %v, want in_progress", started.Status)
 	}
 }
+
+func TestTaskService_FindOrAddTask(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	service := NewTaskService(store)
+	ctx := context.Background()
+
+	first, err := service.FindOrAddTask(ctx, "Write API docs")
+	if err != nil {
+		t.Fatalf("FindOrAddTask() error = %v", err)
+	}
+	again, err := service.FindOrAddTask(ctx, "write api docs")
+	if err != nil || again.ID != first.ID {
+		t.Errorf("FindOrAddTask() = %v, %v, want the pending task %s", again, err, first.ID)
+	}
+
+	if err := service.CompleteTask(ctx, first.ID); err != nil {
+		t.Fatal(err)
+	}
+	fresh, err := service.FindOrAddTask(ctx, "Write API docs")
+	if err != nil || fresh.ID == first.ID {
+		t.Errorf("FindOrAddTask() after completing = %v, %v, want a new task", fresh, err)
+	}
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/domain"
+	"github.com/xvierd/flow-cli/internal/ports"
+)
+
+// WebhookTestEvent is the event name of deliveries sent by "flow webhooks test".
+const WebhookTestEvent domain.HookEvent = "test"
+
+// webhookRetryBatch bounds how many queued deliveries one retry pass sends.
+const webhookRetryBatch = 20
+
+// webhookClaim is how long a newly queued delivery is left to the process
+// that queued it before a retry pass may send it instead.
+const webhookClaim = time.Minute
+
+// Webhook is a URL that receives session events.
+type Webhook struct {
+	URL    string
+	Events []domain.HookEvent // empty means every event
+	Secret string
+}
+
+// Wants reports whether the webhook subscribes to event.
+func (w Webhook) Wants(event domain.HookEvent) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookPayload is the JSON body POSTed to webhooks.
+type WebhookPayload struct {
+	Event      domain.HookEvent `json:"event"`
+	OccurredAt string           `json:"occurred_at"`
+	Session    WebhookSession   `json:"session"`
+	Task       *WebhookTask     `json:"task"`
+}
+
+// WebhookSession describes the session in a webhook payload.
+type WebhookSession struct {
+	ID              string   `json:"id"`
+	Type            string   `json:"type"`
+	Status          string   `json:"status"`
+	Methodology     string   `json:"methodology"`
+	DurationSeconds int      `json:"duration_seconds"`
+	StartedAt       string   `json:"started_at"`
+	CompletedAt     *string  `json:"completed_at"`
+	Tags            []string `json:"tags"`
+	IntendedOutcome string   `json:"intended_outcome"`
+	Accomplishment  string   `json:"accomplishment"`
+	Notes           string   `json:"notes"`
+	FocusScore      *int     `json:"focus_score"`
+	Distractions    int      `json:"distractions"`
+	GitBranch       string   `json:"git_branch"`
+	GitCommit       string   `json:"git_commit"`
+	TaskID          *string  `json:"task_id"`
+}
+
+// WebhookTask describes the session's task in a webhook payload.
+type WebhookTask struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Tags        []string `json:"tags"`
+}
+
+// NewWebhookPayload builds the payload for a session event. task may be nil.
+func NewWebhookPayload(event domain.HookEvent, session *domain.PomodoroSession, task *domain.Task, now time.Time) WebhookPayload {
+	const layout = "2006-01-02T15:04:05"
+	payload := WebhookPayload{
+		Event:      event,
+		OccurredAt: now.Format(layout),
+		Session: WebhookSession{
+			ID:              session.ID,
+			Type:            string(session.Type),
+			Status:          string(session.Status),
+			Methodology:     string(session.Methodology),
+			DurationSeconds: int(session.Duration.Seconds()),
+			StartedAt:       session.StartedAt.Format(layout),
+			Tags:            session.Tags,
+			IntendedOutcome: session.IntendedOutcome,
+			Accomplishment:  session.Accomplishment,
+			Notes:           session.Notes,
+			FocusScore:      session.FocusScore,
+			Distractions:    len(session.Distractions),
+			GitBranch:       session.GitBranch,
+			GitCommit:       session.GitCommit,
+			TaskID:          session.TaskID,
+		},
+	}
+	if session.CompletedAt != nil {
+		completed := session.CompletedAt.Format(layout)
+		payload.Session.CompletedAt = &completed
+	}
+	if task != nil {
+		payload.Task = &WebhookTask{
+			ID:          task.ID,
+			Title:       task.Title,
+			Description: task.Description,
+			Status:      string(task.Status),
+			Tags:        task.Tags,
+		}
+	}
+	return payload
+}
+
+// WebhookService POSTs session events to the configured webhooks. Failed
+// deliveries are queued in storage and retried with backoff.
+type WebhookService struct {
+	storage  ports.Storage
+	sender   ports.WebhookSender
+	webhooks []Webhook
+	sending  sync.WaitGroup // background sends started by Run
+}
+
+// Ensure WebhookService runs as a session hook.
+var _ ports.HookRunner = (*WebhookService)(nil)
+
+// NewWebhookService creates a webhook service.
+func NewWebhookService(storage ports.Storage, sender ports.WebhookSender, webhooks []Webhook) *WebhookService {
+	return &WebhookService{storage: storage, sender: sender, webhooks: webhooks}
+}
+
+// Webhooks returns the configured webhooks.
+func (s *WebhookService) Webhooks() []Webhook {
+	return s.webhooks
+}
+
+// Run implements ports.HookRunner: it queues the event for every webhook
+// subscribed to it and sends the deliveries in the background, so a slow
+// or unreachable endpoint never holds up the session. Deliveries still
+// unsent when the process exits are left to RunRetries.
+func (s *WebhookService) Run(ctx context.Context, event domain.HookEvent, session *domain.PomodoroSession, task *domain.Task) error {
+	payload, err := json.Marshal(NewWebhookPayload(event, session, task, time.Now()))
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	type queued struct {
+		delivery *domain.WebhookDelivery
+		secret   string
+	}
+	var deliveries []queued
+	for _, w := range s.webhooks {
+		if !w.Wants(event) {
+			continue
+		}
+		d := domain.NewWebhookDelivery(w.URL, event, payload)
+		d.NextAttemptAt = d.CreatedAt.Add(webhookClaim)
+		if err := s.storage.Webhooks().Save(ctx, d); err != nil {
+			return fmt.Errorf("failed to queue webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, queued{d, w.Secret})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	// Failures are recorded on each delivery and retried from the queue.
+	ctx = context.WithoutCancel(ctx)
+	s.sending.Add(1)
+	go func() {
+		defer s.sending.Done()
+		for _, q := range deliveries {
+			_ = s.attempt(ctx, q.delivery, q.secret)
+		}
+	}()
+	return nil
+}
+
+// Wait blocks until the deliveries Run is sending in the background are
+// done, or timeout passes, and reports whether they finished.
+func (s *WebhookService) Wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		s.sending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// RetryDue retries queued deliveries whose backoff has passed and returns
+// how many went through.
+func (s *WebhookService) RetryDue(ctx context.Context) (int, error) {
+	due, err := s.storage.Webhooks().FindDue(ctx, time.Now(), webhookRetryBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, d := range due {
+		w, ok := s.find(d.URL)
+		if !ok {
+			d.GiveUp("webhook is no longer configured")
+			if err := s.storage.Webhooks().Save(ctx, d); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+		if s.attempt(ctx, d, w.Secret) == nil {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// RunRetries retries queued deliveries every interval until ctx is cancelled.
+func (s *WebhookService) RunRetries(ctx context.Context, interval time.Duration, logf func(format string, args ...interface{})) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := s.RetryDue(ctx); err != nil {
+			logf("webhook retry failed: %v", err)
+		}
+	}
+}
+
+// WebhookTestResult is the outcome of a test delivery to one webhook.
+type WebhookTestResult struct {
+	URL string
+	Err error
+}
+
+// Test sends a sample completed session to every webhook right away. Test
+// deliveries are neither queued nor logged.
+func (s *WebhookService) Test(ctx context.Context) []WebhookTestResult {
+	session := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), nil)
+	session.Complete()
+	payload, _ := json.Marshal(NewWebhookPayload(WebhookTestEvent, session, nil, time.Now()))
+
+	results := make([]WebhookTestResult, 0, len(s.webhooks))
+	for _, w := range s.webhooks {
+		delivery := domain.NewWebhookDelivery(w.URL, WebhookTestEvent, payload)
+		results = append(results, WebhookTestResult{URL: w.URL, Err: s.sender.Send(ctx, delivery, w.Secret)})
+	}
+	return results
+}
+
+// Log returns the latest deliveries, newest first.
+func (s *WebhookService) Log(ctx context.Context, limit int) ([]*domain.WebhookDelivery, error) {
+	return s.storage.Webhooks().FindRecent(ctx, limit)
+}
+
+// attempt sends a delivery once and records the outcome.
+func (s *WebhookService) attempt(ctx context.Context, d *domain.WebhookDelivery, secret string) error {
+	err := s.sender.Send(ctx, d, secret)
+	if err != nil {
+		d.RecordFailure(err, time.Now())
+	} else {
+		d.RecordSuccess(time.Now())
+	}
+	if saveErr := s.storage.Webhooks().Save(ctx, d); saveErr != nil {
+		return saveErr
+	}
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", d.URL, err)
+	}
+	return nil
+}
+
+func (s *WebhookService) find(url string) (Webhook, bool) {
+	for _, w := range s.webhooks {
+		if w.URL == url {
+			return w, true
+		}
+	}
+	return Webhook{}, false
+}
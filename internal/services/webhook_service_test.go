@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xvierd/flow-cli/internal/adapters/webhook"
+	"github.com/xvierd/flow-cli/internal/domain"
+)
+
+// webhookReceiver is an httptest endpoint that records signed deliveries
+// and fails while down is set.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	down     bool
+	payloads []WebhookPayload
+	badSigs  int
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.down {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+		return
+	}
+	body, _ := io.ReadAll(req.Body)
+	if req.Header.Get(webhook.HeaderSignature) != webhook.Sign("s3cret", body) {
+		r.badSigs++
+	}
+	var payload WebhookPayload
+	_ = json.Unmarshal(body, &payload)
+	r.payloads = append(r.payloads, payload)
+}
+
+func TestWebhookService_DeliversAndRetries(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	receiver := &webhookReceiver{}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+
+	svc := NewWebhookService(store, webhook.NewSender(), []Webhook{
+		{URL: ts.URL, Events: []domain.HookEvent{domain.HookComplete}, Secret: "s3cret"},
+	})
+
+	task, _ := domain.NewTask("Ship webhooks")
+	session := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), &task.ID)
+
+	// Events the webhook does not subscribe to are not sent.
+	if err := svc.Run(ctx, domain.HookStart, session, task); err != nil {
+		t.Fatalf("Run(on_start) error = %v", err)
+	}
+	session.Complete()
+	if err := svc.Run(ctx, domain.HookComplete, session, task); err != nil {
+		t.Fatalf("Run(on_complete) error = %v", err)
+	}
+	if !svc.Wait(5 * time.Second) {
+		t.Fatal("Wait() timed out")
+	}
+	if len(receiver.payloads) != 1 || receiver.badSigs != 0 {
+		t.Fatalf("received %d payloads with %d bad signatures, want 1 signed", len(receiver.payloads), receiver.badSigs)
+	}
+	got := receiver.payloads[0]
+	if got.Event != domain.HookComplete || got.Session.ID != session.ID || got.Session.Status != "completed" ||
+		got.Session.DurationSeconds != 1500 || got.Task == nil || got.Task.Title != "Ship webhooks" {
+		t.Errorf("payload = %+v, want the completed session and its task", got)
+	}
+
+	// A failed delivery is queued, then retried once its backoff passes.
+	receiver.mu.Lock()
+	receiver.down = true
+	receiver.mu.Unlock()
+	if err := svc.Run(ctx, domain.HookComplete, session, task); err != nil {
+		t.Fatalf("Run() to a failing webhook error = %v, want the failure left to the queue", err)
+	}
+	svc.Wait(5 * time.Second)
+	log, _ := svc.Log(ctx, 10)
+	if len(log) != 2 || log[0].Status != domain.WebhookPending || log[0].Attempts != 1 {
+		t.Fatalf("log = %v, want the failed delivery pending", log)
+	}
+	failed := log[0]
+
+	receiver.mu.Lock()
+	receiver.down = false
+	receiver.mu.Unlock()
+	if n, err := svc.RetryDue(ctx); err != nil || n != 0 {
+		t.Errorf("RetryDue() before the backoff = %d, %v; want nothing retried", n, err)
+	}
+	failed.NextAttemptAt = time.Now().Add(-time.Second)
+	if err := store.Webhooks().Save(ctx, failed); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if n, err := svc.RetryDue(ctx); err != nil || n != 1 {
+		t.Errorf("RetryDue() after the backoff = %d, %v; want 1 delivered", n, err)
+	}
+	if len(receiver.payloads) != 2 {
+		t.Errorf("received %d payloads, want the retried one too", len(receiver.payloads))
+	}
+	log, _ = svc.Log(ctx, 10)
+	if log[0].Status != domain.WebhookDelivered || log[0].Attempts != 2 {
+		t.Errorf("retried delivery = %+v, want delivered on attempt 2", log[0])
+	}
+}
+
+func TestWebhookService_RunDoesNotWaitForDelivery(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	svc := NewWebhookService(store, webhook.NewSender(), []Webhook{{URL: ts.URL}})
+	session := domain.NewPomodoroSession(domain.DefaultPomodoroConfig(), nil)
+
+	started := time.Now()
+	if err := svc.Run(ctx, domain.HookStart, session, nil); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("Run() took %v with a hanging endpoint, want it to return at once", elapsed)
+	}
+
+	// The delivery in flight is queued, but not yet due for a retry pass.
+	log, _ := svc.Log(ctx, 10)
+	if len(log) != 1 || log[0].Status != domain.WebhookPending {
+		t.Fatalf("log = %v, want the delivery queued", log)
+	}
+	if due, _ := store.Webhooks().FindDue(ctx, time.Now(), 10); len(due) != 0 {
+		t.Errorf("FindDue() = %v, want the delivery in flight left alone", due)
+	}
+}
+
+func TestWebhookService_GivesUpOnRemovedWebhooks(t *testing.T) {
+	store, cleanup := setupTestStorage(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	stale := domain.NewWebhookDelivery("http://example.invalid/old", domain.HookStart, []byte(`{}`))
+	stale.RecordFailure(errors.New("connection refused"), time.Now().Add(-time.Hour))
+	if err := store.Webhooks().Save(ctx, stale); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	svc := NewWebhookService(store, webhook.NewSender(), nil)
+	if _, err := svc.RetryDue(ctx); err != nil {
+		t.Fatalf("RetryDue() error = %v", err)
+	}
+	log, _ := svc.Log(ctx, 1)
+	if len(log) != 1 || log[0].Status != domain.WebhookFailed {
+		t.Errorf("delivery to a removed webhook = %v, want failed", log)
+	}
+}